@@ -10,9 +10,14 @@ import (
 	cmdGCP "github.com/OctopusDeploy/cli/pkg/cmd/account/gcp"
 	cmdGenericOidc "github.com/OctopusDeploy/cli/pkg/cmd/account/generic-oidc"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/account/list"
+	cmdRotate "github.com/OctopusDeploy/cli/pkg/cmd/account/rotate"
 	cmdSSH "github.com/OctopusDeploy/cli/pkg/cmd/account/ssh"
+	cmdTest "github.com/OctopusDeploy/cli/pkg/cmd/account/test"
 	cmdToken "github.com/OctopusDeploy/cli/pkg/cmd/account/token"
+	cmdUpdate "github.com/OctopusDeploy/cli/pkg/cmd/account/update"
+	cmdUsages "github.com/OctopusDeploy/cli/pkg/cmd/account/usages"
 	cmdUsr "github.com/OctopusDeploy/cli/pkg/cmd/account/username"
+	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/account/view"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
@@ -33,6 +38,11 @@ func NewCmdAccount(f factory.Factory) *cobra.Command {
 	cmd.AddCommand(cmdDelete.NewCmdDelete(f))
 	cmd.AddCommand(cmdCreate.NewCmdCreate(f))
 	cmd.AddCommand(cmdList.NewCmdList(f))
+	cmd.AddCommand(cmdView.NewCmdView(f))
+	cmd.AddCommand(cmdUpdate.NewCmdUpdate(f))
+	cmd.AddCommand(cmdRotate.NewCmdRotate(f))
+	cmd.AddCommand(cmdTest.NewCmdTest(f))
+	cmd.AddCommand(cmdUsages.NewCmdUsages(f))
 	cmd.AddCommand(cmdAWS.NewCmdAws(f))
 	cmd.AddCommand(cmdAzure.NewCmdAzure(f))
 	cmd.AddCommand(cmdAzureOidc.NewCmdAzureOidc(f))
@@ -2,11 +2,14 @@ package delete
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/shared"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/OctopusDeploy/cli/pkg/question/selectors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
@@ -14,20 +17,30 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const FlagForce = "force"
+
 func NewCmdDelete(f factory.Factory) *cobra.Command {
 	var skipConfirmation bool
+	var force bool
 	cmd := &cobra.Command{
-		Use:     "delete {<name> | <id>}",
-		Short:   "Delete an account",
-		Long:    "Delete an account in Octopus Deploy",
+		Use:   "delete {<name> | <id>}",
+		Short: "Delete an account",
+		Long: heredoc.Docf(`
+			Delete an account in Octopus Deploy.
+
+			An account which is used by deployment targets, steps or variables is only deleted when
+			--%[2]s is given, as deleting it breaks the deployments which use it. Use
+			'%[1]s account usages' to see what uses an account.
+		`, constants.ExecutableName, FlagForce),
 		Aliases: []string{"del", "rm", "remove"},
 		Example: heredoc.Docf(`
 			%[1]s account delete
 			%[1]s account rm
+			%[1]s account delete "Old AWS account" --force -y
 		`, constants.ExecutableName),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
-				return deleteRun(f, cmd)
+				return deleteRun(f, cmd, force)
 			}
 
 			itemIDOrName := args[0]
@@ -58,6 +71,10 @@ func NewCmdDelete(f factory.Factory) *cobra.Command {
 				return fmt.Errorf("cannot find an account with name or ID of '%s'", itemIDOrName)
 			}
 
+			if err := checkUsages(cmd.OutOrStdout(), client, itemToDelete, force); err != nil {
+				return err
+			}
+
 			if !skipConfirmation { // TODO NO_PROMPT env var or whatever we do there
 				return question.DeleteWithConfirmation(f.Ask, "space", itemToDelete.GetName(), itemToDelete.GetID(), func() error {
 					return delete(client, itemToDelete)
@@ -69,11 +86,12 @@ func NewCmdDelete(f factory.Factory) *cobra.Command {
 	}
 
 	question.RegisterConfirmDeletionFlag(cmd, &skipConfirmation, "account")
+	cmd.Flags().BoolVar(&force, FlagForce, false, "Delete the account even when it is in use")
 
 	return cmd
 }
 
-func deleteRun(f factory.Factory, cmd *cobra.Command) error {
+func deleteRun(f factory.Factory, cmd *cobra.Command, force bool) error {
 	client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
 	if err != nil {
 		return err
@@ -89,6 +107,10 @@ func deleteRun(f factory.Factory, cmd *cobra.Command) error {
 		return err
 	}
 
+	if err := checkUsages(cmd.OutOrStdout(), client, accountToDelete, force); err != nil {
+		return err
+	}
+
 	return question.DeleteWithConfirmation(f.Ask, "account", accountToDelete.GetName(), accountToDelete.GetID(), func() error {
 		return delete(client, accountToDelete)
 	})
@@ -97,3 +119,27 @@ func deleteRun(f factory.Factory, cmd *cobra.Command) error {
 func delete(client *client.Client, accountToDelete accounts.IAccount) error {
 	return client.Accounts.DeleteByID(accountToDelete.GetID())
 }
+
+func checkUsages(out io.Writer, client *client.Client, account accounts.IAccount, force bool) error {
+	usages, err := shared.GetUsages(client, account)
+	if err != nil {
+		return err
+	}
+	return CheckUsages(out, account, usages, force)
+}
+
+// CheckUsages lists what uses an account which is about to be deleted, and stops the deletion
+// unless it is forced.
+func CheckUsages(out io.Writer, account accounts.IAccount, usages []*shared.Usage, force bool) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(out, "Account '%s' is used by:\n", account.GetName())
+	shared.PrintUsages(out, usages)
+	if !force {
+		return fmt.Errorf("account '%s' is in use, deleting it will break the deployments which use it; use --%s to delete it anyway", account.GetName(), FlagForce)
+	}
+	fmt.Fprintln(out, output.Yellow("Deleting it anyway, as --"+FlagForce+" was given."))
+	return nil
+}
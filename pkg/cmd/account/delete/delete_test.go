@@ -0,0 +1,42 @@
+package delete_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/delete"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/shared"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUsages_InUseRequiresForce(t *testing.T) {
+	account, err := accounts.NewTokenAccount("GitHub", core.NewSensitiveValue("token"))
+	require.NoError(t, err)
+	usages := []*shared.Usage{{Kind: shared.UsageDeploymentTarget, Name: "web-01", Id: "Machines-1"}}
+	out := &bytes.Buffer{}
+
+	err = delete.CheckUsages(out, account, usages, false)
+
+	assert.EqualError(t, err, "account 'GitHub' is in use, deleting it will break the deployments which use it; use --force to delete it anyway")
+	assert.Contains(t, out.String(), "Deployment target: web-01")
+}
+
+func TestCheckUsages_InUseWithForce(t *testing.T) {
+	account, err := accounts.NewTokenAccount("GitHub", core.NewSensitiveValue("token"))
+	require.NoError(t, err)
+	usages := []*shared.Usage{{Kind: shared.UsageLibraryVariableSet, Name: "Tokens", Id: "LibraryVariableSets-1"}}
+
+	assert.NoError(t, delete.CheckUsages(&bytes.Buffer{}, account, usages, true))
+}
+
+func TestCheckUsages_Unused(t *testing.T) {
+	account, err := accounts.NewTokenAccount("GitHub", core.NewSensitiveValue("token"))
+	require.NoError(t, err)
+	out := &bytes.Buffer{}
+
+	assert.NoError(t, delete.CheckUsages(out, account, []*shared.Usage{}, false))
+	assert.Empty(t, out.String())
+}
@@ -3,6 +3,7 @@ package list
 import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/shared"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/output"
@@ -40,17 +41,6 @@ func NewCmdList(f factory.Factory) *cobra.Command {
 				Type string
 			}
 
-			accountTypeMap := map[accounts.AccountType]string{
-				accounts.AccountTypeAmazonWebServicesAccount:   "AWS Account",
-				accounts.AccountTypeAzureSubscription:          "Azure Subscription",
-				accounts.AccountTypeAzureServicePrincipal:      "Azure Service Principal",
-				accounts.AccountTypeAzureOIDC:                  "Azure OpenID Connect",
-				accounts.AccountTypeGoogleCloudPlatformAccount: "Google Cloud Account",
-				accounts.AccountTypeSSHKeyPair:                 "SSH Key Pair",
-				accounts.AccountTypeUsernamePassword:           "Username/Password",
-				accounts.AccountTypeToken:                      "Token",
-			}
-
 			return output.PrintArray(items, cmd, output.Mappers[accounts.IAccount]{
 				Json: func(item accounts.IAccount) any {
 					return AccountJson{Id: item.GetID(), Slug: item.GetSlug(), Name: item.GetName(), Type: string(item.GetAccountType())}
@@ -58,7 +48,7 @@ func NewCmdList(f factory.Factory) *cobra.Command {
 				Table: output.TableDefinition[accounts.IAccount]{
 					Header: []string{"NAME", "TYPE", "SLUG"},
 					Row: func(item accounts.IAccount) []string {
-						return []string{item.GetName(), accountTypeMap[item.GetAccountType()], item.GetSlug()}
+						return []string{item.GetName(), shared.AccountTypeNames[item.GetAccountType()], item.GetSlug()}
 					}},
 				Basic: func(item accounts.IAccount) string {
 					return item.GetName()
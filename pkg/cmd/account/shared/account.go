@@ -0,0 +1,64 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+)
+
+type GetAccountCallback func(idOrName string) (accounts.IAccount, error)
+type GetAllAccountsCallback func() ([]accounts.IAccount, error)
+
+// AccountTypeNames are the names of the types of account as they are shown in Octopus.
+var AccountTypeNames = map[accounts.AccountType]string{
+	accounts.AccountTypeAmazonWebServicesAccount:   "AWS Account",
+	accounts.AccountTypeAwsOIDC:                    "AWS OpenID Connect",
+	accounts.AccountTypeAzureSubscription:          "Azure Subscription",
+	accounts.AccountTypeAzureServicePrincipal:      "Azure Service Principal",
+	accounts.AccountTypeAzureOIDC:                  "Azure OpenID Connect",
+	accounts.AccountTypeGenericOIDCAccount:         "Generic OpenID Connect",
+	accounts.AccountTypeGoogleCloudPlatformAccount: "Google Cloud Account",
+	accounts.AccountTypeSSHKeyPair:                 "SSH Key Pair",
+	accounts.AccountTypeUsernamePassword:           "Username/Password",
+	accounts.AccountTypeToken:                      "Token",
+}
+
+// GetAccount looks up an account by ID, falling back to an exact (case-insensitive) name match.
+func GetAccount(octopus *client.Client, idOrName string) (accounts.IAccount, error) {
+	account, _ := octopus.Accounts.GetByID(idOrName)
+	if account != nil {
+		return account, nil
+	}
+
+	// the server cannot look up an account by ID and name at the same time
+	matches, err := octopus.Accounts.Get(accounts.AccountsQuery{PartialName: idOrName})
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matches.Items {
+		if strings.EqualFold(match.GetName(), idOrName) {
+			return match, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot find an account with name or ID of '%s'", idOrName)
+}
+
+// ResolveAccount finds the named account, or prompts for one when no name was given.
+func ResolveAccount(ask question.Asker, noPrompt bool, idOrName string, message string, getAccount GetAccountCallback, getAllAccounts GetAllAccountsCallback) (accounts.IAccount, error) {
+	if idOrName != "" {
+		return getAccount(idOrName)
+	}
+	if noPrompt {
+		return nil, fmt.Errorf("must supply account identifier")
+	}
+
+	allAccounts, err := getAllAccounts()
+	if err != nil {
+		return nil, err
+	}
+	return selectors.ByName(ask, allAccounts, message)
+}
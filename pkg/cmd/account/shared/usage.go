@@ -0,0 +1,86 @@
+package shared
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+)
+
+const (
+	UsageDeploymentTarget   = "Deployment target"
+	UsageDeploymentStep     = "Deployment process step"
+	UsageRunbookStep        = "Runbook step"
+	UsageProjectVariable    = "Project variable"
+	UsageLibraryVariableSet = "Library variable set"
+)
+
+type GetUsagesCallback func(account accounts.IAccount) ([]*Usage, error)
+
+// Usage is something which references an account, and would break if the account was deleted.
+type Usage struct {
+	Kind string `json:"Kind"`
+	// Owner is the project (and runbook) a step or variable belongs to, if any.
+	Owner string `json:"Owner,omitempty"`
+	Name  string `json:"Name"`
+	Id    string `json:"Id"`
+}
+
+// GetUsages lists the things which reference an account.
+func GetUsages(octopus *client.Client, account accounts.IAccount) ([]*Usage, error) {
+	accountUsage, err := octopus.Accounts.GetUsages(account)
+	if err != nil {
+		return nil, err
+	}
+	return FlattenUsages(accountUsage), nil
+}
+
+// FlattenUsages turns the usages reported by the server into a single list. Releases and runbook
+// snapshots are left out, as they keep a copy of the variables and are not affected by a deletion
+// in the same way.
+func FlattenUsages(accountUsage *accounts.AccountUsage) []*Usage {
+	usages := []*Usage{}
+	if accountUsage == nil {
+		return usages
+	}
+
+	for _, target := range accountUsage.Targets {
+		usages = append(usages, &Usage{Kind: UsageDeploymentTarget, Name: target.TargetName, Id: target.TargetID})
+	}
+	for _, process := range accountUsage.DeploymentProcesses {
+		for _, step := range process.Steps {
+			usages = append(usages, &Usage{Kind: UsageDeploymentStep, Owner: process.ProjectName, Name: step.StepName, Id: step.StepID})
+		}
+	}
+	for _, process := range accountUsage.RunbookProcesses {
+		for _, step := range process.Steps {
+			usages = append(usages, &Usage{Kind: UsageRunbookStep, Owner: fmt.Sprintf("%s / %s", process.ProjectName, process.RunbookName), Name: step.StepName, Id: step.StepID})
+		}
+	}
+	for _, project := range accountUsage.ProjectVariableSets {
+		if project.IsCurrentlyBeingUsedInProject {
+			usages = append(usages, &Usage{Kind: UsageProjectVariable, Name: project.ProjectName, Id: project.ProjectID})
+		}
+	}
+	for _, libraryVariableSet := range accountUsage.LibraryVariableSets {
+		usages = append(usages, &Usage{Kind: UsageLibraryVariableSet, Name: libraryVariableSet.LibraryVariableSetName, Id: libraryVariableSet.LibraryVariableSetID})
+	}
+	return usages
+}
+
+// DescribeUsage is a usage as a line of text.
+func DescribeUsage(usage *Usage) string {
+	if usage.Owner != "" {
+		return fmt.Sprintf("%s: %s %s %s", usage.Kind, usage.Owner, output.Dim("/"), usage.Name)
+	}
+	return fmt.Sprintf("%s: %s %s", usage.Kind, usage.Name, output.Dimf("(%s)", usage.Id))
+}
+
+// PrintUsages writes each usage on its own line.
+func PrintUsages(out io.Writer, usages []*Usage) {
+	for _, usage := range usages {
+		fmt.Fprintf(out, "  %s\n", DescribeUsage(usage))
+	}
+}
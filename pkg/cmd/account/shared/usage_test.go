@@ -0,0 +1,46 @@
+package shared_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/shared"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenUsages(t *testing.T) {
+	accountUsage := accounts.NewAccountUsage()
+	accountUsage.Targets = []*accounts.TargetUsageEntry{{TargetID: "Machines-1", TargetName: "web-01"}}
+	accountUsage.DeploymentProcesses = []*deployments.StepUsage{{
+		ProjectID:   "Projects-1",
+		ProjectName: "Web",
+		Steps:       []*deployments.StepUsageEntry{{StepID: "step-1", StepName: "Deploy to S3"}},
+	}}
+	accountUsage.RunbookProcesses = []*runbooks.RunbookStepUsage{{
+		ProjectName: "Web",
+		RunbookName: "Rotate logs",
+		Steps:       []*deployments.StepUsageEntry{{StepID: "step-2", StepName: "Archive"}},
+	}}
+	accountUsage.ProjectVariableSets = []*variables.ProjectVariableSetUsage{
+		{ProjectID: "Projects-1", ProjectName: "Web", IsCurrentlyBeingUsedInProject: true},
+		{ProjectID: "Projects-2", ProjectName: "Retired", IsCurrentlyBeingUsedInProject: false},
+	}
+	accountUsage.LibraryVariableSets = []*variables.LibraryVariableSetUsageEntry{{LibraryVariableSetID: "LibraryVariableSets-1", LibraryVariableSetName: "AWS"}}
+
+	usages := shared.FlattenUsages(accountUsage)
+
+	assert.Equal(t, []*shared.Usage{
+		{Kind: shared.UsageDeploymentTarget, Name: "web-01", Id: "Machines-1"},
+		{Kind: shared.UsageDeploymentStep, Owner: "Web", Name: "Deploy to S3", Id: "step-1"},
+		{Kind: shared.UsageRunbookStep, Owner: "Web / Rotate logs", Name: "Archive", Id: "step-2"},
+		{Kind: shared.UsageProjectVariable, Name: "Web", Id: "Projects-1"},
+		{Kind: shared.UsageLibraryVariableSet, Name: "AWS", Id: "LibraryVariableSets-1"},
+	}, usages)
+}
+
+func TestFlattenUsages_Unused(t *testing.T) {
+	assert.Empty(t, shared.FlattenUsages(accounts.NewAccountUsage()))
+}
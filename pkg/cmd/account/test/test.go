@@ -0,0 +1,140 @@
+package test
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/task/wait"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagAccount = "account"
+	FlagNoWait  = "no-wait"
+	FlagTimeout = "timeout"
+
+	// TaskName is the name of the server task which checks that Octopus can connect with an account.
+	TaskName = "TestAccount"
+)
+
+type TestFlags struct {
+	Account *flag.Flag[string]
+	NoWait  *flag.Flag[bool]
+	Timeout *flag.Flag[int]
+}
+
+func NewTestFlags() *TestFlags {
+	return &TestFlags{
+		Account: flag.New[string](FlagAccount, false),
+		NoWait:  flag.New[bool](FlagNoWait, false),
+		Timeout: flag.New[int](FlagTimeout, false),
+	}
+}
+
+type TestOptions struct {
+	*TestFlags
+	*cmd.Dependencies
+	Command                *cobra.Command
+	GetAccountCallback     shared.GetAccountCallback
+	GetAllAccountsCallback shared.GetAllAccountsCallback
+	StartTaskCallback      func(task *tasks.Task) (*tasks.Task, error)
+	WaitCallback           func(taskID string) error
+}
+
+func NewTestOptions(flags *TestFlags, dependencies *cmd.Dependencies, command *cobra.Command) *TestOptions {
+	return &TestOptions{
+		TestFlags:    flags,
+		Dependencies: dependencies,
+		Command:      command,
+		GetAccountCallback: func(idOrName string) (accounts.IAccount, error) {
+			return shared.GetAccount(dependencies.Client, idOrName)
+		},
+		GetAllAccountsCallback: func() ([]accounts.IAccount, error) {
+			return dependencies.Client.Accounts.GetAll()
+		},
+		StartTaskCallback: func(task *tasks.Task) (*tasks.Task, error) {
+			return dependencies.Client.Tasks.Add(task)
+		},
+		WaitCallback: func(taskID string) error {
+			return wait.WaitRun(wait.NewWaitOps(dependencies, []string{taskID}, flags.Timeout.Value, wait.DefaultPollInterval, false, false, command))
+		},
+	}
+}
+
+func NewCmdTest(f factory.Factory) *cobra.Command {
+	testFlags := NewTestFlags()
+
+	cmd := &cobra.Command{
+		Use:   "test [<name> | <id>]",
+		Short: "Check that Octopus can connect with an account",
+		Long: heredoc.Doc(`
+			Check that Octopus can connect with an account, by running the same server task as the
+			Save and Test button in the web portal. The command waits for the task to finish, and
+			fails when the check fails.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s account test "AWS Production"
+			%[1]s account rotate "AWS Production" --from-file secret-key.txt --no-prompt && %[1]s account test "AWS Production" --no-prompt
+			%[1]s account test Accounts-1 --no-wait
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if testFlags.Account.Value == "" && len(args) > 0 {
+				testFlags.Account.Value = args[0]
+			}
+
+			opts := NewTestOptions(testFlags, cmd.NewDependencies(f, c), c)
+			return testRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&testFlags.Account.Value, testFlags.Account.Name, "a", "", "Name or ID of the account")
+	flags.BoolVar(&testFlags.NoWait.Value, testFlags.NoWait.Name, false, "Start the check without waiting for it to finish")
+	flags.IntVar(&testFlags.Timeout.Value, testFlags.Timeout.Name, wait.DefaultTimeout, "Time in seconds to wait for the check to finish")
+
+	return cmd
+}
+
+func testRun(opts *TestOptions) error {
+	account, err := shared.ResolveAccount(opts.Ask, opts.NoPrompt, opts.Account.Value,
+		"Select the account you wish to test", opts.GetAccountCallback, opts.GetAllAccountsCallback)
+	if err != nil {
+		return err
+	}
+
+	task, err := opts.StartTaskCallback(NewTestTask(account, opts.Space.GetID()))
+	if err != nil {
+		return err
+	}
+
+	if opts.NoWait.Value {
+		_, err = fmt.Fprintf(opts.Out, "Started testing account '%s' %s.\n", account.GetName(), output.Dimf("(%s)", task.GetID()))
+		return err
+	}
+
+	fmt.Fprintf(opts.Out, "Testing account '%s'...\n", account.GetName())
+	if err := opts.WaitCallback(task.GetID()); err != nil {
+		return fmt.Errorf("the test of account '%s' did not succeed, see task %s for details: %w", account.GetName(), task.GetID(), err)
+	}
+	return nil
+}
+
+// NewTestTask is the server task which tests an account.
+func NewTestTask(account accounts.IAccount, spaceID string) *tasks.Task {
+	task := tasks.NewTask()
+	task.Name = TaskName
+	task.Description = fmt.Sprintf("Test account %s", account.GetName())
+	task.SpaceID = spaceID
+	task.Arguments["AccountId"] = account.GetID()
+	return task
+}
@@ -0,0 +1,282 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/shared"
+	sharedTenants "github.com/OctopusDeploy/cli/pkg/cmd/tenant/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagAccount      = "account"
+	FlagName         = "name"
+	FlagDescription  = "description"
+	FlagEnvironment  = "environment"
+	FlagTenantedMode = "tenanted-mode"
+	FlagTenant       = "tenant"
+	FlagTenantTag    = "tenant-tag"
+	FlagUsername     = "username"
+	FlagAccessKey    = "access-key"
+)
+
+type UpdateFlags struct {
+	Account      *flag.Flag[string]
+	Name         *flag.Flag[string]
+	Description  *flag.Flag[string]
+	Environments *flag.Flag[[]string]
+	TenantedMode *flag.Flag[string]
+	Tenants      *flag.Flag[[]string]
+	TenantTags   *flag.Flag[[]string]
+	Username     *flag.Flag[string]
+	AccessKey    *flag.Flag[string]
+}
+
+func NewUpdateFlags() *UpdateFlags {
+	return &UpdateFlags{
+		Account:      flag.New[string](FlagAccount, false),
+		Name:         flag.New[string](FlagName, false),
+		Description:  flag.New[string](FlagDescription, false),
+		Environments: flag.New[[]string](FlagEnvironment, false),
+		TenantedMode: flag.New[string](FlagTenantedMode, false),
+		Tenants:      flag.New[[]string](FlagTenant, false),
+		TenantTags:   flag.New[[]string](FlagTenantTag, false),
+		Username:     flag.New[string](FlagUsername, false),
+		AccessKey:    flag.New[string](FlagAccessKey, false),
+	}
+}
+
+type UpdateAccountCallback func(account accounts.IAccount) (accounts.IAccount, error)
+
+type UpdateOptions struct {
+	*UpdateFlags
+	*cmd.Dependencies
+	IsFlagSet                   flag.IsSetFunc
+	GetAccountCallback          shared.GetAccountCallback
+	GetAllAccountsCallback      shared.GetAllAccountsCallback
+	ResolveEnvironmentsCallback func(identifiers []string) ([]string, error)
+	GetTenantCallback           sharedTenants.GetTenantCallback
+	UpdateAccountCallback       UpdateAccountCallback
+}
+
+func NewUpdateOptions(flags *UpdateFlags, dependencies *cmd.Dependencies, isFlagSet flag.IsSetFunc) *UpdateOptions {
+	return &UpdateOptions{
+		UpdateFlags:  flags,
+		Dependencies: dependencies,
+		IsFlagSet:    isFlagSet,
+		GetAccountCallback: func(idOrName string) (accounts.IAccount, error) {
+			return shared.GetAccount(dependencies.Client, idOrName)
+		},
+		GetAllAccountsCallback: func() ([]accounts.IAccount, error) {
+			return dependencies.Client.Accounts.GetAll()
+		},
+		ResolveEnvironmentsCallback: func(identifiers []string) ([]string, error) {
+			return helper.ResolveEnvironmentNames(identifiers, dependencies.Client)
+		},
+		GetTenantCallback: func(identifier string) (*tenants.Tenant, error) {
+			return sharedTenants.GetTenant(dependencies.Client, identifier)
+		},
+		UpdateAccountCallback: func(account accounts.IAccount) (accounts.IAccount, error) {
+			return dependencies.Client.Accounts.Update(account)
+		},
+	}
+}
+
+func NewCmdUpdate(f factory.Factory) *cobra.Command {
+	updateFlags := NewUpdateFlags()
+
+	cmd := &cobra.Command{
+		Use:   "update [<name> | <id>]",
+		Short: "Update an account",
+		Long: heredoc.Docf(`
+			Update an account in Octopus Deploy.
+
+			Only the settings given as flags are changed. Environments, tenants and tenant tags given
+			as flags replace those of the account. Secrets are changed with '%s account rotate'.
+		`, constants.ExecutableName),
+		Example: heredoc.Docf(`
+			%[1]s account update "AWS Production" --description "Deploys to the production AWS account"
+			%[1]s account update Accounts-1 --environment Staging --environment Production
+			%[1]s account update --account "Build server" --tenanted-mode TenantedOrUntenanted --tenant-tag "Region/us-east"
+		`, constants.ExecutableName),
+		Aliases: []string{"edit"},
+		Args:    usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if updateFlags.Account.Value == "" && len(args) > 0 {
+				updateFlags.Account.Value = args[0]
+			}
+
+			opts := NewUpdateOptions(updateFlags, cmd.NewDependencies(f, c), c.Flags().Changed)
+			return updateRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&updateFlags.Account.Value, updateFlags.Account.Name, "a", "", "Name or ID of the account to update")
+	flags.StringVarP(&updateFlags.Name.Value, updateFlags.Name.Name, "n", "", "New name of the account")
+	flags.StringVarP(&updateFlags.Description.Value, updateFlags.Description.Name, "d", "", "A summary explaining the use of the account to other users")
+	flags.StringArrayVarP(&updateFlags.Environments.Value, updateFlags.Environments.Name, "e", nil, "The environments that are allowed to use this account, replacing the current ones")
+	flags.StringVar(&updateFlags.TenantedMode.Value, updateFlags.TenantedMode.Name, "", "Choose the kind of deployments where this account can be used: Untenanted, Tenanted or TenantedOrUntenanted")
+	flags.StringArrayVar(&updateFlags.Tenants.Value, updateFlags.Tenants.Name, nil, "The tenants that are allowed to use this account, replacing the current ones")
+	flags.StringArrayVar(&updateFlags.TenantTags.Value, updateFlags.TenantTags.Name, nil, "The tenant tags of the tenants allowed to use this account, replacing the current ones, in the format 'tag set name/tag name'")
+	flags.StringVarP(&updateFlags.Username.Value, updateFlags.Username.Name, "u", "", "The username of an SSH key pair or username/password account")
+	flags.StringVar(&updateFlags.AccessKey.Value, updateFlags.AccessKey.Name, "", "The access key of an AWS account")
+
+	return cmd
+}
+
+func updateRun(opts *UpdateOptions) error {
+	account, err := shared.ResolveAccount(opts.Ask, opts.NoPrompt, opts.Account.Value,
+		"Select the account you wish to update", opts.GetAccountCallback, opts.GetAllAccountsCallback)
+	if err != nil {
+		return err
+	}
+	opts.Account.Value = account.GetName()
+
+	if !opts.NoPrompt && !opts.IsFlagSet(FlagName) && !opts.IsFlagSet(FlagDescription) {
+		if err := PromptMissing(opts, account); err != nil {
+			return err
+		}
+	}
+
+	if err := ApplyFlags(opts, account); err != nil {
+		return err
+	}
+	updated, err := opts.UpdateAccountCallback(account)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(opts.Out, "\nSuccessfully updated account '%s' (%s).\n", updated.GetName(), updated.GetID())
+	if err != nil {
+		return err
+	}
+
+	link := output.Bluef("%s/app#/%s/infrastructure/accounts/%s", opts.Host, opts.Space.GetID(), updated.GetID())
+	fmt.Fprintf(opts.Out, "View this account on Octopus Deploy: %s\n", link)
+
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Account, opts.Name, opts.Description, opts.Environments, opts.TenantedMode, opts.Tenants, opts.TenantTags, opts.Username, opts.AccessKey)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+
+	return nil
+}
+
+// ApplyFlags copies the flags that were given onto the account.
+func ApplyFlags(opts *UpdateOptions, account accounts.IAccount) error {
+	if opts.IsFlagSet(FlagUsername) {
+		switch a := account.(type) {
+		case *accounts.SSHKeyAccount:
+			a.Username = opts.Username.Value
+		case *accounts.UsernamePasswordAccount:
+			a.Username = opts.Username.Value
+		default:
+			return fmt.Errorf("--%s can only be used with SSH key pair and username/password accounts", FlagUsername)
+		}
+	}
+	if opts.IsFlagSet(FlagAccessKey) {
+		a, ok := account.(*accounts.AmazonWebServicesAccount)
+		if !ok {
+			return fmt.Errorf("--%s can only be used with AWS accounts", FlagAccessKey)
+		}
+		a.AccessKey = opts.AccessKey.Value
+	}
+
+	if opts.IsFlagSet(FlagName) {
+		if strings.TrimSpace(opts.Name.Value) == "" {
+			return fmt.Errorf("the name of an account cannot be empty")
+		}
+		account.SetName(opts.Name.Value)
+	}
+	if opts.IsFlagSet(FlagDescription) {
+		account.SetDescription(opts.Description.Value)
+	}
+	if opts.IsFlagSet(FlagEnvironment) {
+		environmentIDs, err := opts.ResolveEnvironmentsCallback(opts.Environments.Value)
+		if err != nil {
+			return err
+		}
+		account.SetEnvironmentIDs(environmentIDs)
+	}
+	if opts.IsFlagSet(FlagTenantedMode) {
+		mode, err := parseTenantedMode(opts.TenantedMode.Value)
+		if err != nil {
+			return err
+		}
+		account.SetTenantedDeploymentMode(mode)
+	}
+	if opts.IsFlagSet(FlagTenant) {
+		tenantIDs := make([]string, 0, len(opts.Tenants.Value))
+		for _, identifier := range opts.Tenants.Value {
+			tenant, err := opts.GetTenantCallback(identifier)
+			if err != nil {
+				return fmt.Errorf("cannot find tenant '%s'", identifier)
+			}
+			tenantIDs = append(tenantIDs, tenant.GetID())
+		}
+		account.SetTenantIDs(tenantIDs)
+	}
+	if opts.IsFlagSet(FlagTenantTag) {
+		account.SetTenantTags(opts.TenantTags.Value)
+	}
+	return nil
+}
+
+func parseTenantedMode(value string) (core.TenantedDeploymentMode, error) {
+	for _, mode := range []core.TenantedDeploymentMode{
+		core.TenantedDeploymentModeUntenanted,
+		core.TenantedDeploymentModeTenanted,
+		core.TenantedDeploymentModeTenantedOrUntenanted,
+	} {
+		if strings.EqualFold(string(mode), value) {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("unknown tenanted mode '%s', must be one of Untenanted, Tenanted or TenantedOrUntenanted", value)
+}
+
+// PromptMissing asks for a new name and description, offering the current values as defaults.
+func PromptMissing(opts *UpdateOptions, account accounts.IAccount) error {
+	opts.Name.Value = account.GetName()
+	if err := opts.Ask(&survey.Input{
+		Message: "Name",
+		Help:    "A short, memorable, unique name for this account.",
+		Default: opts.Name.Value,
+	}, &opts.Name.Value, survey.WithValidator(survey.ComposeValidators(
+		survey.MaxLength(200),
+		survey.MinLength(1),
+		survey.Required,
+	))); err != nil {
+		return err
+	}
+
+	opts.Description.Value = account.GetDescription()
+	if err := opts.Ask(&survey.Input{
+		Message: "Description",
+		Help:    "A summary explaining the use of the account to other users.",
+		Default: opts.Description.Value,
+	}, &opts.Description.Value); err != nil {
+		return err
+	}
+
+	// the prompted values are always applied, and shown in the automation command
+	isFlagSet := opts.IsFlagSet
+	opts.IsFlagSet = func(name string) bool {
+		return name == FlagName || name == FlagDescription || isFlagSet(name)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package update_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/update"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFlags_OnlyChangesGivenFlags(t *testing.T) {
+	account, err := accounts.NewUsernamePasswordAccount("Database")
+	require.NoError(t, err)
+	account.Username = "admin"
+	account.Description = "Production database"
+	account.EnvironmentIDs = []string{"Environments-1"}
+
+	flags := update.NewUpdateFlags()
+	flags.Username.Value = "deployer"
+	flags.Environments.Value = []string{"Staging", "Production"}
+	flags.TenantedMode.Value = "tenantedoruntenanted"
+	given := map[string]bool{update.FlagUsername: true, update.FlagEnvironment: true, update.FlagTenantedMode: true}
+	opts := update.NewUpdateOptions(flags, &cmd.Dependencies{}, func(name string) bool { return given[name] })
+	opts.ResolveEnvironmentsCallback = func(identifiers []string) ([]string, error) {
+		return []string{"Environments-2", "Environments-3"}, nil
+	}
+
+	err = update.ApplyFlags(opts, account)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Database", account.GetName())
+	assert.Equal(t, "Production database", account.GetDescription())
+	assert.Equal(t, "deployer", account.Username)
+	assert.Equal(t, []string{"Environments-2", "Environments-3"}, account.GetEnvironmentIDs())
+	assert.Equal(t, core.TenantedDeploymentModeTenantedOrUntenanted, account.GetTenantedDeploymentMode())
+}
+
+func TestApplyFlags_UsernameOnlyForAccountsWithUsername(t *testing.T) {
+	account, err := accounts.NewTokenAccount("GitHub", core.NewSensitiveValue("token"))
+	require.NoError(t, err)
+
+	flags := update.NewUpdateFlags()
+	flags.Username.Value = "deployer"
+	opts := update.NewUpdateOptions(flags, &cmd.Dependencies{}, func(name string) bool { return name == update.FlagUsername })
+
+	err = update.ApplyFlags(opts, account)
+
+	assert.EqualError(t, err, "--username can only be used with SSH key pair and username/password accounts")
+}
@@ -0,0 +1,120 @@
+package usages
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/spf13/cobra"
+)
+
+const FlagAccount = "account"
+
+type UsagesFlags struct {
+	Account *flag.Flag[string]
+}
+
+func NewUsagesFlags() *UsagesFlags {
+	return &UsagesFlags{
+		Account: flag.New[string](FlagAccount, false),
+	}
+}
+
+type UsagesOptions struct {
+	*UsagesFlags
+	*cmd.Dependencies
+	Command                *cobra.Command
+	GetAccountCallback     shared.GetAccountCallback
+	GetAllAccountsCallback shared.GetAllAccountsCallback
+	GetUsagesCallback      shared.GetUsagesCallback
+}
+
+func NewUsagesOptions(flags *UsagesFlags, dependencies *cmd.Dependencies, command *cobra.Command) *UsagesOptions {
+	return &UsagesOptions{
+		UsagesFlags:  flags,
+		Dependencies: dependencies,
+		Command:      command,
+		GetAccountCallback: func(idOrName string) (accounts.IAccount, error) {
+			return shared.GetAccount(dependencies.Client, idOrName)
+		},
+		GetAllAccountsCallback: func() ([]accounts.IAccount, error) {
+			return dependencies.Client.Accounts.GetAll()
+		},
+		GetUsagesCallback: func(account accounts.IAccount) ([]*shared.Usage, error) {
+			return shared.GetUsages(dependencies.Client, account)
+		},
+	}
+}
+
+func NewCmdUsages(f factory.Factory) *cobra.Command {
+	usagesFlags := NewUsagesFlags()
+
+	cmd := &cobra.Command{
+		Use:   "usages [<name> | <id>]",
+		Short: "List what uses an account",
+		Long: heredoc.Doc(`
+			List the deployment targets, deployment process steps, runbook steps, project variables
+			and library variable sets which reference an account in Octopus Deploy.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s account usages "AWS Production"
+			%[1]s account usages --account Accounts-1 --output-format json
+		`, constants.ExecutableName),
+		Aliases: []string{"usage"},
+		Args:    usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if usagesFlags.Account.Value == "" && len(args) > 0 {
+				usagesFlags.Account.Value = args[0]
+			}
+
+			opts := NewUsagesOptions(usagesFlags, cmd.NewDependencies(f, c), c)
+			return usagesRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&usagesFlags.Account.Value, usagesFlags.Account.Name, "a", "", "Name or ID of the account")
+
+	return cmd
+}
+
+func usagesRun(opts *UsagesOptions) error {
+	account, err := shared.ResolveAccount(opts.Ask, opts.NoPrompt, opts.Account.Value,
+		"Select the account you wish to see the usages of", opts.GetAccountCallback, opts.GetAllAccountsCallback)
+	if err != nil {
+		return err
+	}
+
+	usages, err := opts.GetUsagesCallback(account)
+	if err != nil {
+		return err
+	}
+
+	outputFormat, _ := opts.Command.Flags().GetString(constants.FlagOutputFormat)
+	if len(usages) == 0 && !constants.IsProgrammaticOutputFormat(outputFormat) {
+		_, err = fmt.Fprintf(opts.Out, "Account '%s' is not used by anything.\n", account.GetName())
+		return err
+	}
+
+	return output.PrintArray(usages, opts.Command, output.Mappers[*shared.Usage]{
+		Json: func(u *shared.Usage) any {
+			return u
+		},
+		Table: output.TableDefinition[*shared.Usage]{
+			Header: []string{"KIND", "PROJECT", "NAME", "ID"},
+			Row: func(u *shared.Usage) []string {
+				return []string{u.Kind, u.Owner, output.Bold(u.Name), u.Id}
+			},
+		},
+		Basic: func(u *shared.Usage) string {
+			return shared.DescribeUsage(u)
+		},
+	})
+}
@@ -0,0 +1,283 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/google/uuid"
+	"github.com/pkg/browser"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagAccount = "account"
+	FlagWeb     = "web"
+)
+
+type ViewFlags struct {
+	Account *flag.Flag[string]
+	Web     *flag.Flag[bool]
+}
+
+func NewViewFlags() *ViewFlags {
+	return &ViewFlags{
+		Account: flag.New[string](FlagAccount, false),
+		Web:     flag.New[bool](FlagWeb, false),
+	}
+}
+
+type ViewOptions struct {
+	*ViewFlags
+	*cmd.Dependencies
+	Command                 *cobra.Command
+	GetAccountCallback      shared.GetAccountCallback
+	GetAllAccountsCallback  shared.GetAllAccountsCallback
+	GetEnvironmentsByIDs    func(ids []string) ([]*environments.Environment, error)
+	GetTenantsByIDsCallback func(ids []string) ([]*tenants.Tenant, error)
+}
+
+func NewViewOptions(flags *ViewFlags, dependencies *cmd.Dependencies, command *cobra.Command) *ViewOptions {
+	return &ViewOptions{
+		ViewFlags:    flags,
+		Dependencies: dependencies,
+		Command:      command,
+		GetAccountCallback: func(idOrName string) (accounts.IAccount, error) {
+			return shared.GetAccount(dependencies.Client, idOrName)
+		},
+		GetAllAccountsCallback: func() ([]accounts.IAccount, error) {
+			return dependencies.Client.Accounts.GetAll()
+		},
+		GetEnvironmentsByIDs: func(ids []string) ([]*environments.Environment, error) {
+			if len(ids) == 0 {
+				return nil, nil
+			}
+			return dependencies.Client.Environments.GetByIDs(ids)
+		},
+		GetTenantsByIDsCallback: func(ids []string) ([]*tenants.Tenant, error) {
+			if len(ids) == 0 {
+				return nil, nil
+			}
+			return dependencies.Client.Tenants.GetByIDs(ids)
+		},
+	}
+}
+
+func NewCmdView(f factory.Factory) *cobra.Command {
+	viewFlags := NewViewFlags()
+	cmd := &cobra.Command{
+		Args:  usage.MaximumNArgs(1),
+		Use:   "view [<name> | <id>]",
+		Short: "View an account",
+		Long:  "View an account in Octopus Deploy. Secrets are never shown, only whether they are set.",
+		Example: heredoc.Docf(`
+			%[1]s account view Accounts-1
+			%[1]s account view "AWS Production"
+			%[1]s account view --account "AWS Production" --output-format json
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, args []string) error {
+			if viewFlags.Account.Value == "" && len(args) > 0 {
+				viewFlags.Account.Value = args[0]
+			}
+
+			opts := NewViewOptions(viewFlags, cmd.NewDependencies(f, c), c)
+			return viewRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&viewFlags.Account.Value, viewFlags.Account.Name, "a", "", "Name or ID of the account")
+	flags.BoolVarP(&viewFlags.Web.Value, viewFlags.Web.Name, "w", false, "Open in web browser")
+
+	return cmd
+}
+
+// Detail is a setting of an account that depends on its type.
+type Detail struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+type AccountAsJson struct {
+	Id                     string             `json:"Id"`
+	Name                   string             `json:"Name"`
+	Slug                   string             `json:"Slug"`
+	AccountType            string             `json:"AccountType"`
+	Description            string             `json:"Description"`
+	Environments           []output.IdAndName `json:"Environments"`
+	TenantedDeploymentMode string             `json:"TenantedDeploymentMode"`
+	Tenants                []output.IdAndName `json:"Tenants"`
+	TenantTags             []string           `json:"TenantTags"`
+	Details                []Detail           `json:"Details"`
+	WebUrl                 string             `json:"WebUrl"`
+}
+
+func viewRun(opts *ViewOptions) error {
+	account, err := shared.ResolveAccount(opts.Ask, opts.NoPrompt, opts.Account.Value,
+		"Select the account you wish to view", opts.GetAccountCallback, opts.GetAllAccountsCallback)
+	if err != nil {
+		return err
+	}
+
+	accountEnvironments, err := opts.GetEnvironmentsByIDs(account.GetEnvironmentIDs())
+	if err != nil {
+		return err
+	}
+	accountTenants, err := opts.GetTenantsByIDsCallback(account.GetTenantIDs())
+	if err != nil {
+		return err
+	}
+	environmentNames := util.SliceTransform(accountEnvironments, func(e *environments.Environment) output.IdAndName {
+		return output.IdAndName{Id: e.GetID(), Name: e.Name}
+	})
+	tenantNames := util.SliceTransform(accountTenants, func(t *tenants.Tenant) output.IdAndName {
+		return output.IdAndName{Id: t.GetID(), Name: t.Name}
+	})
+
+	link := util.GenerateWebURL(opts.Host, account.GetSpaceID(), fmt.Sprintf("infrastructure/accounts/%s", account.GetID()))
+	details := Details(account)
+
+	err = output.PrintResource(account, opts.Command, output.Mappers[accounts.IAccount]{
+		Json: func(a accounts.IAccount) any {
+			return AccountAsJson{
+				Id:                     a.GetID(),
+				Name:                   a.GetName(),
+				Slug:                   a.GetSlug(),
+				AccountType:            string(a.GetAccountType()),
+				Description:            a.GetDescription(),
+				Environments:           environmentNames,
+				TenantedDeploymentMode: string(a.GetTenantedDeploymentMode()),
+				Tenants:                tenantNames,
+				TenantTags:             a.GetTenantTags(),
+				Details:                details,
+				WebUrl:                 link,
+			}
+		},
+		Table: output.TableDefinition[accounts.IAccount]{
+			Header: []string{"NAME", "TYPE", "ENVIRONMENTS", "TENANTED", "TENANTS", "TENANT TAGS"},
+			Row: func(a accounts.IAccount) []string {
+				return []string{
+					output.Bold(a.GetName()),
+					shared.AccountTypeNames[a.GetAccountType()],
+					output.FormatAsList(util.SliceTransform(environmentNames, func(e output.IdAndName) string { return e.Name })),
+					string(a.GetTenantedDeploymentMode()),
+					output.FormatAsList(util.SliceTransform(tenantNames, func(t output.IdAndName) string { return t.Name })),
+					output.FormatAsList(a.GetTenantTags()),
+				}
+			},
+		},
+		Basic: func(a accounts.IAccount) string {
+			var s strings.Builder
+			s.WriteString(fmt.Sprintf("%s %s\n", output.Bold(a.GetName()), output.Dimf("(%s)", a.GetID())))
+			s.WriteString(fmt.Sprintf("Type: %s\n", shared.AccountTypeNames[a.GetAccountType()]))
+			if a.GetDescription() == "" {
+				s.WriteString(fmt.Sprintln(output.Dim(constants.NoDescription)))
+			} else {
+				s.WriteString(fmt.Sprintln(output.Dim(a.GetDescription())))
+			}
+			for _, d := range details {
+				s.WriteString(fmt.Sprintf("%s: %s\n", d.Name, d.Value))
+			}
+
+			if len(environmentNames) == 0 {
+				s.WriteString("Environments: all environments\n")
+			} else {
+				s.WriteString(fmt.Sprintf("Environments: %s\n", output.FormatAsList(util.SliceTransform(environmentNames, func(e output.IdAndName) string { return e.Name }))))
+			}
+			s.WriteString(fmt.Sprintf("Tenanted deployments: %s\n", a.GetTenantedDeploymentMode()))
+			if len(tenantNames) > 0 {
+				s.WriteString(fmt.Sprintf("Tenants: %s\n", output.FormatAsList(util.SliceTransform(tenantNames, func(t output.IdAndName) string { return t.Name }))))
+			}
+			if len(a.GetTenantTags()) > 0 {
+				s.WriteString(fmt.Sprintf("Tenant tags: %s\n", output.FormatAsList(a.GetTenantTags())))
+			}
+
+			s.WriteString(fmt.Sprintf("View this account in Octopus Deploy: %s\n", output.Blue(link)))
+			return s.String()
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.Web.Value {
+		browser.OpenURL(link)
+	}
+	return nil
+}
+
+// Details lists the settings particular to the type of an account. Secrets are only shown as set or not set.
+func Details(account accounts.IAccount) []Detail {
+	switch a := account.(type) {
+	case *accounts.AmazonWebServicesAccount:
+		return []Detail{{"Access key", a.AccessKey}, {"Secret key", describeSecret(a.SecretKey)}}
+	case *accounts.AwsOIDCAccount:
+		return []Detail{{"Role ARN", a.RoleArn}, {"Session duration", a.SessionDuration}}
+	case *accounts.AzureServicePrincipalAccount:
+		return []Detail{
+			{"Subscription ID", describeUUID(a.SubscriptionID)},
+			{"Tenant ID", describeUUID(a.TenantID)},
+			{"Application ID", describeUUID(a.ApplicationID)},
+			{"Application password", describeSecret(a.ApplicationPassword)},
+			{"Azure environment", describeAzureEnvironment(a.AzureEnvironment)},
+		}
+	case *accounts.AzureOIDCAccount:
+		return []Detail{
+			{"Subscription ID", describeUUID(a.SubscriptionID)},
+			{"Tenant ID", describeUUID(a.TenantID)},
+			{"Application ID", describeUUID(a.ApplicationID)},
+			{"Audience", a.Audience},
+			{"Azure environment", describeAzureEnvironment(a.AzureEnvironment)},
+		}
+	case *accounts.AzureSubscriptionAccount:
+		return []Detail{
+			{"Subscription ID", describeUUID(a.SubscriptionID)},
+			{"Certificate thumbprint", a.CertificateThumbprint},
+			{"Azure environment", describeAzureEnvironment(a.AzureEnvironment)},
+		}
+	case *accounts.GenericOIDCAccount:
+		return []Detail{{"Audience", a.Audience}, {"Deployment subject keys", strings.Join(a.DeploymentSubjectKeys, ", ")}}
+	case *accounts.GoogleCloudPlatformAccount:
+		return []Detail{{"JSON key", describeSecret(a.JsonKey)}}
+	case *accounts.SSHKeyAccount:
+		return []Detail{{"Username", a.Username}, {"Private key", describeSecret(a.PrivateKeyFile)}, {"Passphrase", describeSecret(a.PrivateKeyPassphrase)}}
+	case *accounts.TokenAccount:
+		return []Detail{{"Token", describeSecret(a.Token)}}
+	case *accounts.UsernamePasswordAccount:
+		return []Detail{{"Username", a.Username}, {"Password", describeSecret(a.Password)}}
+	}
+	return []Detail{}
+}
+
+func describeSecret(value *core.SensitiveValue) string {
+	if value != nil && value.HasValue {
+		return "set"
+	}
+	return "not set"
+}
+
+func describeUUID(value *uuid.UUID) string {
+	if value == nil {
+		return ""
+	}
+	return value.String()
+}
+
+func describeAzureEnvironment(environment string) string {
+	if environment == "" {
+		return "AzureCloud"
+	}
+	return environment
+}
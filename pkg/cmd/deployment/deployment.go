@@ -0,0 +1,29 @@
+package deployment
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/deployment/list"
+	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/deployment/view"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdDeployment(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "deployment <command>",
+		Short:   "View deployments",
+		Long:    "View deployments in Octopus Deploy, and what is deployed where",
+		Example: heredoc.Docf("%s deployment list --dashboard", constants.ExecutableName),
+		Aliases: []string{"deployments"},
+		Annotations: map[string]string{
+			annotations.IsCore: "true",
+		},
+	}
+
+	cmd.AddCommand(cmdList.NewCmdList(f))
+	cmd.AddCommand(cmdView.NewCmdView(f))
+
+	return cmd
+}
@@ -0,0 +1,266 @@
+package list
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/deployment/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/dashboard"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+)
+
+const untenanted = "Untenanted"
+
+type DashboardItemAsJson struct {
+	ProjectId       string `json:"ProjectId"`
+	ProjectName     string `json:"ProjectName"`
+	EnvironmentId   string `json:"EnvironmentId"`
+	EnvironmentName string `json:"EnvironmentName"`
+	TenantId        string `json:"TenantId,omitempty"`
+	TenantName      string `json:"TenantName,omitempty"`
+	ReleaseId       string `json:"ReleaseId"`
+	ReleaseVersion  string `json:"ReleaseVersion"`
+	DeploymentId    string `json:"DeploymentId"`
+	TaskId          string `json:"TaskId"`
+	State           string `json:"State"`
+}
+
+// dashboardRun prints the release currently deployed to each environment as a matrix,
+// like the dashboard in the web portal. For a single tenanted project the rows are its
+// tenants; otherwise they are projects, with the tenants of tenanted projects summarised.
+func dashboardRun(opts *ListOptions) error {
+	if opts.Release.Value != "" || len(opts.Channels.Value) > 0 || opts.Since.Value != "" || opts.Until.Value != "" {
+		return errors.New("--dashboard shows the current release only, so it can't be combined with --release, --channel, --since or --until")
+	}
+
+	selectedProjects, err := findProjects(opts, opts.Projects.Value)
+	if err != nil {
+		return err
+	}
+	projectIDs := util.SliceTransform(selectedProjects, func(p *projects.Project) string { return p.GetID() })
+	environmentIDs, err := findEnvironmentIDs(opts, opts.Environments.Value)
+	if err != nil {
+		return err
+	}
+	tenantIDs, err := findTenantIDs(opts, opts.Tenants.Value)
+	if err != nil {
+		return err
+	}
+
+	singleProjectID := ""
+	if len(projectIDs) == 1 {
+		singleProjectID = projectIDs[0]
+	}
+	dash, err := shared.GetDashboard(opts.Client, singleProjectID, tenantIDs)
+	if err != nil {
+		return err
+	}
+
+	items := util.SliceFilter(dash.Items, func(item *dashboard.DashboardItem) bool {
+		return (len(projectIDs) == 0 || util.SliceContains(projectIDs, item.ProjectID)) &&
+			(len(environmentIDs) == 0 || util.SliceContains(environmentIDs, item.EnvironmentID)) &&
+			(len(tenantIDs) == 0 || util.SliceContains(tenantIDs, item.TenantID))
+	})
+	environments := util.SliceFilter(dash.Environments, func(e *shared.DashboardResource) bool {
+		return len(environmentIDs) == 0 || util.SliceContains(environmentIDs, e.ID)
+	})
+	names := dashboardNames(dash)
+
+	outputFormat, err := opts.Command.Flags().GetString(constants.FlagOutputFormat)
+	if err != nil { // should never happen, but fallback if it does
+		outputFormat = constants.OutputFormatTable
+	}
+
+	switch strings.ToLower(outputFormat) {
+	case constants.OutputFormatJson:
+		result := util.SliceTransform(items, func(item *dashboard.DashboardItem) DashboardItemAsJson {
+			return dashboardItemToJson(item, names)
+		})
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		opts.Command.Println(string(data))
+	case constants.OutputFormatBasic:
+		for _, item := range items {
+			line := fmt.Sprintf("%s %s %s", shared.Lookup(names, item.ProjectID), shared.Lookup(names, item.EnvironmentID), item.ReleaseVersion)
+			if item.TenantID != "" {
+				line = fmt.Sprintf("%s %s", line, shared.Lookup(names, item.TenantID))
+			}
+			opts.Command.Println(line)
+		}
+	case constants.OutputFormatTable, "":
+		isTenantView := singleProjectID != "" && util.SliceContainsAny(items, func(item *dashboard.DashboardItem) bool { return item.TenantID != "" })
+		return printMatrix(opts, dash, items, environments, names, isTenantView)
+	default:
+		return usage.NewUsageError(
+			fmt.Sprintf("unsupported output format %s. Valid values are 'json', 'table', 'basic'. Defaults to table", outputFormat),
+			opts.Command)
+	}
+	return nil
+}
+
+func dashboardNames(dash *shared.Dashboard) map[string]string {
+	names := map[string]string{}
+	for _, resources := range [][]*shared.DashboardResource{dash.Projects, dash.Environments, dash.Tenants} {
+		for _, r := range resources {
+			names[r.ID] = r.Name
+		}
+	}
+	return names
+}
+
+func dashboardItemToJson(item *dashboard.DashboardItem, names map[string]string) DashboardItemAsJson {
+	result := DashboardItemAsJson{
+		ProjectId:       item.ProjectID,
+		ProjectName:     shared.Lookup(names, item.ProjectID),
+		EnvironmentId:   item.EnvironmentID,
+		EnvironmentName: shared.Lookup(names, item.EnvironmentID),
+		TenantId:        item.TenantID,
+		ReleaseId:       item.ReleaseID,
+		ReleaseVersion:  item.ReleaseVersion,
+		DeploymentId:    item.DeploymentID,
+		TaskId:          item.TaskID,
+		State:           itemState(item),
+	}
+	if item.TenantID != "" {
+		result.TenantName = shared.Lookup(names, item.TenantID)
+	}
+	return result
+}
+
+// itemState is the state of a dashboard item as the web portal colours it, where a
+// successful deployment that logged warnings or errors stands out from a clean one.
+func itemState(item *dashboard.DashboardItem) string {
+	if item.State == "Success" && item.HasWarningsOrErrors {
+		return "SuccessWithWarning"
+	}
+	return item.State
+}
+
+// stateSeverity orders states so a cell summarising several deployments shows the
+// worst of them.
+func stateSeverity(state string) int {
+	switch state {
+	case "Failed", "TimedOut":
+		return 3
+	case "SuccessWithWarning", "Queued", "Executing", "Cancelling", "Canceled":
+		return 2
+	case "Success":
+		return 1
+	}
+	return 0
+}
+
+func printMatrix(opts *ListOptions, dash *shared.Dashboard, items []*dashboard.DashboardItem, environments []*shared.DashboardResource, names map[string]string, isTenantView bool) error {
+	if len(items) == 0 {
+		opts.Command.Println("Nothing has been deployed yet")
+		return nil
+	}
+
+	rowKey := func(item *dashboard.DashboardItem) string { return item.ProjectID }
+	if isTenantView {
+		rowKey = func(item *dashboard.DashboardItem) string { return item.TenantID }
+	}
+
+	cells := map[string]map[string][]*dashboard.DashboardItem{}
+	var rowIDs []string
+	for _, item := range items {
+		key := rowKey(item)
+		if _, ok := cells[key]; !ok {
+			cells[key] = map[string][]*dashboard.DashboardItem{}
+			rowIDs = append(rowIDs, key)
+		}
+		cells[key][item.EnvironmentID] = append(cells[key][item.EnvironmentID], item)
+	}
+	rowIDs = orderRows(dash, rowIDs, names, isTenantView)
+
+	t := output.NewTable(opts.Out)
+	header := []string{output.Bold("PROJECT")}
+	if isTenantView {
+		header[0] = output.Bold("TENANT")
+		opts.Command.Printf("Project %s\n", output.Cyan(shared.Lookup(names, items[0].ProjectID)))
+	}
+	for _, e := range environments {
+		header = append(header, output.Bold(strings.ToUpper(e.Name)))
+	}
+	t.AddRow(header...)
+
+	for _, rowID := range rowIDs {
+		rowName := shared.Lookup(names, rowID)
+		if rowID == "" {
+			rowName = untenanted
+		}
+		row := []string{rowName}
+		for _, e := range environments {
+			row = append(row, formatCell(cells[rowID][e.ID], isTenantView))
+		}
+		t.AddRow(row...)
+	}
+	return t.Print()
+}
+
+// orderRows lists projects in the order the server returned them, and tenants by name
+// with untenanted deployments last.
+func orderRows(dash *shared.Dashboard, rowIDs []string, names map[string]string, isTenantView bool) []string {
+	if isTenantView {
+		sort.SliceStable(rowIDs, func(i, j int) bool {
+			if rowIDs[i] == "" || rowIDs[j] == "" {
+				return rowIDs[j] == ""
+			}
+			return strings.ToLower(shared.Lookup(names, rowIDs[i])) < strings.ToLower(shared.Lookup(names, rowIDs[j]))
+		})
+		return rowIDs
+	}
+
+	var result []string
+	for _, p := range dash.Projects {
+		if util.SliceContains(rowIDs, p.ID) {
+			result = append(result, p.ID)
+		}
+	}
+	for _, id := range rowIDs {
+		if !util.SliceContains(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// formatCell shows the release version in a cell coloured by how its deployment went.
+// When the cell covers the tenants of a tenanted project, each version deployed is
+// shown with the number of tenants that have it.
+func formatCell(items []*dashboard.DashboardItem, isTenantView bool) string {
+	if len(items) == 0 {
+		return output.Dim("-")
+	}
+	if len(items) == 1 && (isTenantView || items[0].TenantID == "") {
+		return output.FormatByTaskState(itemState(items[0]), items[0].ReleaseVersion)
+	}
+
+	var versions []string
+	counts := map[string]int{}
+	states := map[string]string{}
+	for _, item := range items {
+		if _, ok := counts[item.ReleaseVersion]; !ok {
+			versions = append(versions, item.ReleaseVersion)
+		}
+		counts[item.ReleaseVersion]++
+		if state := itemState(item); stateSeverity(state) > stateSeverity(states[item.ReleaseVersion]) {
+			states[item.ReleaseVersion] = state
+		}
+	}
+
+	cell := make([]string, 0, len(versions))
+	for _, v := range versions {
+		cell = append(cell, output.FormatByTaskState(states[v], fmt.Sprintf("%s (%d)", v, counts[v])))
+	}
+	return strings.Join(cell, ", ")
+}
@@ -0,0 +1,282 @@
+package list
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/deployment/shared"
+	sharedProgression "github.com/OctopusDeploy/cli/pkg/cmd/release/progression/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/executionscommon"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject     = "project"
+	FlagEnvironment = "environment"
+	FlagTenant      = "tenant"
+	FlagChannel     = "channel"
+	FlagRelease     = "release"
+	FlagSince       = "since"
+	FlagUntil       = "until"
+	FlagLimit       = "limit"
+	FlagDashboard   = "dashboard"
+)
+
+const defaultLimit = 30
+
+type ListFlags struct {
+	Projects     *flag.Flag[[]string]
+	Environments *flag.Flag[[]string]
+	Tenants      *flag.Flag[[]string]
+	Channels     *flag.Flag[[]string]
+	Release      *flag.Flag[string]
+	Since        *flag.Flag[string]
+	Until        *flag.Flag[string]
+	Limit        *flag.Flag[int]
+	Dashboard    *flag.Flag[bool]
+}
+
+func NewListFlags() *ListFlags {
+	return &ListFlags{
+		Projects:     flag.New[[]string](FlagProject, false),
+		Environments: flag.New[[]string](FlagEnvironment, false),
+		Tenants:      flag.New[[]string](FlagTenant, false),
+		Channels:     flag.New[[]string](FlagChannel, false),
+		Release:      flag.New[string](FlagRelease, false),
+		Since:        flag.New[string](FlagSince, false),
+		Until:        flag.New[string](FlagUntil, false),
+		Limit:        flag.New[int](FlagLimit, false),
+		Dashboard:    flag.New[bool](FlagDashboard, false),
+	}
+}
+
+type ListOptions struct {
+	*ListFlags
+	*cmd.Dependencies
+	Command *cobra.Command
+}
+
+func NewCmdList(f factory.Factory) *cobra.Command {
+	listFlags := NewListFlags()
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List deployments",
+		Long:  "List deployments in Octopus Deploy, most recent first, or show what is deployed where as a dashboard",
+		Example: heredoc.Docf(`
+			%[1]s deployment list
+			%[1]s deployment list --project myProject --environment Production --since 2024-01-01
+			%[1]s deployment list --project myProject --release 2.0
+			%[1]s deployment list --dashboard
+			%[1]s deployment list --dashboard --project myTenantedProject
+		`, constants.ExecutableName),
+		Aliases: []string{"ls"},
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := &ListOptions{
+				ListFlags:    listFlags,
+				Dependencies: cmd.NewDependencies(f, c),
+				Command:      c,
+			}
+			if opts.Dashboard.Value {
+				return dashboardRun(opts)
+			}
+			return listRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&listFlags.Projects.Value, listFlags.Projects.Name, "p", nil, "Name or ID of a project to list deployments for. May be specified multiple times")
+	flags.StringSliceVarP(&listFlags.Environments.Value, listFlags.Environments.Name, "e", nil, "Name or ID of an environment to list deployments to. May be specified multiple times")
+	flags.StringSliceVar(&listFlags.Tenants.Value, listFlags.Tenants.Name, nil, "Name or ID of a tenant to list deployments for. May be specified multiple times")
+	flags.StringSliceVar(&listFlags.Channels.Value, listFlags.Channels.Name, nil, "Name or ID of a channel to list deployments from. May be specified multiple times; requires --project")
+	flags.StringVar(&listFlags.Release.Value, listFlags.Release.Name, "", "Version of the release to list deployments of; requires a single --project")
+	flags.StringVar(&listFlags.Since.Value, listFlags.Since.Name, "", "Only list deployments created on or after this date, as YYYY-MM-DD or RFC3339")
+	flags.StringVar(&listFlags.Until.Value, listFlags.Until.Name, "", "Only list deployments created on or before this date, as YYYY-MM-DD or RFC3339")
+	flags.IntVar(&listFlags.Limit.Value, listFlags.Limit.Name, defaultLimit, "Maximum number of deployments to list; 0 lists them all")
+	flags.BoolVar(&listFlags.Dashboard.Value, listFlags.Dashboard.Name, false, "Show the current release of each project in each environment instead of a list")
+
+	return cmd
+}
+
+func listRun(opts *ListOptions) error {
+	query, filter, err := buildQuery(opts)
+	if err != nil {
+		return err
+	}
+
+	allDeployments, err := shared.GetDeployments(opts.Client, *query, *filter, opts.Limit.Value)
+	if err != nil {
+		return err
+	}
+
+	lookups, err := shared.GetLookups(opts.Client, allDeployments)
+	if err != nil {
+		return err
+	}
+
+	items := util.SliceTransform(allDeployments, func(d *deployments.Deployment) shared.DeploymentAsJson {
+		return shared.ToJson(d, lookups)
+	})
+	hasTenants := util.SliceContainsAny(items, func(d shared.DeploymentAsJson) bool { return d.TenantId != "" })
+
+	return output.PrintArray(items, opts.Command, output.Mappers[shared.DeploymentAsJson]{
+		Json: func(item shared.DeploymentAsJson) any {
+			return item
+		},
+		Table: output.TableDefinition[shared.DeploymentAsJson]{
+			Header: tableHeader(hasTenants),
+			Row: func(item shared.DeploymentAsJson) []string {
+				row := []string{item.ProjectName, item.ReleaseVersion, item.EnvironmentName}
+				if hasTenants {
+					row = append(row, item.TenantName)
+				}
+				return append(row, output.FormatTaskState(item.State), shared.FormatTime(item.Created))
+			}},
+		Basic: func(item shared.DeploymentAsJson) string {
+			return fmt.Sprintf("%s %s %s", item.ProjectName, item.ReleaseVersion, item.EnvironmentName)
+		},
+	})
+}
+
+func tableHeader(hasTenants bool) []string {
+	if hasTenants {
+		return []string{"PROJECT", "RELEASE", "ENVIRONMENT", "TENANT", "STATE", "CREATED"}
+	}
+	return []string{"PROJECT", "RELEASE", "ENVIRONMENT", "STATE", "CREATED"}
+}
+
+// buildQuery turns the filter flags into the query the server understands and the
+// filter we apply ourselves, resolving names to IDs along the way.
+func buildQuery(opts *ListOptions) (*shared.DeploymentsQuery, *shared.Filter, error) {
+	query := &shared.DeploymentsQuery{}
+	filter := &shared.Filter{}
+
+	selectedProjects, err := findProjects(opts, opts.Projects.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range selectedProjects {
+		query.Projects = append(query.Projects, p.GetID())
+	}
+
+	if query.Environments, err = findEnvironmentIDs(opts, opts.Environments.Value); err != nil {
+		return nil, nil, err
+	}
+	if query.Tenants, err = findTenantIDs(opts, opts.Tenants.Value); err != nil {
+		return nil, nil, err
+	}
+
+	if len(opts.Channels.Value) > 0 {
+		if len(selectedProjects) == 0 {
+			return nil, nil, errors.New("--channel requires --project, as channels belong to a project")
+		}
+		for _, channelNameOrID := range opts.Channels.Value {
+			channelID, err := findChannelID(opts, selectedProjects, channelNameOrID)
+			if err != nil {
+				return nil, nil, err
+			}
+			query.Channels = append(query.Channels, channelID)
+		}
+	}
+
+	if opts.Release.Value != "" {
+		if len(selectedProjects) != 1 {
+			return nil, nil, errors.New("--release requires a single --project, as release versions belong to a project")
+		}
+		release, err := sharedProgression.FindRelease(opts.Client, selectedProjects[0], opts.Release.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		filter.ReleaseID = release.GetID()
+	}
+
+	if filter.Since, err = parseDate(FlagSince, opts.Since.Value, false); err != nil {
+		return nil, nil, err
+	}
+	if filter.Until, err = parseDate(FlagUntil, opts.Until.Value, true); err != nil {
+		return nil, nil, err
+	}
+	if filter.Since != nil && filter.Until != nil && filter.Until.Before(*filter.Since) {
+		return nil, nil, errors.New("--until must not be before --since")
+	}
+
+	return query, filter, nil
+}
+
+func findProjects(opts *ListOptions, projectNamesOrIDs []string) ([]*projects.Project, error) {
+	var result []*projects.Project
+	for _, p := range projectNamesOrIDs {
+		project, err := selectors.FindProject(opts.Client, p)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, project)
+	}
+	return result, nil
+}
+
+func findEnvironmentIDs(opts *ListOptions, environmentNamesOrIDs []string) ([]string, error) {
+	foundEnvironments, err := executionscommon.FindEnvironments(opts.Client, environmentNamesOrIDs)
+	if err != nil {
+		return nil, err
+	}
+	return util.SliceTransform(foundEnvironments, func(e *environments.Environment) string { return e.GetID() }), nil
+}
+
+func findTenantIDs(opts *ListOptions, tenantNamesOrIDs []string) ([]string, error) {
+	var result []string
+	for _, t := range tenantNamesOrIDs {
+		tenant, err := opts.Client.Tenants.GetByIdentifier(t)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tenant.GetID())
+	}
+	return result, nil
+}
+
+func findChannelID(opts *ListOptions, selectedProjects []*projects.Project, channelNameOrID string) (string, error) {
+	for _, project := range selectedProjects {
+		allChannels, err := opts.Client.Projects.GetChannels(project)
+		if err != nil {
+			return "", err
+		}
+		for _, c := range allChannels {
+			if strings.EqualFold(c.GetID(), channelNameOrID) || strings.EqualFold(c.Name, channelNameOrID) {
+				return c.GetID(), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no channel found with name or ID of '%s'", channelNameOrID)
+}
+
+// parseDate reads a --since or --until value. A plain date covers the whole day, so for
+// --until it means the end of that day.
+func parseDate(flagName string, value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("--%s must be a date as YYYY-MM-DD or RFC3339, but was '%s'", flagName, value)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return &t, nil
+}
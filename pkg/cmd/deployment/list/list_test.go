@@ -0,0 +1,204 @@
+package list_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd/deployment/shared"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/dashboard"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/releases"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func TestDeploymentList(t *testing.T) {
+	const spaceID = "Spaces-1"
+	const fireProjectID = "Projects-22"
+
+	space1 := fixtures.NewSpace(spaceID, "Default Space")
+	fireProject := fixtures.NewProject(spaceID, fireProjectID, "Fire Project", "Lifecycles-1", "ProjectGroups-1", "")
+	devEnvironment := fixtures.NewEnvironment(spaceID, "Environments-1", "Dev")
+	testEnvironment := fixtures.NewEnvironment(spaceID, "Environments-2", "Test")
+
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	newDeployment := func(id string, releaseID string, environmentID string, taskID string) *deployments.Deployment {
+		d := deployments.NewDeployment(environmentID, releaseID)
+		d.ID = id
+		d.ProjectID = fireProjectID
+		d.TaskID = taskID
+		d.Created = &created
+		return d
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer)
+	}{
+		{"lists deployments filtered by project and environment", func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"deployment", "list", "--project", fireProjectID, "--environment", "Dev", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/Projects-22").RespondWith(fireProject)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{devEnvironment, testEnvironment})
+
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/deployments?take=100&projects=Projects-22&environments=Environments-1").RespondWith(resources.Resources[*deployments.Deployment]{
+				Items: []*deployments.Deployment{
+					newDeployment("Deployments-2", "Releases-2", devEnvironment.ID, "ServerTasks-2"),
+					newDeployment("Deployments-1", "Releases-1", devEnvironment.ID, "ServerTasks-1"),
+				},
+			})
+
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/tasks?ids=ServerTasks-2%2CServerTasks-1&take=2").RespondWith(resources.Resources[*tasks.Task]{
+				Items: []*tasks.Task{
+					{Resource: resources.Resource{ID: "ServerTasks-1"}, State: "Success"},
+					{Resource: resources.Resource{ID: "ServerTasks-2"}, State: "Failed"},
+				},
+			})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects?ids=Projects-22&take=1").RespondWith(resources.Resources[*projects.Project]{
+				Items: []*projects.Project{fireProject},
+			})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/releases?ids=Releases-2&ids=Releases-1&take=2").RespondWith(resources.Resources[*releases.Release]{
+				Items: []*releases.Release{
+					fixtures.NewRelease(spaceID, "Releases-1", "1.0", fireProjectID, "Channels-1"),
+					fixtures.NewRelease(spaceID, "Releases-2", "1.1", fireProjectID, "Channels-1"),
+				},
+			})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments?ids=Environments-1").RespondWith(resources.Resources[*environments.Environment]{
+				Items: []*environments.Environment{devEnvironment},
+			})
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+
+			assert.Equal(t, heredoc.Doc(`
+				PROJECT       RELEASE  ENVIRONMENT  STATE    CREATED
+				Fire Project  1.1      Dev          Failed   Fri, 01 Mar 2024 10:00:00 +0000
+				Fire Project  1.0      Dev          Success  Fri, 01 Mar 2024 10:00:00 +0000
+				`), stdOut.String())
+			assert.Equal(t, "", stdErr.String())
+		}},
+
+		{"release filter requires a single project", func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"deployment", "list", "--release", "1.0", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.EqualError(t, err, "--release requires a single --project, as release versions belong to a project")
+		}},
+
+		{"dashboard prints a project by environment matrix", func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"deployment", "list", "--dashboard", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/dashboard").RespondWith(shared.Dashboard{
+				Projects: []*shared.DashboardResource{
+					{ID: "Projects-22", Name: "Fire Project"},
+					{ID: "Projects-23", Name: "Tenanted Project"},
+				},
+				Environments: []*shared.DashboardResource{
+					{ID: "Environments-1", Name: "Dev"},
+					{ID: "Environments-2", Name: "Test"},
+				},
+				Tenants: []*shared.DashboardResource{
+					{ID: "Tenants-1", Name: "Alpha"},
+					{ID: "Tenants-2", Name: "Beta"},
+				},
+				Items: []*dashboard.DashboardItem{
+					{ProjectID: "Projects-22", EnvironmentID: "Environments-1", ReleaseVersion: "1.1", State: "Success"},
+					{ProjectID: "Projects-22", EnvironmentID: "Environments-2", ReleaseVersion: "1.0", State: "Failed"},
+					{ProjectID: "Projects-23", EnvironmentID: "Environments-1", TenantID: "Tenants-1", ReleaseVersion: "3.0", State: "Success"},
+					{ProjectID: "Projects-23", EnvironmentID: "Environments-1", TenantID: "Tenants-2", ReleaseVersion: "3.0", State: "Success"},
+				},
+			})
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+
+			assert.Equal(t, heredoc.Doc(`
+				PROJECT           DEV      TEST
+				Fire Project      1.1      1.0
+				Tenanted Project  3.0 (2)  -
+				`), stdOut.String())
+			assert.Equal(t, "", stdErr.String())
+		}},
+
+		{"dashboard for a tenanted project prints a tenant by environment matrix", func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"deployment", "list", "--dashboard", "--project", fireProjectID, "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/Projects-22").RespondWith(fireProject)
+
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/dashboard?projectId=Projects-22").RespondWith(shared.Dashboard{
+				Projects:     []*shared.DashboardResource{{ID: "Projects-22", Name: "Fire Project"}},
+				Environments: []*shared.DashboardResource{{ID: "Environments-1", Name: "Dev"}, {ID: "Environments-2", Name: "Test"}},
+				Tenants:      []*shared.DashboardResource{{ID: "Tenants-1", Name: "Beta"}, {ID: "Tenants-2", Name: "Alpha"}},
+				Items: []*dashboard.DashboardItem{
+					{ProjectID: "Projects-22", EnvironmentID: "Environments-1", TenantID: "Tenants-1", ReleaseVersion: "2.0", State: "Success"},
+					{ProjectID: "Projects-22", EnvironmentID: "Environments-2", TenantID: "Tenants-1", ReleaseVersion: "1.9", State: "Executing"},
+					{ProjectID: "Projects-22", EnvironmentID: "Environments-1", TenantID: "Tenants-2", ReleaseVersion: "2.0", State: "Success"},
+					{ProjectID: "Projects-22", EnvironmentID: "Environments-1", ReleaseVersion: "1.5", State: "Success"},
+				},
+			})
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+
+			assert.Equal(t, heredoc.Doc(`
+				Project Fire Project
+				TENANT      DEV  TEST
+				Alpha       2.0  -
+				Beta        2.0  1.9
+				Untenanted  1.5  -
+				`), stdOut.String())
+			assert.Equal(t, "", stdErr.String())
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			fac := testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider)
+			rootCmd := cmdRoot.NewCmdRoot(fac, nil, askProvider)
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+			test.run(t, api, qa, rootCmd, stdout, stderr)
+		})
+	}
+}
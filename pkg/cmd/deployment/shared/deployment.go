@@ -0,0 +1,288 @@
+package shared
+
+import (
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/dashboard"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/releases"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+)
+
+// the SDK only lists deployments per release, so we query the space-wide endpoint ourselves
+const deploymentsTemplate = "/api/{spaceId}/deployments{?skip,take,projects,environments,tenants,channels,taskState}"
+
+const dashboardTemplate = "/api/{spaceId}/dashboard{?projectId,selectedTenants}"
+
+const pageSize = 100
+
+// DeploymentsQuery holds the filters the server applies to the deployments endpoint.
+type DeploymentsQuery struct {
+	Projects     []string `uri:"projects,omitempty"`
+	Environments []string `uri:"environments,omitempty"`
+	Tenants      []string `uri:"tenants,omitempty"`
+	Channels     []string `uri:"channels,omitempty"`
+	Skip         int      `uri:"skip,omitempty"`
+	Take         int      `uri:"take,omitempty"`
+}
+
+// Filter holds the criteria for deployments the server can't filter on.
+type Filter struct {
+	ReleaseID string
+	Since     *time.Time
+	Until     *time.Time
+}
+
+func (f *Filter) matches(d *deployments.Deployment) bool {
+	if f.ReleaseID != "" && d.ReleaseID != f.ReleaseID {
+		return false
+	}
+	if f.Since != nil && (d.Created == nil || d.Created.Before(*f.Since)) {
+		return false
+	}
+	if f.Until != nil && (d.Created == nil || d.Created.After(*f.Until)) {
+		return false
+	}
+	return true
+}
+
+// GetDeployments returns up to limit deployments matching query and filter, most recent
+// first. The server returns deployments newest first, so paging stops as soon as we pass
+// the start of the date range.
+func GetDeployments(octopus *client.Client, query DeploymentsQuery, filter Filter, limit int) ([]*deployments.Deployment, error) {
+	var result []*deployments.Deployment
+	query.Take = pageSize
+	for {
+		page, err := newclient.GetByQuery[deployments.Deployment](octopus, deploymentsTemplate, octopus.GetSpaceID(), query)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range page.Items {
+			if filter.Since != nil && d.Created != nil && d.Created.Before(*filter.Since) {
+				return result, nil
+			}
+			if filter.matches(d) {
+				result = append(result, d)
+				if limit > 0 && len(result) >= limit {
+					return result, nil
+				}
+			}
+		}
+		if len(page.Items) < query.Take || page.Links.PageNext == "" {
+			return result, nil
+		}
+		query.Skip += len(page.Items)
+	}
+}
+
+// Lookups turns the IDs deployments refer to into names and task states for display.
+// Anything that can't be found is shown by its ID.
+type Lookups struct {
+	Projects     map[string]string
+	Releases     map[string]string
+	Environments map[string]string
+	Tenants      map[string]string
+	Tasks        map[string]*tasks.Task
+}
+
+func NewLookups() *Lookups {
+	return &Lookups{
+		Projects:     map[string]string{},
+		Releases:     map[string]string{},
+		Environments: map[string]string{},
+		Tenants:      map[string]string{},
+		Tasks:        map[string]*tasks.Task{},
+	}
+}
+
+// GetLookups loads the names and task states for deployments. Task states are required,
+// names are best-effort.
+func GetLookups(octopus *client.Client, allDeployments []*deployments.Deployment) (*Lookups, error) {
+	lookups := NewLookups()
+	if len(allDeployments) == 0 {
+		return lookups, nil
+	}
+
+	var projectIDs, releaseIDs, environmentIDs, tenantIDs, taskIDs []string
+	for _, d := range allDeployments {
+		projectIDs = append(projectIDs, d.ProjectID)
+		releaseIDs = append(releaseIDs, d.ReleaseID)
+		environmentIDs = append(environmentIDs, d.EnvironmentID)
+		if d.TenantID != "" {
+			tenantIDs = append(tenantIDs, d.TenantID)
+		}
+		taskIDs = append(taskIDs, d.TaskID)
+	}
+	projectIDs = util.SliceDistinct(projectIDs)
+	releaseIDs = util.SliceDistinct(releaseIDs)
+	environmentIDs = util.SliceDistinct(environmentIDs)
+	tenantIDs = util.SliceDistinct(tenantIDs)
+	taskIDs = util.SliceDistinct(taskIDs)
+
+	foundTasks, err := octopus.Tasks.Get(tasks.TasksQuery{IDs: taskIDs, Take: len(taskIDs)})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range foundTasks.Items {
+		lookups.Tasks[t.GetID()] = t
+	}
+
+	if foundProjects, err := octopus.Projects.Get(projects.ProjectsQuery{IDs: projectIDs, Take: len(projectIDs)}); err == nil {
+		for _, p := range foundProjects.Items {
+			lookups.Projects[p.GetID()] = p.GetName()
+		}
+	}
+	if foundReleases, err := octopus.Releases.Get(releases.ReleasesQuery{IDs: releaseIDs, Take: len(releaseIDs)}); err == nil {
+		for _, r := range foundReleases.Items {
+			lookups.Releases[r.GetID()] = r.Version
+		}
+	}
+	if foundEnvironments, err := octopus.Environments.GetByIDs(environmentIDs); err == nil {
+		for _, e := range foundEnvironments {
+			lookups.Environments[e.GetID()] = e.Name
+		}
+	}
+	if len(tenantIDs) > 0 {
+		if foundTenants, err := octopus.Tenants.GetByIDs(tenantIDs); err == nil {
+			for _, t := range foundTenants {
+				lookups.Tenants[t.GetID()] = t.Name
+			}
+		}
+	}
+	return lookups, nil
+}
+
+func Lookup(names map[string]string, id string) string {
+	if name, ok := names[id]; ok && name != "" {
+		return name
+	}
+	return id
+}
+
+// State returns the state of the task running deployment, or an empty string if the
+// task couldn't be found.
+func (l *Lookups) State(deployment *deployments.Deployment) string {
+	if t, ok := l.Tasks[deployment.TaskID]; ok {
+		return t.State
+	}
+	return ""
+}
+
+func FormatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC1123Z)
+}
+
+type DeploymentAsJson struct {
+	Id              string     `json:"Id"`
+	ProjectId       string     `json:"ProjectId"`
+	ProjectName     string     `json:"ProjectName"`
+	ReleaseId       string     `json:"ReleaseId"`
+	ReleaseVersion  string     `json:"ReleaseVersion"`
+	ChannelId       string     `json:"ChannelId"`
+	EnvironmentId   string     `json:"EnvironmentId"`
+	EnvironmentName string     `json:"EnvironmentName"`
+	TenantId        string     `json:"TenantId,omitempty"`
+	TenantName      string     `json:"TenantName,omitempty"`
+	TaskId          string     `json:"TaskId"`
+	State           string     `json:"State"`
+	Created         *time.Time `json:"Created,omitempty"`
+	CompletedTime   *time.Time `json:"CompletedTime,omitempty"`
+	DeployedBy      string     `json:"DeployedBy,omitempty"`
+}
+
+func ToJson(d *deployments.Deployment, lookups *Lookups) DeploymentAsJson {
+	result := DeploymentAsJson{
+		Id:              d.GetID(),
+		ProjectId:       d.ProjectID,
+		ProjectName:     Lookup(lookups.Projects, d.ProjectID),
+		ReleaseId:       d.ReleaseID,
+		ReleaseVersion:  Lookup(lookups.Releases, d.ReleaseID),
+		ChannelId:       d.ChannelID,
+		EnvironmentId:   d.EnvironmentID,
+		EnvironmentName: Lookup(lookups.Environments, d.EnvironmentID),
+		TenantId:        d.TenantID,
+		TaskId:          d.TaskID,
+		State:           lookups.State(d),
+		Created:         d.Created,
+		DeployedBy:      d.DeployedBy,
+	}
+	if d.TenantID != "" {
+		result.TenantName = Lookup(lookups.Tenants, d.TenantID)
+	}
+	if t, ok := lookups.Tasks[d.TaskID]; ok {
+		result.CompletedTime = t.CompletedTime
+	}
+	return result
+}
+
+// Dashboard is the server's view of the current release of each project in each
+// environment (and for each tenant of tenanted projects), as shown on the web dashboard.
+type Dashboard struct {
+	Projects     []*DashboardResource       `json:"Projects"`
+	Environments []*DashboardResource       `json:"Environments"`
+	Tenants      []*DashboardResource       `json:"Tenants"`
+	Items        []*dashboard.DashboardItem `json:"Items"`
+}
+
+type DashboardResource struct {
+	ID   string `json:"Id"`
+	Name string `json:"Name"`
+}
+
+type dashboardQuery struct {
+	SpaceID         string   `uri:"spaceId"`
+	ProjectID       string   `uri:"projectId,omitempty"`
+	SelectedTenants []string `uri:"selectedTenants,omitempty"`
+}
+
+// GetDashboard loads the dashboard for the space, or for a single project when
+// projectID is set.
+func GetDashboard(octopus *client.Client, projectID string, tenantIDs []string) (*Dashboard, error) {
+	return newclient.GetResourceByQuery[Dashboard](octopus, dashboardTemplate, dashboardQuery{
+		SpaceID:         octopus.GetSpaceID(),
+		ProjectID:       projectID,
+		SelectedTenants: tenantIDs,
+	})
+}
+
+// FindLatestSuccessful returns the most recent deployment matching query whose task
+// succeeded, or nil if there isn't one.
+func FindLatestSuccessful(octopus *client.Client, query DeploymentsQuery) (*deployments.Deployment, error) {
+	query.Take = pageSize
+	for {
+		page, err := newclient.GetByQuery[deployments.Deployment](octopus, deploymentsTemplate, octopus.GetSpaceID(), query)
+		if err != nil {
+			return nil, err
+		}
+		if len(page.Items) == 0 {
+			return nil, nil
+		}
+
+		taskIDs := util.SliceDistinct(util.SliceTransform(page.Items, func(d *deployments.Deployment) string { return d.TaskID }))
+		foundTasks, err := octopus.Tasks.Get(tasks.TasksQuery{IDs: taskIDs, Take: len(taskIDs)})
+		if err != nil {
+			return nil, err
+		}
+		states := map[string]string{}
+		for _, t := range foundTasks.Items {
+			states[t.GetID()] = t.State
+		}
+
+		for _, d := range page.Items {
+			if states[d.TaskID] == "Success" {
+				return d, nil
+			}
+		}
+		if len(page.Items) < query.Take || page.Links.PageNext == "" {
+			return nil, nil
+		}
+		query.Skip += len(page.Items)
+	}
+}
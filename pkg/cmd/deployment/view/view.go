@@ -0,0 +1,169 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/deployment/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/pkg/browser"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagWeb = "web"
+
+	// how many recent deployments to offer when prompting
+	recentDeployments = 30
+)
+
+type ViewFlags struct {
+	Web *flag.Flag[bool]
+}
+
+func NewViewFlags() *ViewFlags {
+	return &ViewFlags{
+		Web: flag.New[bool](FlagWeb, false),
+	}
+}
+
+type ViewOptions struct {
+	*ViewFlags
+	*cmd.Dependencies
+	Command *cobra.Command
+	ID      string
+}
+
+func NewCmdView(f factory.Factory) *cobra.Command {
+	viewFlags := NewViewFlags()
+	cmd := &cobra.Command{
+		Use:   "view [<id>]",
+		Short: "View a deployment",
+		Long:  "View a deployment in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s deployment view Deployments-123
+			%[1]s deployment view Deployments-123 --web
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := &ViewOptions{
+				ViewFlags:    viewFlags,
+				Dependencies: cmd.NewDependencies(f, c),
+				Command:      c,
+			}
+			if len(args) > 0 {
+				opts.ID = args[0]
+			}
+			return viewRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&viewFlags.Web.Value, viewFlags.Web.Name, "w", false, "Open in web browser")
+
+	return cmd
+}
+
+func viewRun(opts *ViewOptions) error {
+	deployment, err := resolveDeployment(opts)
+	if err != nil {
+		return err
+	}
+
+	lookups, err := shared.GetLookups(opts.Client, []*deployments.Deployment{deployment})
+	if err != nil {
+		return err
+	}
+	result := shared.ToJson(deployment, lookups)
+
+	url := util.GenerateWebURL(opts.Host, deployment.SpaceID, fmt.Sprintf("tasks/%s", deployment.TaskID))
+	if project, err := opts.Client.Projects.GetByID(deployment.ProjectID); err == nil {
+		url = util.GenerateWebURL(opts.Host, deployment.SpaceID,
+			fmt.Sprintf("projects/%s/deployments/releases/%s/deployments/%s", project.Slug, result.ReleaseVersion, deployment.GetID()))
+	}
+
+	err = output.PrintResource(result, opts.Command, output.Mappers[shared.DeploymentAsJson]{
+		Json: func(d shared.DeploymentAsJson) any {
+			return d
+		},
+		Table: output.TableDefinition[shared.DeploymentAsJson]{
+			Header: []string{"PROJECT", "RELEASE", "ENVIRONMENT", "TENANT", "STATE", "CREATED", "WEB URL"},
+			Row: func(d shared.DeploymentAsJson) []string {
+				return []string{d.ProjectName, d.ReleaseVersion, d.EnvironmentName, d.TenantName, output.FormatTaskState(d.State), shared.FormatTime(d.Created), output.Blue(url)}
+			},
+		},
+		Basic: func(d shared.DeploymentAsJson) string {
+			var result strings.Builder
+
+			result.WriteString(fmt.Sprintf("%s %s\n", output.Bold(deployment.Name), output.Dimf("(%s)", d.Id)))
+			result.WriteString(fmt.Sprintf("Project: %s\n", d.ProjectName))
+			result.WriteString(fmt.Sprintf("Release: %s\n", d.ReleaseVersion))
+			result.WriteString(fmt.Sprintf("Environment: %s\n", d.EnvironmentName))
+			if d.TenantName != "" {
+				result.WriteString(fmt.Sprintf("Tenant: %s\n", d.TenantName))
+			}
+			result.WriteString(fmt.Sprintf("State: %s\n", output.FormatTaskState(d.State)))
+			if d.Created != nil {
+				result.WriteString(fmt.Sprintf("Created: %s\n", shared.FormatTime(d.Created)))
+			}
+			if d.CompletedTime != nil {
+				result.WriteString(fmt.Sprintf("Completed: %s\n", shared.FormatTime(d.CompletedTime)))
+			}
+			if d.DeployedBy != "" {
+				result.WriteString(fmt.Sprintf("Deployed by: %s\n", d.DeployedBy))
+			}
+			if t, ok := lookups.Tasks[d.TaskId]; ok && t.ErrorMessage != "" {
+				result.WriteString(fmt.Sprintf("Error: %s\n", output.Red(t.ErrorMessage)))
+			}
+
+			result.WriteString(fmt.Sprintf("\nView this deployment in Octopus Deploy: %s\n", output.Blue(url)))
+			return result.String()
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.Web.Value {
+		_ = browser.OpenURL(url)
+	}
+	return nil
+}
+
+func resolveDeployment(opts *ViewOptions) (*deployments.Deployment, error) {
+	if opts.ID != "" {
+		return opts.Client.Deployments.GetByID(opts.ID)
+	}
+	if opts.NoPrompt {
+		return nil, errors.New("deployment ID must be specified")
+	}
+
+	recent, err := shared.GetDeployments(opts.Client, shared.DeploymentsQuery{}, shared.Filter{}, recentDeployments)
+	if err != nil {
+		return nil, err
+	}
+	if len(recent) == 0 {
+		return nil, errors.New("no deployments found")
+	}
+	lookups, err := shared.GetLookups(opts.Client, recent)
+	if err != nil {
+		return nil, err
+	}
+	return question.SelectMap(opts.Ask, "Select the deployment you wish to view", recent, func(d *deployments.Deployment) string {
+		description := fmt.Sprintf("%s %s to %s", shared.Lookup(lookups.Projects, d.ProjectID), shared.Lookup(lookups.Releases, d.ReleaseID), shared.Lookup(lookups.Environments, d.EnvironmentID))
+		if d.TenantID != "" {
+			description = fmt.Sprintf("%s for %s", description, shared.Lookup(lookups.Tenants, d.TenantID))
+		}
+		return fmt.Sprintf("%s (%s)", description, d.GetID())
+	})
+}
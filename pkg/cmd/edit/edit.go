@@ -0,0 +1,268 @@
+package edit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/surveyext"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/spf13/cobra"
+)
+
+type GetResourceCallback func(path string) (Resource, error)
+type PutResourceCallback func(path string, resource Resource) (Resource, error)
+type EditCallback func(message string, document string, validate func(string) error) (string, error)
+
+type EditOptions struct {
+	*cmd.Dependencies
+	Kind                *Kind
+	IdOrName            string
+	GetResourceCallback GetResourceCallback
+	PutResourceCallback PutResourceCallback
+	EditCallback        EditCallback
+	// SaveEditsCallback keeps the edited document when it cannot be saved to the server, and
+	// returns where it was kept.
+	SaveEditsCallback func(document string) (string, error)
+}
+
+func NewEditOptions(dependencies *cmd.Dependencies, kind *Kind, idOrName string) *EditOptions {
+	return &EditOptions{
+		Dependencies: dependencies,
+		Kind:         kind,
+		IdOrName:     idOrName,
+		GetResourceCallback: func(path string) (Resource, error) {
+			return doRequest(dependencies, http.MethodGet, path, nil)
+		},
+		PutResourceCallback: func(path string, resource Resource) (Resource, error) {
+			return doRequest(dependencies, http.MethodPut, path, resource)
+		},
+		EditCallback: func(message string, document string, validate func(string) error) (string, error) {
+			var edited string
+			err := dependencies.Ask(&surveyext.OctoEditor{
+				Editor: &survey.Editor{
+					Message:       message,
+					Help:          "Save and close the editor to update the resource. Leave it unchanged to cancel.",
+					FileName:      "*.json",
+					Default:       document,
+					AppendDefault: true,
+					HideDefault:   true,
+				},
+			}, &edited, survey.WithValidator(func(answer interface{}) error {
+				return validate(answer.(string))
+			}))
+			return edited, err
+		},
+		SaveEditsCallback: func(document string) (string, error) {
+			f, err := os.CreateTemp("", "octopus-edit-*.json")
+			if err != nil {
+				return "", err
+			}
+			defer f.Close()
+			_, err = f.WriteString(document)
+			return f.Name(), err
+		},
+	}
+}
+
+func NewCmdEdit(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit <kind> {<name> | <id>}",
+		Short: "Edit a resource in your editor",
+		Long: heredoc.Docf(`
+			Edit the JSON of a resource in Octopus Deploy with the editor set with '%[1]s config set %[2]s'.
+
+			The resource is saved when the editor is closed, once the JSON is valid. If someone else
+			changes the resource while it is being edited, your changes are applied over theirs as long
+			as you did not both change the same fields. Otherwise nothing is saved, and your changes are
+			kept in a file so they are not lost.
+
+			The kinds of resource that can be edited are %[3]s.
+		`, constants.ExecutableName, constants.ConfigEditor, strings.Join(KindNames(), ", ")),
+		Example: heredoc.Docf(`
+			%[1]s edit deployment-target web-01
+			%[1]s edit account Accounts-1
+			%[1]s edit worker-pool "Linux Workers"
+		`, constants.ExecutableName),
+		Args: usage.ExactArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			kind, err := FindKind(args[0])
+			if err != nil {
+				return err
+			}
+
+			opts := NewEditOptions(cmd.NewDependencies(f, c), kind, args[1])
+			return EditRun(opts)
+		},
+		Annotations: map[string]string{
+			annotations.IsCore: "true",
+		},
+	}
+
+	return cmd
+}
+
+func EditRun(opts *EditOptions) error {
+	if opts.NoPrompt {
+		return fmt.Errorf("editing a resource needs an interactive terminal, so cannot be used with --%s", constants.FlagNoPrompt)
+	}
+
+	original, err := findResource(opts)
+	if err != nil {
+		return err
+	}
+	path := resourcePath(opts, original.ID())
+
+	document, err := original.Format()
+	if err != nil {
+		return err
+	}
+	edited, err := opts.EditCallback(fmt.Sprintf("Edit %s '%s'", opts.Kind.Name, displayName(original)), document, func(text string) error {
+		_, err := parseEdited(original, text)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(edited) == strings.TrimSpace(document) {
+		_, err = fmt.Fprintln(opts.Out, "Edit cancelled, no changes made.")
+		return err
+	}
+	editedResource, err := parseEdited(original, edited)
+	if err != nil {
+		return err
+	}
+
+	// fetch the resource again to find out whether it changed while it was being edited
+	current, err := opts.GetResourceCallback(path)
+	if err != nil {
+		return err
+	}
+	merged, err := Merge(original, editedResource, current)
+	if err != nil {
+		var conflict *ConflictError
+		if errors.As(err, &conflict) {
+			if savedTo, saveErr := opts.SaveEditsCallback(edited); saveErr == nil {
+				return fmt.Errorf("%w; nothing was saved, a copy of your changes is in %s", err, savedTo)
+			}
+		}
+		return err
+	}
+	if original.Fingerprint() != current.Fingerprint() {
+		fmt.Fprintln(opts.Out, output.Yellow("The resource was changed by someone else while it was being edited; your changes were applied over theirs."))
+	}
+
+	updated, err := opts.PutResourceCallback(path, merged)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(opts.Out, "Successfully updated %s '%s' (%s).\n", opts.Kind.Name, displayName(updated), updated.ID())
+	return err
+}
+
+func parseEdited(original Resource, text string) (Resource, error) {
+	edited, err := ParseResource([]byte(text))
+	if err != nil {
+		return nil, fmt.Errorf("the resource is not valid JSON: %w", err)
+	}
+	if edited.ID() != original.ID() {
+		return nil, fmt.Errorf("the Id of the resource cannot be changed from '%s'", original.ID())
+	}
+	if _, ok := original["Name"]; ok && edited.Name() == "" {
+		return nil, fmt.Errorf("the Name of the resource cannot be empty")
+	}
+	if links, ok := original[fieldLinks]; ok {
+		edited[fieldLinks] = links
+	}
+	return edited, nil
+}
+
+func findResource(opts *EditOptions) (Resource, error) {
+	resource, err := opts.GetResourceCallback(resourcePath(opts, opts.IdOrName))
+	if err == nil {
+		return resource, nil
+	}
+	var apiError *core.APIError
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusNotFound {
+		return nil, err
+	}
+
+	// fall back to looking the resource up by name
+	page, err := opts.GetResourceCallback(fmt.Sprintf("/api/%s/%s?partialName=%s&take=2147483647",
+		opts.Space.GetID(), opts.Kind.Collection, url.QueryEscape(opts.IdOrName)))
+	if err != nil {
+		return nil, err
+	}
+	items, _ := page["Items"].([]any)
+	for _, item := range items {
+		if resource, ok := item.(map[string]any); ok && strings.EqualFold(Resource(resource).Name(), opts.IdOrName) {
+			// fetch it on its own, as it will be fetched again to check for changes before saving
+			return opts.GetResourceCallback(resourcePath(opts, Resource(resource).ID()))
+		}
+	}
+	return nil, fmt.Errorf("cannot find a %s with name or ID of '%s'", opts.Kind.Name, opts.IdOrName)
+}
+
+func resourcePath(opts *EditOptions, id string) string {
+	return fmt.Sprintf("/api/%s/%s/%s", opts.Space.GetID(), opts.Kind.Collection, url.PathEscape(id))
+}
+
+func displayName(resource Resource) string {
+	if name := resource.Name(); name != "" {
+		return name
+	}
+	return resource.ID()
+}
+
+func doRequest(dependencies *cmd.Dependencies, method string, path string, body Resource) (Resource, error) {
+	var content io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		content = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, path, content)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := dependencies.Client.HttpSession().DoRawRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiError := &core.APIError{}
+		if json.Unmarshal(data, apiError) != nil || apiError.ErrorMessage == "" {
+			apiError.ErrorMessage = strings.TrimSpace(string(data))
+		}
+		apiError.StatusCode = resp.StatusCode
+		return nil, apiError
+	}
+	return ParseResource(data)
+}
@@ -0,0 +1,99 @@
+package edit_test
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/edit"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOptions(t *testing.T, out *bytes.Buffer, server map[string]string) *edit.EditOptions {
+	space := spaces.NewSpace("Default")
+	space.ID = "Spaces-1"
+	kind, err := edit.FindKind("worker-pool")
+	require.Nil(t, err)
+
+	opts := edit.NewEditOptions(&cmd.Dependencies{Out: out, Space: space}, kind, "Linux")
+	opts.GetResourceCallback = func(path string) (edit.Resource, error) {
+		if document, ok := server[path]; ok {
+			return edit.ParseResource([]byte(document))
+		}
+		return nil, &core.APIError{ErrorMessage: "not found", StatusCode: http.StatusNotFound}
+	}
+	return opts
+}
+
+func TestEditRun_SavesByName(t *testing.T) {
+	out := &bytes.Buffer{}
+	opts := newOptions(t, out, map[string]string{
+		"/api/Spaces-1/workerpools?partialName=Linux&take=2147483647": `{"Items": [{"Id": "WorkerPools-2", "Name": "Linux Large"}, {"Id": "WorkerPools-1", "Name": "Linux"}]}`,
+		"/api/Spaces-1/workerpools/WorkerPools-1":                     `{"Id": "WorkerPools-1", "Name": "Linux", "Links": {"Self": "/api/Spaces-1/workerpools/WorkerPools-1"}}`,
+	})
+	opts.EditCallback = func(message string, document string, validate func(string) error) (string, error) {
+		assert.Equal(t, "Edit worker-pool 'Linux'", message)
+		assert.NotContains(t, document, "Links")
+		assert.EqualError(t, validate(`{"Id": "WorkerPools-9", "Name": "Linux"}`), "the Id of the resource cannot be changed from 'WorkerPools-1'")
+		return strings.Replace(document, `"Linux"`, `"Linux Workers"`, 1), nil
+	}
+	var saved edit.Resource
+	opts.PutResourceCallback = func(path string, resource edit.Resource) (edit.Resource, error) {
+		assert.Equal(t, "/api/Spaces-1/workerpools/WorkerPools-1", path)
+		saved = resource
+		return resource, nil
+	}
+
+	err := edit.EditRun(opts)
+	require.Nil(t, err)
+
+	assert.Equal(t, "Linux Workers", saved.Name())
+	assert.Contains(t, saved, "Links")
+	assert.Equal(t, "Successfully updated worker-pool 'Linux Workers' (WorkerPools-1).\n", out.String())
+}
+
+func TestEditRun_OnlyLooksUpByNameWhenNotFound(t *testing.T) {
+	out := &bytes.Buffer{}
+	opts := newOptions(t, out, map[string]string{})
+	forbidden := &core.APIError{ErrorMessage: "You do not have permission to perform this action.", StatusCode: http.StatusForbidden}
+	var requested []string
+	opts.GetResourceCallback = func(path string) (edit.Resource, error) {
+		requested = append(requested, path)
+		return nil, forbidden
+	}
+
+	err := edit.EditRun(opts)
+	assert.Equal(t, forbidden, err)
+	assert.Equal(t, []string{"/api/Spaces-1/workerpools/Linux"}, requested)
+}
+
+func TestEditRun_ConflictKeepsEdits(t *testing.T) {
+	out := &bytes.Buffer{}
+	server := map[string]string{
+		"/api/Spaces-1/workerpools/Linux": `{"Id": "WorkerPools-1", "Name": "Linux"}`,
+	}
+	opts := newOptions(t, out, server)
+	opts.EditCallback = func(message string, document string, validate func(string) error) (string, error) {
+		// someone renames the worker pool while it is being edited
+		server["/api/Spaces-1/workerpools/WorkerPools-1"] = `{"Id": "WorkerPools-1", "Name": "Ubuntu"}`
+		return `{"Id": "WorkerPools-1", "Name": "Linux Workers"}`, nil
+	}
+	opts.PutResourceCallback = func(path string, resource edit.Resource) (edit.Resource, error) {
+		t.Fatal("the resource should not be saved")
+		return nil, nil
+	}
+	var kept string
+	opts.SaveEditsCallback = func(document string) (string, error) {
+		kept = document
+		return "/tmp/octopus-edit-1.json", nil
+	}
+
+	err := edit.EditRun(opts)
+	assert.EqualError(t, err, "the resource was changed by someone else while it was being edited, and both changed [Name]; nothing was saved, a copy of your changes is in /tmp/octopus-edit-1.json")
+	assert.Equal(t, `{"Id": "WorkerPools-1", "Name": "Linux Workers"}`, kept)
+}
@@ -0,0 +1,56 @@
+package edit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kind is a type of resource that can be edited, and the collection it lives in within a space.
+type Kind struct {
+	Name       string
+	Aliases    []string
+	Collection string
+}
+
+var kinds = []*Kind{
+	{Name: "account", Collection: "accounts"},
+	{Name: "certificate", Collection: "certificates"},
+	{Name: "channel", Collection: "channels"},
+	{Name: "deployment-target", Aliases: []string{"target"}, Collection: "machines"},
+	{Name: "environment", Collection: "environments"},
+	{Name: "feed", Collection: "feeds"},
+	{Name: "library-variable-set", Aliases: []string{"variable-set"}, Collection: "libraryvariablesets"},
+	{Name: "lifecycle", Collection: "lifecycles"},
+	{Name: "machine-policy", Collection: "machinepolicies"},
+	{Name: "project", Collection: "projects"},
+	{Name: "project-group", Collection: "projectgroups"},
+	{Name: "tenant", Collection: "tenants"},
+	{Name: "worker", Collection: "workers"},
+	{Name: "worker-pool", Collection: "workerpools"},
+}
+
+// FindKind looks up a kind of resource by its name or one of its aliases.
+func FindKind(name string) (*Kind, error) {
+	for _, k := range kinds {
+		if strings.EqualFold(k.Name, name) {
+			return k, nil
+		}
+		for _, alias := range k.Aliases {
+			if strings.EqualFold(alias, name) {
+				return k, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("cannot edit a '%s', the kinds of resource that can be edited are %s", name, strings.Join(KindNames(), ", "))
+}
+
+// KindNames are the names of the kinds of resource that can be edited.
+func KindNames() []string {
+	names := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		names = append(names, k.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,141 @@
+package edit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Resource is the JSON document of a resource, as returned by the Octopus Server.
+type Resource map[string]any
+
+const (
+	fieldID    = "Id"
+	fieldLinks = "Links"
+)
+
+// ParseResource reads a JSON document, keeping numbers as they were written.
+func ParseResource(data []byte) (Resource, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var resource Resource
+	if err := decoder.Decode(&resource); err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, fmt.Errorf("the document must be a JSON object")
+	}
+	return resource, nil
+}
+
+// ID is the ID of the resource, if it has one.
+func (r Resource) ID() string {
+	id, _ := r[fieldID].(string)
+	return id
+}
+
+// Name is the name of the resource, if it has one.
+func (r Resource) Name() string {
+	name, _ := r["Name"].(string)
+	return name
+}
+
+// WithoutLinks is a copy of the resource without its links, which are not edited.
+func (r Resource) WithoutLinks() Resource {
+	copied := Resource{}
+	for k, v := range r {
+		if k != fieldLinks {
+			copied[k] = v
+		}
+	}
+	return copied
+}
+
+// Format is the resource as it is shown in the editor.
+func (r Resource) Format() (string, error) {
+	data, err := json.MarshalIndent(r.WithoutLinks(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// Fingerprint identifies the content of a resource, so that a change made by someone else while it
+// was being edited can be detected. The server updates LastModifiedOn on most resources when they
+// change, which the fingerprint includes, but not all resources have it.
+func (r Resource) Fingerprint() string {
+	data, _ := json.Marshal(r.WithoutLinks())
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ChangedFields are the top-level fields which differ between two versions of a resource.
+func ChangedFields(before Resource, after Resource) []string {
+	var changed []string
+	for k, v := range after {
+		if k == fieldLinks {
+			continue
+		}
+		if existing, ok := before[k]; !ok || !reflect.DeepEqual(existing, v) {
+			changed = append(changed, k)
+		}
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok && k != fieldLinks {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// ConflictError is returned when a resource was changed by someone else in the same fields
+// that were edited.
+type ConflictError struct {
+	Fields []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("the resource was changed by someone else while it was being edited, and both changed %v", e.Fields)
+}
+
+// Merge applies the fields changed in the editor to the current version of the resource. When
+// the resource has not changed since it was fetched, the edited document is used as it is. When
+// it has changed, the edits are applied over the current version as long as nobody else changed
+// the same top-level fields; otherwise a ConflictError is returned.
+func Merge(original Resource, edited Resource, current Resource) (Resource, error) {
+	if original.Fingerprint() == current.Fingerprint() {
+		return edited, nil
+	}
+
+	mine := ChangedFields(original, edited)
+	theirs := ChangedFields(original, current)
+	var overlapping []string
+	for _, field := range mine {
+		for _, other := range theirs {
+			if field == other {
+				overlapping = append(overlapping, field)
+			}
+		}
+	}
+	if len(overlapping) > 0 {
+		return nil, &ConflictError{Fields: overlapping}
+	}
+
+	merged := Resource{}
+	for k, v := range current {
+		merged[k] = v
+	}
+	for _, field := range mine {
+		if v, ok := edited[field]; ok {
+			merged[field] = v
+		} else {
+			delete(merged, field)
+		}
+	}
+	return merged, nil
+}
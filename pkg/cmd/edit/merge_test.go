@@ -0,0 +1,60 @@
+package edit_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/edit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parse(t *testing.T, document string) edit.Resource {
+	resource, err := edit.ParseResource([]byte(document))
+	require.Nil(t, err)
+	return resource
+}
+
+func TestMerge_Unchanged(t *testing.T) {
+	original := parse(t, `{"Id": "WorkerPools-1", "Name": "Linux", "SortOrder": 1}`)
+	edited := parse(t, `{"Id": "WorkerPools-1", "Name": "Linux Workers", "SortOrder": 1}`)
+
+	merged, err := edit.Merge(original, edited, original)
+	require.Nil(t, err)
+	assert.Equal(t, edited, merged)
+}
+
+func TestMerge_ChangedElsewhere(t *testing.T) {
+	original := parse(t, `{"Id": "WorkerPools-1", "Name": "Linux", "Description": "", "SortOrder": 1, "LastModifiedOn": "2026-01-01"}`)
+	edited := parse(t, `{"Id": "WorkerPools-1", "Name": "Linux Workers", "SortOrder": 1, "LastModifiedOn": "2026-01-01"}`)
+	current := parse(t, `{"Id": "WorkerPools-1", "Name": "Linux", "Description": "", "SortOrder": 5, "LastModifiedOn": "2026-01-02"}`)
+
+	merged, err := edit.Merge(original, edited, current)
+	require.Nil(t, err)
+	assert.Equal(t, parse(t, `{"Id": "WorkerPools-1", "Name": "Linux Workers", "SortOrder": 5, "LastModifiedOn": "2026-01-02"}`), merged)
+}
+
+func TestMerge_Conflict(t *testing.T) {
+	original := parse(t, `{"Id": "WorkerPools-1", "Name": "Linux", "SortOrder": 1}`)
+	edited := parse(t, `{"Id": "WorkerPools-1", "Name": "Linux Workers", "SortOrder": 1}`)
+	current := parse(t, `{"Id": "WorkerPools-1", "Name": "Ubuntu", "SortOrder": 1}`)
+
+	_, err := edit.Merge(original, edited, current)
+	assert.Equal(t, &edit.ConflictError{Fields: []string{"Name"}}, err)
+}
+
+func TestChangedFields_IgnoresLinks(t *testing.T) {
+	before := parse(t, `{"Id": "Accounts-1", "Name": "AWS", "Links": {"Self": "/a"}}`)
+	after := parse(t, `{"Id": "Accounts-1", "Name": "AWS", "Description": "Prod", "Links": {"Self": "/b"}}`)
+
+	assert.Equal(t, []string{"Description"}, edit.ChangedFields(before, after))
+	assert.Equal(t, before.Fingerprint(), parse(t, `{"Id": "Accounts-1", "Name": "AWS"}`).Fingerprint())
+}
+
+func TestFindKind(t *testing.T) {
+	kind, err := edit.FindKind("Target")
+	require.Nil(t, err)
+	assert.Equal(t, "machines", kind.Collection)
+
+	_, err = edit.FindKind("runbook")
+	assert.ErrorContains(t, err, "cannot edit a 'runbook'")
+}
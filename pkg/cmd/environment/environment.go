@@ -5,7 +5,10 @@ import (
 	cmdCreate "github.com/OctopusDeploy/cli/pkg/cmd/environment/create"
 	cmdDelete "github.com/OctopusDeploy/cli/pkg/cmd/environment/delete"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/environment/list"
+	cmdReorder "github.com/OctopusDeploy/cli/pkg/cmd/environment/reorder"
 	cmdTag "github.com/OctopusDeploy/cli/pkg/cmd/environment/tag"
+	cmdUpdate "github.com/OctopusDeploy/cli/pkg/cmd/environment/update"
+	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/environment/view"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
@@ -30,5 +33,8 @@ func NewCmdEnvironment(f factory.Factory) *cobra.Command {
 	cmd.AddCommand(cmdDelete.NewCmdDelete(f))
 	cmd.AddCommand(cmdCreate.NewCmdCreate(f))
 	cmd.AddCommand(cmdTag.NewCmdTag(f))
+	cmd.AddCommand(cmdView.NewCmdView(f))
+	cmd.AddCommand(cmdUpdate.NewCmdUpdate(f))
+	cmd.AddCommand(cmdReorder.NewCmdReorder(f))
 	return cmd
 }
@@ -0,0 +1,190 @@
+package reorder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagEnvironment = "environment"
+
+	moveToEnd = "(last)"
+)
+
+type ReorderFlags struct {
+	Environments *flag.Flag[[]string]
+}
+
+func NewReorderFlags() *ReorderFlags {
+	return &ReorderFlags{
+		Environments: flag.New[[]string](FlagEnvironment, false),
+	}
+}
+
+type SaveSortOrderCallback func(environmentIDs []string) error
+
+type ReorderOptions struct {
+	*ReorderFlags
+	*cmd.Dependencies
+	GetAllEnvironmentsCallback shared.GetAllEnvironmentsCallback
+	SaveSortOrderCallback      SaveSortOrderCallback
+}
+
+func NewReorderOptions(flags *ReorderFlags, dependencies *cmd.Dependencies) *ReorderOptions {
+	return &ReorderOptions{
+		ReorderFlags: flags,
+		Dependencies: dependencies,
+		GetAllEnvironmentsCallback: func() ([]*environments.Environment, error) {
+			return shared.GetAllEnvironments(dependencies.Client)
+		},
+		SaveSortOrderCallback: func(environmentIDs []string) error {
+			// the client library has no call for this, so the endpoint is used directly
+			path := fmt.Sprintf("/api/%s/environments/sortorder", dependencies.Client.GetSpaceID())
+			_, err := newclient.Put[any](dependencies.Client.HttpSession(), path, environmentIDs)
+			return err
+		},
+	}
+}
+
+func NewCmdReorder(f factory.Factory) *cobra.Command {
+	reorderFlags := NewReorderFlags()
+
+	cmd := &cobra.Command{
+		Use:   "reorder [<environment>...]",
+		Short: "Set the sort order of environments",
+		Long: heredoc.Doc(`
+			Set the order in which environments are shown and offered in Octopus Deploy.
+
+			The named environments are moved to the top in the order given, and the rest follow in
+			their current order.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s environment reorder Development Test Staging Production
+			%[1]s environment reorder --environment Development --environment Test
+		`, constants.ExecutableName),
+		Aliases: []string{"sort"},
+		RunE: func(c *cobra.Command, args []string) error {
+			reorderFlags.Environments.Value = append(reorderFlags.Environments.Value, args...)
+
+			opts := NewReorderOptions(reorderFlags, cmd.NewDependencies(f, c))
+			return reorderRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringArrayVarP(&reorderFlags.Environments.Value, reorderFlags.Environments.Name, "e", nil, "Name or ID of an environment, in the order they should appear. Multiple environments can be supplied")
+
+	return cmd
+}
+
+func reorderRun(opts *ReorderOptions) error {
+	allEnvironments, err := opts.GetAllEnvironmentsCallback()
+	if err != nil {
+		return err
+	}
+
+	if len(opts.Environments.Value) == 0 {
+		if opts.NoPrompt {
+			return fmt.Errorf("must supply the environments in the order they should appear")
+		}
+		if err := PromptMissing(opts, allEnvironments); err != nil {
+			return err
+		}
+	}
+
+	ordered, err := Reorder(allEnvironments, opts.Environments.Value)
+	if err != nil {
+		return err
+	}
+
+	if err := opts.SaveSortOrderCallback(util.SliceTransform(ordered, func(e *environments.Environment) string { return e.GetID() })); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(opts.Out, "\nSuccessfully reordered environments:")
+	for i, e := range ordered {
+		fmt.Fprintf(opts.Out, "%d. %s %s\n", i+1, e.Name, output.Dimf("(%s)", e.GetID()))
+	}
+
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Environments)
+		fmt.Fprintf(opts.Out, "%s\n", autoCmd)
+	}
+
+	return nil
+}
+
+// Reorder moves the named environments to the top in the order given, leaving the rest in
+// their current order after them.
+func Reorder(allEnvironments []*environments.Environment, identifiers []string) ([]*environments.Environment, error) {
+	var ordered []*environments.Environment
+	for _, identifier := range identifiers {
+		matching := util.SliceFilter(allEnvironments, func(e *environments.Environment) bool {
+			return strings.EqualFold(e.GetID(), identifier) || strings.EqualFold(e.Name, identifier)
+		})
+		if len(matching) == 0 {
+			return nil, fmt.Errorf("cannot find an environment with name or ID of '%s'", identifier)
+		}
+		if util.SliceContains(ordered, matching[0]) {
+			return nil, fmt.Errorf("environment '%s' was given more than once", matching[0].Name)
+		}
+		ordered = append(ordered, matching[0])
+	}
+
+	for _, e := range allEnvironments {
+		if !util.SliceContains(ordered, e) {
+			ordered = append(ordered, e)
+		}
+	}
+	return ordered, nil
+}
+
+// PromptMissing asks for an environment to move and where to put it, and records the
+// resulting order of every environment.
+func PromptMissing(opts *ReorderOptions, allEnvironments []*environments.Environment) error {
+	names := util.SliceTransform(allEnvironments, func(e *environments.Environment) string { return e.Name })
+
+	var moving string
+	if err := opts.Ask(&survey.Select{
+		Message: "Select the environment to move",
+		Options: names,
+	}, &moving); err != nil {
+		return err
+	}
+
+	others := util.SliceFilter(names, func(name string) bool { return name != moving })
+	var before string
+	if err := opts.Ask(&survey.Select{
+		Message: fmt.Sprintf("Move '%s' before", moving),
+		Options: append(others, moveToEnd),
+	}, &before); err != nil {
+		return err
+	}
+
+	var order []string
+	for _, name := range others {
+		if name == before {
+			order = append(order, moving)
+		}
+		order = append(order, name)
+	}
+	if before == moveToEnd {
+		order = append(order, moving)
+	}
+	opts.Environments.Value = order
+
+	return nil
+}
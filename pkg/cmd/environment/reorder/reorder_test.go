@@ -0,0 +1,49 @@
+package reorder_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/reorder"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEnvironments(names ...string) []*environments.Environment {
+	var result []*environments.Environment
+	for i, name := range names {
+		e := environments.NewEnvironment(name)
+		e.ID = fmt.Sprintf("Environments-%d", i+1)
+		result = append(result, e)
+	}
+	return result
+}
+
+func names(items []*environments.Environment) []string {
+	return util.SliceTransform(items, func(e *environments.Environment) string { return e.Name })
+}
+
+func TestReorder_MovesNamedEnvironmentsToTheTop(t *testing.T) {
+	all := newEnvironments("Production", "Staging", "Test", "Development")
+
+	ordered, err := reorder.Reorder(all, []string{"development", "Environments-3"})
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"Development", "Test", "Production", "Staging"}, names(ordered))
+}
+
+func TestReorder_UnknownEnvironment(t *testing.T) {
+	all := newEnvironments("Production", "Test")
+
+	_, err := reorder.Reorder(all, []string{"Test", "QA"})
+	assert.EqualError(t, err, "cannot find an environment with name or ID of 'QA'")
+}
+
+func TestReorder_DuplicateEnvironment(t *testing.T) {
+	all := newEnvironments("Production", "Test")
+
+	_, err := reorder.Reorder(all, []string{"Test", "Environments-2"})
+	assert.EqualError(t, err, "environment 'Test' was given more than once")
+}
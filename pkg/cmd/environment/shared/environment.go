@@ -0,0 +1,56 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+)
+
+type GetEnvironmentCallback func(environmentIdentifier string) (*environments.Environment, error)
+type GetAllEnvironmentsCallback func() ([]*environments.Environment, error)
+
+// GetEnvironment looks up an environment by ID, falling back to an exact (case-insensitive) name match.
+func GetEnvironment(octopus *client.Client, environmentIdentifier string) (*environments.Environment, error) {
+	environment, _ := environments.GetByID(octopus, octopus.GetSpaceID(), environmentIdentifier)
+	if environment != nil {
+		return environment, nil
+	}
+
+	matches, err := environments.Get(octopus, octopus.GetSpaceID(), environments.EnvironmentsQuery{
+		PartialName: environmentIdentifier,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, env := range matches.Items {
+		if strings.EqualFold(env.Name, environmentIdentifier) {
+			return env, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot find an environment with name or ID of '%s'", environmentIdentifier)
+}
+
+// GetAllEnvironments returns the environments of the space in their sort order.
+func GetAllEnvironments(octopus *client.Client) ([]*environments.Environment, error) {
+	return environments.GetAll(octopus, octopus.GetSpaceID())
+}
+
+// ResolveEnvironment finds the named environment, or prompts for one when no name was given.
+func ResolveEnvironment(ask question.Asker, noPrompt bool, environmentIdentifier string, message string, getEnvironment GetEnvironmentCallback, getAllEnvironments GetAllEnvironmentsCallback) (*environments.Environment, error) {
+	if environmentIdentifier != "" {
+		return getEnvironment(environmentIdentifier)
+	}
+
+	if noPrompt {
+		return nil, fmt.Errorf("must supply environment identifier")
+	}
+
+	return selectors.Select(ask, message, getAllEnvironments, func(item *environments.Environment) string {
+		return item.Name
+	})
+}
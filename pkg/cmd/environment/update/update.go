@@ -0,0 +1,270 @@
+package update
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tagsets"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagEnvironment           = "environment"
+	FlagName                  = "name"
+	FlagDescription           = "description"
+	FlagUseGuidedFailure      = "use-guided-failure"
+	FlagDynamicInfrastructure = "allow-dynamic-infrastructure"
+	FlagTag                   = "tag"
+)
+
+type UpdateFlags struct {
+	Environment           *flag.Flag[string]
+	Name                  *flag.Flag[string]
+	Description           *flag.Flag[string]
+	GuidedFailureMode     *flag.Flag[bool]
+	DynamicInfrastructure *flag.Flag[bool]
+	Tag                   *flag.Flag[[]string]
+}
+
+func NewUpdateFlags() *UpdateFlags {
+	return &UpdateFlags{
+		Environment:           flag.New[string](FlagEnvironment, false),
+		Name:                  flag.New[string](FlagName, false),
+		Description:           flag.New[string](FlagDescription, false),
+		GuidedFailureMode:     flag.New[bool](FlagUseGuidedFailure, false),
+		DynamicInfrastructure: flag.New[bool](FlagDynamicInfrastructure, false),
+		Tag:                   flag.New[[]string](FlagTag, false),
+	}
+}
+
+type GetAllTagSetsCallback func() ([]*tagsets.TagSet, error)
+type UpdateEnvironmentCallback func(environment *environments.Environment) (*environments.Environment, error)
+
+type UpdateOptions struct {
+	*UpdateFlags
+	*cmd.Dependencies
+	IsFlagSet                  flag.IsSetFunc
+	GetEnvironmentCallback     shared.GetEnvironmentCallback
+	GetAllEnvironmentsCallback shared.GetAllEnvironmentsCallback
+	GetAllTagSetsCallback      GetAllTagSetsCallback
+	UpdateEnvironmentCallback  UpdateEnvironmentCallback
+}
+
+func NewUpdateOptions(flags *UpdateFlags, dependencies *cmd.Dependencies, isFlagSet flag.IsSetFunc) *UpdateOptions {
+	return &UpdateOptions{
+		UpdateFlags:  flags,
+		Dependencies: dependencies,
+		IsFlagSet:    isFlagSet,
+		GetEnvironmentCallback: func(environmentIdentifier string) (*environments.Environment, error) {
+			return shared.GetEnvironment(dependencies.Client, environmentIdentifier)
+		},
+		GetAllEnvironmentsCallback: func() ([]*environments.Environment, error) {
+			return shared.GetAllEnvironments(dependencies.Client)
+		},
+		GetAllTagSetsCallback: func() ([]*tagsets.TagSet, error) {
+			result, err := tagsets.Get(dependencies.Client, dependencies.Client.GetSpaceID(), tagsets.TagSetsQuery{
+				Scopes: []string{string(tagsets.TagSetScopeEnvironment)},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return result.Items, nil
+		},
+		UpdateEnvironmentCallback: func(environment *environments.Environment) (*environments.Environment, error) {
+			return environments.Update(dependencies.Client, environment)
+		},
+	}
+}
+
+func NewCmdUpdate(f factory.Factory) *cobra.Command {
+	updateFlags := NewUpdateFlags()
+
+	cmd := &cobra.Command{
+		Use:   "update [<name> | <id>]",
+		Short: "Update an environment",
+		Long: heredoc.Doc(`
+			Update an environment in Octopus Deploy.
+
+			Only the settings given as flags are changed. When prompting, the current value of each
+			setting not given is offered as the default. Tags given with --tag replace the tags of
+			the environment.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s environment update Production --use-guided-failure
+			%[1]s environment update --environment Staging --name "Pre-production" --description "Final checks before release"
+			%[1]s environment update Test --allow-dynamic-infrastructure=false --tag "Region/us-east"
+		`, constants.ExecutableName),
+		Aliases: []string{"edit"},
+		Args:    usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if updateFlags.Environment.Value == "" && len(args) > 0 {
+				updateFlags.Environment.Value = args[0]
+			}
+
+			opts := NewUpdateOptions(updateFlags, cmd.NewDependencies(f, c), c.Flags().Changed)
+			return updateRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&updateFlags.Environment.Value, updateFlags.Environment.Name, "e", "", "Name or ID of the environment to update")
+	flags.StringVarP(&updateFlags.Name.Value, updateFlags.Name.Name, "n", "", "New name of the environment")
+	flags.StringVarP(&updateFlags.Description.Value, updateFlags.Description.Name, "d", "", "Description of the environment")
+	flags.BoolVar(&updateFlags.GuidedFailureMode.Value, updateFlags.GuidedFailureMode.Name, false, "Use guided failure mode by default")
+	flags.BoolVar(&updateFlags.DynamicInfrastructure.Value, updateFlags.DynamicInfrastructure.Name, false, "Allow dynamic infrastructure")
+	flags.StringArrayVarP(&updateFlags.Tag.Value, updateFlags.Tag.Name, "t", []string{}, "Tag to apply to environment, replacing its current tags, must use canonical name: <tag_set>/<tag_name>")
+
+	return cmd
+}
+
+func updateRun(opts *UpdateOptions) error {
+	environment, err := shared.ResolveEnvironment(opts.Ask, opts.NoPrompt, opts.Environment.Value,
+		"Select the environment you wish to update", opts.GetEnvironmentCallback, opts.GetAllEnvironmentsCallback)
+	if err != nil {
+		return err
+	}
+	opts.Environment.Value = environment.Name
+
+	changedFlags := FillUnsetFlags(opts, environment)
+	if !opts.NoPrompt {
+		if err := PromptMissing(opts, changedFlags); err != nil {
+			return err
+		}
+	} else if changedFlags[FlagTag] {
+		tagSets, err := opts.GetAllTagSetsCallback()
+		if err != nil {
+			return err
+		}
+		if err := selectors.ValidateTags(opts.Tag.Value, tagSets); err != nil {
+			return err
+		}
+	}
+
+	ApplyFlags(opts, environment)
+	updated, err := opts.UpdateEnvironmentCallback(environment)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(opts.Out, "\nSuccessfully updated environment '%s' (%s).\n", updated.Name, updated.GetID())
+	if err != nil {
+		return err
+	}
+
+	link := output.Bluef("%s/app#/%s/infrastructure/environments/%s", opts.Host, opts.Space.GetID(), updated.GetID())
+	fmt.Fprintf(opts.Out, "View this environment on Octopus Deploy: %s\n", link)
+
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Environment, opts.Name, opts.Description, opts.GuidedFailureMode, opts.DynamicInfrastructure, opts.Tag)
+		fmt.Fprintf(opts.Out, "%s\n", autoCmd)
+	}
+
+	return nil
+}
+
+// FillUnsetFlags gives each flag that was not set on the command line the current value of the
+// environment, and returns the flags that were set.
+func FillUnsetFlags(opts *UpdateOptions, environment *environments.Environment) map[string]bool {
+	changed := map[string]bool{}
+	for _, name := range []string{FlagName, FlagDescription, FlagUseGuidedFailure, FlagDynamicInfrastructure, FlagTag} {
+		changed[name] = opts.IsFlagSet(name)
+	}
+
+	if !changed[FlagName] {
+		opts.Name.Value = environment.Name
+	}
+	if !changed[FlagDescription] {
+		opts.Description.Value = environment.Description
+	}
+	if !changed[FlagUseGuidedFailure] {
+		opts.GuidedFailureMode.Value = environment.UseGuidedFailure
+	}
+	if !changed[FlagDynamicInfrastructure] {
+		opts.DynamicInfrastructure.Value = environment.AllowDynamicInfrastructure
+	}
+	if !changed[FlagTag] {
+		opts.Tag.Value = environment.EnvironmentTags
+	}
+	return changed
+}
+
+// ApplyFlags copies the values of the flags onto the environment.
+func ApplyFlags(opts *UpdateOptions, environment *environments.Environment) {
+	environment.Name = opts.Name.Value
+	environment.Description = opts.Description.Value
+	environment.UseGuidedFailure = opts.GuidedFailureMode.Value
+	environment.AllowDynamicInfrastructure = opts.DynamicInfrastructure.Value
+	environment.EnvironmentTags = opts.Tag.Value
+}
+
+func PromptMissing(opts *UpdateOptions, changedFlags map[string]bool) error {
+	if !changedFlags[FlagName] {
+		if err := opts.Ask(&survey.Input{
+			Message: "Name",
+			Help:    "A short, memorable, unique name for this environment.",
+			Default: opts.Name.Value,
+		}, &opts.Name.Value, survey.WithValidator(survey.ComposeValidators(
+			survey.MaxLength(200),
+			survey.MinLength(1),
+			survey.Required,
+		))); err != nil {
+			return err
+		}
+	}
+
+	if !changedFlags[FlagDescription] {
+		if err := opts.Ask(&survey.Input{
+			Message: "Description",
+			Help:    "A short, memorable, description for this environment.",
+			Default: opts.Description.Value,
+		}, &opts.Description.Value); err != nil {
+			return err
+		}
+	}
+
+	if !changedFlags[FlagUseGuidedFailure] {
+		if err := opts.Ask(&survey.Confirm{
+			Message: "Use guided failure",
+			Help:    "If guided failure is enabled for an environment, Octopus Deploy will prompt for user intervention if a deployment fails in the environment.",
+			Default: opts.GuidedFailureMode.Value,
+		}, &opts.GuidedFailureMode.Value); err != nil {
+			return err
+		}
+	}
+
+	if !changedFlags[FlagDynamicInfrastructure] {
+		if err := opts.Ask(&survey.Confirm{
+			Message: "Allow dynamic infrastructure",
+			Help:    "If dynamic infrastructure is enabled for an environment, deployments to this environment are allowed to create infrastructure, such as targets and accounts.",
+			Default: opts.DynamicInfrastructure.Value,
+		}, &opts.DynamicInfrastructure.Value); err != nil {
+			return err
+		}
+	}
+
+	tagSets, err := opts.GetAllTagSetsCallback()
+	if err != nil {
+		return err
+	}
+	var newTags []string
+	if changedFlags[FlagTag] {
+		newTags = opts.Tag.Value
+	}
+	tags, err := selectors.Tags(opts.Ask, opts.Tag.Value, newTags, tagSets)
+	if err != nil {
+		return err
+	}
+	opts.Tag.Value = tags
+
+	return nil
+}
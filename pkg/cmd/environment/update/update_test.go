@@ -0,0 +1,35 @@
+package update_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/update"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillUnsetFlags_OnlyChangesGivenFlags(t *testing.T) {
+	environment := environments.NewEnvironment("Production")
+	environment.Description = "Live"
+	environment.UseGuidedFailure = true
+	environment.AllowDynamicInfrastructure = true
+	environment.EnvironmentTags = []string{"Region/us-east"}
+
+	flags := update.NewUpdateFlags()
+	flags.DynamicInfrastructure.Value = false
+	flags.Description.Value = "Customer facing"
+	given := map[string]bool{update.FlagDynamicInfrastructure: true, update.FlagDescription: true}
+	opts := update.NewUpdateOptions(flags, &cmd.Dependencies{}, func(name string) bool { return given[name] })
+
+	changed := update.FillUnsetFlags(opts, environment)
+	update.ApplyFlags(opts, environment)
+
+	assert.True(t, changed[update.FlagDynamicInfrastructure])
+	assert.False(t, changed[update.FlagName])
+	assert.Equal(t, "Production", environment.Name)
+	assert.Equal(t, "Customer facing", environment.Description)
+	assert.True(t, environment.UseGuidedFailure)
+	assert.False(t, environment.AllowDynamicInfrastructure)
+	assert.Equal(t, []string{"Region/us-east"}, environment.EnvironmentTags)
+}
@@ -0,0 +1,321 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/shared"
+	targetShared "github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	tenantShared "github.com/OctopusDeploy/cli/pkg/cmd/tenant/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/extensions"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/pkg/browser"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagEnvironment = "environment"
+	FlagWeb         = "web"
+)
+
+type ViewFlags struct {
+	Environment *flag.Flag[string]
+	Web         *flag.Flag[bool]
+}
+
+func NewViewFlags() *ViewFlags {
+	return &ViewFlags{
+		Environment: flag.New[string](FlagEnvironment, false),
+		Web:         flag.New[bool](FlagWeb, false),
+	}
+}
+
+type ViewOptions struct {
+	*ViewFlags
+	*cmd.Dependencies
+	Command                    *cobra.Command
+	GetEnvironmentCallback     shared.GetEnvironmentCallback
+	GetAllEnvironmentsCallback shared.GetAllEnvironmentsCallback
+	GetTargetsCallback         func(environmentID string) ([]*machines.DeploymentTarget, error)
+	GetAllTenantsCallback      tenantShared.GetAllTenantsCallback
+	GetAllLifecyclesCallback   func() ([]*lifecycles.Lifecycle, error)
+}
+
+func NewViewOptions(flags *ViewFlags, dependencies *cmd.Dependencies, command *cobra.Command) *ViewOptions {
+	return &ViewOptions{
+		ViewFlags:    flags,
+		Dependencies: dependencies,
+		Command:      command,
+		GetEnvironmentCallback: func(environmentIdentifier string) (*environments.Environment, error) {
+			return shared.GetEnvironment(dependencies.Client, environmentIdentifier)
+		},
+		GetAllEnvironmentsCallback: func() ([]*environments.Environment, error) {
+			return shared.GetAllEnvironments(dependencies.Client)
+		},
+		GetTargetsCallback: func(environmentID string) ([]*machines.DeploymentTarget, error) {
+			return targetShared.GetAllTargets(*dependencies.Client, machines.MachinesQuery{EnvironmentIDs: []string{environmentID}})
+		},
+		GetAllTenantsCallback: func() ([]*tenants.Tenant, error) {
+			return tenantShared.GetAllTenants(dependencies.Client)
+		},
+		GetAllLifecyclesCallback: func() ([]*lifecycles.Lifecycle, error) {
+			return dependencies.Client.Lifecycles.GetAll()
+		},
+	}
+}
+
+func NewCmdView(f factory.Factory) *cobra.Command {
+	viewFlags := NewViewFlags()
+	cmd := &cobra.Command{
+		Args:  usage.MaximumNArgs(1),
+		Use:   "view [<name> | <id>]",
+		Short: "View an environment",
+		Long:  "View an environment in Octopus Deploy, including the deployment targets, tenants and lifecycles that use it",
+		Example: heredoc.Docf(`
+			%[1]s environment view Environments-1
+			%[1]s environment view Production
+			%[1]s environment view --environment Production --output-format json
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, args []string) error {
+			if viewFlags.Environment.Value == "" && len(args) > 0 {
+				viewFlags.Environment.Value = args[0]
+			}
+
+			opts := NewViewOptions(viewFlags, cmd.NewDependencies(f, c), c)
+			return viewRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&viewFlags.Environment.Value, viewFlags.Environment.Name, "e", "", "Name or ID of the environment")
+	flags.BoolVarP(&viewFlags.Web.Value, viewFlags.Web.Name, "w", false, "Open in web browser")
+
+	return cmd
+}
+
+// Usage is where an environment is used.
+type Usage struct {
+	Targets    []*machines.DeploymentTarget
+	Tenants    []*tenants.Tenant
+	Lifecycles []*LifecycleUsage
+}
+
+// LifecycleUsage is a lifecycle with the phases that deploy to an environment. A lifecycle
+// without phases deploys to every environment, so it has no phases to show.
+type LifecycleUsage struct {
+	Lifecycle       *lifecycles.Lifecycle
+	Phases          []string
+	AllEnvironments bool
+}
+
+type EnvironmentAsJson struct {
+	*environments.Environment
+	ExtensionSettings []ExtensionSettingAsJson `json:"ExtensionSettings"`
+	DeploymentTargets []output.IdAndName       `json:"DeploymentTargets"`
+	Tenants           []output.IdAndName       `json:"Tenants"`
+	Lifecycles        []LifecycleAsJson        `json:"Lifecycles"`
+	WebUrl            string                   `json:"WebUrl"`
+}
+
+type ExtensionSettingAsJson struct {
+	Extension string `json:"Extension"`
+	Setting   string `json:"Setting"`
+	Value     string `json:"Value"`
+}
+
+type LifecycleAsJson struct {
+	Id     string   `json:"Id"`
+	Name   string   `json:"Name"`
+	Phases []string `json:"Phases"`
+
+	AllEnvironments bool `json:"AllEnvironments"`
+}
+
+func viewRun(opts *ViewOptions) error {
+	environment, err := shared.ResolveEnvironment(opts.Ask, opts.NoPrompt, opts.Environment.Value,
+		"Select the environment you wish to view", opts.GetEnvironmentCallback, opts.GetAllEnvironmentsCallback)
+	if err != nil {
+		return err
+	}
+
+	usage, err := GetUsage(opts, environment.GetID())
+	if err != nil {
+		return err
+	}
+
+	link := util.GenerateWebURL(opts.Host, environment.SpaceID, fmt.Sprintf("infrastructure/environments/%s", environment.GetID()))
+
+	err = output.PrintResource(environment, opts.Command, output.Mappers[*environments.Environment]{
+		Json: func(e *environments.Environment) any {
+			e.Links = nil // ensure the links collection is not serialised
+			return EnvironmentAsJson{
+				Environment:       e,
+				ExtensionSettings: DescribeExtensionSettings(e.ExtensionSettings),
+				DeploymentTargets: util.SliceTransform(usage.Targets, func(t *machines.DeploymentTarget) output.IdAndName {
+					return output.IdAndName{Id: t.GetID(), Name: t.Name}
+				}),
+				Tenants: util.SliceTransform(usage.Tenants, func(t *tenants.Tenant) output.IdAndName {
+					return output.IdAndName{Id: t.GetID(), Name: t.Name}
+				}),
+				Lifecycles: util.SliceTransform(usage.Lifecycles, func(l *LifecycleUsage) LifecycleAsJson {
+					return LifecycleAsJson{Id: l.Lifecycle.GetID(), Name: l.Lifecycle.Name, Phases: l.Phases, AllEnvironments: l.AllEnvironments}
+				}),
+				WebUrl: link,
+			}
+		},
+		Table: output.TableDefinition[*environments.Environment]{
+			Header: []string{"NAME", "ID", "GUIDED FAILURE", "DYNAMIC INFRASTRUCTURE", "TAGS", "TARGETS", "TENANTS", "LIFECYCLES"},
+			Row: func(e *environments.Environment) []string {
+				return []string{
+					output.Bold(e.Name),
+					output.Dim(e.GetID()),
+					strconv.FormatBool(e.UseGuidedFailure),
+					strconv.FormatBool(e.AllowDynamicInfrastructure),
+					output.FormatAsList(e.EnvironmentTags),
+					strconv.Itoa(len(usage.Targets)),
+					strconv.Itoa(len(usage.Tenants)),
+					output.FormatAsList(util.SliceTransform(usage.Lifecycles, func(l *LifecycleUsage) string { return l.Lifecycle.Name })),
+				}
+			},
+		},
+		Basic: func(e *environments.Environment) string {
+			return formatBasic(e, usage, link)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.Web.Value {
+		browser.OpenURL(link)
+	}
+
+	return nil
+}
+
+// GetUsage finds the deployment targets in an environment, the tenants connected to it for any
+// project, and the lifecycles with a phase that deploys to it.
+func GetUsage(opts *ViewOptions, environmentID string) (*Usage, error) {
+	targets, err := opts.GetTargetsCallback(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	allTenants, err := opts.GetAllTenantsCallback()
+	if err != nil {
+		return nil, err
+	}
+	connectedTenants := util.SliceFilter(allTenants, func(t *tenants.Tenant) bool {
+		for _, environmentIDs := range t.ProjectEnvironments {
+			if util.SliceContains(environmentIDs, environmentID) {
+				return true
+			}
+		}
+		return false
+	})
+
+	allLifecycles, err := opts.GetAllLifecyclesCallback()
+	if err != nil {
+		return nil, err
+	}
+	var lifecycleUsages []*LifecycleUsage
+	for _, l := range allLifecycles {
+		if len(l.Phases) == 0 {
+			lifecycleUsages = append(lifecycleUsages, &LifecycleUsage{Lifecycle: l, AllEnvironments: true})
+			continue
+		}
+		var phases []string
+		for _, phase := range l.Phases {
+			if util.SliceContains(phase.AutomaticDeploymentTargets, environmentID) || util.SliceContains(phase.OptionalDeploymentTargets, environmentID) {
+				phases = append(phases, phase.Name)
+			}
+		}
+		if len(phases) > 0 {
+			lifecycleUsages = append(lifecycleUsages, &LifecycleUsage{Lifecycle: l, Phases: phases})
+		}
+	}
+
+	return &Usage{Targets: targets, Tenants: connectedTenants, Lifecycles: lifecycleUsages}, nil
+}
+
+// DescribeExtensionSettings lists the settings of the Jira, Jira Service Management and ServiceNow
+// integrations for an environment.
+func DescribeExtensionSettings(settings []extensions.ExtensionSettings) []ExtensionSettingAsJson {
+	described := []ExtensionSettingAsJson{}
+	for _, s := range settings {
+		switch setting := s.(type) {
+		case *environments.JiraExtensionSettings:
+			environmentType := setting.JiraEnvironmentType
+			if environmentType == "" {
+				environmentType = "unmapped"
+			}
+			described = append(described, ExtensionSettingAsJson{Extension: "Jira", Setting: "Environment type", Value: environmentType})
+		case *environments.JiraServiceManagementExtensionSettings:
+			described = append(described, ExtensionSettingAsJson{Extension: "Jira Service Management", Setting: "Change controlled", Value: strconv.FormatBool(setting.IsChangeControlled())})
+		case *environments.ServiceNowExtensionSettings:
+			described = append(described, ExtensionSettingAsJson{Extension: "ServiceNow", Setting: "Change controlled", Value: strconv.FormatBool(setting.IsChangeControlled())})
+		}
+	}
+	sort.SliceStable(described, func(i, j int) bool { return described[i].Extension < described[j].Extension })
+	return described
+}
+
+func formatBasic(e *environments.Environment, usage *Usage, link string) string {
+	var s strings.Builder
+
+	s.WriteString(fmt.Sprintf("%s %s\n", output.Bold(e.Name), output.Dimf("(%s)", e.GetID())))
+	if e.Description == "" {
+		s.WriteString(fmt.Sprintln(output.Dim(constants.NoDescription)))
+	} else {
+		s.WriteString(fmt.Sprintln(output.Dim(e.Description)))
+	}
+
+	s.WriteString(fmt.Sprintf("Use guided failure: %t\n", e.UseGuidedFailure))
+	s.WriteString(fmt.Sprintf("Allow dynamic infrastructure: %t\n", e.AllowDynamicInfrastructure))
+	if len(e.EnvironmentTags) > 0 {
+		s.WriteString(fmt.Sprintf("Tags: %s\n", output.FormatAsList(e.EnvironmentTags)))
+	}
+
+	if settings := DescribeExtensionSettings(e.ExtensionSettings); len(settings) > 0 {
+		s.WriteString(fmt.Sprintln(output.Bold("\nExtension settings")))
+		for _, setting := range settings {
+			s.WriteString(fmt.Sprintf("%s: %s: %s\n", setting.Extension, setting.Setting, setting.Value))
+		}
+	}
+
+	s.WriteString(fmt.Sprintln(output.Bold(fmt.Sprintf("\nDeployment targets (%d)", len(usage.Targets)))))
+	for _, t := range usage.Targets {
+		s.WriteString(fmt.Sprintf("%s %s\n", t.Name, output.Dimf("(%s)", t.GetID())))
+	}
+
+	s.WriteString(fmt.Sprintln(output.Bold(fmt.Sprintf("\nTenants (%d)", len(usage.Tenants)))))
+	for _, t := range usage.Tenants {
+		s.WriteString(fmt.Sprintf("%s %s\n", t.Name, output.Dimf("(%s)", t.GetID())))
+	}
+
+	s.WriteString(fmt.Sprintln(output.Bold(fmt.Sprintf("\nLifecycles (%d)", len(usage.Lifecycles)))))
+	for _, l := range usage.Lifecycles {
+		if l.AllEnvironments {
+			s.WriteString(fmt.Sprintf("%s %s\n", l.Lifecycle.Name, output.Dim("(no phases, so every environment)")))
+			continue
+		}
+		s.WriteString(fmt.Sprintf("%s %s\n", l.Lifecycle.Name, output.Dimf("(phases: %s)", strings.Join(l.Phases, ", "))))
+	}
+
+	s.WriteString(fmt.Sprintf("\nView this environment in Octopus Deploy: %s\n", output.Blue(link)))
+
+	return s.String()
+}
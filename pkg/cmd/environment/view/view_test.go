@@ -0,0 +1,82 @@
+package view_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/view"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/extensions"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUsage(t *testing.T) {
+	connected := tenants.NewTenant("Bobs Wood Shop")
+	connected.ID = "Tenants-1"
+	connected.ProjectEnvironments = map[string][]string{"Projects-1": {"Environments-1", "Environments-2"}}
+	notConnected := tenants.NewTenant("Sallys Tackle Truck")
+	notConnected.ID = "Tenants-2"
+	notConnected.ProjectEnvironments = map[string][]string{"Projects-1": {"Environments-2"}}
+
+	lifecycle := lifecycles.NewLifecycle("Default Lifecycle")
+	lifecycle.ID = "Lifecycles-1"
+	test := lifecycles.NewPhase("Test")
+	test.AutomaticDeploymentTargets = []string{"Environments-1"}
+	hotfix := lifecycles.NewPhase("Hotfix")
+	hotfix.OptionalDeploymentTargets = []string{"Environments-1"}
+	production := lifecycles.NewPhase("Production")
+	production.AutomaticDeploymentTargets = []string{"Environments-2"}
+	lifecycle.Phases = []*lifecycles.Phase{test, hotfix, production}
+	unrelated := lifecycles.NewLifecycle("Production only")
+	unrelated.Phases = []*lifecycles.Phase{production}
+	implicit := lifecycles.NewLifecycle("Every environment")
+
+	target := &machines.DeploymentTarget{}
+	target.Name = "web-01"
+
+	opts := view.NewViewOptions(view.NewViewFlags(), &cmd.Dependencies{}, nil)
+	var requestedEnvironmentID string
+	opts.GetTargetsCallback = func(environmentID string) ([]*machines.DeploymentTarget, error) {
+		requestedEnvironmentID = environmentID
+		return []*machines.DeploymentTarget{target}, nil
+	}
+	opts.GetAllTenantsCallback = func() ([]*tenants.Tenant, error) {
+		return []*tenants.Tenant{connected, notConnected}, nil
+	}
+	opts.GetAllLifecyclesCallback = func() ([]*lifecycles.Lifecycle, error) {
+		return []*lifecycles.Lifecycle{lifecycle, unrelated, implicit}, nil
+	}
+
+	usage, err := view.GetUsage(opts, "Environments-1")
+	require.Nil(t, err)
+
+	assert.Equal(t, "Environments-1", requestedEnvironmentID)
+	assert.Equal(t, []*machines.DeploymentTarget{target}, usage.Targets)
+	assert.Equal(t, []*tenants.Tenant{connected}, usage.Tenants)
+	require.Len(t, usage.Lifecycles, 2)
+	assert.Equal(t, lifecycle, usage.Lifecycles[0].Lifecycle)
+	assert.Equal(t, []string{"Test", "Hotfix"}, usage.Lifecycles[0].Phases)
+	// a lifecycle without phases deploys to every environment
+	assert.Equal(t, implicit, usage.Lifecycles[1].Lifecycle)
+	assert.True(t, usage.Lifecycles[1].AllEnvironments)
+}
+
+func TestDescribeExtensionSettings(t *testing.T) {
+	settings := []extensions.ExtensionSettings{
+		environments.NewServiceNowExtensionSettings(false),
+		environments.NewJiraExtensionSettings("production"),
+		environments.NewJiraServiceManagementExtensionSettings(true),
+		environments.NewJiraExtensionSettings(""),
+	}
+
+	assert.Equal(t, []view.ExtensionSettingAsJson{
+		{Extension: "Jira", Setting: "Environment type", Value: "production"},
+		{Extension: "Jira", Setting: "Environment type", Value: "unmapped"},
+		{Extension: "Jira Service Management", Setting: "Change controlled", Value: "true"},
+		{Extension: "ServiceNow", Setting: "Change controlled", Value: "false"},
+	}, view.DescribeExtensionSettings(settings))
+}
@@ -0,0 +1,144 @@
+package create
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
+)
+
+const FlagName = "name"
+
+type CreateFlags struct {
+	Name *flag.Flag[string]
+	*shared.SettingsFlags
+}
+
+func NewCreateFlags() *CreateFlags {
+	return &CreateFlags{
+		Name:          flag.New[string](FlagName, false),
+		SettingsFlags: shared.NewSettingsFlags(),
+	}
+}
+
+type CreateMachinePolicyCallback func(policy *machines.MachinePolicy) (*machines.MachinePolicy, error)
+
+type CreateOptions struct {
+	*CreateFlags
+	*cmd.Dependencies
+	// IsFlagSet reports whether a flag was given on the command line, so that the settings not
+	// given keep the defaults of a new machine policy.
+	IsFlagSet                   func(name string) bool
+	ReadFileCallback            func(path string) ([]byte, error)
+	CreateMachinePolicyCallback CreateMachinePolicyCallback
+}
+
+func NewCreateOptions(flags *CreateFlags, dependencies *cmd.Dependencies, isFlagSet func(name string) bool) *CreateOptions {
+	return &CreateOptions{
+		CreateFlags:      flags,
+		Dependencies:     dependencies,
+		IsFlagSet:        isFlagSet,
+		ReadFileCallback: os.ReadFile,
+		CreateMachinePolicyCallback: func(policy *machines.MachinePolicy) (*machines.MachinePolicy, error) {
+			return dependencies.Client.MachinePolicies.Add(policy)
+		},
+	}
+}
+
+func NewCmdCreate(f factory.Factory) *cobra.Command {
+	createFlags := NewCreateFlags()
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a machine policy",
+		Long: heredoc.Doc(`
+			Create a machine policy in Octopus Deploy.
+
+			Settings which are not given keep the defaults Octopus Deploy uses for a new machine policy.
+			Health check scripts are read from the files given.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s machine-policy create
+			%[1]s machine-policy create --name "Linux servers" --bash-health-check-file health.sh --health-check-interval 1h
+			%[1]s machine-policy create --name "Autoscaled" --connectivity MayBeOfflineAndCanBeSkipped --delete-unavailable-after 2h
+		`, constants.ExecutableName),
+		Aliases: []string{"new"},
+		RunE: func(c *cobra.Command, _ []string) error {
+			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c), c.Flags().Changed)
+			return createRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&createFlags.Name.Value, createFlags.Name.Name, "n", "", "Name of the machine policy")
+	shared.RegisterSettingsFlags(cmd, createFlags.SettingsFlags)
+
+	return cmd
+}
+
+func createRun(opts *CreateOptions) error {
+	if !opts.NoPrompt {
+		if err := PromptMissing(opts); err != nil {
+			return err
+		}
+	}
+	if opts.Name.Value == "" {
+		return fmt.Errorf("must supply a name for the machine policy")
+	}
+
+	policy, err := NewMachinePolicy(opts)
+	if err != nil {
+		return err
+	}
+
+	created, err := opts.CreateMachinePolicyCallback(policy)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(opts.Out, "\nSuccessfully created machine policy '%s' (%s).\n", created.Name, created.GetID())
+	if err != nil {
+		return err
+	}
+
+	link := output.Bluef("%s/app#/%s/infrastructure/machinepolicies/%s", opts.Host, opts.Space.GetID(), created.GetID())
+	fmt.Fprintf(opts.Out, "View this machine policy on Octopus Deploy: %s\n", link)
+
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), append([]flag.Generatable{opts.Name}, opts.SettingsFlags.GeneratableFlags()...)...)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+
+	return nil
+}
+
+// NewMachinePolicy builds a machine policy with the defaults for a new one, changing the settings
+// which were given as flags.
+func NewMachinePolicy(opts *CreateOptions) (*machines.MachinePolicy, error) {
+	policy := machines.NewMachinePolicy(opts.Name.Value)
+	isSet := func(name string) bool {
+		// the description may have been answered at a prompt rather than given as a flag
+		return opts.IsFlagSet(name) || (name == shared.FlagDescription && opts.Description.Value != "")
+	}
+	if err := shared.ApplySettings(policy, opts.SettingsFlags, isSet, opts.ReadFileCallback); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func PromptMissing(opts *CreateOptions) error {
+	if err := question.AskName(opts.Ask, "", "machine policy", &opts.Name.Value); err != nil {
+		return err
+	}
+
+	return question.AskDescription(opts.Ask, "", "machine policy", &opts.Description.Value)
+}
@@ -0,0 +1,126 @@
+package delete
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/shared"
+	targetShared "github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	workerShared "github.com/OctopusDeploy/cli/pkg/cmd/worker/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
+)
+
+type DeleteOptions struct {
+	*cmd.Dependencies
+	MachinePolicy                 string
+	SkipConfirmation              bool
+	GetMachinePolicyCallback      shared.GetMachinePolicyCallback
+	GetAllMachinePoliciesCallback machinescommon.GetAllMachinePoliciesCallback
+	GetTargetsCallback            shared.GetTargetsCallback
+	GetWorkersCallback            shared.GetWorkersCallback
+	DeleteMachinePolicyCallback   func(policy *machines.MachinePolicy) error
+}
+
+func NewDeleteOptions(dependencies *cmd.Dependencies) *DeleteOptions {
+	return &DeleteOptions{
+		Dependencies: dependencies,
+		GetMachinePolicyCallback: func(identifier string) (*machines.MachinePolicy, error) {
+			return shared.GetMachinePolicy(dependencies.Client, identifier)
+		},
+		GetAllMachinePoliciesCallback: func() ([]*machines.MachinePolicy, error) {
+			return dependencies.Client.MachinePolicies.GetAll()
+		},
+		GetTargetsCallback: func() ([]*machines.DeploymentTarget, error) {
+			return targetShared.GetAllTargets(*dependencies.Client, machines.MachinesQuery{})
+		},
+		GetWorkersCallback: func() ([]*machines.Worker, error) {
+			return workerShared.GetWorkersByQuery(*dependencies.Client, machines.WorkersQuery{}, nil)
+		},
+		DeleteMachinePolicyCallback: func(policy *machines.MachinePolicy) error {
+			return dependencies.Client.MachinePolicies.DeleteByID(policy.GetID())
+		},
+	}
+}
+
+func NewCmdDelete(f factory.Factory) *cobra.Command {
+	var skipConfirmation bool
+	cmd := &cobra.Command{
+		Use:   "delete {<name> | <id>}",
+		Short: "Delete a machine policy",
+		Long: heredoc.Docf(`
+			Delete a machine policy in Octopus Deploy.
+
+			The default machine policy cannot be deleted, nor can a machine policy which deployment
+			targets or workers still use. Use '%[1]s machine-policy usages' to see what uses it.
+		`, constants.ExecutableName),
+		Aliases: []string{"del", "rm", "remove"},
+		Example: heredoc.Docf(`
+			%[1]s machine-policy delete
+			%[1]s machine-policy rm "Linux servers" -y
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := NewDeleteOptions(cmd.NewDependencies(f, c))
+			if len(args) > 0 {
+				opts.MachinePolicy = args[0]
+			}
+			opts.SkipConfirmation = skipConfirmation
+			return deleteRun(opts)
+		},
+	}
+
+	question.RegisterConfirmDeletionFlag(cmd, &skipConfirmation, "machine policy")
+
+	return cmd
+}
+
+func deleteRun(opts *DeleteOptions) error {
+	policy, err := shared.ResolveMachinePolicy(opts.Ask, opts.NoPrompt, opts.MachinePolicy,
+		"Select the machine policy you wish to delete:", opts.GetMachinePolicyCallback, opts.GetAllMachinePoliciesCallback)
+	if err != nil {
+		return err
+	}
+
+	if err := CheckCanDelete(opts, policy); err != nil {
+		return err
+	}
+
+	if opts.SkipConfirmation {
+		return opts.DeleteMachinePolicyCallback(policy)
+	}
+	return question.DeleteWithConfirmation(opts.Ask, "machine policy", policy.Name, policy.GetID(), func() error {
+		return opts.DeleteMachinePolicyCallback(policy)
+	})
+}
+
+// CheckCanDelete stops the default machine policy, or one which machines still use, from being deleted.
+func CheckCanDelete(opts *DeleteOptions, policy *machines.MachinePolicy) error {
+	if policy.IsDefault {
+		return fmt.Errorf("machine policy '%s' is the default machine policy and cannot be deleted", policy.Name)
+	}
+
+	usages, err := shared.GetUsages([]*machines.MachinePolicy{policy}, opts.GetTargetsCallback, opts.GetWorkersCallback)
+	if err != nil {
+		return err
+	}
+	if len(usages) == 0 {
+		return nil
+	}
+
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("machine policy '%s' is used by:\n", policy.Name))
+	for _, u := range usages {
+		s.WriteString(fmt.Sprintf("  %s %s %s\n", u.Kind, u.Name, output.Dimf("(%s)", u.Id)))
+	}
+	s.WriteString(fmt.Sprintf("move them to another machine policy with '%[1]s deployment-target update --machine-policy' or '%[1]s worker update --machine-policy' first", constants.ExecutableName))
+	return fmt.Errorf("%s", s.String())
+}
@@ -0,0 +1,41 @@
+package delete_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/delete"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOptions(workers ...*machines.Worker) *delete.DeleteOptions {
+	opts := delete.NewDeleteOptions(&cmd.Dependencies{NoPrompt: true})
+	opts.GetTargetsCallback = func() ([]*machines.DeploymentTarget, error) { return nil, nil }
+	opts.GetWorkersCallback = func() ([]*machines.Worker, error) { return workers, nil }
+	return opts
+}
+
+func TestCheckCanDelete_RefusesDefaultPolicy(t *testing.T) {
+	policy := machines.NewMachinePolicy("Default Machine Policy")
+	policy.IsDefault = true
+
+	err := delete.CheckCanDelete(newOptions(), policy)
+
+	assert.EqualError(t, err, "machine policy 'Default Machine Policy' is the default machine policy and cannot be deleted")
+}
+
+func TestCheckCanDelete_RefusesPolicyInUse(t *testing.T) {
+	policy := machines.NewMachinePolicy("Linux servers")
+	policy.ID = "MachinePolicies-2"
+	worker := machines.NewWorker("worker-01", machines.NewCloudRegionEndpoint())
+	worker.ID = "Workers-1"
+	worker.MachinePolicyID = "MachinePolicies-2"
+
+	assert.NoError(t, delete.CheckCanDelete(newOptions(), policy))
+
+	err := delete.CheckCanDelete(newOptions(worker), policy)
+
+	assert.ErrorContains(t, err, "machine policy 'Linux servers' is used by:\n  Worker worker-01")
+	assert.ErrorContains(t, err, "worker update --machine-policy")
+}
@@ -0,0 +1,65 @@
+package list
+
+import (
+	"strconv"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdList(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List machine policies",
+		Long:  "List machine policies in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s machine-policy list
+			%[1]s machine-policy ls
+		`, constants.ExecutableName),
+		Aliases: []string{"ls"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
+			if err != nil {
+				return err
+			}
+
+			allPolicies, err := client.MachinePolicies.GetAll()
+			if err != nil {
+				return err
+			}
+
+			return output.PrintArray(allPolicies, cmd, output.Mappers[*machines.MachinePolicy]{
+				Json: func(item *machines.MachinePolicy) any {
+					return struct {
+						Id          string `json:"Id"`
+						Name        string `json:"Name"`
+						Description string `json:"Description"`
+						IsDefault   bool   `json:"IsDefault"`
+					}{
+						Id:          item.GetID(),
+						Name:        item.Name,
+						Description: item.Description,
+						IsDefault:   item.IsDefault,
+					}
+				},
+				Table: output.TableDefinition[*machines.MachinePolicy]{
+					Header: []string{"NAME", "DEFAULT", "HEALTH CHECK", "CLEANUP"},
+					Row: func(item *machines.MachinePolicy) []string {
+						return []string{output.Bold(item.Name), strconv.FormatBool(item.IsDefault), shared.DescribeHealthCheck(item.MachineHealthCheckPolicy), shared.DescribeCleanup(item.MachineCleanupPolicy)}
+					},
+				},
+				Basic: func(item *machines.MachinePolicy) string {
+					return item.Name
+				},
+			})
+		},
+	}
+
+	return cmd
+}
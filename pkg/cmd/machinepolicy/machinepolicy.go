@@ -0,0 +1,39 @@
+package machinepolicy
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	cmdCreate "github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/create"
+	cmdDelete "github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/delete"
+	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/list"
+	cmdUpdate "github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/update"
+	cmdUsages "github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/usages"
+	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/view"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdMachinePolicy(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "machine-policy <command>",
+		Short:   "Manage machine policies",
+		Long:    "Manage the machine policies of deployment targets and workers in Octopus Deploy",
+		Aliases: []string{"machine-policies"},
+		Example: heredoc.Docf(`
+			%[1]s machine-policy list
+			%[1]s machine-policy usages "Default Machine Policy"
+		`, constants.ExecutableName),
+		Annotations: map[string]string{
+			annotations.IsInfrastructure: "true",
+		},
+	}
+
+	cmd.AddCommand(cmdList.NewCmdList(f))
+	cmd.AddCommand(cmdView.NewCmdView(f))
+	cmd.AddCommand(cmdCreate.NewCmdCreate(f))
+	cmd.AddCommand(cmdUpdate.NewCmdUpdate(f))
+	cmd.AddCommand(cmdDelete.NewCmdDelete(f))
+	cmd.AddCommand(cmdUsages.NewCmdUsages(f))
+	return cmd
+}
@@ -0,0 +1,86 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+)
+
+type GetMachinePolicyCallback func(identifier string) (*machines.MachinePolicy, error)
+type GetTargetsCallback func() ([]*machines.DeploymentTarget, error)
+type GetWorkersCallback func() ([]*machines.Worker, error)
+
+const (
+	KindDeploymentTarget = "Deployment target"
+	KindWorker           = "Worker"
+)
+
+// GetMachinePolicy looks up a machine policy by ID, falling back to a case-insensitive name match.
+func GetMachinePolicy(octopus *client.Client, identifier string) (*machines.MachinePolicy, error) {
+	policy, _ := octopus.MachinePolicies.GetByID(identifier)
+	if policy != nil {
+		return policy, nil
+	}
+
+	return machinescommon.FindMachinePolicy(octopus.MachinePolicies.GetAll, identifier)
+}
+
+// ResolveMachinePolicy finds the named machine policy, or prompts for one when no name was given.
+func ResolveMachinePolicy(ask question.Asker, noPrompt bool, identifier string, message string, getMachinePolicy GetMachinePolicyCallback, getAllMachinePolicies machinescommon.GetAllMachinePoliciesCallback) (*machines.MachinePolicy, error) {
+	if identifier != "" {
+		return getMachinePolicy(identifier)
+	}
+
+	if noPrompt {
+		return nil, fmt.Errorf("must supply machine policy identifier")
+	}
+
+	return selectors.Select(ask, message, getAllMachinePolicies, func(item *machines.MachinePolicy) string {
+		return item.Name
+	})
+}
+
+// Usage is a deployment target or worker which uses a machine policy.
+type Usage struct {
+	MachinePolicyId string `json:"MachinePolicyId"`
+	MachinePolicy   string `json:"MachinePolicy"`
+	Kind            string `json:"Kind"`
+	Id              string `json:"Id"`
+	Name            string `json:"Name"`
+}
+
+// GetUsages finds the deployment targets and workers which use the machine policies given, in the
+// order of the policies.
+func GetUsages(policies []*machines.MachinePolicy, getTargets GetTargetsCallback, getWorkers GetWorkersCallback) ([]*Usage, error) {
+	targets, err := getTargets()
+	if err != nil {
+		return nil, err
+	}
+	workers, err := getWorkers()
+	if err != nil {
+		return nil, err
+	}
+
+	usages := []*Usage{}
+	for _, policy := range policies {
+		newUsage := func(kind string, id string, name string) *Usage {
+			return &Usage{MachinePolicyId: policy.GetID(), MachinePolicy: policy.Name, Kind: kind, Id: id, Name: name}
+		}
+		for _, t := range targets {
+			if strings.EqualFold(t.MachinePolicyID, policy.GetID()) {
+				usages = append(usages, newUsage(KindDeploymentTarget, t.GetID(), t.Name))
+			}
+		}
+		for _, w := range workers {
+			if strings.EqualFold(w.MachinePolicyID, policy.GetID()) {
+				usages = append(usages, newUsage(KindWorker, w.GetID(), w.Name))
+			}
+		}
+	}
+	return usages, nil
+}
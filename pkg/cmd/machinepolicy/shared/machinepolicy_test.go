@@ -0,0 +1,113 @@
+package shared_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/shared"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/stretchr/testify/assert"
+)
+
+func readFile(path string) ([]byte, error) {
+	if path == "health.sh" {
+		return []byte("echo healthy\n"), nil
+	}
+	return nil, errors.New("open " + path + ": no such file or directory")
+}
+
+func TestApplySettings_OnlyChangesGivenFlags(t *testing.T) {
+	policy := machines.NewMachinePolicy("Linux servers")
+	policy.Description = "Servers in the data centre"
+	flags := shared.NewSettingsFlags()
+	flags.BashHealthCheck.Value = "health.sh"
+	flags.HealthCheckInterval.Value = "30m"
+	flags.CalamariUpdate.Value = "updatealways"
+	flags.DeleteUnavailableAfter.Value = "2h"
+	given := map[string]bool{
+		shared.FlagBashHealthCheck:        true,
+		shared.FlagHealthCheckInterval:    true,
+		shared.FlagCalamariUpdate:         true,
+		shared.FlagDeleteUnavailableAfter: true,
+	}
+
+	err := shared.ApplySettings(policy, flags, func(name string) bool { return given[name] }, readFile)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Servers in the data centre", policy.Description)
+	assert.Equal(t, shared.ScriptRunTypeInline, policy.MachineHealthCheckPolicy.BashHealthCheckPolicy.RunType)
+	assert.Equal(t, "echo healthy\n", *policy.MachineHealthCheckPolicy.BashHealthCheckPolicy.ScriptBody)
+	assert.Equal(t, "Custom script (1 line)", shared.DescribeScript(policy.MachineHealthCheckPolicy.BashHealthCheckPolicy))
+	assert.Equal(t, "Default script", shared.DescribeScript(policy.MachineHealthCheckPolicy.PowerShellHealthCheckPolicy))
+	assert.Equal(t, 30*time.Minute, policy.MachineHealthCheckPolicy.HealthCheckInterval)
+	assert.Equal(t, "UpdateAlways", policy.MachineUpdatePolicy.CalamariUpdateBehavior)
+	assert.Equal(t, "Delete machines unavailable for 2h", shared.DescribeCleanup(policy.MachineCleanupPolicy))
+}
+
+func TestApplySettings_ChecksEveryFlagBeforeChanging(t *testing.T) {
+	policy := machines.NewMachinePolicy("Linux servers")
+	flags := shared.NewSettingsFlags()
+	flags.Description.Value = "Changed"
+	flags.Connectivity.Value = "sometimes"
+	given := map[string]bool{shared.FlagDescription: true, shared.FlagConnectivity: true}
+
+	err := shared.ApplySettings(policy, flags, func(name string) bool { return given[name] }, readFile)
+
+	assert.EqualError(t, err, "unknown value 'sometimes' for --connectivity, must be one of ExpectedToBeOnline, MayBeOfflineAndCanBeSkipped")
+	assert.Equal(t, "", policy.Description)
+}
+
+func TestApplySettings_ResetsScriptsAndCleanup(t *testing.T) {
+	policy := machines.NewMachinePolicy("Linux servers")
+	body := "echo healthy"
+	policy.MachineHealthCheckPolicy.PowerShellHealthCheckPolicy = &machines.MachineScriptPolicy{RunType: shared.ScriptRunTypeInline, ScriptBody: &body}
+	policy.MachineCleanupPolicy.DeleteMachinesBehavior = shared.CleanupDelete
+	flags := shared.NewSettingsFlags()
+	flags.DeleteUnavailableAfter.Value = "0"
+	given := map[string]bool{shared.FlagPowerShellHealthCheck: true, shared.FlagDeleteUnavailableAfter: true}
+
+	err := shared.ApplySettings(policy, flags, func(name string) bool { return given[name] }, readFile)
+
+	assert.NoError(t, err)
+	assert.Equal(t, shared.ScriptRunTypeInherit, policy.MachineHealthCheckPolicy.PowerShellHealthCheckPolicy.RunType)
+	assert.Nil(t, policy.MachineHealthCheckPolicy.PowerShellHealthCheckPolicy.ScriptBody)
+	assert.Equal(t, shared.CleanupDoNotDelete, policy.MachineCleanupPolicy.DeleteMachinesBehavior)
+}
+
+func TestApplySettings_RejectsBadDurations(t *testing.T) {
+	flags := shared.NewSettingsFlags()
+	flags.HealthCheckInterval.Value = "daily"
+
+	err := shared.ApplySettings(machines.NewMachinePolicy("Linux servers"), flags, func(name string) bool { return name == shared.FlagHealthCheckInterval }, readFile)
+
+	assert.EqualError(t, err, "cannot read --health-check-interval 'daily', give a duration such as 90m or 24h")
+}
+
+func TestGetUsages_GroupsMachinesByPolicy(t *testing.T) {
+	defaultPolicy := machines.NewMachinePolicy("Default Machine Policy")
+	defaultPolicy.ID = "MachinePolicies-1"
+	linuxPolicy := machines.NewMachinePolicy("Linux servers")
+	linuxPolicy.ID = "MachinePolicies-2"
+
+	web := machines.NewDeploymentTarget("web-01", machines.NewCloudRegionEndpoint(), []string{"Environments-1"}, []string{"web"})
+	web.ID = "Machines-1"
+	web.MachinePolicyID = "MachinePolicies-2"
+	db := machines.NewDeploymentTarget("db-01", machines.NewCloudRegionEndpoint(), []string{"Environments-1"}, []string{"db"})
+	db.ID = "Machines-2"
+	db.MachinePolicyID = "MachinePolicies-1"
+	worker := machines.NewWorker("worker-01", machines.NewCloudRegionEndpoint())
+	worker.ID = "Workers-1"
+	worker.MachinePolicyID = "machinepolicies-1"
+
+	usages, err := shared.GetUsages([]*machines.MachinePolicy{defaultPolicy, linuxPolicy},
+		func() ([]*machines.DeploymentTarget, error) { return []*machines.DeploymentTarget{web, db}, nil },
+		func() ([]*machines.Worker, error) { return []*machines.Worker{worker}, nil })
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*shared.Usage{
+		{MachinePolicyId: "MachinePolicies-1", MachinePolicy: "Default Machine Policy", Kind: shared.KindDeploymentTarget, Id: "Machines-2", Name: "db-01"},
+		{MachinePolicyId: "MachinePolicies-1", MachinePolicy: "Default Machine Policy", Kind: shared.KindWorker, Id: "Workers-1", Name: "worker-01"},
+		{MachinePolicyId: "MachinePolicies-2", MachinePolicy: "Linux servers", Kind: shared.KindDeploymentTarget, Id: "Machines-1", Name: "web-01"},
+	}, usages)
+}
@@ -0,0 +1,277 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagDescription            = "description"
+	FlagHealthCheckType        = "health-check-type"
+	FlagHealthCheckInterval    = "health-check-interval"
+	FlagPowerShellHealthCheck  = "powershell-health-check-file"
+	FlagBashHealthCheck        = "bash-health-check-file"
+	FlagConnectivity           = "connectivity"
+	FlagCalamariUpdate         = "calamari-update"
+	FlagTentacleUpdate         = "tentacle-update"
+	FlagKubernetesAgentUpdate  = "kubernetes-agent-update"
+	FlagDeleteUnavailableAfter = "delete-unavailable-after"
+
+	ScriptRunTypeInherit = "InheritFromDefault"
+	ScriptRunTypeInline  = "Inline"
+
+	CleanupDoNotDelete = "DoNotDelete"
+	CleanupDelete      = "DeleteUnavailableMachines"
+)
+
+var (
+	HealthCheckTypes        = []string{"RunScript", "OnlyConnectivity"}
+	ConnectivityBehaviors   = []string{"ExpectedToBeOnline", "MayBeOfflineAndCanBeSkipped"}
+	CalamariUpdateBehaviors = []string{"UpdateOnDeployment", "UpdateOnNewMachine", "UpdateAlways"}
+	TentacleUpdateBehaviors = []string{"NeverUpdate", "Update"}
+)
+
+// SettingsFlags are the settings of a machine policy, shared by create and update.
+type SettingsFlags struct {
+	Description            *flag.Flag[string]
+	HealthCheckType        *flag.Flag[string]
+	HealthCheckInterval    *flag.Flag[string]
+	PowerShellHealthCheck  *flag.Flag[string]
+	BashHealthCheck        *flag.Flag[string]
+	Connectivity           *flag.Flag[string]
+	CalamariUpdate         *flag.Flag[string]
+	TentacleUpdate         *flag.Flag[string]
+	KubernetesAgentUpdate  *flag.Flag[string]
+	DeleteUnavailableAfter *flag.Flag[string]
+}
+
+func NewSettingsFlags() *SettingsFlags {
+	return &SettingsFlags{
+		Description:            flag.New[string](FlagDescription, false),
+		HealthCheckType:        flag.New[string](FlagHealthCheckType, false),
+		HealthCheckInterval:    flag.New[string](FlagHealthCheckInterval, false),
+		PowerShellHealthCheck:  flag.New[string](FlagPowerShellHealthCheck, false),
+		BashHealthCheck:        flag.New[string](FlagBashHealthCheck, false),
+		Connectivity:           flag.New[string](FlagConnectivity, false),
+		CalamariUpdate:         flag.New[string](FlagCalamariUpdate, false),
+		TentacleUpdate:         flag.New[string](FlagTentacleUpdate, false),
+		KubernetesAgentUpdate:  flag.New[string](FlagKubernetesAgentUpdate, false),
+		DeleteUnavailableAfter: flag.New[string](FlagDeleteUnavailableAfter, false),
+	}
+}
+
+func RegisterSettingsFlags(cmd *cobra.Command, flags *SettingsFlags) {
+	f := cmd.Flags()
+	f.StringVarP(&flags.Description.Value, flags.Description.Name, "d", "", "Description of the machine policy")
+	f.StringVar(&flags.HealthCheckType.Value, flags.HealthCheckType.Name, "", fmt.Sprintf("How machines are health checked: %s", output.FormatAsList(HealthCheckTypes)))
+	f.StringVar(&flags.HealthCheckInterval.Value, flags.HealthCheckInterval.Name, "", "How often machines are health checked, such as 30m or 24h")
+	f.StringVar(&flags.PowerShellHealthCheck.Value, flags.PowerShellHealthCheck.Name, "", "File with the PowerShell health check script, or '' for the default script")
+	f.StringVar(&flags.BashHealthCheck.Value, flags.BashHealthCheck.Name, "", "File with the Bash health check script, or '' for the default script")
+	f.StringVar(&flags.Connectivity.Value, flags.Connectivity.Name, "", fmt.Sprintf("Whether machines must be online during deployments: %s", output.FormatAsList(ConnectivityBehaviors)))
+	f.StringVar(&flags.CalamariUpdate.Value, flags.CalamariUpdate.Name, "", fmt.Sprintf("When Calamari is updated on machines: %s", output.FormatAsList(CalamariUpdateBehaviors)))
+	f.StringVar(&flags.TentacleUpdate.Value, flags.TentacleUpdate.Name, "", fmt.Sprintf("Whether Tentacles are updated automatically: %s", output.FormatAsList(TentacleUpdateBehaviors)))
+	f.StringVar(&flags.KubernetesAgentUpdate.Value, flags.KubernetesAgentUpdate.Name, "", fmt.Sprintf("Whether Kubernetes agents are updated automatically: %s", output.FormatAsList(TentacleUpdateBehaviors)))
+	f.StringVar(&flags.DeleteUnavailableAfter.Value, flags.DeleteUnavailableAfter.Name, "", "Delete machines which have been unavailable for this long, such as 24h, or 0 to keep them")
+}
+
+// GeneratableFlags are the settings flags, for the automation command.
+func (f *SettingsFlags) GeneratableFlags() []flag.Generatable {
+	return []flag.Generatable{f.Description, f.HealthCheckType, f.HealthCheckInterval, f.PowerShellHealthCheck, f.BashHealthCheck,
+		f.Connectivity, f.CalamariUpdate, f.TentacleUpdate, f.KubernetesAgentUpdate, f.DeleteUnavailableAfter}
+}
+
+// ApplySettings changes the settings of a machine policy for each flag which was set, reading
+// health check scripts with readFile. Every flag is checked before the policy is changed.
+func ApplySettings(policy *machines.MachinePolicy, flags *SettingsFlags, isFlagSet func(name string) bool, readFile func(path string) ([]byte, error)) error {
+	var changes []func()
+	set := func(f *flag.Flag[string], change func(value string) (func(), error)) error {
+		if !isFlagSet(f.Name) {
+			return nil
+		}
+		apply, err := change(f.Value)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, apply)
+		return nil
+	}
+	oneOf := func(f *flag.Flag[string], allowed []string, target *string) error {
+		return set(f, func(value string) (func(), error) {
+			found, ok := machinescommon.FindIgnoringCase(allowed, value)
+			if !ok {
+				return nil, fmt.Errorf("unknown value '%s' for --%s, must be one of %s", value, f.Name, output.FormatAsList(allowed))
+			}
+			return func() { *target = found }, nil
+		})
+	}
+	script := func(f *flag.Flag[string], target *machines.MachineScriptPolicy) error {
+		return set(f, func(path string) (func(), error) {
+			if path == "" {
+				return func() { target.RunType = ScriptRunTypeInherit; target.ScriptBody = nil }, nil
+			}
+			content, err := readFile(path)
+			if err != nil {
+				return nil, err
+			}
+			body := string(content)
+			return func() { target.RunType = ScriptRunTypeInline; target.ScriptBody = &body }, nil
+		})
+	}
+
+	ensurePolicies(policy)
+	healthCheck := policy.MachineHealthCheckPolicy
+	err := set(flags.Description, func(value string) (func(), error) {
+		return func() { policy.Description = value }, nil
+	})
+	if err == nil {
+		err = oneOf(flags.HealthCheckType, HealthCheckTypes, &healthCheck.HealthCheckType)
+	}
+	if err == nil {
+		err = set(flags.HealthCheckInterval, func(value string) (func(), error) {
+			interval, err := parseDuration(flags.HealthCheckInterval.Name, value)
+			if err != nil {
+				return nil, err
+			}
+			if interval <= 0 {
+				return nil, fmt.Errorf("--%s must be more than zero", flags.HealthCheckInterval.Name)
+			}
+			return func() { healthCheck.HealthCheckInterval = interval; healthCheck.HealthCheckCron = "" }, nil
+		})
+	}
+	if err == nil {
+		err = script(flags.PowerShellHealthCheck, healthCheck.PowerShellHealthCheckPolicy)
+	}
+	if err == nil {
+		err = script(flags.BashHealthCheck, healthCheck.BashHealthCheckPolicy)
+	}
+	if err == nil {
+		err = oneOf(flags.Connectivity, ConnectivityBehaviors, &policy.MachineConnectivityPolicy.MachineConnectivityBehavior)
+	}
+	if err == nil {
+		err = oneOf(flags.CalamariUpdate, CalamariUpdateBehaviors, &policy.MachineUpdatePolicy.CalamariUpdateBehavior)
+	}
+	if err == nil {
+		err = oneOf(flags.TentacleUpdate, TentacleUpdateBehaviors, &policy.MachineUpdatePolicy.TentacleUpdateBehavior)
+	}
+	if err == nil {
+		err = oneOf(flags.KubernetesAgentUpdate, TentacleUpdateBehaviors, &policy.MachineUpdatePolicy.KubernetesAgentUpdateBehavior)
+	}
+	if err == nil {
+		err = set(flags.DeleteUnavailableAfter, func(value string) (func(), error) {
+			after, err := parseDuration(flags.DeleteUnavailableAfter.Name, value)
+			if err != nil {
+				return nil, err
+			}
+			cleanup := policy.MachineCleanupPolicy
+			if after <= 0 {
+				return func() { cleanup.DeleteMachinesBehavior = CleanupDoNotDelete }, nil
+			}
+			return func() { cleanup.DeleteMachinesBehavior = CleanupDelete; cleanup.DeleteMachinesElapsedTimeSpan = after }, nil
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, apply := range changes {
+		apply()
+	}
+	return nil
+}
+
+// IsAnySettingSet reports whether any of the settings flags were given.
+func IsAnySettingSet(flags *SettingsFlags, isFlagSet func(name string) bool) bool {
+	for _, f := range flags.GeneratableFlags() {
+		if isFlagSet(f.GetName()) {
+			return true
+		}
+	}
+	return false
+}
+
+// ensurePolicies fills in the parts of a machine policy the server left out, so they can be set.
+func ensurePolicies(policy *machines.MachinePolicy) {
+	if policy.MachineHealthCheckPolicy == nil {
+		policy.MachineHealthCheckPolicy = machines.NewMachineHealthCheckPolicy()
+	}
+	if policy.MachineHealthCheckPolicy.PowerShellHealthCheckPolicy == nil {
+		policy.MachineHealthCheckPolicy.PowerShellHealthCheckPolicy = machines.NewMachineScriptPolicy()
+	}
+	if policy.MachineHealthCheckPolicy.BashHealthCheckPolicy == nil {
+		policy.MachineHealthCheckPolicy.BashHealthCheckPolicy = machines.NewMachineScriptPolicy()
+	}
+	if policy.MachineConnectivityPolicy == nil {
+		policy.MachineConnectivityPolicy = machines.NewMachineConnectivityPolicy()
+	}
+	if policy.MachineUpdatePolicy == nil {
+		policy.MachineUpdatePolicy = machines.NewMachineUpdatePolicy()
+	}
+	if policy.MachineCleanupPolicy == nil {
+		policy.MachineCleanupPolicy = machines.NewMachineCleanupPolicy()
+	}
+}
+
+func parseDuration(flagName string, value string) (time.Duration, error) {
+	if strings.TrimSpace(value) == "0" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read --%s '%s', give a duration such as 90m or 24h", flagName, value)
+	}
+	return duration, nil
+}
+
+// DescribeScript describes a health check script, as the scripts can be long.
+func DescribeScript(policy *machines.MachineScriptPolicy) string {
+	if policy == nil || policy.RunType != ScriptRunTypeInline || policy.ScriptBody == nil {
+		return "Default script"
+	}
+	lines := strings.Count(strings.TrimRight(*policy.ScriptBody, "\n"), "\n") + 1
+	if lines == 1 {
+		return "Custom script (1 line)"
+	}
+	return fmt.Sprintf("Custom script (%d lines)", lines)
+}
+
+// DescribeCleanup describes when unavailable machines are deleted.
+func DescribeCleanup(policy *machines.MachineCleanupPolicy) string {
+	if policy == nil || policy.DeleteMachinesBehavior != CleanupDelete {
+		return "Never delete unavailable machines"
+	}
+	return fmt.Sprintf("Delete machines unavailable for %s", FormatDuration(policy.DeleteMachinesElapsedTimeSpan))
+}
+
+// DescribeHealthCheck describes how and how often machines are health checked.
+func DescribeHealthCheck(policy *machines.MachineHealthCheckPolicy) string {
+	if policy == nil {
+		return ""
+	}
+	description := "Run script"
+	if policy.HealthCheckType == "OnlyConnectivity" {
+		description = "Connectivity only"
+	}
+	if policy.HealthCheckCron != "" {
+		return fmt.Sprintf("%s, cron '%s'", description, policy.HealthCheckCron)
+	}
+	return fmt.Sprintf("%s every %s", description, FormatDuration(policy.HealthCheckInterval))
+}
+
+// FormatDuration shows a duration without the zero minutes and seconds, such as 24h rather than 24h0m0s.
+func FormatDuration(duration time.Duration) string {
+	formatted := duration.String()
+	if strings.HasSuffix(formatted, "m0s") {
+		formatted = strings.TrimSuffix(formatted, "0s")
+	}
+	if strings.HasSuffix(formatted, "h0m") {
+		formatted = strings.TrimSuffix(formatted, "0m")
+	}
+	return formatted
+}
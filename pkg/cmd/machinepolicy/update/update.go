@@ -0,0 +1,200 @@
+package update
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagMachinePolicy = "machine-policy"
+	FlagName          = "name"
+)
+
+type UpdateFlags struct {
+	MachinePolicy *flag.Flag[string]
+	Name          *flag.Flag[string]
+	*shared.SettingsFlags
+}
+
+func NewUpdateFlags() *UpdateFlags {
+	return &UpdateFlags{
+		MachinePolicy: flag.New[string](FlagMachinePolicy, false),
+		Name:          flag.New[string](FlagName, false),
+		SettingsFlags: shared.NewSettingsFlags(),
+	}
+}
+
+type UpdateMachinePolicyCallback func(policy *machines.MachinePolicy) (*machines.MachinePolicy, error)
+
+type UpdateOptions struct {
+	*UpdateFlags
+	*cmd.Dependencies
+	IsFlagSet                     flag.IsSetFunc
+	ReadFileCallback              func(path string) ([]byte, error)
+	GetMachinePolicyCallback      shared.GetMachinePolicyCallback
+	GetAllMachinePoliciesCallback machinescommon.GetAllMachinePoliciesCallback
+	UpdateMachinePolicyCallback   UpdateMachinePolicyCallback
+}
+
+func NewUpdateOptions(flags *UpdateFlags, dependencies *cmd.Dependencies, isFlagSet flag.IsSetFunc) *UpdateOptions {
+	return &UpdateOptions{
+		UpdateFlags:      flags,
+		Dependencies:     dependencies,
+		IsFlagSet:        isFlagSet,
+		ReadFileCallback: os.ReadFile,
+		GetMachinePolicyCallback: func(identifier string) (*machines.MachinePolicy, error) {
+			return shared.GetMachinePolicy(dependencies.Client, identifier)
+		},
+		GetAllMachinePoliciesCallback: func() ([]*machines.MachinePolicy, error) {
+			return dependencies.Client.MachinePolicies.GetAll()
+		},
+		UpdateMachinePolicyCallback: func(policy *machines.MachinePolicy) (*machines.MachinePolicy, error) {
+			return dependencies.Client.MachinePolicies.Update(policy)
+		},
+	}
+}
+
+func NewCmdUpdate(f factory.Factory) *cobra.Command {
+	updateFlags := NewUpdateFlags()
+
+	cmd := &cobra.Command{
+		Use:   "update [<name> | <id>]",
+		Short: "Update a machine policy",
+		Long: heredoc.Docf(`
+			Update a machine policy in Octopus Deploy.
+
+			Only the settings given as flags are changed. Health check scripts are read from the
+			files given; use --%[1]s '' or --%[2]s ''
+			to go back to the default script.
+		`, shared.FlagPowerShellHealthCheck, shared.FlagBashHealthCheck),
+		Example: heredoc.Docf(`
+			%[1]s machine-policy update "Linux servers" --bash-health-check-file health.sh
+			%[1]s machine-policy update --machine-policy "Autoscaled" --delete-unavailable-after 0
+			%[1]s machine-policy update "Default Machine Policy" --calamari-update UpdateAlways --tentacle-update Update
+		`, constants.ExecutableName),
+		Aliases: []string{"edit"},
+		Args:    usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if updateFlags.MachinePolicy.Value == "" && len(args) > 0 {
+				updateFlags.MachinePolicy.Value = args[0]
+			}
+
+			opts := NewUpdateOptions(updateFlags, cmd.NewDependencies(f, c), c.Flags().Changed)
+			return updateRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&updateFlags.MachinePolicy.Value, updateFlags.MachinePolicy.Name, "", "Name or ID of the machine policy to update")
+	flags.StringVarP(&updateFlags.Name.Value, updateFlags.Name.Name, "n", "", "New name of the machine policy")
+	shared.RegisterSettingsFlags(cmd, updateFlags.SettingsFlags)
+
+	return cmd
+}
+
+func updateRun(opts *UpdateOptions) error {
+	policy, err := shared.ResolveMachinePolicy(opts.Ask, opts.NoPrompt, opts.MachinePolicy.Value,
+		"Select the machine policy you wish to update", opts.GetMachinePolicyCallback, opts.GetAllMachinePoliciesCallback)
+	if err != nil {
+		return err
+	}
+	opts.MachinePolicy.Value = policy.Name
+
+	if !opts.NoPrompt {
+		if err := PromptMissing(opts, policy); err != nil {
+			return err
+		}
+	}
+
+	if err := ApplyFlags(opts, policy); err != nil {
+		return err
+	}
+
+	updated, err := opts.UpdateMachinePolicyCallback(policy)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(opts.Out, "\nSuccessfully updated machine policy '%s' (%s).\n", updated.Name, updated.GetID())
+	if err != nil {
+		return err
+	}
+
+	link := output.Bluef("%s/app#/%s/infrastructure/machinepolicies/%s", opts.Host, opts.Space.GetID(), updated.GetID())
+	fmt.Fprintf(opts.Out, "View this machine policy on Octopus Deploy: %s\n", link)
+
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), append([]flag.Generatable{opts.MachinePolicy, opts.Name}, opts.SettingsFlags.GeneratableFlags()...)...)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+
+	return nil
+}
+
+// ApplyFlags changes the machine policy for each flag which was given, returning an error when
+// none were given.
+func ApplyFlags(opts *UpdateOptions, policy *machines.MachinePolicy) error {
+	if !opts.IsFlagSet(FlagName) && !shared.IsAnySettingSet(opts.SettingsFlags, opts.IsFlagSet) {
+		return fmt.Errorf("nothing to update, give the settings to change as flags")
+	}
+
+	if err := shared.ApplySettings(policy, opts.SettingsFlags, opts.IsFlagSet, opts.ReadFileCallback); err != nil {
+		return err
+	}
+	if opts.IsFlagSet(FlagName) {
+		if opts.Name.Value == "" {
+			return fmt.Errorf("the name of a machine policy cannot be empty")
+		}
+		policy.Name = opts.Name.Value
+	}
+	return nil
+}
+
+// PromptMissing asks for the name and description when they were not given, offering the current
+// values as the defaults. The answers are treated as if they were given as flags.
+func PromptMissing(opts *UpdateOptions, policy *machines.MachinePolicy) error {
+	prompted := flag.NewPrompted(opts.IsFlagSet)
+	if !opts.IsFlagSet(FlagName) {
+		opts.Name.Value = policy.Name
+		if err := opts.Ask(&survey.Input{
+			Message: "Name",
+			Help:    "A short, memorable, unique name for this machine policy.",
+			Default: policy.Name,
+		}, &opts.Name.Value, survey.WithValidator(survey.ComposeValidators(
+			survey.MaxLength(200),
+			survey.MinLength(1),
+			survey.Required,
+		))); err != nil {
+			return err
+		}
+		prompted.Record(opts.Name, policy.Name)
+	}
+
+	if !opts.IsFlagSet(shared.FlagDescription) {
+		opts.Description.Value = policy.Description
+		if err := opts.Ask(&survey.Input{
+			Message: "Description",
+			Help:    "A short, memorable, description for this machine policy.",
+			Default: policy.Description,
+		}, &opts.Description.Value); err != nil {
+			return err
+		}
+		prompted.Record(opts.Description, policy.Description)
+	}
+
+	opts.IsFlagSet = prompted.IsSet
+	return nil
+}
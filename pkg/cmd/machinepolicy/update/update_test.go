@@ -0,0 +1,37 @@
+package update_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/update"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyFlags_OnlyChangesGivenFlags(t *testing.T) {
+	policy := machines.NewMachinePolicy("Linux servers")
+	policy.Description = "Servers in the data centre"
+	flags := update.NewUpdateFlags()
+	flags.Name.Value = "Linux web servers"
+	flags.TentacleUpdate.Value = "update"
+	given := map[string]bool{update.FlagName: true, shared.FlagTentacleUpdate: true}
+	opts := update.NewUpdateOptions(flags, &cmd.Dependencies{}, func(name string) bool { return given[name] })
+
+	err := update.ApplyFlags(opts, policy)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Linux web servers", policy.Name)
+	assert.Equal(t, "Servers in the data centre", policy.Description)
+	assert.Equal(t, "Update", policy.MachineUpdatePolicy.TentacleUpdateBehavior)
+	assert.Equal(t, "UpdateOnDeployment", policy.MachineUpdatePolicy.CalamariUpdateBehavior)
+}
+
+func TestApplyFlags_RequiresAChange(t *testing.T) {
+	opts := update.NewUpdateOptions(update.NewUpdateFlags(), &cmd.Dependencies{}, func(name string) bool { return name == update.FlagMachinePolicy })
+
+	err := update.ApplyFlags(opts, machines.NewMachinePolicy("Linux servers"))
+
+	assert.EqualError(t, err, "nothing to update, give the settings to change as flags")
+}
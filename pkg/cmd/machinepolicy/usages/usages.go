@@ -0,0 +1,141 @@
+package usages
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/shared"
+	targetShared "github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	workerShared "github.com/OctopusDeploy/cli/pkg/cmd/worker/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
+)
+
+const FlagMachinePolicy = "machine-policy"
+
+type UsagesFlags struct {
+	MachinePolicy *flag.Flag[string]
+}
+
+func NewUsagesFlags() *UsagesFlags {
+	return &UsagesFlags{
+		MachinePolicy: flag.New[string](FlagMachinePolicy, false),
+	}
+}
+
+type UsagesOptions struct {
+	*UsagesFlags
+	*cmd.Dependencies
+	Command                       *cobra.Command
+	GetMachinePolicyCallback      shared.GetMachinePolicyCallback
+	GetAllMachinePoliciesCallback machinescommon.GetAllMachinePoliciesCallback
+	GetTargetsCallback            shared.GetTargetsCallback
+	GetWorkersCallback            shared.GetWorkersCallback
+}
+
+func NewUsagesOptions(flags *UsagesFlags, dependencies *cmd.Dependencies, command *cobra.Command) *UsagesOptions {
+	return &UsagesOptions{
+		UsagesFlags:  flags,
+		Dependencies: dependencies,
+		Command:      command,
+		GetMachinePolicyCallback: func(identifier string) (*machines.MachinePolicy, error) {
+			return shared.GetMachinePolicy(dependencies.Client, identifier)
+		},
+		GetAllMachinePoliciesCallback: func() ([]*machines.MachinePolicy, error) {
+			return dependencies.Client.MachinePolicies.GetAll()
+		},
+		GetTargetsCallback: func() ([]*machines.DeploymentTarget, error) {
+			return targetShared.GetAllTargets(*dependencies.Client, machines.MachinesQuery{})
+		},
+		GetWorkersCallback: func() ([]*machines.Worker, error) {
+			return workerShared.GetWorkersByQuery(*dependencies.Client, machines.WorkersQuery{}, nil)
+		},
+	}
+}
+
+func NewCmdUsages(f factory.Factory) *cobra.Command {
+	usagesFlags := NewUsagesFlags()
+
+	cmd := &cobra.Command{
+		Use:   "usages [<name> | <id>]",
+		Short: "List the machines which use machine policies",
+		Long: heredoc.Doc(`
+			List the deployment targets and workers which use a machine policy in Octopus Deploy.
+			When no machine policy is given, the machines of every machine policy are listed.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s machine-policy usages
+			%[1]s machine-policy usages "Default Machine Policy"
+			%[1]s machine-policy usages --machine-policy MachinePolicies-2 --output-format json
+		`, constants.ExecutableName),
+		Aliases: []string{"usage"},
+		Args:    usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if usagesFlags.MachinePolicy.Value == "" && len(args) > 0 {
+				usagesFlags.MachinePolicy.Value = args[0]
+			}
+
+			opts := NewUsagesOptions(usagesFlags, cmd.NewDependencies(f, c), c)
+			return usagesRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&usagesFlags.MachinePolicy.Value, usagesFlags.MachinePolicy.Name, "", "Name or ID of the machine policy, all machine policies when not given")
+
+	return cmd
+}
+
+func usagesRun(opts *UsagesOptions) error {
+	var policies []*machines.MachinePolicy
+	if opts.MachinePolicy.Value != "" {
+		policy, err := opts.GetMachinePolicyCallback(opts.MachinePolicy.Value)
+		if err != nil {
+			return err
+		}
+		policies = []*machines.MachinePolicy{policy}
+	} else {
+		allPolicies, err := opts.GetAllMachinePoliciesCallback()
+		if err != nil {
+			return err
+		}
+		policies = allPolicies
+	}
+
+	usages, err := shared.GetUsages(policies, opts.GetTargetsCallback, opts.GetWorkersCallback)
+	if err != nil {
+		return err
+	}
+
+	outputFormat, _ := opts.Command.Flags().GetString(constants.FlagOutputFormat)
+	if len(usages) == 0 && !constants.IsProgrammaticOutputFormat(outputFormat) {
+		if len(policies) == 1 {
+			_, err = fmt.Fprintf(opts.Out, "Machine policy '%s' is not used by any deployment targets or workers.\n", policies[0].Name)
+		} else {
+			_, err = fmt.Fprintln(opts.Out, "No machine policies are used by deployment targets or workers.")
+		}
+		return err
+	}
+
+	return output.PrintArray(usages, opts.Command, output.Mappers[*shared.Usage]{
+		Json: func(u *shared.Usage) any {
+			return u
+		},
+		Table: output.TableDefinition[*shared.Usage]{
+			Header: []string{"MACHINE POLICY", "KIND", "NAME", "ID"},
+			Row: func(u *shared.Usage) []string {
+				return []string{u.MachinePolicy, u.Kind, output.Bold(u.Name), u.Id}
+			},
+		},
+		Basic: func(u *shared.Usage) string {
+			return fmt.Sprintf("%s: %s %s %s", u.MachinePolicy, u.Kind, u.Name, output.Dimf("(%s)", u.Id))
+		},
+	})
+}
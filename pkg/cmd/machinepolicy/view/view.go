@@ -0,0 +1,199 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/pkg/browser"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagMachinePolicy = "machine-policy"
+	FlagWeb           = "web"
+)
+
+type ViewFlags struct {
+	MachinePolicy *flag.Flag[string]
+	Web           *flag.Flag[bool]
+}
+
+func NewViewFlags() *ViewFlags {
+	return &ViewFlags{
+		MachinePolicy: flag.New[string](FlagMachinePolicy, false),
+		Web:           flag.New[bool](FlagWeb, false),
+	}
+}
+
+type ViewOptions struct {
+	*ViewFlags
+	*cmd.Dependencies
+	Command                       *cobra.Command
+	GetMachinePolicyCallback      shared.GetMachinePolicyCallback
+	GetAllMachinePoliciesCallback machinescommon.GetAllMachinePoliciesCallback
+}
+
+func NewViewOptions(flags *ViewFlags, dependencies *cmd.Dependencies, command *cobra.Command) *ViewOptions {
+	return &ViewOptions{
+		ViewFlags:    flags,
+		Dependencies: dependencies,
+		Command:      command,
+		GetMachinePolicyCallback: func(identifier string) (*machines.MachinePolicy, error) {
+			return shared.GetMachinePolicy(dependencies.Client, identifier)
+		},
+		GetAllMachinePoliciesCallback: func() ([]*machines.MachinePolicy, error) {
+			return dependencies.Client.MachinePolicies.GetAll()
+		},
+	}
+}
+
+func NewCmdView(f factory.Factory) *cobra.Command {
+	viewFlags := NewViewFlags()
+	cmd := &cobra.Command{
+		Args:  usage.MaximumNArgs(1),
+		Use:   "view [<name> | <id>]",
+		Short: "View a machine policy",
+		Long:  "View a machine policy in Octopus Deploy, including its health check, connectivity, update and cleanup settings",
+		Example: heredoc.Docf(`
+			%[1]s machine-policy view MachinePolicies-1
+			%[1]s machine-policy view "Default Machine Policy"
+			%[1]s machine-policy view --machine-policy "Linux servers" --output-format json
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, args []string) error {
+			if viewFlags.MachinePolicy.Value == "" && len(args) > 0 {
+				viewFlags.MachinePolicy.Value = args[0]
+			}
+
+			opts := NewViewOptions(viewFlags, cmd.NewDependencies(f, c), c)
+			return viewRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&viewFlags.MachinePolicy.Value, viewFlags.MachinePolicy.Name, "", "Name or ID of the machine policy")
+	flags.BoolVarP(&viewFlags.Web.Value, viewFlags.Web.Name, "w", false, "Open in web browser")
+
+	return cmd
+}
+
+type MachinePolicyAsJson struct {
+	*machines.MachinePolicy
+	WebUrl string `json:"WebUrl"`
+}
+
+func viewRun(opts *ViewOptions) error {
+	policy, err := shared.ResolveMachinePolicy(opts.Ask, opts.NoPrompt, opts.MachinePolicy.Value,
+		"Select the machine policy you wish to view", opts.GetMachinePolicyCallback, opts.GetAllMachinePoliciesCallback)
+	if err != nil {
+		return err
+	}
+
+	link := util.GenerateWebURL(opts.Host, policy.SpaceID, fmt.Sprintf("infrastructure/machinepolicies/%s", policy.GetID()))
+
+	err = output.PrintResource(policy, opts.Command, output.Mappers[*machines.MachinePolicy]{
+		Json: func(p *machines.MachinePolicy) any {
+			p.Links = nil // ensure the links collection is not serialised
+			return MachinePolicyAsJson{MachinePolicy: p, WebUrl: link}
+		},
+		Table: output.TableDefinition[*machines.MachinePolicy]{
+			Header: []string{"NAME", "ID", "DEFAULT", "HEALTH CHECK", "CONNECTIVITY", "CALAMARI UPDATE", "TENTACLE UPDATE", "CLEANUP"},
+			Row: func(p *machines.MachinePolicy) []string {
+				return []string{
+					output.Bold(p.Name),
+					output.Dim(p.GetID()),
+					strconv.FormatBool(p.IsDefault),
+					shared.DescribeHealthCheck(p.MachineHealthCheckPolicy),
+					connectivityBehavior(p),
+					calamariUpdateBehavior(p),
+					tentacleUpdateBehavior(p),
+					shared.DescribeCleanup(p.MachineCleanupPolicy),
+				}
+			},
+		},
+		Basic: func(p *machines.MachinePolicy) string {
+			return formatBasic(p, link)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.Web.Value {
+		browser.OpenURL(link)
+	}
+
+	return nil
+}
+
+func connectivityBehavior(p *machines.MachinePolicy) string {
+	if p.MachineConnectivityPolicy == nil {
+		return ""
+	}
+	return p.MachineConnectivityPolicy.MachineConnectivityBehavior
+}
+
+func calamariUpdateBehavior(p *machines.MachinePolicy) string {
+	if p.MachineUpdatePolicy == nil {
+		return ""
+	}
+	return p.MachineUpdatePolicy.CalamariUpdateBehavior
+}
+
+func tentacleUpdateBehavior(p *machines.MachinePolicy) string {
+	if p.MachineUpdatePolicy == nil {
+		return ""
+	}
+	return p.MachineUpdatePolicy.TentacleUpdateBehavior
+}
+
+func formatBasic(p *machines.MachinePolicy, link string) string {
+	var s strings.Builder
+
+	s.WriteString(fmt.Sprintf("%s %s\n", output.Bold(p.Name), output.Dimf("(%s)", p.GetID())))
+	if p.Description == "" {
+		s.WriteString(fmt.Sprintln(output.Dim(constants.NoDescription)))
+	} else {
+		s.WriteString(fmt.Sprintln(output.Dim(p.Description)))
+	}
+	if p.IsDefault {
+		s.WriteString("Default machine policy\n")
+	}
+
+	if h := p.MachineHealthCheckPolicy; h != nil {
+		s.WriteString(fmt.Sprintln(output.Bold("\nHealth check")))
+		s.WriteString(fmt.Sprintf("%s\n", shared.DescribeHealthCheck(h)))
+		s.WriteString(fmt.Sprintf("PowerShell: %s\n", shared.DescribeScript(h.PowerShellHealthCheckPolicy)))
+		s.WriteString(fmt.Sprintf("Bash: %s\n", shared.DescribeScript(h.BashHealthCheckPolicy)))
+	}
+
+	s.WriteString(fmt.Sprintln(output.Bold("\nConnectivity")))
+	s.WriteString(fmt.Sprintf("%s\n", connectivityBehavior(p)))
+
+	if u := p.MachineUpdatePolicy; u != nil {
+		s.WriteString(fmt.Sprintln(output.Bold("\nUpdates")))
+		s.WriteString(fmt.Sprintf("Calamari: %s\n", u.CalamariUpdateBehavior))
+		s.WriteString(fmt.Sprintf("Tentacle: %s\n", u.TentacleUpdateBehavior))
+		if u.KubernetesAgentUpdateBehavior != "" {
+			s.WriteString(fmt.Sprintf("Kubernetes agent: %s\n", u.KubernetesAgentUpdateBehavior))
+		}
+	}
+
+	s.WriteString(fmt.Sprintln(output.Bold("\nCleanup")))
+	s.WriteString(fmt.Sprintf("%s\n", shared.DescribeCleanup(p.MachineCleanupPolicy)))
+
+	s.WriteString(fmt.Sprintf("\nView this machine policy in Octopus Deploy: %s\n", output.Blue(link)))
+
+	return s.String()
+}
@@ -0,0 +1,132 @@
+package export
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	sharedProcess "github.com/OctopusDeploy/cli/pkg/cmd/project/process/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject = "project"
+	FlagGitRef  = "git-ref"
+	FlagFormat  = "format"
+	FlagFile    = "file"
+)
+
+type ExportFlags struct {
+	Project *flag.Flag[string]
+	GitRef  *flag.Flag[string]
+	Format  *flag.Flag[string]
+	File    *flag.Flag[string]
+}
+
+func NewExportFlags() *ExportFlags {
+	return &ExportFlags{
+		Project: flag.New[string](FlagProject, false),
+		GitRef:  flag.New[string](FlagGitRef, false),
+		Format:  flag.New[string](FlagFormat, false),
+		File:    flag.New[string](FlagFile, false),
+	}
+}
+
+type ExportOptions struct {
+	*ExportFlags
+	*cmd.Dependencies
+	*sharedProcess.ProcessCallbacks
+}
+
+func NewExportOptions(flags *ExportFlags, dependencies *cmd.Dependencies) *ExportOptions {
+	return &ExportOptions{
+		ExportFlags:      flags,
+		Dependencies:     dependencies,
+		ProcessCallbacks: sharedProcess.NewProcessCallbacks(dependencies),
+	}
+}
+
+func NewCmdExport(f factory.Factory) *cobra.Command {
+	exportFlags := NewExportFlags()
+	cmd := &cobra.Command{
+		Use:   "export [<project>]",
+		Short: "Export the deployment process of a project",
+		Long:  "Export the deployment process of a project in Octopus Deploy as JSON, YAML or OCL",
+		Example: heredoc.Docf(`
+			%[1]s project process export "Deploy Web App"
+			%[1]s project process export -p "Deploy Web App" --format yaml --file process.yaml
+			%[1]s project process export -p "Deploy Web App" --git-ref refs/heads/main --format ocl
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if exportFlags.Project.Value == "" && len(args) > 0 {
+				exportFlags.Project.Value = args[0]
+			}
+
+			opts := NewExportOptions(exportFlags, cmd.NewDependencies(f, c))
+			return exportRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&exportFlags.Project.Value, exportFlags.Project.Name, "p", "", "Name or ID of the project")
+	flags.StringVarP(&exportFlags.GitRef.Value, exportFlags.GitRef.Name, "", "", "The GitRef for the Config-As-Code branch")
+	flags.StringVar(&exportFlags.Format.Value, exportFlags.Format.Name, "", fmt.Sprintf("The format to export, one of %s. Inferred from --%s when not given, otherwise json", output.FormatAsList(sharedProcess.ExportFormats), FlagFile))
+	flags.StringVar(&exportFlags.File.Value, exportFlags.File.Name, "", "The file to write the process to. Writes to standard output when not given")
+
+	return cmd
+}
+
+func exportRun(opts *ExportOptions) error {
+	format := opts.Format.Value
+	if format == "" {
+		format = sharedProcess.FormatFromFileName(opts.File.Value)
+	}
+	if format == "" {
+		format = sharedProcess.FormatJson
+	}
+
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project whose deployment process you wish to export", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	gitRef, err := sharedProcess.ResolveGitRef(opts.Dependencies, opts.ProcessCallbacks, project, opts.GitRef.Value)
+	if err != nil {
+		return err
+	}
+
+	process, err := opts.GetProcessCallback(project, gitRef)
+	if err != nil {
+		return err
+	}
+
+	var lookups *sharedProcess.Lookups
+	if format == sharedProcess.FormatOcl {
+		lookups = opts.GetLookupsCallback(project)
+	}
+
+	data, err := sharedProcess.Marshal(process, format, lookups)
+	if err != nil {
+		return err
+	}
+
+	if opts.File.Value == "" {
+		_, err = fmt.Fprintln(opts.Out, string(data))
+		return err
+	}
+
+	if err := os.WriteFile(opts.File.Value, data, 0644); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(opts.Out, "Exported the deployment process of '%s' to %s\n", project.GetName(), opts.File.Value)
+	return err
+}
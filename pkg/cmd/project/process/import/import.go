@@ -0,0 +1,213 @@
+package importcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	sharedProcess "github.com/OctopusDeploy/cli/pkg/cmd/project/process/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject       = "project"
+	FlagGitRef        = "git-ref"
+	FlagFormat        = "format"
+	FlagFile          = "file"
+	FlagDryRun        = "dry-run"
+	FlagCommitMessage = "commit-message"
+)
+
+type ImportFlags struct {
+	Project       *flag.Flag[string]
+	GitRef        *flag.Flag[string]
+	Format        *flag.Flag[string]
+	File          *flag.Flag[string]
+	DryRun        *flag.Flag[bool]
+	CommitMessage *flag.Flag[string]
+	*question.ConfirmFlags
+}
+
+func NewImportFlags() *ImportFlags {
+	return &ImportFlags{
+		Project:       flag.New[string](FlagProject, false),
+		GitRef:        flag.New[string](FlagGitRef, false),
+		Format:        flag.New[string](FlagFormat, false),
+		File:          flag.New[string](FlagFile, false),
+		DryRun:        flag.New[bool](FlagDryRun, false),
+		CommitMessage: flag.New[string](FlagCommitMessage, false),
+		ConfirmFlags:  question.NewConfirmFlags(),
+	}
+}
+
+type ImportOptions struct {
+	*ImportFlags
+	*cmd.Dependencies
+	*sharedProcess.ProcessCallbacks
+}
+
+func NewImportOptions(flags *ImportFlags, dependencies *cmd.Dependencies) *ImportOptions {
+	return &ImportOptions{
+		ImportFlags:      flags,
+		Dependencies:     dependencies,
+		ProcessCallbacks: sharedProcess.NewProcessCallbacks(dependencies),
+	}
+}
+
+func NewCmdImport(f factory.Factory) *cobra.Command {
+	importFlags := NewImportFlags()
+	cmd := &cobra.Command{
+		Use:   "import [<project>]",
+		Short: "Import the deployment process of a project",
+		Long:  "Replace the steps of a project's deployment process in Octopus Deploy with those in a JSON or YAML file, showing the changes first",
+		Example: heredoc.Docf(`
+			%[1]s project process import -p "Deploy Web App" --file process.yaml
+			%[1]s project process import -p "Deploy Web App" --file process.json --dry-run
+			%[1]s project process import -p "Deploy Web App" --git-ref refs/heads/main --file process.yaml --commit-message "Add smoke tests" -y
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if importFlags.Project.Value == "" && len(args) > 0 {
+				importFlags.Project.Value = args[0]
+			}
+
+			opts := NewImportOptions(importFlags, cmd.NewDependencies(f, c))
+			return importRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&importFlags.Project.Value, importFlags.Project.Name, "p", "", "Name or ID of the project")
+	flags.StringVarP(&importFlags.GitRef.Value, importFlags.GitRef.Name, "", "", "The GitRef for the Config-As-Code branch")
+	flags.StringVar(&importFlags.Format.Value, importFlags.Format.Name, "", fmt.Sprintf("The format of the file, one of %s. Inferred from the file extension when not given", output.FormatAsList(sharedProcess.ImportFormats)))
+	flags.StringVar(&importFlags.File.Value, importFlags.File.Name, "", "The file to read the process from")
+	flags.BoolVar(&importFlags.DryRun.Value, importFlags.DryRun.Name, false, "Show the changes that would be made without saving them")
+	flags.StringVar(&importFlags.CommitMessage.Value, importFlags.CommitMessage.Name, "", fmt.Sprintf("The message of the commit which saves the process of a version-controlled project. Defaults to '%s'", sharedProcess.DefaultCommitMessage))
+	flags.BoolVarP(&importFlags.Confirm.Value, importFlags.Confirm.Name, "y", false, "Don't ask for confirmation before saving the changes")
+
+	return cmd
+}
+
+func importRun(opts *ImportOptions) error {
+	if opts.File.Value == "" {
+		return fmt.Errorf("must supply the file to import with --%s", FlagFile)
+	}
+
+	format := opts.Format.Value
+	if format == "" {
+		format = sharedProcess.FormatFromFileName(opts.File.Value)
+	}
+	if format == "" {
+		return fmt.Errorf("cannot infer the format of '%s'; supply --%s", opts.File.Value, FlagFormat)
+	}
+
+	data, err := os.ReadFile(opts.File.Value)
+	if err != nil {
+		return err
+	}
+
+	incoming, err := sharedProcess.Unmarshal(data, format)
+	if err != nil {
+		return fmt.Errorf("cannot read '%s': %w", opts.File.Value, err)
+	}
+	for _, step := range incoming.Steps {
+		if err := step.Validate(); err != nil {
+			return fmt.Errorf("step '%s' is not valid: %w", step.Name, err)
+		}
+		for _, action := range step.Actions {
+			if err := action.Validate(); err != nil {
+				return fmt.Errorf("action '%s' in step '%s' is not valid: %w", action.Name, step.Name, err)
+			}
+		}
+	}
+
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project whose deployment process you wish to import", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	gitRef, err := sharedProcess.ResolveGitRef(opts.Dependencies, opts.ProcessCallbacks, project, opts.GitRef.Value)
+	if err != nil {
+		return err
+	}
+	opts.GitRef.Value = gitRef
+	opts.Project.Value = project.GetName()
+	if !project.IsVersionControlled {
+		if opts.CommitMessage.Value != "" {
+			return fmt.Errorf("project '%s' is not version controlled, so a commit message cannot be used", project.GetName())
+		}
+	} else if opts.CommitMessage.Value == "" {
+		opts.CommitMessage.Value = sharedProcess.DefaultCommitMessage
+	}
+
+	current, err := opts.GetProcessCallback(project, gitRef)
+	if err != nil {
+		return err
+	}
+
+	// only the steps come from the file; identity, version and links stay with the process being updated
+	updated := *current
+	updated.Steps = incoming.Steps
+
+	before, err := sharedProcess.Marshal(current, sharedProcess.FormatYaml, nil)
+	if err != nil {
+		return err
+	}
+	after, err := sharedProcess.Marshal(&updated, sharedProcess.FormatYaml, nil)
+	if err != nil {
+		return err
+	}
+
+	if !output.HasDiff(string(before), string(after)) {
+		_, err = fmt.Fprintf(opts.Out, "The deployment process of '%s' is already up to date\n", project.GetName())
+		return err
+	}
+
+	fmt.Fprint(opts.Out, output.DiffLines(string(before), string(after)))
+
+	if opts.DryRun.Value {
+		_, err = fmt.Fprintln(opts.Out, output.Dim("Dry run: no changes were saved"))
+		return err
+	}
+
+	if !opts.NoPrompt && !opts.Confirm.Value {
+		var apply bool
+		if err := opts.Ask(&survey.Confirm{
+			Message: "Save these changes to the deployment process?",
+			Default: false,
+		}, &apply); err != nil {
+			return err
+		}
+		if !apply {
+			return errors.New("import cancelled; no changes were saved")
+		}
+	}
+
+	if _, err := opts.UpdateProcessCallback(&updated, opts.CommitMessage.Value); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(opts.Out, "Successfully imported the deployment process of '%s'\n", project.GetName())
+	if err != nil {
+		return err
+	}
+
+	if !opts.NoPrompt {
+		opts.Confirm.Value = true
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Project, opts.GitRef, opts.Format, opts.File, opts.CommitMessage, opts.Confirm)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+
+	return nil
+}
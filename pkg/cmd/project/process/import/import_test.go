@@ -0,0 +1,201 @@
+package importcmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rootResource = testutil.NewRootResource()
+
+const processYaml = `Steps:
+  - Name: Say hello
+    Condition: Success
+    StartTrigger: StartAfterPrevious
+    PackageRequirement: LetOctopusDecide
+    Properties:
+      Octopus.Action.TargetRoles: web
+    Actions:
+      - Name: Say hello
+        ActionType: Octopus.Script
+        Properties:
+          Octopus.Action.Script.ScriptBody: echo hello
+`
+
+func TestProcessImport(t *testing.T) {
+	const spaceID = "Spaces-1"
+	const projectID = "Projects-22"
+
+	space1 := fixtures.NewSpace(spaceID, "Default Space")
+	project := fixtures.NewProject(spaceID, projectID, "Fire Project", "Lifecycles-1", "ProjectGroups-1", "deploymentprocess-"+projectID)
+	vcProject := fixtures.NewVersionControlledProject(spaceID, projectID, "Fire Project", "Lifecycles-1", "ProjectGroups-1", "deploymentprocess-"+projectID)
+	vcProject.IsVersionControlled = true
+
+	newProcess := func() *deployments.DeploymentProcess {
+		process := fixtures.NewDeploymentProcessForProject(spaceID, projectID)
+		process.Links["Self"] = "/api/Spaces-1/deploymentprocesses/deploymentprocess-" + projectID
+		return process
+	}
+
+	writeFile := func(t *testing.T, name string, contents string) string {
+		path := filepath.Join(t.TempDir(), name)
+		require.Nil(t, os.WriteFile(path, []byte(contents), 0644))
+		return path
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer)
+	}{
+		{"requires a file", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"project", "process", "import", "-p", projectID, "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.EqualError(t, err, "must supply the file to import with --file")
+		}},
+
+		{"dry run shows the changes without saving them", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			file := writeFile(t, "process.yaml", processYaml)
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"project", "process", "import", "-p", projectID, "--file", file, "--dry-run", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/"+projectID).RespondWith(project)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/deploymentprocesses/deploymentprocess-"+projectID).RespondWith(newProcess())
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+
+			assert.Contains(t, stdOut.String(), "+       Name: Say hello")
+			assert.Contains(t, stdOut.String(), "+         Octopus.Action.TargetRoles: web")
+			assert.Contains(t, stdOut.String(), "Dry run: no changes were saved")
+		}},
+
+		{"saves the imported steps onto the existing process", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			file := writeFile(t, "process.yaml", processYaml)
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"project", "process", "import", "-p", projectID, "--file", file, "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/"+projectID).RespondWith(project)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/deploymentprocesses/deploymentprocess-"+projectID).RespondWith(newProcess())
+
+			req := api.ExpectRequest(t, "PUT", "/api/Spaces-1/deploymentprocesses/deploymentprocess-"+projectID)
+			body, err := testutil.ReadJson[deployments.DeploymentProcess](req.Request.Body)
+			require.Nil(t, err)
+			assert.Equal(t, "deploymentprocess-"+projectID, body.ID)
+			require.Len(t, body.Steps, 1)
+			assert.Equal(t, "Say hello", body.Steps[0].Name)
+			assert.Equal(t, "echo hello", body.Steps[0].Actions[0].Properties["Octopus.Action.Script.ScriptBody"].Value)
+			req.RespondWith(body)
+
+			_, err = testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+			assert.Contains(t, stdOut.String(), "Successfully imported the deployment process of 'Fire Project'")
+		}},
+
+		{"saves the process of a version-controlled project with a commit message", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			file := writeFile(t, "process.yaml", processYaml)
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"project", "process", "import", "-p", projectID, "--file", file, "--git-ref", "refs/heads/main", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			const processPath = "/api/Spaces-1/projects/" + projectID + "/refs%2Fheads%2Fmain/deploymentprocesses"
+			process := newProcess()
+			process.Links["Self"] = processPath
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/"+projectID).RespondWithJSON(fixtures.AsServerResponse(vcProject))
+			api.ExpectRequest(t, "GET", processPath).RespondWith(process)
+
+			req := api.ExpectRequest(t, "PUT", processPath)
+			body, err := testutil.ReadJson[map[string]any](req.Request.Body)
+			require.Nil(t, err)
+			assert.Equal(t, "Update the deployment process", body["ChangeDescription"])
+			assert.Equal(t, "deploymentprocess-"+projectID, body["Id"])
+			req.RespondWith(process)
+
+			_, err = testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+			assert.Contains(t, stdOut.String(), "Successfully imported the deployment process of 'Fire Project'")
+		}},
+
+		{"commit message cannot be used on a database project", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			file := writeFile(t, "process.yaml", processYaml)
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"project", "process", "import", "-p", projectID, "--file", file, "--commit-message", "Add steps", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/"+projectID).RespondWith(project)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.EqualError(t, err, "project 'Fire Project' is not version controlled, so a commit message cannot be used")
+		}},
+
+		{"git ref cannot be used on a database project", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			file := writeFile(t, "process.yaml", processYaml)
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"project", "process", "import", "-p", projectID, "--file", file, "--git-ref", "refs/heads/main", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/"+projectID).RespondWith(project)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.EqualError(t, err, "project 'Fire Project' is not version controlled, so a git reference cannot be used")
+		}},
+	}
+
+	previousColor := output.IsColorEnabled
+	output.IsColorEnabled = false
+	defer func() { output.IsColorEnabled = previousColor }()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			fac := testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider)
+			rootCmd := cmdRoot.NewCmdRoot(fac, nil, askProvider)
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+			test.run(t, api, rootCmd, stdout, stderr)
+		})
+	}
+}
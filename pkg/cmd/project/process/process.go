@@ -0,0 +1,36 @@
+package process
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/spf13/cobra"
+
+	cmdExport "github.com/OctopusDeploy/cli/pkg/cmd/project/process/export"
+	cmdImport "github.com/OctopusDeploy/cli/pkg/cmd/project/process/import"
+	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/project/process/view"
+)
+
+func NewCmdProcess(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "process <command>",
+		Aliases: []string{"deployment-process"},
+		Short:   "Manage project deployment processes",
+		Long:    "Manage project deployment processes in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s project process view "Deploy Web App"
+			%[1]s project process export -p "Deploy Web App" --format yaml --file process.yaml
+			%[1]s project process import -p "Deploy Web App" --file process.yaml
+		`, constants.ExecutableName),
+		Annotations: map[string]string{
+			annotations.IsCore: "true",
+		},
+	}
+
+	cmd.AddCommand(cmdView.NewCmdView(f))
+	cmd.AddCommand(cmdExport.NewCmdExport(f))
+	cmd.AddCommand(cmdImport.NewCmdImport(f))
+
+	return cmd
+}
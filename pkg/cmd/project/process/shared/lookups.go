@@ -0,0 +1,81 @@
+package shared
+
+import (
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+)
+
+// Lookups resolves the environment and channel IDs a process refers to into
+// something a person can read. Entries that can't be resolved fall back to the ID.
+type Lookups struct {
+	EnvironmentNames map[string]string
+	EnvironmentSlugs map[string]string
+	ChannelNames     map[string]string
+}
+
+func NewLookups() *Lookups {
+	return &Lookups{
+		EnvironmentNames: map[string]string{},
+		EnvironmentSlugs: map[string]string{},
+		ChannelNames:     map[string]string{},
+	}
+}
+
+func (l *Lookups) EnvironmentName(id string) string {
+	if name, ok := l.EnvironmentNames[id]; ok {
+		return name
+	}
+	return id
+}
+
+func (l *Lookups) EnvironmentSlug(id string) string {
+	if slug, ok := l.EnvironmentSlugs[id]; ok && slug != "" {
+		return slug
+	}
+	return id
+}
+
+func (l *Lookups) ChannelName(id string) string {
+	if name, ok := l.ChannelNames[id]; ok {
+		return name
+	}
+	return id
+}
+
+func (l *Lookups) EnvironmentNamesFor(ids []string) []string {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		names = append(names, l.EnvironmentName(id))
+	}
+	return names
+}
+
+func (l *Lookups) ChannelNamesFor(ids []string) []string {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		names = append(names, l.ChannelName(id))
+	}
+	return names
+}
+
+// getLookups is best-effort: the process can still be shown with raw IDs if the
+// caller can't read environments or channels.
+func getLookups(dependencies *cmd.Dependencies, project *projects.Project) *Lookups {
+	lookups := NewLookups()
+
+	if allEnvironments, err := selectors.GetAllEnvironments(dependencies.Client); err == nil {
+		for _, e := range allEnvironments {
+			lookups.EnvironmentNames[e.GetID()] = e.Name
+			lookups.EnvironmentSlugs[e.GetID()] = e.Slug
+		}
+	}
+
+	if allChannels, err := dependencies.Client.Projects.GetChannels(project); err == nil {
+		for _, c := range allChannels {
+			lookups.ChannelNames[c.GetID()] = c.Name
+		}
+	}
+
+	return lookups
+}
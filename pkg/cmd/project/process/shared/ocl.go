@@ -0,0 +1,202 @@
+package shared
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/packages"
+)
+
+var nonSlugCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ToOcl renders a deployment process in the Octopus Configuration Language used by
+// version-controlled projects, so the process of a database project can be
+// reviewed in the same shape as one stored in git. Environments are written as
+// slugs as they are in a repository; sensitive property values are never
+// returned by the server and are left out.
+func ToOcl(process *deployments.DeploymentProcess, lookups *Lookups) string {
+	if lookups == nil {
+		lookups = NewLookups()
+	}
+
+	w := &oclWriter{}
+	for i, step := range process.Steps {
+		if i > 0 {
+			w.line("")
+		}
+		writeStep(w, step, lookups)
+	}
+	return w.String()
+}
+
+func writeStep(w *oclWriter, step *deployments.DeploymentStep, lookups *Lookups) {
+	w.open("step %s", quote(slugify(step.Name)))
+	w.attribute("name", quote(step.Name))
+	if step.Condition != "" && step.Condition != deployments.DeploymentStepConditionTypeSuccess {
+		w.attribute("condition", quote(string(step.Condition)))
+	}
+	if step.PackageRequirement != "" && step.PackageRequirement != "LetOctopusDecide" {
+		w.attribute("package_requirement", quote(string(step.PackageRequirement)))
+	}
+	if step.StartTrigger != "" && step.StartTrigger != deployments.DeploymentStepStartTriggerStartAfterPrevious {
+		w.attribute("start_trigger", quote(string(step.StartTrigger)))
+	}
+	w.properties(step.Properties)
+
+	for _, action := range step.Actions {
+		w.line("")
+		writeAction(w, action, lookups)
+	}
+	w.close()
+}
+
+func writeAction(w *oclWriter, action *deployments.DeploymentAction, lookups *Lookups) {
+	w.open("action %s", quote(slugify(action.Name)))
+	w.attribute("action_type", quote(action.ActionType))
+	if len(action.Channels) > 0 {
+		w.attribute("channels", list(lookups.ChannelNamesFor(action.Channels), slugify))
+	}
+	if len(action.Environments) > 0 {
+		w.attribute("environments", list(action.Environments, lookups.EnvironmentSlug))
+	}
+	if len(action.ExcludedEnvironments) > 0 {
+		w.attribute("excluded_environments", list(action.ExcludedEnvironments, lookups.EnvironmentSlug))
+	}
+	if action.IsDisabled {
+		w.attribute("is_disabled", "true")
+	}
+	if action.IsRequired {
+		w.attribute("is_required", "true")
+	}
+	if action.Notes != "" {
+		w.attribute("notes", str(action.Notes))
+	}
+	if len(action.TenantTags) > 0 {
+		w.attribute("tenant_tags", list(action.TenantTags, nil))
+	}
+	if action.WorkerPool != "" {
+		w.attribute("worker_pool", quote(action.WorkerPool))
+	}
+	if action.WorkerPoolVariable != "" {
+		w.attribute("worker_pool_variable", quote(action.WorkerPoolVariable))
+	}
+	w.properties(action.Properties)
+
+	for _, pkg := range action.Packages {
+		w.line("")
+		writePackage(w, pkg)
+	}
+	w.close()
+}
+
+func writePackage(w *oclWriter, pkg *packages.PackageReference) {
+	if pkg.Name == "" {
+		w.open("packages")
+	} else {
+		w.open("packages %s", quote(pkg.Name))
+	}
+	w.attribute("acquisition_location", quote(pkg.AcquisitionLocation))
+	w.attribute("feed", quote(pkg.FeedID))
+	w.attribute("package_id", quote(pkg.PackageID))
+
+	properties := map[string]core.PropertyValue{}
+	for k, v := range pkg.Properties {
+		properties[k] = core.NewPropertyValue(v, false)
+	}
+	w.properties(properties)
+	w.close()
+}
+
+type oclWriter struct {
+	builder strings.Builder
+	depth   int
+}
+
+func (w *oclWriter) String() string {
+	return w.builder.String()
+}
+
+func (w *oclWriter) line(format string, args ...any) {
+	text := fmt.Sprintf(format, args...)
+	if text != "" {
+		w.builder.WriteString(strings.Repeat("    ", w.depth))
+		w.builder.WriteString(text)
+	}
+	w.builder.WriteString("\n")
+}
+
+func (w *oclWriter) open(format string, args ...any) {
+	w.line(format+" {", args...)
+	w.depth++
+}
+
+func (w *oclWriter) close() {
+	w.depth--
+	w.line("}")
+}
+
+func (w *oclWriter) attribute(name string, value string) {
+	w.line("%s = %s", name, value)
+}
+
+func (w *oclWriter) properties(properties map[string]core.PropertyValue) {
+	keys := make([]string, 0, len(properties))
+	for k, v := range properties {
+		if !v.IsSensitive {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	sort.Strings(keys)
+
+	w.open("properties =")
+	for _, k := range keys {
+		value := properties[k].Value
+		if strings.Contains(value, "\n") {
+			// heredocs keep scripts readable, which is the point of exporting them
+			w.line("%s = <<-EOT", k)
+			w.depth++
+			for _, scriptLine := range strings.Split(strings.TrimRight(value, "\n"), "\n") {
+				w.line("%s", scriptLine)
+			}
+			w.depth--
+			w.line("EOT")
+			continue
+		}
+		w.attribute(k, quote(value))
+	}
+	w.close()
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// str quotes single-line values and uses a heredoc for multi-line ones.
+func str(s string) string {
+	if !strings.Contains(s, "\n") {
+		return quote(s)
+	}
+	return "<<-EOT\n" + s + "\nEOT"
+}
+
+func list(items []string, transform func(string) string) string {
+	quoted := make([]string, 0, len(items))
+	for _, item := range items {
+		if transform != nil {
+			item = transform(item)
+		}
+		quoted = append(quoted, quote(item))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func slugify(name string) string {
+	return strings.Trim(nonSlugCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
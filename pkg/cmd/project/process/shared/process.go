@@ -0,0 +1,204 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	sharedBranches "github.com/OctopusDeploy/cli/pkg/cmd/project/branch/shared"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatJson = "json"
+	FormatYaml = "yaml"
+	FormatOcl  = "ocl"
+
+	// PropertyTargetRoles is the step property holding the comma-separated target tags (roles) a step runs on.
+	PropertyTargetRoles = "Octopus.Action.TargetRoles"
+	// PropertyConditionExpression is the step property holding the expression for a variable run condition.
+	PropertyConditionExpression = "Octopus.Step.ConditionVariableExpression"
+
+	// DefaultCommitMessage is the message of the commit which saves the process of a version-controlled project.
+	DefaultCommitMessage = "Update the deployment process"
+)
+
+var ExportFormats = []string{FormatJson, FormatYaml, FormatOcl}
+var ImportFormats = []string{FormatJson, FormatYaml}
+
+type GetProcessCallback func(project *projects.Project, gitRef string) (*deployments.DeploymentProcess, error)
+type UpdateProcessCallback func(process *deployments.DeploymentProcess, commitMessage string) (*deployments.DeploymentProcess, error)
+
+type ProcessCallbacks struct {
+	GetProcessCallback    GetProcessCallback
+	UpdateProcessCallback UpdateProcessCallback
+	GetLookupsCallback    func(project *projects.Project) *Lookups
+	*sharedBranches.ProjectBranchCallbacks
+}
+
+func NewProcessCallbacks(dependencies *cmd.Dependencies) *ProcessCallbacks {
+	return &ProcessCallbacks{
+		GetProcessCallback: func(project *projects.Project, gitRef string) (*deployments.DeploymentProcess, error) {
+			return deployments.GetDeploymentProcessByGitRef(dependencies.Client, dependencies.Space.GetID(), project, gitRef)
+		},
+		UpdateProcessCallback: func(process *deployments.DeploymentProcess, commitMessage string) (*deployments.DeploymentProcess, error) {
+			if commitMessage == "" {
+				return deployments.UpdateDeploymentProcess(dependencies.Client, process)
+			}
+			return newclient.Update[deployments.DeploymentProcess](dependencies.Client, process.Links["Self"], process.SpaceID, process.ID,
+				&versionControlledProcess{DeploymentProcess: process, ChangeDescription: commitMessage})
+		},
+		GetLookupsCallback: func(project *projects.Project) *Lookups {
+			return getLookups(dependencies, project)
+		},
+		ProjectBranchCallbacks: sharedBranches.NewProjectBranchCallbacks(dependencies),
+	}
+}
+
+// versionControlledProcess is a deployment process with the message of the commit which saves it.
+type versionControlledProcess struct {
+	*deployments.DeploymentProcess
+	ChangeDescription string `json:"ChangeDescription,omitempty"`
+}
+
+// ResolveGitRef works out which git reference the process of a version-controlled
+// project should be read from or written to. An explicit --git-ref always wins; in
+// interactive mode the user picks a branch, and in automation mode the project's
+// default branch is used. Database projects have a single process, so asking for
+// a git reference on one is an error rather than something to silently ignore.
+func ResolveGitRef(dependencies *cmd.Dependencies, callbacks *ProcessCallbacks, project *projects.Project, gitRef string) (string, error) {
+	if !project.IsVersionControlled {
+		if gitRef != "" {
+			return "", fmt.Errorf("project '%s' is not version controlled, so a git reference cannot be used", project.GetName())
+		}
+		return "", nil
+	}
+
+	if gitRef != "" {
+		return gitRef, nil
+	}
+
+	defaultBranch := project.PersistenceSettings.(projects.GitPersistenceSettings).DefaultBranch()
+	if dependencies.NoPrompt {
+		return defaultBranch, nil
+	}
+
+	branches, err := callbacks.GetAllBranchesCallback(project.GetID())
+	if err != nil {
+		return "", err
+	}
+
+	// if the default branch is in the list, move it to the top
+	defaultBranchInList := util.SliceFilter(branches, func(b *projects.GitReference) bool { return b.Name == defaultBranch })
+	if len(defaultBranchInList) > 0 {
+		branches = util.SliceExcept(branches, func(b *projects.GitReference) bool { return b.Name == defaultBranch })
+		branches = append(defaultBranchInList, branches...)
+	}
+
+	selected, err := question.SelectMap(dependencies.Ask, "Select the branch to use for the deployment process", branches, func(b *projects.GitReference) string {
+		return b.Name
+	})
+	if err != nil {
+		return "", err
+	}
+	return selected.CanonicalName, nil
+}
+
+// FormatFromFileName infers a document format from a file extension, returning
+// an empty string when the extension is not recognised.
+func FormatFromFileName(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".json":
+		return FormatJson
+	case ".yaml", ".yml":
+		return FormatYaml
+	case ".ocl":
+		return FormatOcl
+	}
+	return ""
+}
+
+// Marshal renders a deployment process as a document in the given format. Links
+// are dropped as they only make sense against the server the process came from.
+func Marshal(process *deployments.DeploymentProcess, format string, lookups *Lookups) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case FormatJson:
+		document, err := toDocument(process)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(document, "", "  ")
+	case FormatYaml:
+		document, err := toDocument(process)
+		if err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(document)
+	case FormatOcl:
+		return []byte(ToOcl(process, lookups)), nil
+	}
+	return nil, fmt.Errorf("unsupported format '%s'. Valid values are %s", format, output.FormatAsList(ExportFormats))
+}
+
+// Unmarshal reads a deployment process from a document in the given format.
+func Unmarshal(data []byte, format string) (*deployments.DeploymentProcess, error) {
+	process := &deployments.DeploymentProcess{}
+	switch strings.ToLower(format) {
+	case FormatJson:
+		if err := json.Unmarshal(data, process); err != nil {
+			return nil, err
+		}
+	case FormatYaml:
+		var document any
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, err
+		}
+		// round-trip through JSON so the SDK's JSON handling of steps and property values applies
+		jsonData, err := json.Marshal(document)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(jsonData, process); err != nil {
+			return nil, err
+		}
+	case FormatOcl:
+		return nil, fmt.Errorf("importing OCL is not supported; export the process as %s instead", output.FormatAsList(ImportFormats))
+	default:
+		return nil, fmt.Errorf("unsupported format '%s'. Valid values are %s", format, output.FormatAsList(ImportFormats))
+	}
+	return process, nil
+}
+
+func toDocument(process *deployments.DeploymentProcess) (map[string]any, error) {
+	data, err := json.Marshal(process)
+	if err != nil {
+		return nil, err
+	}
+	var document map[string]any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	delete(document, "Links")
+	return document, nil
+}
+
+// GetTargetRoles returns the target tags (roles) a step runs on.
+func GetTargetRoles(step *deployments.DeploymentStep) []string {
+	roles := []string{}
+	if property, ok := step.Properties[PropertyTargetRoles]; ok {
+		for _, role := range strings.Split(property.Value, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles
+}
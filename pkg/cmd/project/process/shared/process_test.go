@@ -0,0 +1,109 @@
+package shared_test
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd/project/process/shared"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newProcess() *deployments.DeploymentProcess {
+	process := deployments.NewDeploymentProcess("Projects-1")
+	process.ID = "deploymentprocess-Projects-1"
+	process.Version = 3
+	process.Links = map[string]string{"Self": "/api/Spaces-1/deploymentprocesses/deploymentprocess-Projects-1"}
+
+	step := deployments.NewDeploymentStep("Run a Script")
+	step.Properties[shared.PropertyTargetRoles] = core.NewPropertyValue("web, api", false)
+
+	action := deployments.NewDeploymentAction("Run a Script", "Octopus.Script")
+	action.Environments = []string{"Environments-1"}
+	action.Channels = []string{"Channels-1"}
+	action.Properties["Octopus.Action.Script.ScriptBody"] = core.NewPropertyValue("echo one\necho two", false)
+	action.Properties["Octopus.Action.Script.Syntax"] = core.NewPropertyValue("Bash", false)
+	action.Properties["Secret"] = core.NewPropertyValue("shh", true)
+	step.Actions = append(step.Actions, action)
+
+	process.Steps = append(process.Steps, step)
+	return process
+}
+
+func TestGetTargetRoles(t *testing.T) {
+	process := newProcess()
+	assert.Equal(t, []string{"web", "api"}, shared.GetTargetRoles(process.Steps[0]))
+	assert.Equal(t, []string{}, shared.GetTargetRoles(deployments.NewDeploymentStep("no roles")))
+}
+
+func TestFormatFromFileName(t *testing.T) {
+	assert.Equal(t, shared.FormatJson, shared.FormatFromFileName("process.JSON"))
+	assert.Equal(t, shared.FormatYaml, shared.FormatFromFileName("process.yml"))
+	assert.Equal(t, shared.FormatYaml, shared.FormatFromFileName("dir/process.yaml"))
+	assert.Equal(t, shared.FormatOcl, shared.FormatFromFileName("deployment_process.ocl"))
+	assert.Equal(t, "", shared.FormatFromFileName("process.txt"))
+	assert.Equal(t, "", shared.FormatFromFileName(""))
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	for _, format := range shared.ImportFormats {
+		t.Run(format, func(t *testing.T) {
+			data, err := shared.Marshal(newProcess(), format, nil)
+			require.Nil(t, err)
+			assert.NotContains(t, string(data), "Links")
+
+			process, err := shared.Unmarshal(data, format)
+			require.Nil(t, err)
+			assert.Equal(t, int32(3), process.Version)
+			require.Len(t, process.Steps, 1)
+			assert.Equal(t, "Run a Script", process.Steps[0].Name)
+			assert.Equal(t, []string{"web", "api"}, shared.GetTargetRoles(process.Steps[0]))
+			require.Len(t, process.Steps[0].Actions, 1)
+			action := process.Steps[0].Actions[0]
+			assert.Equal(t, "echo one\necho two", action.Properties["Octopus.Action.Script.ScriptBody"].Value)
+			assert.True(t, action.Properties["Secret"].IsSensitive)
+			assert.Equal(t, []string{"Environments-1"}, action.Environments)
+		})
+	}
+}
+
+func TestUnmarshal_OclIsNotSupported(t *testing.T) {
+	_, err := shared.Unmarshal([]byte(`step "a" {}`), shared.FormatOcl)
+	assert.EqualError(t, err, "importing OCL is not supported; export the process as json, yaml instead")
+}
+
+func TestMarshal_UnsupportedFormat(t *testing.T) {
+	_, err := shared.Marshal(newProcess(), "xml", nil)
+	assert.EqualError(t, err, "unsupported format 'xml'. Valid values are json, yaml, ocl")
+}
+
+func TestToOcl(t *testing.T) {
+	lookups := shared.NewLookups()
+	lookups.EnvironmentNames["Environments-1"] = "Production"
+	lookups.EnvironmentSlugs["Environments-1"] = "production"
+	lookups.ChannelNames["Channels-1"] = "Hot Fix"
+
+	assert.Equal(t, heredoc.Doc(`
+		step "run-a-script" {
+		    name = "Run a Script"
+		    properties = {
+		        Octopus.Action.TargetRoles = "web, api"
+		    }
+
+		    action "run-a-script" {
+		        action_type = "Octopus.Script"
+		        channels = ["hot-fix"]
+		        environments = ["production"]
+		        properties = {
+		            Octopus.Action.Script.ScriptBody = <<-EOT
+		                echo one
+		                echo two
+		            EOT
+		            Octopus.Action.Script.Syntax = "Bash"
+		        }
+		    }
+		}
+		`), shared.ToOcl(newProcess(), lookups))
+}
@@ -0,0 +1,304 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	sharedProcess "github.com/OctopusDeploy/cli/pkg/cmd/project/process/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject = "project"
+	FlagGitRef  = "git-ref"
+)
+
+type ViewFlags struct {
+	Project *flag.Flag[string]
+	GitRef  *flag.Flag[string]
+}
+
+func NewViewFlags() *ViewFlags {
+	return &ViewFlags{
+		Project: flag.New[string](FlagProject, false),
+		GitRef:  flag.New[string](FlagGitRef, false),
+	}
+}
+
+type ViewOptions struct {
+	*ViewFlags
+	*cmd.Dependencies
+	*sharedProcess.ProcessCallbacks
+	Command *cobra.Command
+}
+
+func NewViewOptions(flags *ViewFlags, dependencies *cmd.Dependencies, command *cobra.Command) *ViewOptions {
+	return &ViewOptions{
+		ViewFlags:        flags,
+		Dependencies:     dependencies,
+		ProcessCallbacks: sharedProcess.NewProcessCallbacks(dependencies),
+		Command:          command,
+	}
+}
+
+func NewCmdView(f factory.Factory) *cobra.Command {
+	viewFlags := NewViewFlags()
+	cmd := &cobra.Command{
+		Use:   "view [<project>]",
+		Short: "View the deployment process of a project",
+		Long:  "View the steps and actions of a project's deployment process in Octopus Deploy, along with the roles, environments and channels they are conditional on",
+		Example: heredoc.Docf(`
+			%[1]s project process view "Deploy Web App"
+			%[1]s project process view -p "Deploy Web App" --git-ref refs/heads/main
+			%[1]s project process view -p "Deploy Web App" -f json
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if viewFlags.Project.Value == "" && len(args) > 0 {
+				viewFlags.Project.Value = args[0]
+			}
+
+			opts := NewViewOptions(viewFlags, cmd.NewDependencies(f, c), c)
+			return viewRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&viewFlags.Project.Value, viewFlags.Project.Name, "p", "", "Name or ID of the project")
+	flags.StringVarP(&viewFlags.GitRef.Value, viewFlags.GitRef.Name, "", "", "The GitRef for the Config-As-Code branch")
+
+	return cmd
+}
+
+type ProcessAsJson struct {
+	ProjectId   string       `json:"ProjectId"`
+	ProjectName string       `json:"ProjectName"`
+	GitRef      string       `json:"GitRef,omitempty"`
+	Version     int32        `json:"Version"`
+	Steps       []StepAsJson `json:"Steps"`
+}
+
+type StepAsJson struct {
+	Id                  string         `json:"Id"`
+	Name                string         `json:"Name"`
+	Condition           string         `json:"Condition"`
+	ConditionExpression string         `json:"ConditionExpression,omitempty"`
+	StartTrigger        string         `json:"StartTrigger"`
+	Roles               []string       `json:"Roles"`
+	Actions             []ActionAsJson `json:"Actions"`
+}
+
+type ActionAsJson struct {
+	Id                   string   `json:"Id"`
+	Name                 string   `json:"Name"`
+	ActionType           string   `json:"ActionType"`
+	IsDisabled           bool     `json:"IsDisabled"`
+	IsRequired           bool     `json:"IsRequired"`
+	Environments         []string `json:"Environments"`
+	ExcludedEnvironments []string `json:"ExcludedEnvironments"`
+	Channels             []string `json:"Channels"`
+	TenantTags           []string `json:"TenantTags"`
+}
+
+func viewRun(opts *ViewOptions) error {
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project whose deployment process you wish to view", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	gitRef, err := sharedProcess.ResolveGitRef(opts.Dependencies, opts.ProcessCallbacks, project, opts.GitRef.Value)
+	if err != nil {
+		return err
+	}
+
+	process, err := opts.GetProcessCallback(project, gitRef)
+	if err != nil {
+		return err
+	}
+
+	lookups := opts.GetLookupsCallback(project)
+
+	outputFormat, err := opts.Command.Flags().GetString(constants.FlagOutputFormat)
+	if err != nil { // should never happen, but fallback if it does
+		outputFormat = constants.OutputFormatTable
+	}
+
+	switch strings.ToLower(outputFormat) {
+	case constants.OutputFormatJson:
+		data, err := json.MarshalIndent(toJson(project, gitRef, process, lookups), "", "  ")
+		if err != nil {
+			return err
+		}
+		opts.Command.Println(string(data))
+	case constants.OutputFormatBasic:
+		opts.Command.Print(FormatTree(project, gitRef, process, lookups))
+	case constants.OutputFormatTable, "":
+		return printTable(opts, process, lookups)
+	default:
+		return usage.NewUsageError(
+			fmt.Sprintf("unsupported output format %s. Valid values are 'json', 'table', 'basic'. Defaults to table", outputFormat),
+			opts.Command)
+	}
+	return nil
+}
+
+func toJson(project *projects.Project, gitRef string, process *deployments.DeploymentProcess, lookups *sharedProcess.Lookups) ProcessAsJson {
+	result := ProcessAsJson{
+		ProjectId:   project.GetID(),
+		ProjectName: project.GetName(),
+		GitRef:      gitRef,
+		Version:     process.Version,
+		Steps:       []StepAsJson{},
+	}
+	for _, step := range process.Steps {
+		stepJson := StepAsJson{
+			Id:                  step.GetID(),
+			Name:                step.Name,
+			Condition:           string(step.Condition),
+			ConditionExpression: step.Properties[sharedProcess.PropertyConditionExpression].Value,
+			StartTrigger:        string(step.StartTrigger),
+			Roles:               sharedProcess.GetTargetRoles(step),
+			Actions:             []ActionAsJson{},
+		}
+		for _, action := range step.Actions {
+			stepJson.Actions = append(stepJson.Actions, ActionAsJson{
+				Id:                   action.GetID(),
+				Name:                 action.Name,
+				ActionType:           action.ActionType,
+				IsDisabled:           action.IsDisabled,
+				IsRequired:           action.IsRequired,
+				Environments:         lookups.EnvironmentNamesFor(action.Environments),
+				ExcludedEnvironments: lookups.EnvironmentNamesFor(action.ExcludedEnvironments),
+				Channels:             lookups.ChannelNamesFor(action.Channels),
+				TenantTags:           append([]string{}, action.TenantTags...),
+			})
+		}
+		result.Steps = append(result.Steps, stepJson)
+	}
+	return result
+}
+
+func printTable(opts *ViewOptions, process *deployments.DeploymentProcess, lookups *sharedProcess.Lookups) error {
+	t := output.NewTable(opts.Out)
+	t.AddRow(output.Bold("STEP"), output.Bold("TYPE"), output.Bold("ROLES"), output.Bold("ENVIRONMENTS"), output.Bold("CHANNELS"), output.Bold("CONDITION"))
+	for i, step := range process.Steps {
+		t.AddRow(output.Bold(fmt.Sprintf("%d. %s", i+1, step.Name)), "", output.FormatAsList(sharedProcess.GetTargetRoles(step)), "", "", formatStepCondition(step))
+		for j, action := range step.Actions {
+			name := fmt.Sprintf("   └─ %d.%d. %s", i+1, j+1, action.Name)
+			if action.IsDisabled {
+				name = output.Dim(name + " (disabled)")
+			}
+			t.AddRow(name, action.ActionType, "", formatEnvironments(action, lookups), output.FormatAsList(lookups.ChannelNamesFor(action.Channels)), formatActionFlags(action))
+		}
+	}
+	return t.Print()
+}
+
+// FormatTree renders the process as an indented tree of steps and their actions,
+// listing the conditions on each one beneath it.
+func FormatTree(project *projects.Project, gitRef string, process *deployments.DeploymentProcess, lookups *sharedProcess.Lookups) string {
+	var result strings.Builder
+
+	result.WriteString(fmt.Sprintf("%s %s\n", output.Bold(project.GetName()), output.Dimf("(version %d)", process.Version)))
+	if gitRef != "" {
+		result.WriteString(fmt.Sprintf("Git ref: %s\n", output.Cyan(gitRef)))
+	}
+	if len(process.Steps) == 0 {
+		result.WriteString(output.Dim("This deployment process has no steps") + "\n")
+		return result.String()
+	}
+
+	for i, step := range process.Steps {
+		lastStep := i == len(process.Steps)-1
+		stepBranch, stepIndent := "├── ", "│   "
+		if lastStep {
+			stepBranch, stepIndent = "└── ", "    "
+		}
+
+		result.WriteString(fmt.Sprintf("%s%s\n", stepBranch, output.Boldf("%d. %s", i+1, step.Name)))
+		if roles := sharedProcess.GetTargetRoles(step); len(roles) > 0 {
+			result.WriteString(fmt.Sprintf("%sRoles: %s\n", stepIndent, output.Cyan(output.FormatAsList(roles))))
+		}
+		result.WriteString(fmt.Sprintf("%sRun: %s\n", stepIndent, formatStepCondition(step)))
+
+		for j, action := range step.Actions {
+			actionBranch, actionIndent := "├── ", "│   "
+			if j == len(step.Actions)-1 {
+				actionBranch, actionIndent = "└── ", "    "
+			}
+
+			name := fmt.Sprintf("%d.%d. %s", i+1, j+1, action.Name)
+			if action.IsDisabled {
+				name = output.Dim(name + " (disabled)")
+			}
+			result.WriteString(fmt.Sprintf("%s%s%s %s\n", stepIndent, actionBranch, name, output.Dimf("[%s]", action.ActionType)))
+
+			if environments := formatEnvironments(action, lookups); environments != "" {
+				result.WriteString(fmt.Sprintf("%s%sEnvironments: %s\n", stepIndent, actionIndent, environments))
+			}
+			if len(action.Channels) > 0 {
+				result.WriteString(fmt.Sprintf("%s%sChannels: %s\n", stepIndent, actionIndent, output.FormatAsList(lookups.ChannelNamesFor(action.Channels))))
+			}
+			if len(action.TenantTags) > 0 {
+				result.WriteString(fmt.Sprintf("%s%sTenant tags: %s\n", stepIndent, actionIndent, output.FormatAsList(action.TenantTags)))
+			}
+			if action.IsRequired {
+				result.WriteString(fmt.Sprintf("%s%s%s\n", stepIndent, actionIndent, output.Yellow("Required")))
+			}
+		}
+	}
+
+	return result.String()
+}
+
+func formatStepCondition(step *deployments.DeploymentStep) string {
+	var condition string
+	switch step.Condition {
+	case deployments.DeploymentStepConditionTypeFailure:
+		condition = "only when a previous step failed"
+	case deployments.DeploymentStepConditionTypeAlways:
+		condition = "always"
+	case deployments.DeploymentStepConditionTypeVariable:
+		condition = fmt.Sprintf("when %s", step.Properties[sharedProcess.PropertyConditionExpression].Value)
+	default:
+		condition = "when previous steps succeed"
+	}
+
+	if step.StartTrigger == deployments.DeploymentStepStartTriggerStartWithPrevious {
+		condition += ", in parallel with the previous step"
+	}
+	return condition
+}
+
+func formatEnvironments(action *deployments.DeploymentAction, lookups *sharedProcess.Lookups) string {
+	if len(action.Environments) > 0 {
+		return output.FormatAsList(lookups.EnvironmentNamesFor(action.Environments))
+	}
+	if len(action.ExcludedEnvironments) > 0 {
+		return "all except " + output.FormatAsList(lookups.EnvironmentNamesFor(action.ExcludedEnvironments))
+	}
+	return ""
+}
+
+func formatActionFlags(action *deployments.DeploymentAction) string {
+	var parts []string
+	if action.IsRequired {
+		parts = append(parts, "required")
+	}
+	if len(action.TenantTags) > 0 {
+		parts = append(parts, "tenant tags: "+output.FormatAsList(action.TenantTags))
+	}
+	return strings.Join(parts, "; ")
+}
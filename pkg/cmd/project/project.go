@@ -12,7 +12,10 @@ import (
 	cmdDisconnect "github.com/OctopusDeploy/cli/pkg/cmd/project/disconnect"
 	cmdEnable "github.com/OctopusDeploy/cli/pkg/cmd/project/enable"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/project/list"
+	cmdProcess "github.com/OctopusDeploy/cli/pkg/cmd/project/process"
 	cmdTag "github.com/OctopusDeploy/cli/pkg/cmd/project/tag"
+	cmdTrigger "github.com/OctopusDeploy/cli/pkg/cmd/project/trigger"
+	cmdUpdate "github.com/OctopusDeploy/cli/pkg/cmd/project/update"
 	cmdVariables "github.com/OctopusDeploy/cli/pkg/cmd/project/variables"
 	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/project/view"
 	"github.com/OctopusDeploy/cli/pkg/constants"
@@ -49,6 +52,9 @@ func NewCmdProject(f factory.Factory) *cobra.Command {
 	cmd.AddCommand(cmdClone.NewCmdClone(f))
 	cmd.AddCommand(cmdBranch.NewCmdBranch(f))
 	cmd.AddCommand(cmdTag.NewCmdTag(f))
+	cmd.AddCommand(cmdProcess.NewCmdProcess(f))
+	cmd.AddCommand(cmdTrigger.NewCmdTrigger(f))
+	cmd.AddCommand(cmdUpdate.NewCmdUpdate(f))
 
 	return cmd
 }
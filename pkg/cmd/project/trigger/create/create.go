@@ -0,0 +1,735 @@
+package create
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	sharedChannel "github.com/OctopusDeploy/cli/pkg/cmd/channel/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/actions"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/filters"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/packages"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/triggers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject           = "project"
+	FlagName              = "name"
+	FlagDescription       = "description"
+	FlagType              = "type"
+	FlagAction            = "action"
+	FlagCron              = "cron"
+	FlagTime              = "time"
+	FlagDays              = "days"
+	FlagTimezone          = "timezone"
+	FlagEnvironment       = "environment"
+	FlagSourceEnvironment = "source-environment"
+	FlagRunbook           = "runbook"
+	FlagRole              = "role"
+	FlagEventGroup        = "event-group"
+	FlagEventCategory     = "event-category"
+	FlagChannel           = "channel"
+	FlagPackage           = "package"
+	FlagTenant            = "tenant"
+	FlagTenantTag         = "tenant-tag"
+	FlagRedeploy          = "redeploy"
+)
+
+const DefaultTimezone = "UTC"
+
+const DefaultEventGroup = "MachineAvailableForDeployment"
+
+// EventGroups are the groups of deployment target events a deployment target trigger
+// most commonly reacts to.
+var EventGroups = []string{
+	"MachineAvailableForDeployment",
+	"MachineUnavailableForDeployment",
+	"MachineCritical",
+	"MachineHealthChanged",
+	"Machine",
+}
+
+type CreateFlags struct {
+	Project           *flag.Flag[string]
+	Name              *flag.Flag[string]
+	Description       *flag.Flag[string]
+	Type              *flag.Flag[string]
+	Action            *flag.Flag[string]
+	Cron              *flag.Flag[string]
+	Time              *flag.Flag[string]
+	Days              *flag.Flag[[]string]
+	Timezone          *flag.Flag[string]
+	Environment       *flag.Flag[[]string]
+	SourceEnvironment *flag.Flag[[]string]
+	Runbook           *flag.Flag[string]
+	Role              *flag.Flag[[]string]
+	EventGroup        *flag.Flag[[]string]
+	EventCategory     *flag.Flag[[]string]
+	Channel           *flag.Flag[string]
+	Package           *flag.Flag[[]string]
+	Tenant            *flag.Flag[[]string]
+	TenantTag         *flag.Flag[[]string]
+	Redeploy          *flag.Flag[bool]
+}
+
+func NewCreateFlags() *CreateFlags {
+	return &CreateFlags{
+		Project:           flag.New[string](FlagProject, false),
+		Name:              flag.New[string](FlagName, false),
+		Description:       flag.New[string](FlagDescription, false),
+		Type:              flag.New[string](FlagType, false),
+		Action:            flag.New[string](FlagAction, false),
+		Cron:              flag.New[string](FlagCron, false),
+		Time:              flag.New[string](FlagTime, false),
+		Days:              flag.New[[]string](FlagDays, false),
+		Timezone:          flag.New[string](FlagTimezone, false),
+		Environment:       flag.New[[]string](FlagEnvironment, false),
+		SourceEnvironment: flag.New[[]string](FlagSourceEnvironment, false),
+		Runbook:           flag.New[string](FlagRunbook, false),
+		Role:              flag.New[[]string](FlagRole, false),
+		EventGroup:        flag.New[[]string](FlagEventGroup, false),
+		EventCategory:     flag.New[[]string](FlagEventCategory, false),
+		Channel:           flag.New[string](FlagChannel, false),
+		Package:           flag.New[[]string](FlagPackage, false),
+		Tenant:            flag.New[[]string](FlagTenant, false),
+		TenantTag:         flag.New[[]string](FlagTenantTag, false),
+		Redeploy:          flag.New[bool](FlagRedeploy, false),
+	}
+}
+
+type CreateOptions struct {
+	*CreateFlags
+	*cmd.Dependencies
+	GetAllEnvironmentsCallback selectors.GetAllEnvironmentsCallback
+}
+
+func NewCreateOptions(createFlags *CreateFlags, dependencies *cmd.Dependencies) *CreateOptions {
+	return &CreateOptions{
+		CreateFlags:  createFlags,
+		Dependencies: dependencies,
+		GetAllEnvironmentsCallback: func() ([]*environments.Environment, error) {
+			return selectors.GetAllEnvironments(dependencies.Client)
+		},
+	}
+}
+
+func NewCmdCreate(f factory.Factory) *cobra.Command {
+	createFlags := NewCreateFlags()
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a project trigger",
+		Long:  "Create a scheduled, deployment target or release creation trigger for a project in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s project trigger create
+			%[1]s project trigger create -p "Deploy Web App" -n "Nightly" --type cron --cron "0 0 2 * * *" --action deploy-latest --source-environment Test --environment Staging
+			%[1]s project trigger create -p "Deploy Web App" -n "Weekday cleanup" --type daily --time 18:30 --days Mon,Tue,Wed,Thu,Fri --timezone Australia/Brisbane --action run-runbook --runbook Cleanup --environment Production
+			%[1]s project trigger create -p "Deploy Web App" -n "New targets" --type deployment-target --environment Production --role web
+			%[1]s project trigger create -p "Deploy Web App" -n "New image" --type external-feed --package "Deploy container:nginx" --channel Default
+			%[1]s project trigger create -p "Deploy Web App" --type built-in-feed --package "Deploy package" --channel Default
+		`, constants.ExecutableName),
+		Aliases: []string{"new"},
+		RunE: func(c *cobra.Command, _ []string) error {
+			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c))
+
+			return createRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&createFlags.Project.Value, createFlags.Project.Name, "p", "", "Name or ID of the project to create the trigger for")
+	flags.StringVarP(&createFlags.Name.Value, createFlags.Name.Name, "n", "", "Name of the trigger")
+	flags.StringVarP(&createFlags.Description.Value, createFlags.Description.Name, "d", "", "Description of the trigger")
+	flags.StringVarP(&createFlags.Type.Value, createFlags.Type.Name, "t", "", fmt.Sprintf("The type of trigger, one of %s", output.FormatAsList(shared.CreatableTypes)))
+	flags.StringVar(&createFlags.Action.Value, createFlags.Action.Name, "", fmt.Sprintf("What a scheduled trigger does, one of %s", output.FormatAsList(shared.ScheduledActions)))
+	flags.StringVar(&createFlags.Cron.Value, createFlags.Cron.Name, "", "The cron expression of a cron trigger")
+	flags.StringVar(&createFlags.Time.Value, createFlags.Time.Name, "", "The time of day a daily trigger runs at, as HH:MM")
+	flags.StringSliceVar(&createFlags.Days.Value, createFlags.Days.Name, []string{}, "The days of the week a daily trigger runs on. Defaults to every day")
+	flags.StringVar(&createFlags.Timezone.Value, createFlags.Timezone.Name, "", fmt.Sprintf("The timezone of a scheduled trigger. Defaults to %s", DefaultTimezone))
+	flags.StringArrayVar(&createFlags.Environment.Value, createFlags.Environment.Name, []string{}, "The environment to deploy to or run the runbook in; for a deployment target trigger, an environment to watch")
+	flags.StringArrayVar(&createFlags.SourceEnvironment.Value, createFlags.SourceEnvironment.Name, []string{}, "The environment to promote the latest release from, for the deploy-latest action")
+	flags.StringVar(&createFlags.Runbook.Value, createFlags.Runbook.Name, "", "The runbook to run, for the run-runbook action")
+	flags.StringArrayVar(&createFlags.Role.Value, createFlags.Role.Name, []string{}, "A target role to watch, for a deployment target trigger")
+	flags.StringArrayVar(&createFlags.EventGroup.Value, createFlags.EventGroup.Name, []string{}, fmt.Sprintf("An event group to watch, for a deployment target trigger. Defaults to %s", DefaultEventGroup))
+	flags.StringArrayVar(&createFlags.EventCategory.Value, createFlags.EventCategory.Name, []string{}, "An event category to watch, for a deployment target trigger")
+	flags.StringVar(&createFlags.Channel.Value, createFlags.Channel.Name, "", "The channel releases are created in or deployed from")
+	flags.StringArrayVar(&createFlags.Package.Value, createFlags.Package.Name, []string{}, "A package to watch, as <step>[:<package-reference>], for a feed trigger")
+	flags.StringArrayVar(&createFlags.Tenant.Value, createFlags.Tenant.Name, []string{}, "A tenant to deploy to or run the runbook for")
+	flags.StringArrayVar(&createFlags.TenantTag.Value, createFlags.TenantTag.Name, []string{}, "A tenant tag to deploy to or run the runbook for, as <tag_set>/<tag_name>")
+	flags.BoolVar(&createFlags.Redeploy.Value, createFlags.Redeploy.Name, false, "Redeploy to targets that already have the current release")
+
+	return cmd
+}
+
+func createRun(opts *CreateOptions) error {
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project to create the trigger for", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+	opts.Project.Value = project.GetName()
+
+	if !opts.NoPrompt {
+		if err := PromptMissing(opts, project); err != nil {
+			return err
+		}
+	}
+
+	if err := validate(opts); err != nil {
+		return err
+	}
+
+	if opts.Type.Value == shared.TypeBuiltInFeed {
+		err = createBuiltInFeedTrigger(opts, project)
+	} else {
+		err = createProjectTrigger(opts, project)
+	}
+	if err != nil {
+		return err
+	}
+
+	link := output.Bluef("%s/app#/%s/projects/%s/triggers", opts.Host, project.SpaceID, project.Slug)
+	fmt.Fprintf(opts.Out, "View the triggers of this project on Octopus Deploy: %s\n", link)
+
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(),
+			opts.Project, opts.Name, opts.Description, opts.Type, opts.Action, opts.Cron, opts.Time, opts.Days, opts.Timezone,
+			opts.Environment, opts.SourceEnvironment, opts.Runbook, opts.Role, opts.EventGroup, opts.EventCategory,
+			opts.Channel, opts.Package, opts.Tenant, opts.TenantTag, opts.Redeploy)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+
+	return nil
+}
+
+func validate(opts *CreateOptions) error {
+	if !util.SliceContains(shared.CreatableTypes, opts.Type.Value) {
+		if opts.Type.Value == "" {
+			return fmt.Errorf("trigger type must be specified with --%s", FlagType)
+		}
+		return fmt.Errorf("unsupported trigger type '%s'. Valid values are %s", opts.Type.Value, output.FormatAsList(shared.CreatableTypes))
+	}
+	if opts.Type.Value != shared.TypeBuiltInFeed && opts.Name.Value == "" {
+		return fmt.Errorf("trigger name must be specified with --%s", FlagName)
+	}
+
+	switch opts.Type.Value {
+	case shared.TypeCron, shared.TypeDaily:
+		if opts.Type.Value == shared.TypeCron && opts.Cron.Value == "" {
+			return fmt.Errorf("a cron trigger requires --%s", FlagCron)
+		}
+		if opts.Type.Value == shared.TypeDaily && opts.Time.Value == "" {
+			return fmt.Errorf("a daily trigger requires --%s", FlagTime)
+		}
+		switch opts.Action.Value {
+		case shared.ActionDeployLatest:
+			if len(opts.SourceEnvironment.Value) == 0 {
+				return fmt.Errorf("the %s action requires --%s", shared.ActionDeployLatest, FlagSourceEnvironment)
+			}
+			if len(opts.Environment.Value) != 1 {
+				return fmt.Errorf("the %s action requires exactly one --%s to deploy to", shared.ActionDeployLatest, FlagEnvironment)
+			}
+		case shared.ActionDeployNew:
+			if len(opts.Environment.Value) != 1 {
+				return fmt.Errorf("the %s action requires exactly one --%s to deploy to", shared.ActionDeployNew, FlagEnvironment)
+			}
+		case shared.ActionRunRunbook:
+			if opts.Runbook.Value == "" {
+				return fmt.Errorf("the %s action requires --%s", shared.ActionRunRunbook, FlagRunbook)
+			}
+			if len(opts.Environment.Value) == 0 {
+				return fmt.Errorf("the %s action requires --%s", shared.ActionRunRunbook, FlagEnvironment)
+			}
+		case "":
+			return fmt.Errorf("a scheduled trigger requires --%s, one of %s", FlagAction, output.FormatAsList(shared.ScheduledActions))
+		default:
+			return fmt.Errorf("unsupported action '%s'. Valid values are %s", opts.Action.Value, output.FormatAsList(shared.ScheduledActions))
+		}
+	case shared.TypeExternalFeed:
+		if len(opts.Package.Value) == 0 {
+			return fmt.Errorf("an external feed trigger requires at least one --%s", FlagPackage)
+		}
+	case shared.TypeBuiltInFeed:
+		if len(opts.Package.Value) != 1 {
+			return fmt.Errorf("a built-in feed trigger requires exactly one --%s", FlagPackage)
+		}
+	}
+
+	for _, p := range opts.Package.Value {
+		if _, _, err := shared.ParsePackage(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createProjectTrigger(opts *CreateOptions, project *projects.Project) error {
+	filter, err := buildFilter(opts)
+	if err != nil {
+		return err
+	}
+	action, err := buildAction(opts, project)
+	if err != nil {
+		return err
+	}
+
+	trigger := triggers.NewProjectTrigger(opts.Name.Value, opts.Description.Value, false, project, action, filter)
+	createdTrigger, err := triggers.Add(opts.Client, trigger)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(opts.Out, "\nSuccessfully created trigger '%s' (%s) for project '%s'.\n", createdTrigger.Name, createdTrigger.GetID(), project.GetName())
+	return err
+}
+
+func createBuiltInFeedTrigger(opts *CreateOptions, project *projects.Project) error {
+	if project.AutoCreateRelease {
+		return fmt.Errorf("project '%s' already has a built-in package repository trigger", project.GetName())
+	}
+
+	step, packageReference, err := shared.ParsePackage(opts.Package.Value[0])
+	if err != nil {
+		return err
+	}
+	strategy := &projects.ReleaseCreationStrategy{
+		ReleaseCreationPackage: &packages.DeploymentActionPackage{
+			DeploymentAction: step,
+			PackageReference: packageReference,
+		},
+	}
+	if opts.Channel.Value != "" {
+		channel, err := sharedChannel.ResolveChannel(opts.Client, opts.Ask, opts.Out, false, "", project, opts.Channel.Value)
+		if err != nil {
+			return err
+		}
+		strategy.ChannelID = channel.GetID()
+	}
+
+	project.AutoCreateRelease = true
+	project.ReleaseCreationStrategy = strategy
+	if _, err := projects.Update(opts.Client, project); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(opts.Out, "\nSuccessfully created the built-in package repository trigger for project '%s'.\n", project.GetName())
+	return err
+}
+
+func buildFilter(opts *CreateOptions) (filters.ITriggerFilter, error) {
+	timezone := opts.Timezone.Value
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+
+	switch opts.Type.Value {
+	case shared.TypeCron:
+		return filters.NewCronScheduledTriggerFilter(opts.Cron.Value, timezone), nil
+	case shared.TypeDaily:
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("unknown timezone '%s'", timezone)
+		}
+		start, err := ParseTimeOfDay(opts.Time.Value, location)
+		if err != nil {
+			return nil, err
+		}
+		days, err := ParseWeekdays(opts.Days.Value)
+		if err != nil {
+			return nil, err
+		}
+		return filters.NewOnceDailyScheduledTriggerFilter(days, start), nil
+	case shared.TypeDeploymentTarget:
+		environmentIDs, err := resolveEnvironments(opts, opts.Environment.Value)
+		if err != nil {
+			return nil, err
+		}
+		eventGroups := opts.EventGroup.Value
+		if len(eventGroups) == 0 && len(opts.EventCategory.Value) == 0 {
+			eventGroups = []string{DefaultEventGroup}
+		}
+		return filters.NewDeploymentTargetFilter(environmentIDs, opts.EventCategory.Value, eventGroups, opts.Role.Value), nil
+	case shared.TypeExternalFeed:
+		feedPackages := make([]packages.DeploymentActionSlugPackage, 0, len(opts.Package.Value))
+		for _, p := range opts.Package.Value {
+			step, packageReference, err := shared.ParsePackage(p)
+			if err != nil {
+				return nil, err
+			}
+			feedPackages = append(feedPackages, packages.DeploymentActionSlugPackage{
+				DeploymentActionSlug: step,
+				PackageReference:     packageReference,
+			})
+		}
+		return filters.NewFeedTriggerFilter(feedPackages), nil
+	}
+	return nil, fmt.Errorf("unsupported trigger type '%s'", opts.Type.Value)
+}
+
+func buildAction(opts *CreateOptions, project *projects.Project) (actions.ITriggerAction, error) {
+	channelID := ""
+	if opts.Channel.Value != "" {
+		channel, err := sharedChannel.ResolveChannel(opts.Client, opts.Ask, opts.Out, false, "", project, opts.Channel.Value)
+		if err != nil {
+			return nil, err
+		}
+		channelID = channel.GetID()
+	}
+
+	switch opts.Type.Value {
+	case shared.TypeDeploymentTarget:
+		return actions.NewAutoDeployAction(opts.Redeploy.Value), nil
+	case shared.TypeExternalFeed:
+		return actions.NewCreateReleaseAction(channelID), nil
+	}
+
+	environmentIDs, err := resolveEnvironments(opts, opts.Environment.Value)
+	if err != nil {
+		return nil, err
+	}
+	tenantIDs, err := resolveTenants(opts, opts.Tenant.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch opts.Action.Value {
+	case shared.ActionDeployLatest:
+		sourceEnvironmentIDs, err := resolveEnvironments(opts, opts.SourceEnvironment.Value)
+		if err != nil {
+			return nil, err
+		}
+		action := actions.NewDeployLatestReleaseAction(environmentIDs[0], opts.Redeploy.Value, sourceEnvironmentIDs, "")
+		action.Channel = channelID
+		action.Tenants = tenantIDs
+		action.TenantTags = opts.TenantTag.Value
+		return action, nil
+	case shared.ActionDeployNew:
+		var gitRef *actions.VersionControlReference
+		if project.IsVersionControlled {
+			if settings, ok := project.PersistenceSettings.(projects.GitPersistenceSettings); ok {
+				gitRef = &actions.VersionControlReference{GitRef: settings.DefaultBranch()}
+			}
+		}
+		action := actions.NewDeployNewReleaseAction(environmentIDs[0], "", gitRef)
+		action.Channel = channelID
+		action.Tenants = tenantIDs
+		action.TenantTags = opts.TenantTag.Value
+		return action, nil
+	case shared.ActionRunRunbook:
+		runbook, err := selectors.FindRunbook(opts.Client, project, opts.Runbook.Value)
+		if err != nil {
+			return nil, err
+		}
+		action := actions.NewRunRunbookAction()
+		action.Runbook = runbook.GetID()
+		action.Environments = environmentIDs
+		action.Tenants = tenantIDs
+		action.TenantTags = opts.TenantTag.Value
+		return action, nil
+	}
+	return nil, fmt.Errorf("unsupported action '%s'", opts.Action.Value)
+}
+
+func resolveEnvironments(opts *CreateOptions, identifiers []string) ([]string, error) {
+	if len(identifiers) == 0 {
+		return []string{}, nil
+	}
+
+	allEnvs, err := opts.GetAllEnvironmentsCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		var found *environments.Environment
+		for _, e := range allEnvs {
+			if strings.EqualFold(e.GetID(), identifier) || strings.EqualFold(e.Name, identifier) {
+				found = e
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("no environment found with name or ID of '%s'", identifier)
+		}
+		ids = append(ids, found.GetID())
+	}
+	return ids, nil
+}
+
+func resolveTenants(opts *CreateOptions, identifiers []string) ([]string, error) {
+	ids := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		tenant, err := opts.Client.Tenants.GetByIdentifier(identifier)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, tenant.GetID())
+	}
+	return ids, nil
+}
+
+// ParseTimeOfDay reads a HH:MM time as today's date at that time in location.
+func ParseTimeOfDay(value string, location *time.Location) (time.Time, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("time '%s' must be given as HH:MM", value)
+	}
+	now := time.Now().In(location)
+	return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, location), nil
+}
+
+// ParseWeekdays reads day names, or their three letter abbreviations, in any case. No
+// days means every day.
+func ParseWeekdays(values []string) ([]filters.Weekday, error) {
+	if len(values) == 0 {
+		return filters.WeekdayValues(), nil
+	}
+
+	days := make([]filters.Weekday, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		var found *filters.Weekday
+		for _, d := range filters.WeekdayValues() {
+			if strings.EqualFold(d.String(), value) || (len(value) == 3 && strings.EqualFold(d.String()[:3], value)) {
+				found = &d
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("'%s' is not a day of the week", value)
+		}
+		days = append(days, *found)
+	}
+	return days, nil
+}
+
+func PromptMissing(opts *CreateOptions, project *projects.Project) error {
+	if opts.Type.Value == "" {
+		selectedType, err := selectors.SelectOptions(opts.Ask, "Select the type of trigger", func() []*selectors.SelectOption[string] {
+			return []*selectors.SelectOption[string]{
+				{Value: shared.TypeCron, Display: "Scheduled, using a cron expression"},
+				{Value: shared.TypeDaily, Display: "Scheduled, once a day"},
+				{Value: shared.TypeDeploymentTarget, Display: "When deployment targets change"},
+				{Value: shared.TypeExternalFeed, Display: "When a package is pushed to an external feed"},
+				{Value: shared.TypeBuiltInFeed, Display: "When a package is pushed to the built-in package repository"},
+			}
+		})
+		if err != nil {
+			return err
+		}
+		opts.Type.Value = selectedType.Value
+	}
+
+	if opts.Type.Value != shared.TypeBuiltInFeed {
+		if err := question.AskName(opts.Ask, "", "trigger", &opts.Name.Value); err != nil {
+			return err
+		}
+		if err := question.AskDescription(opts.Ask, "", "trigger", &opts.Description.Value); err != nil {
+			return err
+		}
+	}
+
+	switch opts.Type.Value {
+	case shared.TypeCron, shared.TypeDaily:
+		return promptScheduled(opts, project)
+	case shared.TypeDeploymentTarget:
+		return promptDeploymentTarget(opts)
+	case shared.TypeExternalFeed, shared.TypeBuiltInFeed:
+		return promptFeed(opts, project)
+	}
+	return nil
+}
+
+func promptScheduled(opts *CreateOptions, project *projects.Project) error {
+	if opts.Type.Value == shared.TypeCron && opts.Cron.Value == "" {
+		if err := opts.Ask(&survey.Input{
+			Message: "Cron expression",
+			Help:    "Six fields: seconds, minutes, hours, day of month, month and day of week. For example, '0 0 9 * * Mon-Fri' runs at 9am on weekdays.",
+		}, &opts.Cron.Value, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+	}
+
+	if opts.Type.Value == shared.TypeDaily {
+		if opts.Time.Value == "" {
+			if err := opts.Ask(&survey.Input{
+				Message: "Time of day (HH:MM)",
+				Default: "09:00",
+			}, &opts.Time.Value, survey.WithValidator(func(ans interface{}) error {
+				_, err := ParseTimeOfDay(ans.(string), time.UTC)
+				return err
+			})); err != nil {
+				return err
+			}
+		}
+		if len(opts.Days.Value) == 0 {
+			allDays := filters.WeekdayValues()
+			selectedDays, err := question.MultiSelectMap(opts.Ask, "Days of the week", allDays, func(d filters.Weekday) string {
+				return d.String()
+			}, true)
+			if err != nil {
+				return err
+			}
+			for _, d := range selectedDays {
+				opts.Days.Value = append(opts.Days.Value, d.String())
+			}
+		}
+	}
+
+	if opts.Timezone.Value == "" {
+		if err := opts.Ask(&survey.Input{
+			Message: "Timezone",
+			Default: DefaultTimezone,
+		}, &opts.Timezone.Value); err != nil {
+			return err
+		}
+	}
+
+	if opts.Action.Value == "" {
+		selectedAction, err := selectors.SelectOptions(opts.Ask, "Select what the trigger does", func() []*selectors.SelectOption[string] {
+			return []*selectors.SelectOption[string]{
+				{Value: shared.ActionDeployLatest, Display: "Promote the latest release from one environment to another"},
+				{Value: shared.ActionDeployNew, Display: "Create a new release and deploy it"},
+				{Value: shared.ActionRunRunbook, Display: "Run a runbook"},
+			}
+		})
+		if err != nil {
+			return err
+		}
+		opts.Action.Value = selectedAction.Value
+	}
+
+	switch opts.Action.Value {
+	case shared.ActionDeployLatest:
+		if len(opts.SourceEnvironment.Value) == 0 {
+			sourceEnvs, err := selectors.EnvironmentsMultiSelect(opts.Ask, opts.GetAllEnvironmentsCallback, "Select the environments to promote the latest release from", true)
+			if err != nil {
+				return err
+			}
+			opts.SourceEnvironment.Value = util.SliceTransform(sourceEnvs, func(e *environments.Environment) string { return e.Name })
+		}
+		if len(opts.Environment.Value) == 0 {
+			env, err := selectors.EnvironmentSelect(opts.Ask, opts.GetAllEnvironmentsCallback, "Select the environment to deploy to")
+			if err != nil {
+				return err
+			}
+			opts.Environment.Value = []string{env.Name}
+		}
+	case shared.ActionDeployNew:
+		if len(opts.Environment.Value) == 0 {
+			env, err := selectors.EnvironmentSelect(opts.Ask, opts.GetAllEnvironmentsCallback, "Select the environment to deploy to")
+			if err != nil {
+				return err
+			}
+			opts.Environment.Value = []string{env.Name}
+		}
+	case shared.ActionRunRunbook:
+		if opts.Runbook.Value == "" {
+			runbook, err := selectors.Runbook("Select the runbook to run", opts.Client, opts.Ask, project.GetID())
+			if err != nil {
+				return err
+			}
+			opts.Runbook.Value = runbook.Name
+		}
+		if len(opts.Environment.Value) == 0 {
+			envs, err := selectors.EnvironmentsMultiSelect(opts.Ask, opts.GetAllEnvironmentsCallback, "Select the environments to run the runbook in", true)
+			if err != nil {
+				return err
+			}
+			opts.Environment.Value = util.SliceTransform(envs, func(e *environments.Environment) string { return e.Name })
+		}
+	}
+
+	if opts.Action.Value != shared.ActionRunRunbook && opts.Channel.Value == "" {
+		channel, err := selectors.Channel(opts.Client, opts.Ask, opts.Out, "Select the channel to deploy releases from", project)
+		if err != nil {
+			return err
+		}
+		opts.Channel.Value = channel.Name
+	}
+	return nil
+}
+
+func promptDeploymentTarget(opts *CreateOptions) error {
+	if len(opts.EventGroup.Value) == 0 && len(opts.EventCategory.Value) == 0 {
+		eventGroups, err := question.MultiSelectMap(opts.Ask, "Select the deployment target events to react to", EventGroups, func(g string) string {
+			return g
+		}, true)
+		if err != nil {
+			return err
+		}
+		opts.EventGroup.Value = eventGroups
+	}
+
+	if len(opts.Environment.Value) == 0 {
+		envs, err := selectors.EnvironmentsMultiSelect(opts.Ask, opts.GetAllEnvironmentsCallback, "Select the environments to watch, or none for all environments", false)
+		if err != nil {
+			return err
+		}
+		opts.Environment.Value = util.SliceTransform(envs, func(e *environments.Environment) string { return e.Name })
+	}
+
+	if len(opts.Role.Value) == 0 {
+		var roles string
+		if err := opts.Ask(&survey.Input{
+			Message: "Target roles to watch, comma separated (leave blank for all roles)",
+		}, &roles); err != nil {
+			return err
+		}
+		for _, role := range strings.Split(roles, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				opts.Role.Value = append(opts.Role.Value, role)
+			}
+		}
+	}
+
+	if !opts.Redeploy.Value {
+		if err := opts.Ask(&survey.Confirm{
+			Message: "Redeploy to targets that already have the current release?",
+			Default: false,
+		}, &opts.Redeploy.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func promptFeed(opts *CreateOptions, project *projects.Project) error {
+	if len(opts.Package.Value) == 0 {
+		var value string
+		if err := opts.Ask(&survey.Input{
+			Message: "Package to watch, as <step>[:<package-reference>]",
+			Help:    "The step name or slug, followed by the name of the package reference when the step has more than one package.",
+		}, &value, survey.WithValidator(func(ans interface{}) error {
+			_, _, err := shared.ParsePackage(ans.(string))
+			return err
+		})); err != nil {
+			return err
+		}
+		opts.Package.Value = []string{value}
+	}
+
+	if opts.Channel.Value == "" {
+		channel, err := selectors.Channel(opts.Client, opts.Ask, opts.Out, "Select the channel to create releases in", project)
+		if err != nil {
+			return err
+		}
+		opts.Channel.Value = channel.Name
+	}
+	return nil
+}
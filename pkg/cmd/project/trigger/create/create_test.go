@@ -0,0 +1,131 @@
+package create_test
+
+import (
+	"bytes"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func TestTriggerCreate(t *testing.T) {
+	const spaceID = "Spaces-1"
+	const projectID = "Projects-22"
+
+	space1 := fixtures.NewSpace(spaceID, "Default Space")
+	staging := fixtures.NewEnvironment(spaceID, "Environments-2", "Staging")
+
+	newProject := func() *projects.Project {
+		return fixtures.NewProject(spaceID, projectID, "Fire Project", "Lifecycles-1", "ProjectGroups-1", "deploymentprocess-"+projectID)
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer)
+	}{
+		{"creates a cron trigger that deploys a new release", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"project", "trigger", "create", "-p", projectID, "-n", "Nightly", "--type", "cron", "--cron", "0 0 2 * * *",
+					"--action", "deploy-new", "--environment", "staging", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/"+projectID).RespondWith(newProject())
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments").RespondWith(resources.Resources[*environments.Environment]{
+				Items: []*environments.Environment{staging},
+			})
+
+			req := api.ExpectRequest(t, "POST", "/api/Spaces-1/projecttriggers/")
+			body, err := testutil.ReadJson[map[string]any](req.Request.Body)
+			require.Nil(t, err)
+			assert.Equal(t, "Nightly", body["Name"])
+			assert.Equal(t, projectID, body["ProjectId"])
+			assert.Equal(t, map[string]any{"FilterType": "CronExpressionSchedule", "CronExpression": "0 0 2 * * *", "Timezone": "UTC"}, withoutLinks(body["Filter"]))
+			assert.Equal(t, "DeployNewRelease", body["Action"].(map[string]any)["ActionType"])
+			assert.Equal(t, "Environments-2", body["Action"].(map[string]any)["EnvironmentId"])
+
+			body["Id"] = "ProjectTriggers-1"
+			req.RespondWith(body)
+
+			_, err = testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+			assert.Contains(t, stdOut.String(), "Successfully created trigger 'Nightly' (ProjectTriggers-1) for project 'Fire Project'.")
+		}},
+
+		{"creates the built-in feed trigger on the project", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"project", "trigger", "create", "-p", projectID, "--type", "built-in-feed", "--package", "Deploy web:site", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/"+projectID).RespondWith(newProject())
+
+			req := api.ExpectRequest(t, "PUT", "/api/Spaces-1/projects/"+projectID)
+			body, err := testutil.ReadJson[projects.Project](req.Request.Body)
+			require.Nil(t, err)
+			assert.True(t, body.AutoCreateRelease)
+			require.NotNil(t, body.ReleaseCreationStrategy)
+			assert.Equal(t, "Deploy web", body.ReleaseCreationStrategy.ReleaseCreationPackage.DeploymentAction)
+			assert.Equal(t, "site", body.ReleaseCreationStrategy.ReleaseCreationPackage.PackageReference)
+			req.RespondWith(body)
+
+			_, err = testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+			assert.Contains(t, stdOut.String(), "Successfully created the built-in package repository trigger for project 'Fire Project'.")
+		}},
+
+		{"daily trigger requires a time", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"project", "trigger", "create", "-p", projectID, "-n", "Daily", "--type", "daily", "--action", "deploy-new", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/"+projectID).RespondWith(newProject())
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.EqualError(t, err, "a daily trigger requires --time")
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			fac := testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider)
+			rootCmd := cmdRoot.NewCmdRoot(fac, nil, askProvider)
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+			test.run(t, api, rootCmd, stdout, stderr)
+		})
+	}
+}
+
+func withoutLinks(value any) map[string]any {
+	result := map[string]any{}
+	for k, v := range value.(map[string]any) {
+		if k != "Links" && k != "Id" {
+			result[k] = v
+		}
+	}
+	return result
+}
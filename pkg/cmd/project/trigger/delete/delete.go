@@ -0,0 +1,102 @@
+package delete
+
+import (
+	"errors"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject = "project"
+)
+
+type DeleteFlags struct {
+	Project *flag.Flag[string]
+	*question.ConfirmFlags
+}
+
+func NewDeleteFlags() *DeleteFlags {
+	return &DeleteFlags{
+		Project:      flag.New[string](FlagProject, false),
+		ConfirmFlags: question.NewConfirmFlags(),
+	}
+}
+
+type DeleteOptions struct {
+	*DeleteFlags
+	*cmd.Dependencies
+	IdOrName string
+}
+
+func NewCmdDelete(f factory.Factory) *cobra.Command {
+	deleteFlags := NewDeleteFlags()
+	command := &cobra.Command{
+		Use:   "delete [{<name> | <id>}]",
+		Short: "Delete a project trigger",
+		Long:  "Delete a trigger of a project in Octopus Deploy. Deleting the built-in package repository trigger turns off automatic release creation for the project",
+		Example: heredoc.Docf(`
+			%[1]s project trigger delete "Nightly" -p "Deploy Web App"
+			%[1]s project trigger rm ProjectTriggers-101 -p "Deploy Web App" -y
+		`, constants.ExecutableName),
+		Aliases: []string{"del", "rm", "remove"},
+		Args:    usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := &DeleteOptions{
+				DeleteFlags:  deleteFlags,
+				Dependencies: cmd.NewDependencies(f, c),
+			}
+			if len(args) > 0 {
+				opts.IdOrName = args[0]
+			}
+			return deleteRun(opts)
+		},
+	}
+
+	flags := command.Flags()
+	flags.StringVarP(&deleteFlags.Project.Value, deleteFlags.Project.Name, "p", "", "Name or ID of the project the trigger belongs to")
+	question.RegisterConfirmDeletionFlag(command, &deleteFlags.Confirm.Value, "trigger")
+
+	return command
+}
+
+func deleteRun(opts *DeleteOptions) error {
+	// in automation mode we validate the flags up front, before making any API calls
+	if opts.NoPrompt {
+		if opts.Project.Value == "" {
+			return errors.New("project must be specified")
+		}
+		if opts.IdOrName == "" {
+			return errors.New("trigger name or ID must be specified")
+		}
+	}
+
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project containing the trigger you wish to delete", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	itemToDelete, err := shared.ResolveTrigger(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the trigger you wish to delete:", project, opts.IdOrName)
+	if err != nil {
+		return err
+	}
+
+	doDelete := func() error {
+		return shared.Delete(opts.Client, project, itemToDelete)
+	}
+
+	if opts.Confirm.Value {
+		return doDelete()
+	}
+	return question.DeleteWithConfirmation(opts.Ask, "trigger", itemToDelete.Name, itemToDelete.ID, doDelete)
+}
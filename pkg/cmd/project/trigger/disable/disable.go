@@ -0,0 +1,85 @@
+package disable
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject = "project"
+)
+
+type DisableFlags struct {
+	Project *flag.Flag[string]
+}
+
+func NewDisableFlags() *DisableFlags {
+	return &DisableFlags{
+		Project: flag.New[string](FlagProject, false),
+	}
+}
+
+type DisableOptions struct {
+	*DisableFlags
+	*cmd.Dependencies
+	IdOrName string
+}
+
+func NewCmdDisable(f factory.Factory) *cobra.Command {
+	disableFlags := NewDisableFlags()
+	cmd := &cobra.Command{
+		Use:   "disable [{<name> | <id>}]",
+		Short: "Disable a project trigger",
+		Long:  "Disable a trigger of a project in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s project trigger disable "Nightly" -p "Deploy Web App"
+			%[1]s project trigger disable %[2]s -p "Deploy Web App"
+		`, constants.ExecutableName, shared.BuiltInFeedTriggerID),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := &DisableOptions{
+				DisableFlags: disableFlags,
+				Dependencies: cmd.NewDependencies(f, c),
+			}
+			if len(args) > 0 {
+				opts.IdOrName = args[0]
+			}
+			return disableRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&disableFlags.Project.Value, disableFlags.Project.Name, "p", "", "Name or ID of the project the trigger belongs to")
+
+	return cmd
+}
+
+func disableRun(opts *DisableOptions) error {
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project containing the trigger you wish to disable", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	trigger, err := shared.ResolveTrigger(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the trigger you wish to disable:", project, opts.IdOrName)
+	if err != nil {
+		return err
+	}
+
+	if err := shared.SetDisabled(opts.Client, project, trigger, true); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(opts.Out, "Successfully disabled trigger '%s' of project '%s'\n", trigger.Name, project.GetName())
+	return err
+}
@@ -0,0 +1,85 @@
+package enable
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject = "project"
+)
+
+type EnableFlags struct {
+	Project *flag.Flag[string]
+}
+
+func NewEnableFlags() *EnableFlags {
+	return &EnableFlags{
+		Project: flag.New[string](FlagProject, false),
+	}
+}
+
+type EnableOptions struct {
+	*EnableFlags
+	*cmd.Dependencies
+	IdOrName string
+}
+
+func NewCmdEnable(f factory.Factory) *cobra.Command {
+	enableFlags := NewEnableFlags()
+	cmd := &cobra.Command{
+		Use:   "enable [{<name> | <id>}]",
+		Short: "Enable a project trigger",
+		Long:  "Enable a trigger of a project in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s project trigger enable "Nightly" -p "Deploy Web App"
+			%[1]s project trigger enable %[2]s -p "Deploy Web App"
+		`, constants.ExecutableName, shared.BuiltInFeedTriggerID),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := &EnableOptions{
+				EnableFlags:  enableFlags,
+				Dependencies: cmd.NewDependencies(f, c),
+			}
+			if len(args) > 0 {
+				opts.IdOrName = args[0]
+			}
+			return enableRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&enableFlags.Project.Value, enableFlags.Project.Name, "p", "", "Name or ID of the project the trigger belongs to")
+
+	return cmd
+}
+
+func enableRun(opts *EnableOptions) error {
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project containing the trigger you wish to enable", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	trigger, err := shared.ResolveTrigger(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the trigger you wish to enable:", project, opts.IdOrName)
+	if err != nil {
+		return err
+	}
+
+	if err := shared.SetDisabled(opts.Client, project, trigger, false); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(opts.Out, "Successfully enabled trigger '%s' of project '%s'\n", trigger.Name, project.GetName())
+	return err
+}
@@ -0,0 +1,106 @@
+package list
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject = "project"
+)
+
+type ListFlags struct {
+	Project *flag.Flag[string]
+}
+
+func NewListFlags() *ListFlags {
+	return &ListFlags{
+		Project: flag.New[string](FlagProject, false),
+	}
+}
+
+type ListOptions struct {
+	*ListFlags
+	*cmd.Dependencies
+	Command *cobra.Command
+}
+
+func NewCmdList(f factory.Factory) *cobra.Command {
+	listFlags := NewListFlags()
+	cmd := &cobra.Command{
+		Use:   "list [<project>]",
+		Short: "List project triggers",
+		Long:  "List the triggers of a project in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s project trigger list "Deploy Web App"
+			%[1]s project trigger ls -p "Deploy Web App" -f json
+		`, constants.ExecutableName),
+		Aliases: []string{"ls"},
+		Args:    usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if listFlags.Project.Value == "" && len(args) > 0 {
+				listFlags.Project.Value = args[0]
+			}
+
+			opts := &ListOptions{
+				ListFlags:    listFlags,
+				Dependencies: cmd.NewDependencies(f, c),
+				Command:      c,
+			}
+			return listRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&listFlags.Project.Value, listFlags.Project.Name, "p", "", "Name or ID of the project to list triggers for")
+
+	return cmd
+}
+
+func listRun(opts *ListOptions) error {
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project to list triggers for", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	allTriggers, err := shared.GetTriggers(opts.Client, project)
+	if err != nil {
+		return err
+	}
+
+	lookups := shared.GetLookups(opts.Client, project)
+
+	return output.PrintArray(allTriggers, opts.Command, output.Mappers[*shared.Trigger]{
+		Json: func(t *shared.Trigger) any {
+			return shared.ToJson(t, project, lookups)
+		},
+		Table: output.TableDefinition[*shared.Trigger]{
+			Header: []string{"NAME", "TYPE", "ACTION", "WHEN", "ENABLED"},
+			Row: func(t *shared.Trigger) []string {
+				enabled := output.Green("yes")
+				if t.IsDisabled {
+					enabled = output.Dim("no")
+				}
+				return []string{
+					output.Bold(t.Name),
+					t.Type,
+					shared.DescribeAction(t, project, lookups),
+					shared.DescribeFilter(t, project, lookups),
+					enabled,
+				}
+			},
+		},
+		Basic: func(t *shared.Trigger) string {
+			return t.Name
+		},
+	})
+}
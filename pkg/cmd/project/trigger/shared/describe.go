@@ -0,0 +1,212 @@
+package shared
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/actions"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/filters"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+)
+
+// Lookups turns the IDs a trigger refers to into names for display. Anything that
+// can't be found is shown by its ID.
+type Lookups struct {
+	Environments map[string]string
+	Channels     map[string]string
+	Runbooks     map[string]string
+	Tenants      map[string]string
+}
+
+func NewLookups() *Lookups {
+	return &Lookups{
+		Environments: map[string]string{},
+		Channels:     map[string]string{},
+		Runbooks:     map[string]string{},
+		Tenants:      map[string]string{},
+	}
+}
+
+// GetLookups loads the names a project's triggers may refer to. It is best-effort:
+// a trigger can still be shown without access to, say, tenants.
+func GetLookups(octopus *client.Client, project *projects.Project) *Lookups {
+	lookups := NewLookups()
+	if allEnvs, err := selectors.GetAllEnvironments(octopus); err == nil {
+		for _, e := range allEnvs {
+			lookups.Environments[e.GetID()] = e.Name
+		}
+	}
+	if allChannels, err := octopus.Projects.GetChannels(project); err == nil {
+		for _, c := range allChannels {
+			lookups.Channels[c.GetID()] = c.Name
+		}
+	}
+	if allRunbooks, err := runbooks.List(octopus, octopus.GetSpaceID(), project.GetID(), "", math.MaxInt32); err == nil {
+		for _, r := range allRunbooks.Items {
+			lookups.Runbooks[r.GetID()] = r.Name
+		}
+	}
+	if allTenants, err := octopus.Tenants.GetByProjectID(project.GetID()); err == nil {
+		for _, t := range allTenants {
+			lookups.Tenants[t.GetID()] = t.Name
+		}
+	}
+	return lookups
+}
+
+func lookup(names map[string]string, id string) string {
+	if name, ok := names[id]; ok && name != "" {
+		return name
+	}
+	return id
+}
+
+func lookupAll(names map[string]string, ids []string) string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, lookup(names, id))
+	}
+	return strings.Join(result, ", ")
+}
+
+// DescribeAction says in a sentence what trigger does when it fires.
+func DescribeAction(trigger *Trigger, project *projects.Project, lookups *Lookups) string {
+	if trigger.IsBuiltInFeed() {
+		return describeCreateRelease(project.ReleaseCreationStrategy.ChannelID, lookups)
+	}
+
+	switch action := trigger.ProjectTrigger.Action.(type) {
+	case *actions.AutoDeployAction:
+		if action.ShouldRedeploy {
+			return "Auto deploy, redeploying to targets that already have the current release"
+		}
+		return "Auto deploy"
+	case *actions.DeployLatestReleaseAction:
+		description := fmt.Sprintf("Deploy the latest release from %s to %s",
+			lookupAll(lookups.Environments, action.SourceEnvironments), lookup(lookups.Environments, action.DestinationEnvironment))
+		return description + describeScope(action.Channel, action.Tenants, action.TenantTags, lookups)
+	case *actions.DeployNewReleaseAction:
+		description := fmt.Sprintf("Deploy a new release to %s", lookup(lookups.Environments, action.Environment))
+		return description + describeScope(action.Channel, action.Tenants, action.TenantTags, lookups)
+	case *actions.RunRunbookAction:
+		description := fmt.Sprintf("Run runbook %s in %s", lookup(lookups.Runbooks, action.Runbook), lookupAll(lookups.Environments, action.Environments))
+		return description + describeScope("", action.Tenants, action.TenantTags, lookups)
+	case *actions.CreateReleaseAction:
+		return describeCreateRelease(action.ChannelID, lookups)
+	}
+	return ActionTypeName(trigger.ProjectTrigger.Action)
+}
+
+func describeCreateRelease(channelID string, lookups *Lookups) string {
+	if channelID == "" {
+		return "Create a release"
+	}
+	return fmt.Sprintf("Create a release in channel %s", lookup(lookups.Channels, channelID))
+}
+
+func describeScope(channelID string, tenants []string, tenantTags []string, lookups *Lookups) string {
+	var scope []string
+	if channelID != "" {
+		scope = append(scope, "channel "+lookup(lookups.Channels, channelID))
+	}
+	if len(tenants) > 0 {
+		scope = append(scope, "tenants "+lookupAll(lookups.Tenants, tenants))
+	}
+	if len(tenantTags) > 0 {
+		scope = append(scope, "tenant tags "+strings.Join(tenantTags, ", "))
+	}
+	if len(scope) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", strings.Join(scope, "; "))
+}
+
+// DescribeFilter says in a sentence when trigger fires.
+func DescribeFilter(trigger *Trigger, project *projects.Project, lookups *Lookups) string {
+	if trigger.IsBuiltInFeed() {
+		p := project.ReleaseCreationStrategy.ReleaseCreationPackage
+		return fmt.Sprintf("When package %s is pushed to the built-in repository", FormatPackage(p.DeploymentAction, p.PackageReference))
+	}
+
+	switch filter := trigger.ProjectTrigger.Filter.(type) {
+	case *filters.CronScheduledTriggerFilter:
+		return fmt.Sprintf("On the cron schedule '%s'%s", filter.CronExpression, describeTimeZone(filter.TimeZone))
+	case *filters.OnceDailyScheduledTriggerFilter:
+		return fmt.Sprintf("Daily at %s on %s%s", filter.Start.Format("15:04"), describeDays(filter.Days), describeTimeZone(filter.TimeZone))
+	case *filters.ContinuousDailyScheduledTriggerFilter:
+		return fmt.Sprintf("%s on %s%s", describeInterval(filter), describeDays(filter.Days), describeTimeZone(filter.TimeZone))
+	case *filters.DeploymentTargetFilter:
+		var parts []string
+		if events := append(append([]string{}, filter.EventGroups...), filter.EventCategories...); len(events) > 0 {
+			parts = append(parts, "events "+strings.Join(events, ", "))
+		}
+		if len(filter.Environments) > 0 {
+			parts = append(parts, "environments "+lookupAll(lookups.Environments, filter.Environments))
+		}
+		if len(filter.Roles) > 0 {
+			parts = append(parts, "roles "+strings.Join(filter.Roles, ", "))
+		}
+		if len(parts) == 0 {
+			return "When any deployment target changes"
+		}
+		return "When deployment targets change: " + strings.Join(parts, "; ")
+	case *filters.FeedTriggerFilter:
+		packages := make([]string, 0, len(filter.Packages))
+		for _, p := range filter.Packages {
+			packages = append(packages, FormatPackage(p.DeploymentActionSlug, p.PackageReference))
+		}
+		return fmt.Sprintf("When a new version of %s is pushed to its feed", strings.Join(packages, ", "))
+	}
+	return TypeOf(trigger.ProjectTrigger.Filter)
+}
+
+func describeTimeZone(timeZone string) string {
+	if timeZone == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", timeZone)
+}
+
+func describeDays(days []filters.Weekday) string {
+	if len(days) == 0 || len(days) == len(filters.WeekdayValues()) {
+		return "every day"
+	}
+	names := make([]string, 0, len(days))
+	for _, d := range days {
+		names = append(names, d.String())
+	}
+	return strings.Join(names, ", ")
+}
+
+func describeInterval(filter *filters.ContinuousDailyScheduledTriggerFilter) string {
+	if filter.Interval != nil && *filter.Interval == filters.OnceHourly && filter.HourInterval != nil {
+		return fmt.Sprintf("Every %d hour(s)", *filter.HourInterval)
+	}
+	if filter.Interval != nil && *filter.Interval == filters.OnceEveryMinute && filter.MinuteInterval != nil {
+		return fmt.Sprintf("Every %d minute(s)", *filter.MinuteInterval)
+	}
+	return "Continuously"
+}
+
+// FormatPackage shows a package reference of a step the way --package accepts it.
+func FormatPackage(step string, packageReference string) string {
+	if packageReference == "" {
+		return step
+	}
+	return fmt.Sprintf("%s:%s", step, packageReference)
+}
+
+// ParsePackage reads a --package value of the form <step>[:<package-reference>]; the
+// package reference is empty for a step's primary package.
+func ParsePackage(value string) (string, string, error) {
+	step, packageReference, _ := strings.Cut(value, ":")
+	step = strings.TrimSpace(step)
+	if step == "" {
+		return "", "", fmt.Errorf("package '%s' must be given as <step>[:<package-reference>]", value)
+	}
+	return step, strings.TrimSpace(packageReference), nil
+}
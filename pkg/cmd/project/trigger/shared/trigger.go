@@ -0,0 +1,221 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/actions"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/filters"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/triggers"
+)
+
+const (
+	TypeCron             = "cron"
+	TypeDaily            = "daily"
+	TypeDeploymentTarget = "deployment-target"
+	TypeExternalFeed     = "external-feed"
+	TypeBuiltInFeed      = "built-in-feed"
+
+	// types we can show but not create
+	TypeMonthly = "monthly"
+	TypeGit     = "git"
+
+	ActionDeployLatest = "deploy-latest"
+	ActionDeployNew    = "deploy-new"
+	ActionRunRunbook   = "run-runbook"
+)
+
+// BuiltInFeedTriggerID identifies the built-in package repository trigger. It isn't a
+// trigger resource on the server but a setting of the project itself, so it has no ID
+// of its own.
+const BuiltInFeedTriggerID = "built-in-feed"
+
+const BuiltInFeedTriggerName = "Built-in package repository"
+
+var CreatableTypes = []string{TypeCron, TypeDaily, TypeDeploymentTarget, TypeExternalFeed, TypeBuiltInFeed}
+
+var ScheduledActions = []string{ActionDeployLatest, ActionDeployNew, ActionRunRunbook}
+
+// Trigger is a project trigger as the CLI presents it. ProjectTrigger is nil for the
+// built-in package repository trigger, which is configured on the project.
+type Trigger struct {
+	ID             string
+	Name           string
+	Description    string
+	Type           string
+	IsDisabled     bool
+	ProjectTrigger *triggers.ProjectTrigger
+}
+
+func (t *Trigger) IsBuiltInFeed() bool {
+	return t.ProjectTrigger == nil
+}
+
+func NewTrigger(projectTrigger *triggers.ProjectTrigger) *Trigger {
+	return &Trigger{
+		ID:             projectTrigger.GetID(),
+		Name:           projectTrigger.Name,
+		Description:    projectTrigger.Description,
+		Type:           TypeOf(projectTrigger.Filter),
+		IsDisabled:     projectTrigger.IsDisabled,
+		ProjectTrigger: projectTrigger,
+	}
+}
+
+// NewBuiltInFeedTrigger returns the built-in package repository trigger of project, or
+// nil if the project doesn't have one configured.
+func NewBuiltInFeedTrigger(project *projects.Project) *Trigger {
+	if project.ReleaseCreationStrategy == nil || project.ReleaseCreationStrategy.ReleaseCreationPackage == nil {
+		return nil
+	}
+	return &Trigger{
+		ID:         BuiltInFeedTriggerID,
+		Name:       BuiltInFeedTriggerName,
+		Type:       TypeBuiltInFeed,
+		IsDisabled: !project.AutoCreateRelease,
+	}
+}
+
+func TypeOf(filter filters.ITriggerFilter) string {
+	if filter == nil {
+		return ""
+	}
+	switch filter.GetFilterType() {
+	case filters.CronExpressionSchedule:
+		return TypeCron
+	case filters.OnceDailySchedule, filters.ContinuousDailySchedule, filters.DailySchedule:
+		return TypeDaily
+	case filters.DaysPerMonthSchedule:
+		return TypeMonthly
+	case filters.MachineFilter:
+		return TypeDeploymentTarget
+	case filters.FeedFilter:
+		return TypeExternalFeed
+	case filters.GitFilter:
+		return TypeGit
+	}
+	return filter.GetFilterType().String()
+}
+
+// GetTriggers returns all the triggers of project, including its built-in package
+// repository trigger if it has one.
+func GetTriggers(octopus *client.Client, project *projects.Project) ([]*Trigger, error) {
+	// there's no project-scoped listing in the SDK (ProjectTriggers.GetByProjectID returns
+	// every trigger in the space), so we filter client-side
+	allTriggers, err := triggers.GetAll(octopus, project.SpaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Trigger, 0, len(allTriggers)+1)
+	for _, t := range allTriggers {
+		if t.ProjectID == project.GetID() {
+			result = append(result, NewTrigger(t))
+		}
+	}
+	if builtIn := NewBuiltInFeedTrigger(project); builtIn != nil {
+		result = append(result, builtIn)
+	}
+	return result, nil
+}
+
+// ResolveTrigger finds the trigger of project that a command should operate on, by ID
+// or name, prompting for it in interactive mode when the caller didn't name one.
+func ResolveTrigger(octopus *client.Client, ask question.Asker, promptEnabled bool, questionText string, project *projects.Project, idOrName string) (*Trigger, error) {
+	if idOrName == "" && !promptEnabled {
+		return nil, errors.New("trigger name or ID must be specified")
+	}
+
+	allTriggers, err := GetTriggers(octopus, project)
+	if err != nil {
+		return nil, err
+	}
+
+	if idOrName == "" {
+		if len(allTriggers) == 0 {
+			return nil, fmt.Errorf("project '%s' has no triggers", project.GetName())
+		}
+		return question.SelectMap(ask, questionText, allTriggers, func(t *Trigger) string {
+			return t.Name
+		})
+	}
+
+	for _, t := range allTriggers {
+		if strings.EqualFold(t.ID, idOrName) || strings.EqualFold(t.Name, idOrName) {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("no trigger found with name or ID of '%s' in project '%s'", idOrName, project.GetName())
+}
+
+// SetDisabled enables or disables trigger, which for the built-in package repository
+// trigger means turning automatic release creation on the project on or off.
+func SetDisabled(octopus *client.Client, project *projects.Project, trigger *Trigger, disabled bool) error {
+	if trigger.IsBuiltInFeed() {
+		project.AutoCreateRelease = !disabled
+		_, err := projects.Update(octopus, project)
+		return err
+	}
+
+	trigger.ProjectTrigger.IsDisabled = disabled
+	_, err := triggers.Update(octopus, trigger.ProjectTrigger)
+	return err
+}
+
+// Delete removes trigger. The built-in package repository trigger can't be deleted as
+// such, so we turn off automatic release creation and clear its settings instead.
+func Delete(octopus *client.Client, project *projects.Project, trigger *Trigger) error {
+	if trigger.IsBuiltInFeed() {
+		project.AutoCreateRelease = false
+		project.ReleaseCreationStrategy = nil
+		_, err := projects.Update(octopus, project)
+		return err
+	}
+	return triggers.DeleteById(octopus, project.SpaceID, trigger.ID)
+}
+
+// ActionTypeName describes the kind of thing a trigger does, for the short form of lists.
+func ActionTypeName(action actions.ITriggerAction) string {
+	if action == nil {
+		return ""
+	}
+	switch action.GetActionType() {
+	case actions.AutoDeploy:
+		return "Auto deploy"
+	case actions.DeployLatestRelease:
+		return "Deploy latest release"
+	case actions.DeployNewRelease:
+		return "Deploy new release"
+	case actions.RunRunbook:
+		return "Run runbook"
+	case actions.CreateRelease:
+		return "Create release"
+	}
+	return action.GetActionType().String()
+}
+
+type TriggerAsJson struct {
+	Id          string `json:"Id"`
+	Name        string `json:"Name"`
+	Description string `json:"Description,omitempty"`
+	Type        string `json:"Type"`
+	Action      string `json:"Action"`
+	When        string `json:"When"`
+	IsDisabled  bool   `json:"IsDisabled"`
+}
+
+func ToJson(trigger *Trigger, project *projects.Project, lookups *Lookups) TriggerAsJson {
+	return TriggerAsJson{
+		Id:          trigger.ID,
+		Name:        trigger.Name,
+		Description: trigger.Description,
+		Type:        trigger.Type,
+		Action:      DescribeAction(trigger, project, lookups),
+		When:        DescribeFilter(trigger, project, lookups),
+		IsDisabled:  trigger.IsDisabled,
+	}
+}
@@ -0,0 +1,105 @@
+package shared_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/shared"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/actions"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/filters"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/packages"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/triggers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newProject() *projects.Project {
+	project := projects.NewProject("Fire Project", "Lifecycles-1", "ProjectGroups-1")
+	project.ID = "Projects-1"
+	return project
+}
+
+func newLookups() *shared.Lookups {
+	lookups := shared.NewLookups()
+	lookups.Environments["Environments-1"] = "Test"
+	lookups.Environments["Environments-2"] = "Production"
+	lookups.Channels["Channels-1"] = "Hot Fix"
+	lookups.Runbooks["Runbooks-1"] = "Cleanup"
+	return lookups
+}
+
+func TestDescribe_ScheduledTriggers(t *testing.T) {
+	project := newProject()
+
+	brisbane, err := time.LoadLocation("Australia/Brisbane")
+	require.Nil(t, err)
+	daily := filters.NewOnceDailyScheduledTriggerFilter([]filters.Weekday{filters.Monday, filters.Friday}, time.Date(2024, 1, 1, 18, 30, 0, 0, brisbane))
+	runbook := actions.NewRunRunbookAction()
+	runbook.Runbook = "Runbooks-1"
+	runbook.Environments = []string{"Environments-2"}
+	trigger := shared.NewTrigger(triggers.NewProjectTrigger("Cleanup", "", false, project, runbook, daily))
+
+	assert.Equal(t, shared.TypeDaily, trigger.Type)
+	assert.Equal(t, "Daily at 18:30 on Monday, Friday (Australia/Brisbane)", shared.DescribeFilter(trigger, project, newLookups()))
+	assert.Equal(t, "Run runbook Cleanup in Production", shared.DescribeAction(trigger, project, newLookups()))
+
+	promote := actions.NewDeployLatestReleaseAction("Environments-2", false, []string{"Environments-1"}, "")
+	promote.Channel = "Channels-1"
+	trigger = shared.NewTrigger(triggers.NewProjectTrigger("Nightly", "", true, project, promote, filters.NewCronScheduledTriggerFilter("0 0 2 * * *", "UTC")))
+
+	assert.Equal(t, shared.TypeCron, trigger.Type)
+	assert.True(t, trigger.IsDisabled)
+	assert.Equal(t, "On the cron schedule '0 0 2 * * *' (UTC)", shared.DescribeFilter(trigger, project, newLookups()))
+	assert.Equal(t, "Deploy the latest release from Test to Production (channel Hot Fix)", shared.DescribeAction(trigger, project, newLookups()))
+}
+
+func TestDescribe_DeploymentTargetAndFeedTriggers(t *testing.T) {
+	project := newProject()
+
+	targets := filters.NewDeploymentTargetFilter([]string{"Environments-2", "Environments-9"}, nil, []string{"MachineAvailableForDeployment"}, []string{"web"})
+	trigger := shared.NewTrigger(triggers.NewProjectTrigger("New targets", "", false, project, actions.NewAutoDeployAction(false), targets))
+	assert.Equal(t, shared.TypeDeploymentTarget, trigger.Type)
+	assert.Equal(t, "When deployment targets change: events MachineAvailableForDeployment; environments Production, Environments-9; roles web", shared.DescribeFilter(trigger, project, newLookups()))
+	assert.Equal(t, "Auto deploy", shared.DescribeAction(trigger, project, newLookups()))
+
+	feed := filters.NewFeedTriggerFilter([]packages.DeploymentActionSlugPackage{{DeploymentActionSlug: "deploy-container", PackageReference: "nginx"}})
+	trigger = shared.NewTrigger(triggers.NewProjectTrigger("New image", "", false, project, actions.NewCreateReleaseAction("Channels-1"), feed))
+	assert.Equal(t, shared.TypeExternalFeed, trigger.Type)
+	assert.Equal(t, "When a new version of deploy-container:nginx is pushed to its feed", shared.DescribeFilter(trigger, project, newLookups()))
+	assert.Equal(t, "Create a release in channel Hot Fix", shared.DescribeAction(trigger, project, newLookups()))
+}
+
+func TestNewBuiltInFeedTrigger(t *testing.T) {
+	project := newProject()
+	assert.Nil(t, shared.NewBuiltInFeedTrigger(project))
+
+	project.ReleaseCreationStrategy = &projects.ReleaseCreationStrategy{
+		ReleaseCreationPackage: &packages.DeploymentActionPackage{DeploymentAction: "Deploy web"},
+	}
+	trigger := shared.NewBuiltInFeedTrigger(project)
+	require.NotNil(t, trigger)
+	assert.True(t, trigger.IsBuiltInFeed())
+	assert.True(t, trigger.IsDisabled)
+	assert.Equal(t, shared.BuiltInFeedTriggerID, trigger.ID)
+	assert.Equal(t, "When package Deploy web is pushed to the built-in repository", shared.DescribeFilter(trigger, project, newLookups()))
+	assert.Equal(t, "Create a release", shared.DescribeAction(trigger, project, newLookups()))
+
+	project.AutoCreateRelease = true
+	assert.False(t, shared.NewBuiltInFeedTrigger(project).IsDisabled)
+}
+
+func TestParsePackage(t *testing.T) {
+	step, packageReference, err := shared.ParsePackage("Deploy container:nginx")
+	assert.Nil(t, err)
+	assert.Equal(t, "Deploy container", step)
+	assert.Equal(t, "nginx", packageReference)
+
+	step, packageReference, err = shared.ParsePackage("Deploy web")
+	assert.Nil(t, err)
+	assert.Equal(t, "Deploy web", step)
+	assert.Equal(t, "", packageReference)
+
+	_, _, err = shared.ParsePackage(":nginx")
+	assert.EqualError(t, err, "package ':nginx' must be given as <step>[:<package-reference>]")
+}
@@ -0,0 +1,42 @@
+package trigger
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/spf13/cobra"
+
+	cmdCreate "github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/create"
+	cmdDelete "github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/delete"
+	cmdDisable "github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/disable"
+	cmdEnable "github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/enable"
+	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/list"
+	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/view"
+)
+
+func NewCmdTrigger(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "trigger <command>",
+		Aliases: []string{"triggers"},
+		Short:   "Manage project triggers",
+		Long:    "Manage the scheduled, deployment target and release creation triggers of projects in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s project trigger list -p "Deploy Web App"
+			%[1]s project trigger create -p "Deploy Web App" --type cron --cron "0 0 9 * * Mon-Fri" --action deploy-new --environment Test
+			%[1]s project trigger disable "Nightly" -p "Deploy Web App"
+		`, constants.ExecutableName),
+		Annotations: map[string]string{
+			annotations.IsCore: "true",
+		},
+	}
+
+	cmd.AddCommand(cmdList.NewCmdList(f))
+	cmd.AddCommand(cmdView.NewCmdView(f))
+	cmd.AddCommand(cmdCreate.NewCmdCreate(f))
+	cmd.AddCommand(cmdDelete.NewCmdDelete(f))
+	cmd.AddCommand(cmdEnable.NewCmdEnable(f))
+	cmd.AddCommand(cmdDisable.NewCmdDisable(f))
+
+	return cmd
+}
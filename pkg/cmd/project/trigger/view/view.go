@@ -0,0 +1,127 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/project/trigger/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject = "project"
+)
+
+type ViewFlags struct {
+	Project *flag.Flag[string]
+}
+
+func NewViewFlags() *ViewFlags {
+	return &ViewFlags{
+		Project: flag.New[string](FlagProject, false),
+	}
+}
+
+type ViewOptions struct {
+	*ViewFlags
+	*cmd.Dependencies
+	Command  *cobra.Command
+	IdOrName string
+}
+
+func NewCmdView(f factory.Factory) *cobra.Command {
+	viewFlags := NewViewFlags()
+	cmd := &cobra.Command{
+		Use:   "view [{<name> | <id>}]",
+		Short: "View a project trigger",
+		Long:  "View a trigger of a project in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s project trigger view "Nightly" -p "Deploy Web App"
+			%[1]s project trigger view ProjectTriggers-101 -p "Deploy Web App" -f json
+			%[1]s project trigger view %[2]s -p "Deploy Web App"
+		`, constants.ExecutableName, shared.BuiltInFeedTriggerID),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := &ViewOptions{
+				ViewFlags:    viewFlags,
+				Dependencies: cmd.NewDependencies(f, c),
+				Command:      c,
+			}
+			if len(args) > 0 {
+				opts.IdOrName = args[0]
+			}
+			return viewRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&viewFlags.Project.Value, viewFlags.Project.Name, "p", "", "Name or ID of the project the trigger belongs to")
+
+	return cmd
+}
+
+func viewRun(opts *ViewOptions) error {
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project containing the trigger you wish to view", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	trigger, err := shared.ResolveTrigger(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the trigger you wish to view:", project, opts.IdOrName)
+	if err != nil {
+		return err
+	}
+
+	lookups := shared.GetLookups(opts.Client, project)
+	url := util.GenerateWebURL(opts.Host, project.SpaceID, fmt.Sprintf("projects/%s/triggers", project.Slug))
+
+	return output.PrintResource(trigger, opts.Command, output.Mappers[*shared.Trigger]{
+		Json: func(t *shared.Trigger) any {
+			return shared.ToJson(t, project, lookups)
+		},
+		Table: output.TableDefinition[*shared.Trigger]{
+			Header: []string{"NAME", "TYPE", "ACTION", "WHEN", "ENABLED", "WEB URL"},
+			Row: func(t *shared.Trigger) []string {
+				enabled := "yes"
+				if t.IsDisabled {
+					enabled = "no"
+				}
+				return []string{
+					output.Bold(t.Name),
+					t.Type,
+					shared.DescribeAction(t, project, lookups),
+					shared.DescribeFilter(t, project, lookups),
+					enabled,
+					output.Blue(url),
+				}
+			},
+		},
+		Basic: func(t *shared.Trigger) string {
+			var result strings.Builder
+
+			result.WriteString(fmt.Sprintf("%s %s\n", output.Bold(t.Name), output.Dimf("(%s)", t.ID)))
+			if t.IsDisabled {
+				result.WriteString(fmt.Sprintf("%s\n", output.Yellow("Disabled")))
+			}
+			if t.Description != "" {
+				result.WriteString(fmt.Sprintln(output.Dim(t.Description)))
+			}
+			result.WriteString(fmt.Sprintf("Type: %s\n", t.Type))
+			result.WriteString(fmt.Sprintf("When: %s\n", shared.DescribeFilter(t, project, lookups)))
+			result.WriteString(fmt.Sprintf("Action: %s\n", shared.DescribeAction(t, project, lookups)))
+
+			result.WriteString(fmt.Sprintf("\nView the triggers of this project in Octopus Deploy: %s\n", output.Blue(url)))
+			return result.String()
+		},
+	})
+}
@@ -0,0 +1,297 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/edit"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/packages"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject                = "project"
+	FlagName                   = "name"
+	FlagDescription            = "description"
+	FlagGroup                  = "group"
+	FlagLifecycle              = "lifecycle"
+	FlagTenantedMode           = "tenanted-mode"
+	FlagReleaseVersionTemplate = "release-version-template"
+	FlagReleaseVersionPackage  = "release-version-package"
+	FlagGuidedFailure          = "guided-failure"
+	FlagDiscreteChannelRelease = "discrete-channel-release"
+	FlagEdit                   = "edit"
+)
+
+var tenantedModes = []string{
+	string(core.TenantedDeploymentModeUntenanted),
+	string(core.TenantedDeploymentModeTenanted),
+	string(core.TenantedDeploymentModeTenantedOrUntenanted),
+}
+
+var guidedFailureModes = []string{
+	string(core.GuidedFailureModeEnvironmentDefault),
+	string(core.GuidedFailureModeOn),
+	string(core.GuidedFailureModeOff),
+}
+
+type UpdateFlags struct {
+	Project                *flag.Flag[string]
+	Name                   *flag.Flag[string]
+	Description            *flag.Flag[string]
+	Group                  *flag.Flag[string]
+	Lifecycle              *flag.Flag[string]
+	TenantedMode           *flag.Flag[string]
+	ReleaseVersionTemplate *flag.Flag[string]
+	ReleaseVersionPackage  *flag.Flag[string]
+	GuidedFailure          *flag.Flag[string]
+	DiscreteChannelRelease *flag.Flag[bool]
+	Edit                   *flag.Flag[bool]
+}
+
+func NewUpdateFlags() *UpdateFlags {
+	return &UpdateFlags{
+		Project:                flag.New[string](FlagProject, false),
+		Name:                   flag.New[string](FlagName, false),
+		Description:            flag.New[string](FlagDescription, false),
+		Group:                  flag.New[string](FlagGroup, false),
+		Lifecycle:              flag.New[string](FlagLifecycle, false),
+		TenantedMode:           flag.New[string](FlagTenantedMode, false),
+		ReleaseVersionTemplate: flag.New[string](FlagReleaseVersionTemplate, false),
+		ReleaseVersionPackage:  flag.New[string](FlagReleaseVersionPackage, false),
+		GuidedFailure:          flag.New[string](FlagGuidedFailure, false),
+		DiscreteChannelRelease: flag.New[bool](FlagDiscreteChannelRelease, false),
+		Edit:                   flag.New[bool](FlagEdit, false),
+	}
+}
+
+type GetLifecycleCallback func(idOrName string) (*lifecycles.Lifecycle, error)
+type GetProjectGroupCallback func(idOrName string) (*projectgroups.ProjectGroup, error)
+type UpdateProjectCallback func(project *projects.Project) (*projects.Project, error)
+
+type UpdateOptions struct {
+	*UpdateFlags
+	*cmd.Dependencies
+	IsFlagSet               flag.IsSetFunc
+	GetProjectCallback      func(identifier string) (*projects.Project, error)
+	GetLifecycleCallback    GetLifecycleCallback
+	GetProjectGroupCallback GetProjectGroupCallback
+	UpdateProjectCallback   UpdateProjectCallback
+	EditProjectCallback     func(project *projects.Project) error
+}
+
+func NewUpdateOptions(flags *UpdateFlags, dependencies *cmd.Dependencies, isFlagSet flag.IsSetFunc) *UpdateOptions {
+	return &UpdateOptions{
+		UpdateFlags:  flags,
+		Dependencies: dependencies,
+		IsFlagSet:    isFlagSet,
+		GetProjectCallback: func(identifier string) (*projects.Project, error) {
+			return selectors.ResolveProject(dependencies.Client, dependencies.Ask, !dependencies.NoPrompt,
+				"Select the project you wish to update", identifier)
+		},
+		GetLifecycleCallback: func(idOrName string) (*lifecycles.Lifecycle, error) {
+			return dependencies.Client.Lifecycles.GetByIDOrName(idOrName)
+		},
+		GetProjectGroupCallback: func(idOrName string) (*projectgroups.ProjectGroup, error) {
+			return dependencies.Client.ProjectGroups.GetByIDOrName(idOrName)
+		},
+		UpdateProjectCallback: func(project *projects.Project) (*projects.Project, error) {
+			return dependencies.Client.Projects.Update(project)
+		},
+		EditProjectCallback: func(project *projects.Project) error {
+			kind, err := edit.FindKind("project")
+			if err != nil {
+				return err
+			}
+			return edit.EditRun(edit.NewEditOptions(dependencies, kind, project.GetID()))
+		},
+	}
+}
+
+func NewCmdUpdate(f factory.Factory) *cobra.Command {
+	updateFlags := NewUpdateFlags()
+
+	cmd := &cobra.Command{
+		Use:   "update [<project>]",
+		Short: "Update a project",
+		Long: heredoc.Docf(`
+			Update the settings of a project in Octopus Deploy.
+
+			Only the settings given as flags are changed. With --%[1]s, the project is opened as JSON in the
+			editor set with '%[2]s config set %[3]s', and saved when the editor is closed, just as with
+			'%[2]s edit project'.
+		`, FlagEdit, constants.ExecutableName, constants.ConfigEditor),
+		Example: heredoc.Docf(`
+			%[1]s project update "Deploy Web App" --lifecycle "Fast Track" --guided-failure On
+			%[1]s project update -p "Deploy Web App" --tenanted-mode TenantedOrUntenanted --discrete-channel-release
+			%[1]s project update -p "Deploy Web App" --release-version-package "Deploy Web:web-app"
+			%[1]s project update "Deploy Web App" --edit
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if updateFlags.Project.Value == "" && len(args) > 0 {
+				updateFlags.Project.Value = args[0]
+			}
+
+			opts := NewUpdateOptions(updateFlags, cmd.NewDependencies(f, c), c.Flags().Changed)
+			return updateRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&updateFlags.Project.Value, updateFlags.Project.Name, "p", "", "Name or ID of the project to update")
+	flags.StringVarP(&updateFlags.Name.Value, updateFlags.Name.Name, "n", "", "New name of the project")
+	flags.StringVarP(&updateFlags.Description.Value, updateFlags.Description.Name, "d", "", "Description of the project")
+	flags.StringVarP(&updateFlags.Group.Value, updateFlags.Group.Name, "g", "", "Name or ID of the project group to move the project to")
+	flags.StringVarP(&updateFlags.Lifecycle.Value, updateFlags.Lifecycle.Name, "l", "", "Name or ID of the lifecycle of the project")
+	flags.StringVar(&updateFlags.TenantedMode.Value, updateFlags.TenantedMode.Name, "", fmt.Sprintf("Whether the project is deployed to tenants, one of %s", strings.Join(tenantedModes, ", ")))
+	flags.StringVar(&updateFlags.ReleaseVersionTemplate.Value, updateFlags.ReleaseVersionTemplate.Name, "", "Template for the version of new releases, such as #{Octopus.Version.LastMajor}.#{Octopus.Version.NextMinor}")
+	flags.StringVar(&updateFlags.ReleaseVersionPackage.Value, updateFlags.ReleaseVersionPackage.Name, "", "Take the version of new releases from a package, given as <step>[:<package-reference>]")
+	flags.StringVar(&updateFlags.GuidedFailure.Value, updateFlags.GuidedFailure.Name, "", fmt.Sprintf("Default guided failure mode, one of %s", strings.Join(guidedFailureModes, ", ")))
+	flags.BoolVar(&updateFlags.DiscreteChannelRelease.Value, updateFlags.DiscreteChannelRelease.Name, false, "Treat releases of different channels to the same environment as separate deployments")
+	flags.BoolVar(&updateFlags.Edit.Value, updateFlags.Edit.Name, false, "Edit the project as JSON in your editor")
+	flags.SortFlags = false
+
+	return cmd
+}
+
+func updateRun(opts *UpdateOptions) error {
+	fieldFlagsSet := util.SliceContainsAny(fieldFlags(), opts.IsFlagSet)
+	if opts.Edit.Value {
+		if fieldFlagsSet {
+			return fmt.Errorf("--%s cannot be combined with flags for individual settings", FlagEdit)
+		}
+		if opts.NoPrompt {
+			return fmt.Errorf("--%s needs an interactive terminal, so cannot be used with --%s", FlagEdit, constants.FlagNoPrompt)
+		}
+	} else if !fieldFlagsSet {
+		return fmt.Errorf("nothing to update; supply the settings to change as flags, or use --%s", FlagEdit)
+	}
+
+	project, err := opts.GetProjectCallback(opts.Project.Value)
+	if err != nil {
+		return err
+	}
+	opts.Project.Value = project.GetName()
+
+	if opts.Edit.Value {
+		return opts.EditProjectCallback(project)
+	}
+	if err := ApplyFlags(opts, project); err != nil {
+		return err
+	}
+
+	updated, err := opts.UpdateProjectCallback(project)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(opts.Out, "\nSuccessfully updated project '%s' (%s).\n", updated.GetName(), updated.Slug)
+	if err != nil {
+		return err
+	}
+
+	link := output.Bluef("%s/app#/%s/projects/%s", opts.Host, opts.Space.GetID(), updated.GetID())
+	fmt.Fprintf(opts.Out, "View this project on Octopus Deploy: %s\n", link)
+
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Project, opts.Name, opts.Description, opts.Group,
+			opts.Lifecycle, opts.TenantedMode, opts.ReleaseVersionTemplate, opts.ReleaseVersionPackage, opts.GuidedFailure, opts.DiscreteChannelRelease)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+
+	return nil
+}
+
+func fieldFlags() []string {
+	return []string{FlagName, FlagDescription, FlagGroup, FlagLifecycle, FlagTenantedMode, FlagReleaseVersionTemplate,
+		FlagReleaseVersionPackage, FlagGuidedFailure, FlagDiscreteChannelRelease}
+}
+
+// ApplyFlags changes the settings of the project given as flags, looking up the project group and
+// lifecycle by name or ID.
+func ApplyFlags(opts *UpdateOptions, project *projects.Project) error {
+	if opts.IsFlagSet(FlagReleaseVersionTemplate) && opts.IsFlagSet(FlagReleaseVersionPackage) {
+		return fmt.Errorf("release versions come from either --%s or --%s, not both", FlagReleaseVersionTemplate, FlagReleaseVersionPackage)
+	}
+
+	if opts.IsFlagSet(FlagName) {
+		if opts.Name.Value == "" {
+			return fmt.Errorf("the name of a project cannot be empty")
+		}
+		project.Name = opts.Name.Value
+	}
+	if opts.IsFlagSet(FlagDescription) {
+		project.Description = opts.Description.Value
+	}
+	if opts.IsFlagSet(FlagGroup) {
+		group, err := opts.GetProjectGroupCallback(opts.Group.Value)
+		if err != nil {
+			return err
+		}
+		project.ProjectGroupID = group.GetID()
+	}
+	if opts.IsFlagSet(FlagLifecycle) {
+		lifecycle, err := opts.GetLifecycleCallback(opts.Lifecycle.Value)
+		if err != nil {
+			return err
+		}
+		project.LifecycleID = lifecycle.GetID()
+	}
+	if opts.IsFlagSet(FlagTenantedMode) {
+		mode, err := matchOption(FlagTenantedMode, opts.TenantedMode.Value, tenantedModes)
+		if err != nil {
+			return err
+		}
+		project.TenantedDeploymentMode = core.TenantedDeploymentMode(mode)
+	}
+	if opts.IsFlagSet(FlagReleaseVersionTemplate) {
+		if opts.ReleaseVersionTemplate.Value == "" {
+			return fmt.Errorf("the release version template cannot be empty")
+		}
+		project.VersioningStrategy = &projects.VersioningStrategy{Template: opts.ReleaseVersionTemplate.Value}
+	}
+	if opts.IsFlagSet(FlagReleaseVersionPackage) {
+		step, packageReference, _ := strings.Cut(opts.ReleaseVersionPackage.Value, ":")
+		if step == "" {
+			return fmt.Errorf("--%s must be given as <step>[:<package-reference>]", FlagReleaseVersionPackage)
+		}
+		project.VersioningStrategy = &projects.VersioningStrategy{
+			DonorPackage: &packages.DeploymentActionPackage{DeploymentAction: step, PackageReference: packageReference},
+		}
+	}
+	if opts.IsFlagSet(FlagGuidedFailure) {
+		mode, err := matchOption(FlagGuidedFailure, opts.GuidedFailure.Value, guidedFailureModes)
+		if err != nil {
+			return err
+		}
+		project.DefaultGuidedFailureMode = mode
+	}
+	if opts.IsFlagSet(FlagDiscreteChannelRelease) {
+		project.IsDiscreteChannelRelease = opts.DiscreteChannelRelease.Value
+	}
+
+	return nil
+}
+
+func matchOption(flagName string, value string, options []string) (string, error) {
+	for _, option := range options {
+		if strings.EqualFold(option, value) {
+			return option, nil
+		}
+	}
+	return "", fmt.Errorf("'%s' is not a valid value for --%s, must be one of %s", value, flagName, strings.Join(options, ", "))
+}
@@ -0,0 +1,80 @@
+package update_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/project/update"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newProject() *projects.Project {
+	project := projects.NewProject("Deploy Web App", "Lifecycles-1", "ProjectGroups-1")
+	project.ID = "Projects-1"
+	project.SpaceID = "Spaces-1"
+	project.Description = "The web app"
+	project.Links = map[string]string{"Self": "/api/Spaces-1/projects/Projects-1"}
+	return project
+}
+
+func newOptions(flags *update.UpdateFlags, given ...string) *update.UpdateOptions {
+	return update.NewUpdateOptions(flags, &cmd.Dependencies{}, func(name string) bool {
+		for _, g := range given {
+			if g == name {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func TestApplyFlags_OnlyChangesGivenFlags(t *testing.T) {
+	flags := update.NewUpdateFlags()
+	flags.Lifecycle.Value = "Fast Track"
+	flags.TenantedMode.Value = "tenantedoruntenanted"
+	flags.GuidedFailure.Value = "on"
+	flags.ReleaseVersionPackage.Value = "Deploy Web:web-app"
+	opts := newOptions(flags, update.FlagLifecycle, update.FlagTenantedMode, update.FlagGuidedFailure, update.FlagReleaseVersionPackage)
+	opts.GetLifecycleCallback = func(idOrName string) (*lifecycles.Lifecycle, error) {
+		assert.Equal(t, "Fast Track", idOrName)
+		lifecycle := lifecycles.NewLifecycle("Fast Track")
+		lifecycle.ID = "Lifecycles-2"
+		return lifecycle, nil
+	}
+
+	project := newProject()
+	err := update.ApplyFlags(opts, project)
+	require.Nil(t, err)
+
+	assert.Equal(t, "Deploy Web App", project.Name)
+	assert.Equal(t, "The web app", project.Description)
+	assert.Equal(t, "ProjectGroups-1", project.ProjectGroupID)
+	assert.Equal(t, "Lifecycles-2", project.LifecycleID)
+	assert.Equal(t, core.TenantedDeploymentModeTenantedOrUntenanted, project.TenantedDeploymentMode)
+	assert.Equal(t, "On", project.DefaultGuidedFailureMode)
+	assert.Equal(t, "Deploy Web", project.VersioningStrategy.DonorPackage.DeploymentAction)
+	assert.Equal(t, "web-app", project.VersioningStrategy.DonorPackage.PackageReference)
+}
+
+func TestApplyFlags_InvalidValue(t *testing.T) {
+	flags := update.NewUpdateFlags()
+	flags.TenantedMode.Value = "Sometimes"
+	opts := newOptions(flags, update.FlagTenantedMode)
+
+	err := update.ApplyFlags(opts, newProject())
+	assert.EqualError(t, err, "'Sometimes' is not a valid value for --tenanted-mode, must be one of Untenanted, Tenanted, TenantedOrUntenanted")
+}
+
+func TestApplyFlags_BothVersioningStrategies(t *testing.T) {
+	flags := update.NewUpdateFlags()
+	flags.ReleaseVersionTemplate.Value = "1.0.#{Octopus.Version.NextPatch}"
+	flags.ReleaseVersionPackage.Value = "Deploy Web"
+	opts := newOptions(flags, update.FlagReleaseVersionTemplate, update.FlagReleaseVersionPackage)
+
+	err := update.ApplyFlags(opts, newProject())
+	assert.EqualError(t, err, "release versions come from either --release-version-template or --release-version-package, not both")
+}
@@ -0,0 +1,130 @@
+package export
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	sharedProjectVariable "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	sharedVariable "github.com/OctopusDeploy/cli/pkg/question/shared/variables"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject = "project"
+	FlagGitRef  = "git-ref"
+	FlagFormat  = "format"
+	FlagFile    = "file"
+)
+
+type ExportFlags struct {
+	Project *flag.Flag[string]
+	GitRef  *flag.Flag[string]
+	Format  *flag.Flag[string]
+	File    *flag.Flag[string]
+}
+
+func NewExportFlags() *ExportFlags {
+	return &ExportFlags{
+		Project: flag.New[string](FlagProject, false),
+		GitRef:  flag.New[string](FlagGitRef, false),
+		Format:  flag.New[string](FlagFormat, false),
+		File:    flag.New[string](FlagFile, false),
+	}
+}
+
+type ExportOptions struct {
+	*ExportFlags
+	*cmd.Dependencies
+	*sharedVariable.VariableCallbacks
+}
+
+func NewExportOptions(flags *ExportFlags, dependencies *cmd.Dependencies) *ExportOptions {
+	return &ExportOptions{
+		ExportFlags:       flags,
+		Dependencies:      dependencies,
+		VariableCallbacks: sharedVariable.NewVariableCallbacks(dependencies),
+	}
+}
+
+func NewCmdExport(f factory.Factory) *cobra.Command {
+	exportFlags := NewExportFlags()
+	cmd := &cobra.Command{
+		Use:   "export [<project>]",
+		Short: "Export the variables of a project",
+		Long: heredoc.Doc(`
+			Export all the variables of a project in Octopus Deploy, with their scopes and prompt settings, as JSON or YAML.
+
+			Scopes are written by name so the file can be kept in source control and applied with 'project variables import'.
+			The values of sensitive variables are never exported. The sensitive variables of a
+			version-controlled project are kept in the database, and are exported with the rest.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s project variables export "Deploy Web App"
+			%[1]s project variables export -p "Deploy Web App" --file variables.yaml
+			%[1]s project variables export -p "Deploy Web App" --git-ref refs/heads/main --format json
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if exportFlags.Project.Value == "" && len(args) > 0 {
+				exportFlags.Project.Value = args[0]
+			}
+
+			opts := NewExportOptions(exportFlags, cmd.NewDependencies(f, c))
+			return exportRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&exportFlags.Project.Value, exportFlags.Project.Name, "p", "", "Name or ID of the project")
+	flags.StringVarP(&exportFlags.GitRef.Value, exportFlags.GitRef.Name, "", "", "The GitRef for the Config-As-Code branch. Defaults to the project's default branch")
+	flags.StringVar(&exportFlags.Format.Value, exportFlags.Format.Name, "", fmt.Sprintf("The format to export, one of %s. Inferred from --%s when not given, otherwise yaml", output.FormatAsList(sharedProjectVariable.Formats), FlagFile))
+	flags.StringVar(&exportFlags.File.Value, exportFlags.File.Name, "", "The file to write the variables to. Writes to standard output when not given")
+
+	return cmd
+}
+
+func exportRun(opts *ExportOptions) error {
+	format := opts.Format.Value
+	if format == "" {
+		format = sharedProjectVariable.FormatFromFileName(opts.File.Value)
+	}
+	if format == "" {
+		format = sharedProjectVariable.FormatYaml
+	}
+
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project whose variables you wish to export", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	allVariables, err := sharedProjectVariable.GetProjectVariables(opts.VariableCallbacks, opts.Space.GetID(), project, opts.GitRef.Value)
+	if err != nil {
+		return err
+	}
+	projectVariables := allVariables.VariableSet()
+
+	data, err := sharedProjectVariable.MarshalDocument(sharedProjectVariable.ToDocument(projectVariables, project), format)
+	if err != nil {
+		return err
+	}
+
+	if opts.File.Value == "" {
+		_, err = fmt.Fprintln(opts.Out, string(data))
+		return err
+	}
+
+	if err := os.WriteFile(opts.File.Value, data, 0644); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(opts.Out, "Exported %d variables of '%s' to %s\n", len(projectVariables.Variables), project.GetName(), opts.File.Value)
+	return err
+}
@@ -0,0 +1,217 @@
+package importcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	sharedProjectVariable "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	sharedVariable "github.com/OctopusDeploy/cli/pkg/question/shared/variables"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject = "project"
+	FlagGitRef  = "git-ref"
+	FlagFormat  = "format"
+	FlagFile    = "file"
+	FlagDryRun  = "dry-run"
+)
+
+type ImportFlags struct {
+	Project *flag.Flag[string]
+	GitRef  *flag.Flag[string]
+	Format  *flag.Flag[string]
+	File    *flag.Flag[string]
+	DryRun  *flag.Flag[bool]
+	*question.ConfirmFlags
+}
+
+func NewImportFlags() *ImportFlags {
+	return &ImportFlags{
+		Project:      flag.New[string](FlagProject, false),
+		GitRef:       flag.New[string](FlagGitRef, false),
+		Format:       flag.New[string](FlagFormat, false),
+		File:         flag.New[string](FlagFile, false),
+		DryRun:       flag.New[bool](FlagDryRun, false),
+		ConfirmFlags: question.NewConfirmFlags(),
+	}
+}
+
+type UpdateVariablesCallback func(project *projects.Project, gitRef string, variableSet *variables.VariableSet) error
+
+type ImportOptions struct {
+	*ImportFlags
+	*cmd.Dependencies
+	*sharedVariable.VariableCallbacks
+	UpdateVariablesCallback
+}
+
+func NewImportOptions(flags *ImportFlags, dependencies *cmd.Dependencies) *ImportOptions {
+	return &ImportOptions{
+		ImportFlags:       flags,
+		Dependencies:      dependencies,
+		VariableCallbacks: sharedVariable.NewVariableCallbacks(dependencies),
+		UpdateVariablesCallback: func(project *projects.Project, gitRef string, variableSet *variables.VariableSet) error {
+			if gitRef != "" {
+				_, err := dependencies.Client.ProjectVariables.UpdateByGitRef(dependencies.Space.GetID(), project.GetID(), gitRef, variableSet)
+				return err
+			}
+			_, err := dependencies.Client.Variables.Update(project.GetID(), *variableSet)
+			return err
+		},
+	}
+}
+
+func NewCmdImport(f factory.Factory) *cobra.Command {
+	importFlags := NewImportFlags()
+	cmd := &cobra.Command{
+		Use:   "import [<project>]",
+		Short: "Import the variables of a project",
+		Long: heredoc.Doc(`
+			Replace the variables of a project in Octopus Deploy with those in a JSON or YAML file
+			written by 'project variables export', showing the variables that will be added, changed
+			and removed first. All the changes are saved together.
+
+			Variables are matched by name and scope. A sensitive variable without a value in the file
+			keeps the value it has; giving it a value replaces that value. The sensitive variables of a
+			version-controlled project are saved in the database, and the rest in git.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s project variables import -p "Deploy Web App" --file variables.yaml
+			%[1]s project variables import -p "Deploy Web App" --file variables.json --dry-run
+			%[1]s project variables import -p "Deploy Web App" --git-ref refs/heads/main --file variables.yaml -y
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if importFlags.Project.Value == "" && len(args) > 0 {
+				importFlags.Project.Value = args[0]
+			}
+
+			opts := NewImportOptions(importFlags, cmd.NewDependencies(f, c))
+			return importRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&importFlags.Project.Value, importFlags.Project.Name, "p", "", "Name or ID of the project")
+	flags.StringVarP(&importFlags.GitRef.Value, importFlags.GitRef.Name, "", "", "The GitRef for the Config-As-Code branch. Defaults to the project's default branch")
+	flags.StringVar(&importFlags.Format.Value, importFlags.Format.Name, "", fmt.Sprintf("The format of the file, one of %s. Inferred from the file extension when not given", output.FormatAsList(sharedProjectVariable.Formats)))
+	flags.StringVar(&importFlags.File.Value, importFlags.File.Name, "", "The file to read the variables from")
+	flags.BoolVar(&importFlags.DryRun.Value, importFlags.DryRun.Name, false, "Show the changes that would be made without saving them")
+	flags.BoolVarP(&importFlags.Confirm.Value, importFlags.Confirm.Name, "y", false, "Don't ask for confirmation before saving the changes")
+
+	return cmd
+}
+
+func importRun(opts *ImportOptions) error {
+	if opts.File.Value == "" {
+		return fmt.Errorf("must supply the file to import with --%s", FlagFile)
+	}
+
+	format := opts.Format.Value
+	if format == "" {
+		format = sharedProjectVariable.FormatFromFileName(opts.File.Value)
+	}
+	if format == "" {
+		return fmt.Errorf("cannot infer the format of '%s'; supply --%s", opts.File.Value, FlagFormat)
+	}
+
+	data, err := os.ReadFile(opts.File.Value)
+	if err != nil {
+		return err
+	}
+	document, err := sharedProjectVariable.UnmarshalDocument(data, format)
+	if err != nil {
+		return fmt.Errorf("cannot read '%s': %w", opts.File.Value, err)
+	}
+
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project whose variables you wish to import", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+	opts.Project.Value = project.GetName()
+
+	allVariables, err := sharedProjectVariable.GetProjectVariables(opts.VariableCallbacks, opts.Space.GetID(), project, opts.GitRef.Value)
+	if err != nil {
+		return err
+	}
+	opts.GitRef.Value = allVariables.GitRef
+	projectVariables := allVariables.VariableSet()
+
+	incoming, err := sharedProjectVariable.FromDocument(document, projectVariables, project)
+	if err != nil {
+		return fmt.Errorf("cannot import '%s': %w", opts.File.Value, err)
+	}
+	diff, err := sharedProjectVariable.DiffVariables(projectVariables.Variables, incoming)
+	if err != nil {
+		return fmt.Errorf("cannot import '%s': %w", opts.File.Value, err)
+	}
+
+	if !diff.HasChanges() {
+		_, err = fmt.Fprintf(opts.Out, "The variables of '%s' are already up to date\n", project.GetName())
+		return err
+	}
+
+	fmt.Fprint(opts.Out, diff.Format(projectVariables, project))
+
+	if opts.DryRun.Value {
+		_, err = fmt.Fprintln(opts.Out, output.Dim("Dry run: no changes were saved"))
+		return err
+	}
+
+	if !opts.NoPrompt && !opts.Confirm.Value {
+		var apply bool
+		if err := opts.Ask(&survey.Confirm{
+			Message: "Save these changes to the project variables?",
+			Default: false,
+		}, &apply); err != nil {
+			return err
+		}
+		if !apply {
+			return errors.New("import cancelled; no changes were saved")
+		}
+	}
+
+	// one update for each set, so each is saved completely or not at all. A version-controlled
+	// project keeps its sensitive variables in the database, which is saved before git
+	databaseVariables, gitVariables := allVariables.Split(diff.Variables())
+	if allVariables.Git == nil || diff.HasChangesTo(true) {
+		allVariables.Database.Variables = databaseVariables
+		if err := opts.UpdateVariablesCallback(project, "", allVariables.Database); err != nil {
+			return err
+		}
+	}
+	if allVariables.Git != nil && diff.HasChangesTo(false) {
+		allVariables.Git.Variables = gitVariables
+		if err := opts.UpdateVariablesCallback(project, allVariables.GitRef, allVariables.Git); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(opts.Out, "Successfully imported the variables of '%s'\n", project.GetName())
+	if err != nil {
+		return err
+	}
+
+	if !opts.NoPrompt {
+		opts.Confirm.Value = true
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Project, opts.GitRef, opts.Format, opts.File, opts.Confirm)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+
+	return nil
+}
@@ -0,0 +1,462 @@
+package preview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	sharedProjectVariable "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/tenant/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	sharedVariable "github.com/OctopusDeploy/cli/pkg/question/shared/variables"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/featuretoggle"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject     = "project"
+	FlagGitRef      = "git-ref"
+	FlagName        = "name"
+	FlagEnvironment = "environment"
+	FlagTenant      = "tenant"
+	FlagChannel     = "channel"
+	FlagTarget      = "target"
+	FlagRole        = "role"
+	FlagStep        = "step"
+)
+
+type PreviewFlags struct {
+	Project     *flag.Flag[string]
+	GitRef      *flag.Flag[string]
+	Name        *flag.Flag[string]
+	Environment *flag.Flag[string]
+	Tenant      *flag.Flag[string]
+	Channel     *flag.Flag[string]
+	Target      *flag.Flag[string]
+	Roles       *flag.Flag[[]string]
+	Step        *flag.Flag[string]
+}
+
+func NewPreviewFlags() *PreviewFlags {
+	return &PreviewFlags{
+		Project:     flag.New[string](FlagProject, false),
+		GitRef:      flag.New[string](FlagGitRef, false),
+		Name:        flag.New[string](FlagName, false),
+		Environment: flag.New[string](FlagEnvironment, false),
+		Tenant:      flag.New[string](FlagTenant, false),
+		Channel:     flag.New[string](FlagChannel, false),
+		Target:      flag.New[string](FlagTarget, false),
+		Roles:       flag.New[[]string](FlagRole, false),
+		Step:        flag.New[string](FlagStep, false),
+	}
+}
+
+type PreviewOptions struct {
+	*PreviewFlags
+	Command *cobra.Command
+	*cmd.Dependencies
+	*sharedVariable.VariableCallbacks
+	FeatureToggleCallback func(name string) (bool, error)
+}
+
+func NewPreviewOptions(flags *PreviewFlags, dependencies *cmd.Dependencies, cmd *cobra.Command) *PreviewOptions {
+	return &PreviewOptions{
+		PreviewFlags:      flags,
+		Command:           cmd,
+		Dependencies:      dependencies,
+		VariableCallbacks: sharedVariable.NewVariableCallbacks(dependencies),
+		FeatureToggleCallback: func(name string) (bool, error) {
+			return featuretoggle.IsToggleEnabled(dependencies.Client, name)
+		},
+	}
+}
+
+func NewCmdPreview(f factory.Factory) *cobra.Command {
+	previewFlags := NewPreviewFlags()
+	cmd := &cobra.Command{
+		Use:   "preview [<project>]",
+		Short: "Preview the values of project variables in a deployment",
+		Long: heredoc.Doc(`
+			Preview the value each variable of a project takes in a deployment to an environment, tenant,
+			channel, deployment target or step, from the project's variables, its library variable sets
+			and the tenant's variables.
+
+			Every value a variable could take is shown with the reason it won or lost. A value scoped to
+			a target is the most specific, followed by a step, role, tenant, tenant tag, environment,
+			channel and process. Values that are equally specific are taken from the project before
+			library variable sets and tenants. References such as #{Name} are expanded in values that
+			are not sensitive.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s project variables preview "Deploy Web App" --environment Production
+			%[1]s project variables preview -p "Deploy Web App" --environment Production --tenant "Bobs Fish Shack" --name ConnectionString
+			%[1]s project variables preview -p "Deploy Web App" --environment Test --target web-01 --step "Deploy website"
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if previewFlags.Project.Value == "" && len(args) > 0 {
+				previewFlags.Project.Value = args[0]
+			}
+
+			opts := NewPreviewOptions(previewFlags, cmd.NewDependencies(f, c), c)
+			return previewRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&previewFlags.Project.Value, previewFlags.Project.Name, "p", "", "Name or ID of the project")
+	flags.StringVarP(&previewFlags.GitRef.Value, previewFlags.GitRef.Name, "", "", "The GitRef for the Config-As-Code branch. Defaults to the project's default branch")
+	flags.StringVarP(&previewFlags.Name.Value, previewFlags.Name.Name, "n", "", "Only preview the variable with this name")
+	flags.StringVarP(&previewFlags.Environment.Value, previewFlags.Environment.Name, "e", "", "Name or ID of the environment being deployed to")
+	flags.StringVarP(&previewFlags.Tenant.Value, previewFlags.Tenant.Name, "t", "", "Name or ID of the tenant being deployed")
+	flags.StringVarP(&previewFlags.Channel.Value, previewFlags.Channel.Name, "c", "", "Name or ID of the channel of the release")
+	flags.StringVar(&previewFlags.Target.Value, previewFlags.Target.Name, "", "Name or ID of the deployment target")
+	flags.StringSliceVar(&previewFlags.Roles.Value, previewFlags.Roles.Name, nil, "A target role, in addition to the roles of --target. Multiple roles can be supplied")
+	flags.StringVar(&previewFlags.Step.Value, previewFlags.Step.Name, "", "Name of the step in the deployment process")
+
+	return cmd
+}
+
+func previewRun(opts *PreviewOptions) error {
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project whose variables you wish to preview", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	gitRef, err := sharedProjectVariable.ResolveGitRef(project, opts.GitRef.Value)
+	if err != nil {
+		return err
+	}
+
+	// the sensitive variables of a version controlled project stay in the database
+	projectVariables, err := opts.GetProjectVariables(project.GetID())
+	if err != nil {
+		return err
+	}
+	allProjectVariables := projectVariables.Variables
+	if gitRef != "" {
+		gitVariables, err := opts.GetProjectVariablesByGitRef(opts.Space.GetID(), project.GetID(), gitRef)
+		if err != nil {
+			return err
+		}
+		allProjectVariables = append(allProjectVariables, gitVariables.Variables...)
+	}
+
+	names := map[string]string{}
+	addNames(names, projectVariables.ScopeValues)
+	names[project.GetID()] = "deployment process"
+
+	context, tenant, system, err := buildContext(opts, project, projectVariables.ScopeValues)
+	if err != nil {
+		return err
+	}
+
+	var candidates []*Candidate
+	for _, v := range allProjectVariables {
+		candidates = append(candidates, fromVariable(v, SourceProject, project.GetName()))
+	}
+
+	for _, id := range project.IncludedLibraryVariableSets {
+		libraryVariableSet, err := opts.Client.LibraryVariableSets.GetByID(id)
+		if err != nil {
+			return err
+		}
+		libraryVariables, err := opts.GetProjectVariables(libraryVariableSet.GetID())
+		if err != nil {
+			return err
+		}
+		addNames(names, libraryVariables.ScopeValues)
+		for _, v := range libraryVariables.Variables {
+			candidates = append(candidates, fromVariable(v, SourceLibraryVariableSet, libraryVariableSet.Name))
+		}
+	}
+
+	if tenant != nil {
+		tenantCandidates, err := getTenantCandidates(opts, project, tenant)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, tenantCandidates...)
+	}
+
+	resolutions := Resolve(candidates, context, names, system)
+	if opts.Name.Value != "" {
+		resolutions = util.SliceFilter(resolutions, func(r *Resolution) bool { return strings.EqualFold(r.Name, opts.Name.Value) })
+		if len(resolutions) == 0 {
+			return fmt.Errorf("cannot find variable '%s'", opts.Name.Value)
+		}
+	}
+
+	var rows []*previewRow
+	for _, r := range resolutions {
+		for _, o := range r.Outcomes {
+			rows = append(rows, &previewRow{Resolution: r, Outcome: o})
+		}
+	}
+
+	return output.PrintArray(rows, opts.Command, output.Mappers[*previewRow]{
+		Json: func(item *previewRow) any {
+			return previewRowAsJson{
+				Name:       item.Resolution.Name,
+				Value:      item.value(),
+				Source:     item.Outcome.Candidate.Source.String(),
+				SourceName: item.Outcome.Candidate.SourceName,
+				Scope:      DescribeScope(item.Outcome.Candidate, names),
+				Winner:     item.Outcome.Winner,
+				Reason:     item.Outcome.Reason,
+			}
+		},
+		Table: output.TableDefinition[*previewRow]{
+			Header: []string{"NAME", "VALUE", "SOURCE", "SCOPE", "RESULT"},
+			Row: func(item *previewRow) []string {
+				result := output.Dim(item.Outcome.Reason)
+				if item.Outcome.Winner {
+					result = output.Green("winner")
+				}
+				return []string{output.Bold(item.Resolution.Name), item.value(), item.source(), DescribeScope(item.Outcome.Candidate, names), result}
+			},
+		},
+		Basic: func(item *previewRow) string {
+			if item.Outcome.Winner {
+				return fmt.Sprintf("%s = %s", item.Resolution.Name, item.value())
+			}
+			return fmt.Sprintf("    %s = %s (%s, %s): %s", item.Resolution.Name, item.value(), item.source(), DescribeScope(item.Outcome.Candidate, names), item.Outcome.Reason)
+		},
+	})
+}
+
+type previewRow struct {
+	Resolution *Resolution
+	Outcome    *Outcome
+}
+
+type previewRowAsJson struct {
+	Name       string `json:"Name"`
+	Value      string `json:"Value"`
+	Source     string `json:"Source"`
+	SourceName string `json:"SourceName"`
+	Scope      string `json:"Scope"`
+	Winner     bool   `json:"Winner"`
+	Reason     string `json:"Reason,omitempty"`
+}
+
+func (r *previewRow) value() string {
+	if r.Outcome.Winner {
+		return r.Resolution.Value
+	}
+	if r.Outcome.Candidate.IsSensitive {
+		return "***"
+	}
+	return r.Outcome.Candidate.Value
+}
+
+func (r *previewRow) source() string {
+	return fmt.Sprintf("%s '%s'", r.Outcome.Candidate.Source, r.Outcome.Candidate.SourceName)
+}
+
+// buildContext resolves the flags to the IDs that variables are scoped to, along with the
+// tenant being deployed, if any, and the system variables they imply.
+func buildContext(opts *PreviewOptions, project *projects.Project, scopeValues *variables.VariableScopeValues) (*Context, *tenants.Tenant, map[string]string, error) {
+	context := &Context{ProcessOwnerID: project.GetID(), Roles: opts.Roles.Value}
+	system := map[string]string{"Octopus.Project.Name": project.GetName()}
+
+	var err error
+	if opts.Environment.Value != "" {
+		if context.EnvironmentID, err = findScopeValue("environment", opts.Environment.Value, scopeValues.Environments); err != nil {
+			return nil, nil, nil, err
+		}
+		system["Octopus.Environment.Name"] = referenceName(context.EnvironmentID, scopeValues.Environments)
+	}
+	if opts.Channel.Value != "" {
+		if context.ChannelID, err = findScopeValue("channel", opts.Channel.Value, scopeValues.Channels); err != nil {
+			return nil, nil, nil, err
+		}
+		system["Octopus.Release.Channel.Name"] = referenceName(context.ChannelID, scopeValues.Channels)
+	}
+	if opts.Step.Value != "" {
+		if context.ActionID, err = findScopeValue("step", opts.Step.Value, scopeValues.Actions); err != nil {
+			return nil, nil, nil, err
+		}
+		system["Octopus.Action.Name"] = referenceName(context.ActionID, scopeValues.Actions)
+	}
+	if opts.Target.Value != "" {
+		if context.MachineID, err = findScopeValue("deployment target", opts.Target.Value, scopeValues.Machines); err != nil {
+			return nil, nil, nil, err
+		}
+		target, err := opts.Client.Machines.GetByID(context.MachineID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		context.Roles = append(context.Roles, target.Roles...)
+		system["Octopus.Machine.Name"] = target.Name
+	}
+	var tenant *tenants.Tenant
+	if opts.Tenant.Value != "" {
+		tenant, err = shared.GetTenant(opts.Client, opts.Tenant.Value)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if _, ok := tenant.ProjectEnvironments[project.GetID()]; !ok {
+			return nil, nil, nil, fmt.Errorf("tenant '%s' is not connected to project '%s'", tenant.Name, project.GetName())
+		}
+		context.TenantID = tenant.GetID()
+		context.TenantTags = tenant.TenantTags
+		system["Octopus.Deployment.Tenant.Name"] = tenant.Name
+	}
+
+	return context, tenant, system, nil
+}
+
+// getTenantCandidates returns the values the tenant gives the project and common variable
+// templates of the project, falling back on the default of each template.
+func getTenantCandidates(opts *PreviewOptions, project *projects.Project, tenant *tenants.Tenant) ([]*Candidate, error) {
+	toggleValue, _ := opts.FeatureToggleCallback("CommonVariableScopingFeatureToggle")
+	if !toggleValue {
+		return getTenantCandidatesV1(opts, project, tenant)
+	}
+
+	var candidates []*Candidate
+	projectVariables, err := opts.GetTenantProjectVariables(tenant, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range projectVariables.Variables {
+		if v.ProjectID == project.GetID() {
+			candidates = append(candidates, fromTenantValue(tenant, v.Template.Name, v.Value, v.ProjectName, v.Scope.EnvironmentIds))
+		}
+	}
+	for _, v := range projectVariables.MissingVariables {
+		if v.ProjectID == project.GetID() {
+			candidates = appendTemplateDefault(candidates, v.Template.Name, v.Template.DefaultValue, v.ProjectName)
+		}
+	}
+
+	commonVariables, err := opts.GetTenantCommonVariables(tenant, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range commonVariables.Variables {
+		if util.SliceContains(project.IncludedLibraryVariableSets, v.LibraryVariableSetId) {
+			candidates = append(candidates, fromTenantValue(tenant, v.Template.Name, v.Value, v.LibraryVariableSetName, v.Scope.EnvironmentIds))
+		}
+	}
+	for _, v := range commonVariables.MissingVariables {
+		if util.SliceContains(project.IncludedLibraryVariableSets, v.LibraryVariableSetId) {
+			candidates = appendTemplateDefault(candidates, v.Template.Name, v.Template.DefaultValue, v.LibraryVariableSetName)
+		}
+	}
+	return candidates, nil
+}
+
+func getTenantCandidatesV1(opts *PreviewOptions, project *projects.Project, tenant *tenants.Tenant) ([]*Candidate, error) {
+	tenantVariables, err := opts.GetTenantVariables(tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*Candidate
+	if p, ok := tenantVariables.ProjectVariables[project.GetID()]; ok {
+		for _, t := range p.Templates {
+			candidates = appendTemplateDefault(candidates, t.Name, t.DefaultValue, p.ProjectName)
+			for environmentID, values := range p.Variables {
+				if value, ok := values[t.GetID()]; ok {
+					candidates = append(candidates, fromTenantValue(tenant, t.Name, value, p.ProjectName, []string{environmentID}))
+				}
+			}
+		}
+	}
+	for _, id := range project.IncludedLibraryVariableSets {
+		l, ok := tenantVariables.LibraryVariables[id]
+		if !ok {
+			continue
+		}
+		for _, t := range l.Templates {
+			candidates = appendTemplateDefault(candidates, t.Name, t.DefaultValue, l.LibraryVariableSetName)
+			if value, ok := l.Variables[t.GetID()]; ok {
+				candidates = append(candidates, fromTenantValue(tenant, t.Name, value, l.LibraryVariableSetName, nil))
+			}
+		}
+	}
+	return candidates, nil
+}
+
+func fromVariable(v *variables.Variable, source Source, sourceName string) *Candidate {
+	return &Candidate{
+		Name:        v.Name,
+		Value:       v.Value,
+		IsSensitive: v.IsSensitive,
+		Source:      source,
+		SourceName:  sourceName,
+		Scope:       v.Scope,
+	}
+}
+
+func fromTenantValue(tenant *tenants.Tenant, name string, value core.PropertyValue, sourceName string, environmentIDs []string) *Candidate {
+	return &Candidate{
+		Name:        name,
+		Value:       value.Value,
+		IsSensitive: value.IsSensitive,
+		Source:      SourceTenant,
+		SourceName:  sourceName,
+		Scope:       variables.VariableScope{Environments: environmentIDs},
+		TenantID:    tenant.GetID(),
+	}
+}
+
+func appendTemplateDefault(candidates []*Candidate, name string, defaultValue *core.PropertyValue, sourceName string) []*Candidate {
+	if defaultValue == nil {
+		return candidates
+	}
+	return append(candidates, &Candidate{
+		Name:        name,
+		Value:       defaultValue.Value,
+		IsSensitive: defaultValue.IsSensitive,
+		Source:      SourceTemplateDefault,
+		SourceName:  sourceName,
+	})
+}
+
+func addNames(names map[string]string, scopeValues *variables.VariableScopeValues) {
+	if scopeValues == nil {
+		return
+	}
+	for _, items := range [][]*resources.ReferenceDataItem{scopeValues.Environments, scopeValues.Channels, scopeValues.Machines, scopeValues.Actions} {
+		for _, item := range items {
+			names[item.ID] = item.Name
+		}
+	}
+	for _, item := range scopeValues.Processes {
+		names[item.ID] = item.Name
+	}
+}
+
+func findScopeValue(kind string, value string, items []*resources.ReferenceDataItem) (string, error) {
+	for _, item := range items {
+		if strings.EqualFold(value, item.ID) || strings.EqualFold(value, item.Name) {
+			return item.ID, nil
+		}
+	}
+	return "", fmt.Errorf("cannot find %s '%s'", kind, value)
+}
+
+func referenceName(id string, items []*resources.ReferenceDataItem) string {
+	for _, item := range items {
+		if item.ID == id {
+			return item.Name
+		}
+	}
+	return id
+}
@@ -0,0 +1,260 @@
+package preview
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+)
+
+// Context is the deployment being previewed. Fields left empty were not given, so
+// variables scoped to them do not apply.
+type Context struct {
+	EnvironmentID  string
+	ChannelID      string
+	MachineID      string
+	ActionID       string
+	ProcessOwnerID string
+	TenantID       string
+	TenantTags     []string
+	Roles          []string
+}
+
+type Source int
+
+// Sources in order of precedence, for values that are equally specific.
+const (
+	SourceProject Source = iota
+	SourceLibraryVariableSet
+	SourceTenant
+	SourceTemplateDefault
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceProject:
+		return "project"
+	case SourceLibraryVariableSet:
+		return "library variable set"
+	case SourceTenant:
+		return "tenant"
+	default:
+		return "template default"
+	}
+}
+
+// Candidate is one value that a variable could take.
+type Candidate struct {
+	Name        string
+	Value       string
+	IsSensitive bool
+	Source      Source
+	// the library variable set or project the value comes from
+	SourceName string
+	Scope      variables.VariableScope
+	// set for values given by a tenant, which only apply to that tenant
+	TenantID string
+}
+
+// Outcome says whether a candidate won and, when it did not, why.
+type Outcome struct {
+	Candidate *Candidate
+	Winner    bool
+	Reason    string
+}
+
+// Resolution is the value a variable takes in a Context.
+type Resolution struct {
+	Name string
+	// the winning value with #{...} references expanded
+	Value    string
+	Winner   *Candidate
+	Outcomes []*Outcome
+}
+
+type dimension struct {
+	name      string
+	candidate func(c *Candidate) []string
+	context   func(c *Context) []string
+}
+
+func single(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+// dimensions are ordered from the most to the least specific. A value scoped to a more
+// specific dimension beats one that is not, whatever else either is scoped to.
+var dimensions = []dimension{
+	{"target", func(c *Candidate) []string { return c.Scope.Machines }, func(c *Context) []string { return single(c.MachineID) }},
+	{"step", func(c *Candidate) []string { return c.Scope.Actions }, func(c *Context) []string { return single(c.ActionID) }},
+	{"role", func(c *Candidate) []string { return c.Scope.Roles }, func(c *Context) []string { return c.Roles }},
+	{"tenant", func(c *Candidate) []string { return single(c.TenantID) }, func(c *Context) []string { return single(c.TenantID) }},
+	{"tenant tag", func(c *Candidate) []string { return c.Scope.TenantTags }, func(c *Context) []string { return c.TenantTags }},
+	{"environment", func(c *Candidate) []string { return c.Scope.Environments }, func(c *Context) []string { return single(c.EnvironmentID) }},
+	{"channel", func(c *Candidate) []string { return c.Scope.Channels }, func(c *Context) []string { return single(c.ChannelID) }},
+	{"process", func(c *Candidate) []string { return c.Scope.ProcessOwners }, func(c *Context) []string { return single(c.ProcessOwnerID) }},
+}
+
+// Resolve works out the value of every variable in candidates for context, the way
+// Octopus does during a deployment. names maps scope IDs to names for the reasons given,
+// and system holds the Octopus system variables known for the context, which #{...}
+// references can use.
+func Resolve(candidates []*Candidate, context *Context, names map[string]string, system map[string]string) []*Resolution {
+	var keys []string
+	byName := map[string][]*Candidate{}
+	for _, c := range candidates {
+		key := strings.ToLower(c.Name)
+		if _, ok := byName[key]; !ok {
+			keys = append(keys, key)
+		}
+		byName[key] = append(byName[key], c)
+	}
+	sort.Strings(keys)
+
+	var resolutions []*Resolution
+	for _, key := range keys {
+		resolutions = append(resolutions, resolveVariable(byName[key], context, names))
+	}
+
+	values := map[string]*Candidate{}
+	for _, r := range resolutions {
+		if r.Winner != nil {
+			values[strings.ToLower(r.Name)] = r.Winner
+		}
+	}
+	for _, r := range resolutions {
+		switch {
+		case r.Winner == nil:
+		case r.Winner.IsSensitive:
+			r.Value = "***"
+		default:
+			r.Value = expand(r.Winner.Value, values, system, map[string]bool{strings.ToLower(r.Name): true})
+		}
+	}
+	return resolutions
+}
+
+func resolveVariable(candidates []*Candidate, context *Context, names map[string]string) *Resolution {
+	resolution := &Resolution{Name: candidates[0].Name}
+
+	var applicable []*Candidate
+	var notApplicable []*Outcome
+	for _, c := range candidates {
+		if reason := notApplicableReason(c, context, names); reason != "" {
+			notApplicable = append(notApplicable, &Outcome{Candidate: c, Reason: reason})
+		} else {
+			applicable = append(applicable, c)
+		}
+	}
+
+	sort.SliceStable(applicable, func(i, j int) bool { return compare(applicable[i], applicable[j]) < 0 })
+	for i, c := range applicable {
+		if i == 0 {
+			resolution.Winner = c
+			resolution.Outcomes = append(resolution.Outcomes, &Outcome{Candidate: c, Winner: true})
+			continue
+		}
+		resolution.Outcomes = append(resolution.Outcomes, &Outcome{Candidate: c, Reason: lostReason(applicable[0], c)})
+	}
+	resolution.Outcomes = append(resolution.Outcomes, notApplicable...)
+	return resolution
+}
+
+func notApplicableReason(c *Candidate, context *Context, names map[string]string) string {
+	for _, d := range dimensions {
+		scoped := d.candidate(c)
+		if len(scoped) == 0 {
+			continue
+		}
+		given := d.context(context)
+		if len(given) == 0 {
+			return fmt.Sprintf("scoped to a %s, but no %s was given", d.name, d.name)
+		}
+		if !util.SliceContainsAny(scoped, func(id string) bool { return util.SliceContains(given, id) }) {
+			return fmt.Sprintf("only applies to %s %s", d.name, strings.Join(displayNames(scoped, names), ", "))
+		}
+	}
+	return ""
+}
+
+// compare is negative when a takes precedence over b.
+func compare(a *Candidate, b *Candidate) int {
+	for _, d := range dimensions {
+		aScoped, bScoped := len(d.candidate(a)) > 0, len(d.candidate(b)) > 0
+		if aScoped && !bScoped {
+			return -1
+		}
+		if bScoped && !aScoped {
+			return 1
+		}
+	}
+	return int(a.Source) - int(b.Source)
+}
+
+func lostReason(winner *Candidate, loser *Candidate) string {
+	for _, d := range dimensions {
+		if len(d.candidate(winner)) > 0 && len(d.candidate(loser)) == 0 {
+			return fmt.Sprintf("less specific: the winner is scoped to a %s", d.name)
+		}
+	}
+	if winner.Source != loser.Source {
+		return fmt.Sprintf("as specific as the winner, but %s values take precedence over %s values", winner.Source, loser.Source)
+	}
+	return "as specific as the winner, which is defined first"
+}
+
+// references are #{Name} with nothing but a variable name between the braces; filters and
+// expressions such as #{if Name}...#{/if} are left as they are.
+var reference = regexp.MustCompile(`#\{\s*([^}|\s][^}|]*?)\s*\}`)
+
+func expand(value string, values map[string]*Candidate, system map[string]string, expanding map[string]bool) string {
+	return reference.ReplaceAllStringFunc(value, func(match string) string {
+		name := reference.FindStringSubmatch(match)[1]
+		key := strings.ToLower(name)
+		if c, ok := values[key]; ok {
+			// sensitive values are never shown, and a reference back to a variable being
+			// expanded would never finish
+			if c.IsSensitive || expanding[key] {
+				return match
+			}
+			expanding[key] = true
+			defer delete(expanding, key)
+			return expand(c.Value, values, system, expanding)
+		}
+		for k, v := range system {
+			if strings.EqualFold(k, name) {
+				return v
+			}
+		}
+		return match
+	})
+}
+
+// DescribeScope lists the scope of a candidate by name.
+func DescribeScope(c *Candidate, names map[string]string) string {
+	var parts []string
+	for _, d := range dimensions {
+		if values := d.candidate(c); len(values) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %s", d.name, strings.Join(displayNames(values, names), ", ")))
+		}
+	}
+	if len(parts) == 0 {
+		return "unscoped"
+	}
+	return strings.Join(parts, "; ")
+}
+
+func displayNames(ids []string, names map[string]string) []string {
+	return util.SliceTransform(ids, func(id string) string {
+		if name, ok := names[id]; ok {
+			return name
+		}
+		return id
+	})
+}
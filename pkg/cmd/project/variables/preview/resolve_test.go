@@ -0,0 +1,73 @@
+package preview_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/project/variables/preview"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var names = map[string]string{
+	"Environments-1": "Dev",
+	"Environments-2": "Prod",
+	"Machines-1":     "web-01",
+}
+
+func TestResolve_MostSpecificScopeWins(t *testing.T) {
+	candidates := []*preview.Candidate{
+		{Name: "LogLevel", Value: "Info", Source: preview.SourceProject},
+		{Name: "LogLevel", Value: "Warn", Source: preview.SourceProject, Scope: variables.VariableScope{Environments: []string{"Environments-2"}}},
+		{Name: "LogLevel", Value: "Debug", Source: preview.SourceProject, Scope: variables.VariableScope{Environments: []string{"Environments-1"}}},
+		{Name: "loglevel", Value: "Trace", Source: preview.SourceLibraryVariableSet, Scope: variables.VariableScope{Machines: []string{"Machines-1"}, Environments: []string{"Environments-1"}}},
+		{Name: "LogLevel", Value: "Error", Source: preview.SourceProject, Scope: variables.VariableScope{Actions: []string{"Actions-1"}}},
+	}
+
+	resolutions := preview.Resolve(candidates, &preview.Context{EnvironmentID: "Environments-1", MachineID: "Machines-1"}, names, nil)
+	require.Len(t, resolutions, 1)
+	r := resolutions[0]
+
+	assert.Equal(t, "Trace", r.Value)
+	require.Len(t, r.Outcomes, 5)
+	assert.True(t, r.Outcomes[0].Winner)
+	assert.Equal(t, "target: web-01; environment: Dev", preview.DescribeScope(r.Outcomes[0].Candidate, names))
+	assert.Equal(t, "less specific: the winner is scoped to a target", r.Outcomes[1].Reason)
+	assert.Equal(t, "Debug", r.Outcomes[1].Candidate.Value)
+	assert.Equal(t, "less specific: the winner is scoped to a target", r.Outcomes[2].Reason)
+	assert.Equal(t, "only applies to environment Prod", r.Outcomes[3].Reason)
+	assert.Equal(t, "scoped to a step, but no step was given", r.Outcomes[4].Reason)
+}
+
+func TestResolve_EquallySpecificProjectValueBeatsLibraryVariableSet(t *testing.T) {
+	candidates := []*preview.Candidate{
+		{Name: "Url", Value: "https://library", Source: preview.SourceLibraryVariableSet, SourceName: "Shared"},
+		{Name: "Url", Value: "https://project", Source: preview.SourceProject, SourceName: "Web"},
+		{Name: "Url", Value: "https://tenant", Source: preview.SourceTenant, TenantID: "Tenants-2"},
+	}
+
+	resolutions := preview.Resolve(candidates, &preview.Context{TenantID: "Tenants-1"}, names, nil)
+	require.Len(t, resolutions, 1)
+
+	assert.Equal(t, "https://project", resolutions[0].Value)
+	assert.Equal(t, "as specific as the winner, but project values take precedence over library variable set values", resolutions[0].Outcomes[1].Reason)
+	assert.Equal(t, "only applies to tenant Tenants-2", resolutions[0].Outcomes[2].Reason)
+}
+
+func TestResolve_ExpandsReferences(t *testing.T) {
+	candidates := []*preview.Candidate{
+		{Name: "ConnectionString", Value: "server=#{ Server };db=#{Database};password=#{Password};#{Unknown}"},
+		{Name: "Server", Value: "sql-#{Octopus.Environment.Name | ToLower}"},
+		{Name: "Database", Value: "#{Octopus.Project.Name}-#{Loop}"},
+		{Name: "Loop", Value: "#{Database}"},
+		{Name: "Password", Value: "secret", IsSensitive: true},
+	}
+
+	resolutions := preview.Resolve(candidates, &preview.Context{}, names, map[string]string{"Octopus.Project.Name": "Web"})
+	require.Len(t, resolutions, 5)
+
+	assert.Equal(t, "ConnectionString", resolutions[0].Name)
+	assert.Equal(t, "server=sql-#{Octopus.Environment.Name | ToLower};db=Web-#{Database};password=#{Password};#{Unknown}", resolutions[0].Value)
+	assert.Equal(t, "Password", resolutions[3].Name)
+	assert.Equal(t, "***", resolutions[3].Value)
+}
@@ -0,0 +1,229 @@
+package shared
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+)
+
+// VariableChange pairs a variable the project has with the variable replacing it.
+type VariableChange struct {
+	Before *variables.Variable
+	After  *variables.Variable
+}
+
+// VariablesDiff is the difference between the variables a project has and the variables
+// it should have. Variables are matched by name and scope, so changing the scope of a
+// variable shows up as removing it and adding another.
+type VariablesDiff struct {
+	Added     []*variables.Variable
+	Changed   []*VariableChange
+	Removed   []*variables.Variable
+	Unchanged []*VariableChange
+}
+
+// DiffVariables works out what has to change to turn current into incoming. A sensitive
+// variable in incoming without a value keeps the value it already has.
+func DiffVariables(current []*variables.Variable, incoming []*variables.Variable) (*VariablesDiff, error) {
+	diff := &VariablesDiff{}
+	matched := make([]bool, len(current))
+	for _, after := range incoming {
+		var before *variables.Variable
+		for i, c := range current {
+			if !matched[i] && variableKey(c) == variableKey(after) {
+				before = c
+				matched[i] = true
+				break
+			}
+		}
+
+		if before == nil {
+			if after.IsSensitive && after.Value == "" {
+				return nil, fmt.Errorf("sensitive variable '%s' is new, so it must be given a value", after.Name)
+			}
+			diff.Added = append(diff.Added, after)
+			continue
+		}
+
+		after.ID = before.ID
+		change := &VariableChange{Before: before, After: after}
+		if len(changedFields(before, after)) > 0 {
+			diff.Changed = append(diff.Changed, change)
+		} else {
+			diff.Unchanged = append(diff.Unchanged, change)
+		}
+	}
+	for i, c := range current {
+		if !matched[i] {
+			diff.Removed = append(diff.Removed, c)
+		}
+	}
+	return diff, nil
+}
+
+func (d *VariablesDiff) HasChanges() bool {
+	return len(d.Added) > 0 || len(d.Changed) > 0 || len(d.Removed) > 0
+}
+
+// HasChangesTo reports whether the diff adds, changes or removes a sensitive variable, or one
+// which is not sensitive, as those are saved in different sets by version-controlled projects.
+func (d *VariablesDiff) HasChangesTo(sensitive bool) bool {
+	for _, v := range d.Added {
+		if v.IsSensitive == sensitive {
+			return true
+		}
+	}
+	for _, v := range d.Removed {
+		if v.IsSensitive == sensitive {
+			return true
+		}
+	}
+	for _, c := range d.Changed {
+		if c.Before.IsSensitive == sensitive || c.After.IsSensitive == sensitive {
+			return true
+		}
+	}
+	return false
+}
+
+// Variables returns the variables the project has once the diff is applied. Variables
+// that are kept retain their IDs, which lets the server keep their sensitive values.
+func (d *VariablesDiff) Variables() []*variables.Variable {
+	result := []*variables.Variable{}
+	for _, c := range d.Unchanged {
+		result = append(result, c.Before)
+	}
+	for _, c := range d.Changed {
+		result = append(result, c.After)
+	}
+	return append(result, d.Added...)
+}
+
+// Format describes the diff line by line, in name order, with scopes shown by name.
+func (d *VariablesDiff) Format(variableSet *variables.VariableSet, project *projects.Project) string {
+	references := scopeReferences(variableSet, project)
+	describe := func(v *variables.Variable) string {
+		scope := describeScope(v.Scope, references)
+		if scope == "" {
+			return v.Name
+		}
+		return fmt.Sprintf("%s [%s]", v.Name, scope)
+	}
+
+	type line struct {
+		name string
+		text string
+	}
+	var lines []line
+	for _, v := range d.Added {
+		lines = append(lines, line{v.Name, output.Greenf("+ %s = %s", describe(v), displayValue(v))})
+	}
+	for _, c := range d.Changed {
+		var fields []string
+		for _, field := range changedFields(c.Before, c.After) {
+			if field == "value" {
+				field = fmt.Sprintf("value %s -> %s", displayValue(c.Before), displayValue(c.After))
+			}
+			fields = append(fields, field)
+		}
+		lines = append(lines, line{c.After.Name, output.Yellowf("~ %s: %s", describe(c.After), strings.Join(fields, ", "))})
+	}
+	for _, v := range d.Removed {
+		lines = append(lines, line{v.Name, output.Redf("- %s", describe(v))})
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return strings.ToLower(lines[i].name) < strings.ToLower(lines[j].name)
+	})
+
+	var result strings.Builder
+	for _, l := range lines {
+		result.WriteString(l.text)
+		result.WriteString("\n")
+	}
+	result.WriteString(fmt.Sprintf("%d to add, %d to change, %d to remove\n", len(d.Added), len(d.Changed), len(d.Removed)))
+	return result.String()
+}
+
+// changedFields lists what differs between two variables with the same name and scope.
+func changedFields(before *variables.Variable, after *variables.Variable) []string {
+	var fields []string
+	if before.Type != after.Type {
+		fields = append(fields, "type")
+	}
+	if after.IsSensitive {
+		// the server never returns sensitive values, so a value given in the file is always an update
+		if after.Value != "" {
+			fields = append(fields, "value")
+		}
+	} else if before.Value != after.Value {
+		fields = append(fields, "value")
+	}
+	if before.Description != after.Description {
+		fields = append(fields, "description")
+	}
+	if !reflect.DeepEqual(normalizePrompt(before.Prompt), normalizePrompt(after.Prompt)) {
+		fields = append(fields, "prompt")
+	}
+	return fields
+}
+
+func normalizePrompt(prompt *variables.VariablePromptOptions) *variables.VariablePromptOptions {
+	if prompt == nil {
+		return nil
+	}
+	result := *prompt
+	if result.DisplaySettings != nil && result.DisplaySettings.ControlType == "" && len(result.DisplaySettings.SelectOptions) == 0 {
+		result.DisplaySettings = nil
+	}
+	return &result
+}
+
+func displayValue(v *variables.Variable) string {
+	if v.IsSensitive {
+		return "***"
+	}
+	if v.Value == "" {
+		return output.Dim("(no value)")
+	}
+	return fmt.Sprintf("%q", v.Value)
+}
+
+func describeScope(scope variables.VariableScope, references *references) string {
+	var parts []string
+	add := func(label string, names []string) {
+		if len(names) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %s", label, strings.Join(names, ", ")))
+		}
+	}
+	add("Environments", scopeNames(scope.Environments, references.Environments))
+	add("Channels", scopeNames(scope.Channels, references.Channels))
+	add("Targets", scopeNames(scope.Machines, references.Machines))
+	add("Steps", scopeNames(scope.Actions, references.Actions))
+	add("Roles", scope.Roles)
+	add("Tags", scope.TenantTags)
+	add("Processes", scopeNames(scope.ProcessOwners, references.Processes))
+	return strings.Join(parts, "; ")
+}
+
+func variableKey(v *variables.Variable) string {
+	return strings.ToLower(v.Name) + "|" + scopeKey(v.Scope)
+}
+
+// scopeKey is the same for scopes with the same values, whatever order they are in.
+func scopeKey(scope variables.VariableScope) string {
+	var parts []string
+	for _, values := range [][]string{scope.Environments, scope.Channels, scope.Machines, scope.Actions, scope.Roles, scope.TenantTags, scope.ProcessOwners} {
+		sorted := make([]string, len(values))
+		for i, v := range values {
+			sorted[i] = strings.ToLower(v)
+		}
+		sort.Strings(sorted)
+		parts = append(parts, strings.Join(sorted, ","))
+	}
+	return strings.Join(parts, "|")
+}
@@ -0,0 +1,383 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/output"
+	sharedVariable "github.com/OctopusDeploy/cli/pkg/question/shared/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatJson = "json"
+	FormatYaml = "yaml"
+
+	// ProcessScopeDeployment is how the deployment process of the project is named in process scopes.
+	ProcessScopeDeployment = "deployment"
+
+	variableTypeSensitive = "Sensitive"
+)
+
+var Formats = []string{FormatJson, FormatYaml}
+
+// VariablesDocument is the portable form of a project's variables. Scopes are held by
+// name rather than ID so the document can be kept in source control and read by people.
+// Values of sensitive variables are never included, as the server does not return them.
+type VariablesDocument struct {
+	Variables []*VariableDocument `json:"Variables" yaml:"Variables"`
+}
+
+type VariableDocument struct {
+	Name        string          `json:"Name" yaml:"Name"`
+	Value       string          `json:"Value,omitempty" yaml:"Value,omitempty"`
+	Type        string          `json:"Type,omitempty" yaml:"Type,omitempty"`
+	Description string          `json:"Description,omitempty" yaml:"Description,omitempty"`
+	Scope       *ScopeDocument  `json:"Scope,omitempty" yaml:"Scope,omitempty"`
+	Prompt      *PromptDocument `json:"Prompt,omitempty" yaml:"Prompt,omitempty"`
+}
+
+type ScopeDocument struct {
+	Environments []string `json:"Environments,omitempty" yaml:"Environments,omitempty"`
+	Channels     []string `json:"Channels,omitempty" yaml:"Channels,omitempty"`
+	Targets      []string `json:"Targets,omitempty" yaml:"Targets,omitempty"`
+	Steps        []string `json:"Steps,omitempty" yaml:"Steps,omitempty"`
+	Roles        []string `json:"Roles,omitempty" yaml:"Roles,omitempty"`
+	Tags         []string `json:"Tags,omitempty" yaml:"Tags,omitempty"`
+	Processes    []string `json:"Processes,omitempty" yaml:"Processes,omitempty"`
+}
+
+type PromptDocument struct {
+	Label         string                  `json:"Label" yaml:"Label"`
+	Description   string                  `json:"Description,omitempty" yaml:"Description,omitempty"`
+	Required      bool                    `json:"Required,omitempty" yaml:"Required,omitempty"`
+	ControlType   string                  `json:"ControlType,omitempty" yaml:"ControlType,omitempty"`
+	SelectOptions []*SelectOptionDocument `json:"SelectOptions,omitempty" yaml:"SelectOptions,omitempty"`
+}
+
+type SelectOptionDocument struct {
+	Value       string `json:"Value" yaml:"Value"`
+	DisplayName string `json:"DisplayName" yaml:"DisplayName"`
+}
+
+// FormatFromFileName infers a document format from a file extension, returning
+// an empty string when the extension is not recognised.
+func FormatFromFileName(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".json":
+		return FormatJson
+	case ".yaml", ".yml":
+		return FormatYaml
+	}
+	return ""
+}
+
+func MarshalDocument(document *VariablesDocument, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case FormatJson:
+		return json.MarshalIndent(document, "", "  ")
+	case FormatYaml:
+		return yaml.Marshal(document)
+	}
+	return nil, fmt.Errorf("unsupported format '%s'. Valid values are %s", format, output.FormatAsList(Formats))
+}
+
+func UnmarshalDocument(data []byte, format string) (*VariablesDocument, error) {
+	document := &VariablesDocument{}
+	switch strings.ToLower(format) {
+	case FormatJson:
+		if err := json.Unmarshal(data, document); err != nil {
+			return nil, err
+		}
+	case FormatYaml:
+		if err := yaml.Unmarshal(data, document); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format '%s'. Valid values are %s", format, output.FormatAsList(Formats))
+	}
+	return document, nil
+}
+
+// ToDocument converts the variables of a project to their portable form, sorted by
+// name and scope so that exporting the same variables twice gives the same document.
+func ToDocument(variableSet *variables.VariableSet, project *projects.Project) *VariablesDocument {
+	references := scopeReferences(variableSet, project)
+	document := &VariablesDocument{Variables: []*VariableDocument{}}
+	for _, v := range variableSet.Variables {
+		item := &VariableDocument{
+			Name:        v.Name,
+			Type:        v.Type,
+			Description: v.Description,
+		}
+		if !v.IsSensitive {
+			item.Value = v.Value
+		} else {
+			item.Type = variableTypeSensitive
+		}
+		if !v.Scope.IsEmpty() {
+			item.Scope = &ScopeDocument{
+				Environments: scopeNames(v.Scope.Environments, references.Environments),
+				Channels:     scopeNames(v.Scope.Channels, references.Channels),
+				Targets:      scopeNames(v.Scope.Machines, references.Machines),
+				Steps:        scopeNames(v.Scope.Actions, references.Actions),
+				Roles:        v.Scope.Roles,
+				Tags:         v.Scope.TenantTags, // tags are identified by their canonical name, which is already readable
+				Processes:    scopeNames(v.Scope.ProcessOwners, references.Processes),
+			}
+		}
+		if v.Prompt != nil {
+			item.Prompt = &PromptDocument{
+				Label:       v.Prompt.Label,
+				Description: v.Prompt.Description,
+				Required:    v.Prompt.IsRequired,
+			}
+			if v.Prompt.DisplaySettings != nil {
+				item.Prompt.ControlType = string(v.Prompt.DisplaySettings.ControlType)
+				for _, o := range v.Prompt.DisplaySettings.SelectOptions {
+					item.Prompt.SelectOptions = append(item.Prompt.SelectOptions, &SelectOptionDocument{Value: o.Value, DisplayName: o.DisplayName})
+				}
+			}
+		}
+		document.Variables = append(document.Variables, item)
+	}
+
+	sort.SliceStable(document.Variables, func(i, j int) bool {
+		a, b := document.Variables[i], document.Variables[j]
+		if !strings.EqualFold(a.Name, b.Name) {
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+		return a.Scope.key() < b.Scope.key()
+	})
+	return document
+}
+
+// FromDocument converts variables in their portable form back to variables of the project,
+// resolving scope names to IDs. The variables returned have no IDs; see DiffVariables for
+// matching them up with the variables the project already has.
+func FromDocument(document *VariablesDocument, variableSet *variables.VariableSet, project *projects.Project) ([]*variables.Variable, error) {
+	references := scopeReferences(variableSet, project)
+	result := []*variables.Variable{}
+	for i, item := range document.Variables {
+		if strings.TrimSpace(item.Name) == "" {
+			return nil, fmt.Errorf("variable %d has no name", i+1)
+		}
+
+		v := variables.NewVariable(item.Name)
+		v.Description = item.Description
+		v.Value = item.Value
+		if item.Type != "" {
+			v.Type = item.Type
+		}
+		v.IsSensitive = v.Type == variableTypeSensitive
+
+		if item.Scope != nil {
+			scope, err := item.Scope.toVariableScope(references)
+			if err != nil {
+				return nil, fmt.Errorf("variable '%s': %w", item.Name, err)
+			}
+			v.Scope = *scope
+		}
+
+		if item.Prompt != nil {
+			v.Prompt = &variables.VariablePromptOptions{
+				Label:       item.Prompt.Label,
+				Description: item.Prompt.Description,
+				IsRequired:  item.Prompt.Required,
+			}
+			if item.Prompt.ControlType != "" {
+				var options []*resources.SelectOption
+				for _, o := range item.Prompt.SelectOptions {
+					options = append(options, &resources.SelectOption{Value: o.Value, DisplayName: o.DisplayName})
+				}
+				v.Prompt.DisplaySettings = resources.NewDisplaySettings(resources.ControlType(item.Prompt.ControlType), options)
+			}
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+type references struct {
+	Environments []*resources.ReferenceDataItem
+	Channels     []*resources.ReferenceDataItem
+	Machines     []*resources.ReferenceDataItem
+	Actions      []*resources.ReferenceDataItem
+	Roles        []*resources.ReferenceDataItem
+	TenantTags   []*resources.ReferenceDataItem
+	Processes    []*resources.ReferenceDataItem
+}
+
+func scopeReferences(variableSet *variables.VariableSet, project *projects.Project) *references {
+	result := &references{}
+	if variableSet.ScopeValues != nil {
+		result.Environments = variableSet.ScopeValues.Environments
+		result.Channels = variableSet.ScopeValues.Channels
+		result.Machines = variableSet.ScopeValues.Machines
+		result.Actions = variableSet.ScopeValues.Actions
+		result.Roles = variableSet.ScopeValues.Roles
+		result.TenantTags = variableSet.ScopeValues.TenantTags
+		result.Processes = ConvertProcessScopesToReference(variableSet.ScopeValues.Processes)
+	}
+	result.Processes = append(result.Processes, &resources.ReferenceDataItem{ID: project.GetID(), Name: ProcessScopeDeployment})
+	return result
+}
+
+// scopeNames returns the names of the scope values with the given IDs, falling back to
+// the ID for anything that is no longer known to the server.
+func scopeNames(ids []string, items []*resources.ReferenceDataItem) []string {
+	var names []string
+	for _, id := range ids {
+		name := id
+		for _, i := range items {
+			if strings.EqualFold(i.ID, id) {
+				name = i.Name
+				break
+			}
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func (s *ScopeDocument) toVariableScope(references *references) (*variables.VariableScope, error) {
+	scope := &variables.VariableScope{}
+	var err error
+	if scope.Environments, err = buildSingleScope(s.Environments, references.Environments); err != nil {
+		return nil, err
+	}
+	if scope.Channels, err = buildSingleScope(s.Channels, references.Channels); err != nil {
+		return nil, err
+	}
+	if scope.Machines, err = buildSingleScope(s.Targets, references.Machines); err != nil {
+		return nil, err
+	}
+	if scope.Actions, err = buildSingleScope(s.Steps, references.Actions); err != nil {
+		return nil, err
+	}
+	if scope.TenantTags, err = buildSingleScope(s.Tags, references.TenantTags); err != nil {
+		return nil, err
+	}
+	if scope.ProcessOwners, err = buildSingleScope(s.Processes, references.Processes); err != nil {
+		return nil, err
+	}
+	// roles are free text, so a new role can be introduced by scoping a variable to it
+	scope.Roles = append(scope.Roles, s.Roles...)
+
+	scope.Environments = emptyToNil(scope.Environments)
+	scope.Channels = emptyToNil(scope.Channels)
+	scope.Machines = emptyToNil(scope.Machines)
+	scope.Actions = emptyToNil(scope.Actions)
+	scope.TenantTags = emptyToNil(scope.TenantTags)
+	scope.ProcessOwners = emptyToNil(scope.ProcessOwners)
+	return scope, nil
+}
+
+func (s *ScopeDocument) key() string {
+	if s == nil {
+		return ""
+	}
+	return scopeKey(variables.VariableScope{
+		Environments:  s.Environments,
+		Channels:      s.Channels,
+		Machines:      s.Targets,
+		Actions:       s.Steps,
+		Roles:         s.Roles,
+		TenantTags:    s.Tags,
+		ProcessOwners: s.Processes,
+	})
+}
+
+func emptyToNil(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// ResolveGitRef works out which git reference the variables of a version-controlled project
+// are read from and written to, defaulting to the project's default branch. Database projects
+// have a single set of variables, so asking for a git reference on one is an error.
+func ResolveGitRef(project *projects.Project, gitRef string) (string, error) {
+	if !project.IsVersionControlled {
+		if gitRef != "" {
+			return "", fmt.Errorf("project '%s' is not version controlled, so a git reference cannot be used", project.GetName())
+		}
+		return "", nil
+	}
+	if gitRef != "" {
+		return gitRef, nil
+	}
+	return project.PersistenceSettings.(projects.GitPersistenceSettings).DefaultBranch(), nil
+}
+
+// ProjectVariables are all the variables of a project. A version-controlled project keeps its
+// sensitive variables in the database and the rest in git, so they are read from and saved to
+// both sets; a database project only has the database set.
+type ProjectVariables struct {
+	Database *variables.VariableSet
+	Git      *variables.VariableSet
+	GitRef   string
+}
+
+// GetProjectVariables reads the variables of a project, from the given git reference as well
+// as the database when the project is version controlled.
+func GetProjectVariables(callbacks *sharedVariable.VariableCallbacks, spaceID string, project *projects.Project, gitRef string) (*ProjectVariables, error) {
+	gitRef, err := ResolveGitRef(project, gitRef)
+	if err != nil {
+		return nil, err
+	}
+	result := &ProjectVariables{GitRef: gitRef}
+	if result.Database, err = callbacks.GetProjectVariables(project.GetID()); err != nil {
+		return nil, err
+	}
+	if gitRef != "" {
+		if result.Git, err = callbacks.GetProjectVariablesByGitRef(spaceID, project.GetID(), gitRef); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// VariableSet is every variable of the project as a single set, with the scope values of the
+// set most of them are saved in.
+func (p *ProjectVariables) VariableSet() *variables.VariableSet {
+	set := *p.Database
+	set.Variables = append([]*variables.Variable{}, p.Database.Variables...)
+	if p.Git != nil {
+		set.ScopeValues = p.Git.ScopeValues
+		set.Variables = append(set.Variables, p.Git.Variables...)
+	}
+	return &set
+}
+
+// Split divides the variables of a project between the sets they are saved in. A variable which
+// moves from one set to the other loses its ID, as IDs only mean something within a set.
+func (p *ProjectVariables) Split(all []*variables.Variable) ([]*variables.Variable, []*variables.Variable) {
+	if p.Git == nil {
+		return all, nil
+	}
+	databaseIDs := map[string]bool{}
+	for _, v := range p.Database.Variables {
+		databaseIDs[v.ID] = true
+	}
+
+	database, git := []*variables.Variable{}, []*variables.Variable{}
+	for _, v := range all {
+		if v.ID != "" && databaseIDs[v.ID] != v.IsSensitive {
+			v.ID = ""
+		}
+		if v.IsSensitive {
+			database = append(database, v)
+		} else {
+			git = append(git, v)
+		}
+	}
+	return database, git
+}
@@ -0,0 +1,181 @@
+package shared_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/project/variables/shared"
+	sharedVariable "github.com/OctopusDeploy/cli/pkg/question/shared/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDocumentFixtures() (*projects.Project, *variables.VariableSet) {
+	project := projects.NewProject("Fire Project", "Lifecycles-1", "ProjectGroups-1")
+	project.ID = "Projects-1"
+
+	newVariable := func(id string, name string, value string) *variables.Variable {
+		v := variables.NewVariable(name)
+		v.ID = id
+		v.Value = value
+		return v
+	}
+
+	logLevel := newVariable("1", "LogLevel", "Info")
+	logLevel.Scope.Environments = []string{"Environments-1"}
+	logLevel.Scope.ProcessOwners = []string{"Projects-1"}
+
+	password := newVariable("2", "Password", "")
+	password.Type = "Sensitive"
+	password.IsSensitive = true
+
+	connectionString := newVariable("3", "ConnectionString", "server=dev")
+
+	variableSet := &variables.VariableSet{
+		OwnerID: "Projects-1",
+		ScopeValues: &variables.VariableScopeValues{
+			Environments: []*resources.ReferenceDataItem{{ID: "Environments-1", Name: "Dev"}, {ID: "Environments-2", Name: "Test"}},
+			Processes:    []*resources.ProcessReferenceDataItem{{ID: "Runbooks-1", Name: "Restart"}},
+		},
+		Variables: []*variables.Variable{logLevel, password, connectionString},
+	}
+	return project, variableSet
+}
+
+func TestToDocument_UsesScopeNamesAndOmitsSensitiveValues(t *testing.T) {
+	project, variableSet := newDocumentFixtures()
+
+	data, err := shared.MarshalDocument(shared.ToDocument(variableSet, project), shared.FormatYaml)
+	require.Nil(t, err)
+
+	assert.Equal(t, `Variables:
+    - Name: ConnectionString
+      Value: server=dev
+      Type: String
+    - Name: LogLevel
+      Value: Info
+      Type: String
+      Scope:
+        Environments:
+            - Dev
+        Processes:
+            - deployment
+    - Name: Password
+      Type: Sensitive
+`, string(data))
+}
+
+func TestFromDocument_RoundTripsWithoutChanges(t *testing.T) {
+	project, variableSet := newDocumentFixtures()
+
+	incoming, err := shared.FromDocument(shared.ToDocument(variableSet, project), variableSet, project)
+	require.Nil(t, err)
+
+	diff, err := shared.DiffVariables(variableSet.Variables, incoming)
+	require.Nil(t, err)
+	assert.False(t, diff.HasChanges())
+	assert.Len(t, diff.Variables(), 3)
+}
+
+func TestFromDocument_UnknownScope(t *testing.T) {
+	project, variableSet := newDocumentFixtures()
+	document := &shared.VariablesDocument{Variables: []*shared.VariableDocument{
+		{Name: "LogLevel", Value: "Debug", Scope: &shared.ScopeDocument{Environments: []string{"Production"}}},
+	}}
+
+	_, err := shared.FromDocument(document, variableSet, project)
+	assert.EqualError(t, err, "variable 'LogLevel': cannot find scope value 'Production'")
+}
+
+func TestDiffVariables_AddedChangedAndRemoved(t *testing.T) {
+	project, variableSet := newDocumentFixtures()
+	document := &shared.VariablesDocument{Variables: []*shared.VariableDocument{
+		{Name: "LogLevel", Value: "Debug", Scope: &shared.ScopeDocument{Environments: []string{"dev"}, Processes: []string{"deployment"}}},
+		{Name: "Password", Type: "Sensitive"},
+		{Name: "LogLevel", Value: "Warn", Scope: &shared.ScopeDocument{Environments: []string{"Test"}}},
+	}}
+
+	incoming, err := shared.FromDocument(document, variableSet, project)
+	require.Nil(t, err)
+	diff, err := shared.DiffVariables(variableSet.Variables, incoming)
+	require.Nil(t, err)
+
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, []string{"Environments-2"}, diff.Added[0].Scope.Environments)
+	require.Len(t, diff.Changed, 1)
+	assert.Equal(t, "1", diff.Changed[0].After.ID)
+	assert.Equal(t, "Debug", diff.Changed[0].After.Value)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "ConnectionString", diff.Removed[0].Name)
+	require.Len(t, diff.Unchanged, 1)
+	assert.Equal(t, "2", diff.Unchanged[0].Before.ID)
+
+	assert.Equal(t, "- ConnectionString\n"+
+		"+ LogLevel [Environments: Test] = \"Warn\"\n"+
+		"~ LogLevel [Environments: Dev; Processes: deployment]: value \"Info\" -> \"Debug\"\n"+
+		"1 to add, 1 to change, 1 to remove\n", diff.Format(variableSet, project))
+}
+
+func TestDiffVariables_NewSensitiveVariableNeedsValue(t *testing.T) {
+	project, variableSet := newDocumentFixtures()
+	document := &shared.VariablesDocument{Variables: []*shared.VariableDocument{
+		{Name: "ApiKey", Type: "Sensitive"},
+	}}
+
+	incoming, err := shared.FromDocument(document, variableSet, project)
+	require.Nil(t, err)
+	_, err = shared.DiffVariables(variableSet.Variables, incoming)
+	assert.EqualError(t, err, "sensitive variable 'ApiKey' is new, so it must be given a value")
+}
+
+func TestProjectVariables_VersionControlledKeepsSensitiveVariablesInTheDatabase(t *testing.T) {
+	project, variableSet := newDocumentFixtures()
+	project.IsVersionControlled = true
+	database := &variables.VariableSet{OwnerID: "Projects-1", Variables: variableSet.Variables[1:2]}
+	git := &variables.VariableSet{OwnerID: "Projects-1", ScopeValues: variableSet.ScopeValues, Variables: []*variables.Variable{variableSet.Variables[0], variableSet.Variables[2]}}
+	callbacks := &sharedVariable.VariableCallbacks{
+		GetProjectVariables: func(projectId string) (*variables.VariableSet, error) {
+			return database, nil
+		},
+		GetProjectVariablesByGitRef: func(spaceId string, projectId string, gitRef string) (*variables.VariableSet, error) {
+			assert.Equal(t, "refs/heads/main", gitRef)
+			return git, nil
+		},
+	}
+
+	all, err := shared.GetProjectVariables(callbacks, "Spaces-1", project, "refs/heads/main")
+	require.Nil(t, err)
+	set := all.VariableSet()
+	assert.Equal(t, []string{"ConnectionString", "LogLevel", "Password"}, variableNames(shared.ToDocument(set, project)))
+
+	document := &shared.VariablesDocument{Variables: []*shared.VariableDocument{
+		{Name: "LogLevel", Value: "Debug", Scope: &shared.ScopeDocument{Environments: []string{"Dev"}, Processes: []string{"deployment"}}},
+		{Name: "Password", Type: "Sensitive"},
+		{Name: "ConnectionString", Type: "Sensitive", Value: "server=prod"},
+	}}
+	incoming, err := shared.FromDocument(document, set, project)
+	require.Nil(t, err)
+	diff, err := shared.DiffVariables(set.Variables, incoming)
+	require.Nil(t, err)
+	assert.True(t, diff.HasChangesTo(true))
+	assert.True(t, diff.HasChangesTo(false))
+
+	databaseVariables, gitVariables := all.Split(diff.Variables())
+	require.Len(t, databaseVariables, 2)
+	assert.Equal(t, "2", databaseVariables[0].ID)
+	// the connection string moves into the database, where its git ID means nothing
+	assert.Equal(t, "ConnectionString", databaseVariables[1].Name)
+	assert.Equal(t, "", databaseVariables[1].ID)
+	require.Len(t, gitVariables, 1)
+	assert.Equal(t, "1", gitVariables[0].ID)
+}
+
+func variableNames(document *shared.VariablesDocument) []string {
+	var names []string
+	for _, v := range document.Variables {
+		names = append(names, v.Name)
+	}
+	return names
+}
@@ -0,0 +1,190 @@
+package shared
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+)
+
+const (
+	SyncAdded     = "added"
+	SyncUpdated   = "updated"
+	SyncUnchanged = "unchanged"
+
+	syncStatePermissions = 0600
+)
+
+// SyncChange is what happens to one variable when secrets are synced.
+type SyncChange struct {
+	Name   string
+	Action string
+}
+
+// SyncPlan is the variable set once secrets are synced into it, with what changed.
+type SyncPlan struct {
+	VariableSet  *variables.VariableSet
+	Environments []string
+	Changes      []*SyncChange
+}
+
+// SyncState is kept on the machine that syncs secrets, to tell which have changed since they
+// were last synced. Octopus never returns sensitive values, and nothing derived from them is
+// kept in Octopus, where anyone who can view the variables could check guesses against it.
+type SyncState struct {
+	// Key is random, so that the fingerprints cannot be checked without the state file
+	Key          string            `json:"Key"`
+	Fingerprints map[string]string `json:"Fingerprints"`
+}
+
+// ReadSyncState reads the state file at path, or starts a new one when it does not exist yet.
+func ReadSyncState(path string) (*SyncState, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		return &SyncState{Key: hex.EncodeToString(key), Fingerprints: map[string]string{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := &SyncState{}
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, fmt.Errorf("cannot read the sync state in %s: %w", path, err)
+	}
+	if state.Key == "" {
+		return nil, fmt.Errorf("the sync state in %s has no key", path)
+	}
+	if state.Fingerprints == nil {
+		state.Fingerprints = map[string]string{}
+	}
+	return state, nil
+}
+
+// Write saves the state file, readable only by its owner.
+func (s *SyncState) Write(path string) error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, syncStatePermissions)
+}
+
+// changed records the fingerprint of the value of a variable, and reports whether it differs
+// from the one last recorded. Every value has changed when there is no state.
+func (s *SyncState) changed(ownerID string, name string, scope variables.VariableScope, value string) bool {
+	if s == nil {
+		return true
+	}
+	id := strings.ToLower(ownerID+"/"+name) + "|" + scopeKey(scope)
+	mac := hmac.New(sha256.New, []byte(s.Key))
+	mac.Write([]byte(id + "\n" + value))
+	fingerprint := hex.EncodeToString(mac.Sum(nil))
+
+	changed := s.Fingerprints[id] != fingerprint
+	s.Fingerprints[id] = fingerprint
+	return changed
+}
+
+// PlanSync sets the value of the sensitive variable named after each secret, adding those
+// that do not exist. Only variables scoped to exactly the given environments, and nothing
+// else, are synced, and all other variables are left alone. When there is state, secrets
+// whose value has not changed since they were last synced are left alone too, and the state
+// is updated to be written once the variables are saved.
+func PlanSync(variableSet *variables.VariableSet, secrets map[string]string, environments []string, state *SyncState) (*SyncPlan, error) {
+	var environmentReferences []*resources.ReferenceDataItem
+	if variableSet.ScopeValues != nil {
+		environmentReferences = variableSet.ScopeValues.Environments
+	}
+	environmentIDs, err := buildSingleScope(environments, environmentReferences)
+	if err != nil {
+		return nil, err
+	}
+	scope := variables.VariableScope{Environments: emptyToNil(environmentIDs)}
+
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	plan := &SyncPlan{VariableSet: variableSet, Environments: environments}
+	for _, name := range names {
+		value := secrets[name]
+		matching := util.SliceFilter(variableSet.Variables, func(v *variables.Variable) bool {
+			return strings.EqualFold(v.Name, name) && scopeKey(v.Scope) == scopeKey(scope)
+		})
+		if len(matching) > 1 {
+			return nil, fmt.Errorf("there is more than one variable called '%s' with the same scope", name)
+		}
+
+		if len(matching) == 0 {
+			v := variables.NewVariable(name)
+			v.Type = variableTypeSensitive
+			v.IsSensitive = true
+			v.Value = value
+			v.Scope = scope
+			state.changed(variableSet.OwnerID, name, scope, value)
+			variableSet.Variables = append(variableSet.Variables, v)
+			plan.Changes = append(plan.Changes, &SyncChange{Name: name, Action: SyncAdded})
+			continue
+		}
+
+		v := matching[0]
+		if !v.IsSensitive {
+			return nil, fmt.Errorf("variable '%s' is not sensitive, so a secret will not be synced into it", v.Name)
+		}
+		if !state.changed(variableSet.OwnerID, v.Name, scope, value) {
+			plan.Changes = append(plan.Changes, &SyncChange{Name: v.Name, Action: SyncUnchanged})
+			continue
+		}
+		v.Value = value
+		plan.Changes = append(plan.Changes, &SyncChange{Name: v.Name, Action: SyncUpdated})
+	}
+	return plan, nil
+}
+
+func (p *SyncPlan) HasChanges() bool {
+	return util.SliceContainsAny(p.Changes, func(c *SyncChange) bool { return c.Action != SyncUnchanged })
+}
+
+// Format is the audit log of the sync, which never shows a secret value.
+func (p *SyncPlan) Format(sourceDescription string) string {
+	var b strings.Builder
+	scope := ""
+	if len(p.Environments) > 0 {
+		scope = fmt.Sprintf(" [Environments: %s]", strings.Join(p.Environments, ", "))
+	}
+
+	added, updated, unchanged := 0, 0, 0
+	for _, c := range p.Changes {
+		line := fmt.Sprintf("%s%s = ********", c.Name, scope)
+		switch c.Action {
+		case SyncAdded:
+			added++
+			b.WriteString(output.Greenf("+ %s: added\n", line))
+		case SyncUpdated:
+			updated++
+			b.WriteString(output.Yellowf("~ %s: updated\n", line))
+		default:
+			unchanged++
+			b.WriteString(output.Dimf("  %s: unchanged\n", line))
+		}
+	}
+	fmt.Fprintf(&b, "Synced from %s: %d added, %d updated, %d unchanged\n", sourceDescription, added, updated, unchanged)
+	return b.String()
+}
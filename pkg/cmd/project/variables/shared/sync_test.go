@@ -0,0 +1,93 @@
+package shared_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/project/variables/shared"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanSync_WithoutStateEverySecretIsSaved(t *testing.T) {
+	_, variableSet := newDocumentFixtures()
+	variableSet.Variables[1].Description = "Admin password"
+
+	plan, err := shared.PlanSync(variableSet, map[string]string{"Password": "hunter2", "ApiKey": "abc"}, nil, nil)
+	require.Nil(t, err)
+	require.True(t, plan.HasChanges())
+
+	assert.Len(t, variableSet.Variables, 4)
+	apiKey := variableSet.Variables[3]
+	assert.Equal(t, "ApiKey", apiKey.Name)
+	assert.True(t, apiKey.IsSensitive)
+	assert.Equal(t, "abc", apiKey.Value)
+	assert.Equal(t, "", apiKey.Description)
+	assert.Equal(t, "hunter2", variableSet.Variables[1].Value)
+	// nothing derived from a secret is kept in Octopus
+	assert.Equal(t, "Admin password", variableSet.Variables[1].Description)
+
+	assert.Equal(t, "+ ApiKey = ********: added\n"+
+		"~ Password = ********: updated\n"+
+		"Synced from dotenv:.env: 1 added, 1 updated, 0 unchanged\n", plan.Format("dotenv:.env"))
+}
+
+func TestPlanSync_WithStateOnlyChangedSecretsAreSaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync-state.json")
+	state, err := shared.ReadSyncState(path)
+	require.Nil(t, err)
+
+	_, variableSet := newDocumentFixtures()
+	_, err = shared.PlanSync(variableSet, map[string]string{"Password": "hunter2", "ApiKey": "abc"}, nil, state)
+	require.Nil(t, err)
+	require.Nil(t, state.Write(path))
+
+	info, err := os.Stat(path)
+	require.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	content, err := os.ReadFile(path)
+	require.Nil(t, err)
+	assert.NotContains(t, string(content), "hunter2")
+
+	state, err = shared.ReadSyncState(path)
+	require.Nil(t, err)
+	plan, err := shared.PlanSync(variableSet, map[string]string{"Password": "correct horse", "ApiKey": "abc"}, nil, state)
+	require.Nil(t, err)
+
+	assert.Equal(t, []*shared.SyncChange{
+		{Name: "ApiKey", Action: shared.SyncUnchanged},
+		{Name: "Password", Action: shared.SyncUpdated},
+	}, plan.Changes)
+	assert.Equal(t, "correct horse", variableSet.Variables[1].Value)
+}
+
+func TestReadSyncState_Problems(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync-state.json")
+
+	require.Nil(t, os.WriteFile(path, []byte(`{"Fingerprints": {}}`), 0600))
+	_, err := shared.ReadSyncState(path)
+	assert.EqualError(t, err, "the sync state in "+path+" has no key")
+
+	require.Nil(t, os.WriteFile(path, []byte(`not json`), 0600))
+	_, err = shared.ReadSyncState(path)
+	assert.ErrorContains(t, err, "cannot read the sync state in "+path)
+}
+
+func TestPlanSync_ScopedToEnvironments(t *testing.T) {
+	_, variableSet := newDocumentFixtures()
+
+	plan, err := shared.PlanSync(variableSet, map[string]string{"Password": "hunter2"}, []string{"test"}, nil)
+	require.Nil(t, err)
+
+	assert.Equal(t, shared.SyncAdded, plan.Changes[0].Action)
+	assert.Equal(t, variables.VariableScope{Environments: []string{"Environments-2"}}, variableSet.Variables[3].Scope)
+}
+
+func TestPlanSync_RefusesVariablesThatAreNotSensitive(t *testing.T) {
+	_, variableSet := newDocumentFixtures()
+
+	_, err := shared.PlanSync(variableSet, map[string]string{"ConnectionString": "server=prod"}, nil, nil)
+	assert.EqualError(t, err, "variable 'ConnectionString' is not sensitive, so a secret will not be synced into it")
+}
@@ -0,0 +1,175 @@
+package sync
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	sharedProjectVariable "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	sharedVariable "github.com/OctopusDeploy/cli/pkg/question/shared/variables"
+	"github.com/OctopusDeploy/cli/pkg/secrets"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject     = "project"
+	FlagFrom        = "from"
+	FlagEnvironment = "environment"
+	FlagDryRun      = "dry-run"
+	FlagStateFile   = "state-file"
+)
+
+type SyncFlags struct {
+	Project      *flag.Flag[string]
+	From         *flag.Flag[string]
+	Environments *flag.Flag[[]string]
+	DryRun       *flag.Flag[bool]
+	StateFile    *flag.Flag[string]
+}
+
+func NewSyncFlags() *SyncFlags {
+	return &SyncFlags{
+		Project:      flag.New[string](FlagProject, false),
+		From:         flag.New[string](FlagFrom, false),
+		Environments: flag.New[[]string](FlagEnvironment, false),
+		DryRun:       flag.New[bool](FlagDryRun, false),
+		StateFile:    flag.New[string](FlagStateFile, false),
+	}
+}
+
+type UpdateVariablesCallback func(ownerID string, variableSet *variables.VariableSet) error
+
+type SyncOptions struct {
+	*SyncFlags
+	*cmd.Dependencies
+	*sharedVariable.VariableCallbacks
+	NewSourceCallback func(from string) (secrets.Source, error)
+	UpdateVariablesCallback
+}
+
+func NewSyncOptions(flags *SyncFlags, dependencies *cmd.Dependencies) *SyncOptions {
+	return &SyncOptions{
+		SyncFlags:         flags,
+		Dependencies:      dependencies,
+		VariableCallbacks: sharedVariable.NewVariableCallbacks(dependencies),
+		NewSourceCallback: secrets.NewSource,
+		UpdateVariablesCallback: func(ownerID string, variableSet *variables.VariableSet) error {
+			_, err := dependencies.Client.Variables.Update(ownerID, *variableSet)
+			return err
+		},
+	}
+}
+
+func NewCmdSync(f factory.Factory) *cobra.Command {
+	syncFlags := NewSyncFlags()
+	cmd := &cobra.Command{
+		Use:   "sync [<project>]",
+		Short: "Sync sensitive project variables from a secret store",
+		Long: heredoc.Docf(`
+			Set the values of sensitive project variables from the secrets in a file or secret store,
+			adding a variable for any secret that does not have one. The values are never shown.
+
+			The source is given as <kind>:<location>, where kind is one of:
+			  dotenv  a file of KEY=value lines, such as .env
+			  sops    a YAML file encrypted with SOPS, decrypted with the sops executable
+			  vault   a secret in the HashiCorp Vault key/value engine, such as vault:secret/my-app,
+			          using the %[1]s and %[2]s environment variables
+
+			Nested keys in YAML and Vault secrets become variable names joined with dots.
+
+			Octopus never returns sensitive values, so every value is saved unless --%[3]s is given.
+			The state file keeps a keyed fingerprint of each value synced, on this machine only, to
+			tell which values have changed since. Keep it as private as the secrets themselves.
+		`, secrets.EnvVaultAddress, secrets.EnvVaultToken, FlagStateFile),
+		Example: heredoc.Docf(`
+			%[1]s project variables sync "Deploy Web App" --from dotenv:.env
+			%[1]s project variables sync -p "Deploy Web App" --from sops:secrets.enc.yaml --environment Production
+			%[1]s project variables sync -p "Deploy Web App" --from vault:secret/web-app --dry-run
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if syncFlags.Project.Value == "" && len(args) > 0 {
+				syncFlags.Project.Value = args[0]
+			}
+
+			opts := NewSyncOptions(syncFlags, cmd.NewDependencies(f, c))
+			return syncRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&syncFlags.Project.Value, syncFlags.Project.Name, "p", "", "Name or ID of the project")
+	flags.StringVar(&syncFlags.From.Value, syncFlags.From.Name, "", fmt.Sprintf("The source of the secrets, as <kind>:<location> where kind is one of %s", strings.Join(secrets.Schemes(), ", ")))
+	flags.StringSliceVarP(&syncFlags.Environments.Value, syncFlags.Environments.Name, "e", nil, "Sync the variables scoped to exactly these environments. Multiple environments can be supplied")
+	flags.BoolVar(&syncFlags.DryRun.Value, syncFlags.DryRun.Name, false, "Show what would change without saving it")
+	flags.StringVar(&syncFlags.StateFile.Value, syncFlags.StateFile.Name, "", "A `file` on this machine recording what was last synced, so that only changed values are saved")
+
+	return cmd
+}
+
+func syncRun(opts *SyncOptions) error {
+	if opts.From.Value == "" {
+		return fmt.Errorf("must supply the source of the secrets with --%s", FlagFrom)
+	}
+	source, err := opts.NewSourceCallback(opts.From.Value)
+	if err != nil {
+		return err
+	}
+
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project whose variables you wish to sync", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	values, err := source.Load()
+	if err != nil {
+		return err
+	}
+
+	// sensitive variables are kept in the database, even for version controlled projects
+	projectVariables, err := opts.GetProjectVariables(project.GetID())
+	if err != nil {
+		return err
+	}
+
+	var state *sharedProjectVariable.SyncState
+	if opts.StateFile.Value != "" {
+		if state, err = sharedProjectVariable.ReadSyncState(opts.StateFile.Value); err != nil {
+			return err
+		}
+	}
+
+	plan, err := sharedProjectVariable.PlanSync(projectVariables, values, opts.Environments.Value, state)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(opts.Out, plan.Format(source.Description()))
+
+	if !plan.HasChanges() {
+		return nil
+	}
+	if opts.DryRun.Value {
+		_, err = fmt.Fprintln(opts.Out, output.Dim("Dry run: no changes were saved"))
+		return err
+	}
+
+	if err := opts.UpdateVariablesCallback(project.GetID(), plan.VariableSet); err != nil {
+		return err
+	}
+	if state != nil {
+		if err := state.Write(opts.StateFile.Value); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(opts.Out, "Successfully synced the variables of '%s'\n", project.GetName())
+	return err
+}
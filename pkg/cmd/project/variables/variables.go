@@ -5,8 +5,12 @@ import (
 	cmdCreate "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/create"
 	cmdDelete "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/delete"
 	cmdExclude "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/exclude"
+	cmdExport "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/export"
+	cmdImport "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/import"
 	cmdInclude "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/include"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/list"
+	cmdPreview "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/preview"
+	cmdSync "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/sync"
 	cmdUpdate "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/update"
 	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/view"
 	"github.com/OctopusDeploy/cli/pkg/constants"
@@ -38,6 +42,10 @@ func NewCmdVariables(f factory.Factory) *cobra.Command {
 	cmd.AddCommand(cmdDelete.NewDeleteCmd(f))
 	cmd.AddCommand(cmdInclude.NewIncludeVariableSetCmd(f))
 	cmd.AddCommand(cmdExclude.NewExcludeVariableSetCmd(f))
+	cmd.AddCommand(cmdExport.NewCmdExport(f))
+	cmd.AddCommand(cmdImport.NewCmdImport(f))
+	cmd.AddCommand(cmdPreview.NewCmdPreview(f))
+	cmd.AddCommand(cmdSync.NewCmdSync(f))
 
 	return cmd
 }
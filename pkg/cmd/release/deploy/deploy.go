@@ -153,7 +153,7 @@ func NewCmdDeploy(f factory.Factory) *cobra.Command {
 				deployFlags.Project.Value = args[0]
 			}
 
-			return deployRun(cmd, f, deployFlags)
+			return DeployRun(cmd, f, deployFlags)
 		},
 	}
 
@@ -197,7 +197,11 @@ func NewCmdDeploy(f factory.Factory) *cobra.Command {
 	return cmd
 }
 
-func deployRun(cmd *cobra.Command, f factory.Factory, flags *DeployFlags) error {
+// DeployRun deploys a release as described by flags, asking for anything missing in
+// interactive mode. Commands that work out what to deploy themselves, such as release
+// promote, fill in flags and hand over to it. cmd must have the --force-package-download
+// flag registered, as DeployRun checks whether it was given.
+func DeployRun(cmd *cobra.Command, f factory.Factory, flags *DeployFlags) error {
 	outputFormat, err := cmd.Flags().GetString(constants.FlagOutputFormat)
 	if err != nil { // should never happen, but fallback if it does
 		outputFormat = constants.OutputFormatTable
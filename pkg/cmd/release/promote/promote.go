@@ -0,0 +1,198 @@
+package promote
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	deploymentShared "github.com/OctopusDeploy/cli/pkg/cmd/deployment/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/release/deploy"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/defects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/releases"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagFrom = "from"
+	FlagTo   = "to"
+
+	FlagAliasDeployToLegacy = "deployTo" // octo promote-release used --to or --deployTo
+)
+
+type PromoteFlags struct {
+	From *flag.Flag[string]
+	*deploy.DeployFlags
+}
+
+func NewPromoteFlags() *PromoteFlags {
+	return &PromoteFlags{
+		From:        flag.New[string](FlagFrom, false),
+		DeployFlags: deploy.NewDeployFlags(),
+	}
+}
+
+func NewCmdPromote(f factory.Factory) *cobra.Command {
+	promoteFlags := NewPromoteFlags()
+	cmd := &cobra.Command{
+		Use:   "promote",
+		Short: "Promote the latest successful release from one environment to another",
+		Long: heredoc.Doc(`
+			Promote a release in Octopus Deploy: find the latest release that was deployed successfully
+			to the source environment and deploy it to the destination environment(s).
+
+			Releases that have been prevented from progressing with 'release progression prevent' are not promoted.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s release promote  # fully interactive
+			%[1]s release promote --project MyProject --from Staging --to Production
+			%[1]s release promote -p MyProject --from Staging --to Production --tenant "Bobs Wood Shop"
+			%[1]s release promote -p MyProject --from Test --to Staging --variable VarName:VarValue --no-prompt
+		`, constants.ExecutableName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 && promoteFlags.Project.Value == "" {
+				promoteFlags.Project.Value = args[0]
+			}
+
+			return promoteRun(cmd, f, promoteFlags)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&promoteFlags.Project.Value, promoteFlags.Project.Name, "p", "", "Name or ID of the project to promote the release of")
+	flags.StringVar(&promoteFlags.From.Value, promoteFlags.From.Name, "", "Name of the environment to promote the latest successful release from")
+	flags.StringArrayVar(&promoteFlags.Environments.Value, FlagTo, nil, "Deploy to this environment (can be specified multiple times)")
+	flags.StringArrayVarP(&promoteFlags.Tenants.Value, promoteFlags.Tenants.Name, "", nil, "Deploy to this tenant (can be specified multiple times). Also limits the search for the release to deployments for these tenants")
+	flags.StringArrayVarP(&promoteFlags.TenantTags.Value, promoteFlags.TenantTags.Name, "", nil, "Deploy to tenants matching this tag (can be specified multiple times). Format is 'Tag Set Name/Tag Name', such as 'Regions/South'.")
+	flags.StringVarP(&promoteFlags.DeployAt.Value, promoteFlags.DeployAt.Name, "", "", "Deploy at a later time. Deploy now if omitted.")
+	flags.StringVarP(&promoteFlags.MaxQueueTime.Value, promoteFlags.MaxQueueTime.Name, "", "", "Cancel the deployment if it hasn't started within this time period.")
+	flags.StringArrayVarP(&promoteFlags.Variables.Value, promoteFlags.Variables.Name, "v", nil, "Set the value for a prompted variable in the format Label:Value")
+	flags.BoolVarP(&promoteFlags.UpdateVariables.Value, promoteFlags.UpdateVariables.Name, "", false, "Overwrite the release variable snapshot by re-importing variables from the project.")
+	flags.StringArrayVarP(&promoteFlags.ExcludedSteps.Value, promoteFlags.ExcludedSteps.Name, "", nil, "Exclude specific steps from the deployment")
+	flags.StringVarP(&promoteFlags.GuidedFailureMode.Value, promoteFlags.GuidedFailureMode.Name, "", "", "Enable Guided failure mode (true/false/default)")
+	flags.BoolVarP(&promoteFlags.ForcePackageDownload.Value, promoteFlags.ForcePackageDownload.Name, "", false, "Force re-download of packages")
+	flags.StringArrayVarP(&promoteFlags.DeploymentTargets.Value, promoteFlags.DeploymentTargets.Name, "", nil, "Deploy to this target (can be specified multiple times)")
+	flags.StringArrayVarP(&promoteFlags.ExcludeTargets.Value, promoteFlags.ExcludeTargets.Name, "", nil, "Deploy to targets except for this (can be specified multiple times)")
+	flags.StringArrayVarP(&promoteFlags.DeploymentFreezeNames.Value, promoteFlags.DeploymentFreezeNames.Name, "", nil, "Override this deployment freeze (can be specified multiple times)")
+	flags.StringVarP(&promoteFlags.DeploymentFreezeOverrideReason.Value, promoteFlags.DeploymentFreezeOverrideReason.Name, "", "", "Reason for overriding a deployment freeze")
+
+	flags.SortFlags = false
+
+	// flags aliases for compat with old .NET CLI
+	flagAliases := make(map[string][]string, 10)
+	util.AddFlagAliasesStringSlice(flags, FlagTo, flagAliases, FlagAliasDeployToLegacy)
+	util.AddFlagAliasesStringSlice(flags, deploy.FlagTenantTag, flagAliases, deploy.FlagAliasTag, deploy.FlagAliasTenantTagLegacy)
+	util.AddFlagAliasesString(flags, deploy.FlagDeployAt, flagAliases, deploy.FlagAliasWhen, deploy.FlagAliasDeployAtLegacy)
+	util.AddFlagAliasesString(flags, deploy.FlagDeployAtExpiry, flagAliases, deploy.FlagDeployAtExpire, deploy.FlagAliasNoDeployAfterLegacy)
+	util.AddFlagAliasesString(flags, deploy.FlagUpdateVariables, flagAliases, deploy.FlagAliasUpdateVariablesLegacy)
+	util.AddFlagAliasesString(flags, deploy.FlagGuidedFailure, flagAliases, deploy.FlagAliasGuidedFailureMode, deploy.FlagAliasGuidedFailureModeLegacy)
+	util.AddFlagAliasesBool(flags, deploy.FlagForcePackageDownload, flagAliases, deploy.FlagAliasForcePackageDownloadLegacy)
+	util.AddFlagAliasesStringSlice(flags, deploy.FlagDeploymentTarget, flagAliases, deploy.FlagAliasTarget, deploy.FlagAliasSpecificMachines)
+	util.AddFlagAliasesStringSlice(flags, deploy.FlagExcludeDeploymentTarget, flagAliases, deploy.FlagAliasExcludeTarget, deploy.FlagAliasExcludeMachines)
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		util.ApplyFlagAliases(cmd.Flags(), flagAliases)
+		return nil
+	}
+	return cmd
+}
+
+func promoteRun(cmd *cobra.Command, f factory.Factory, flags *PromoteFlags) error {
+	outputFormat, err := cmd.Flags().GetString(constants.FlagOutputFormat)
+	if err != nil { // should never happen, but fallback if it does
+		outputFormat = constants.OutputFormatTable
+	}
+
+	octopus, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
+	if err != nil {
+		return err
+	}
+
+	project, err := selectors.ResolveProject(octopus, f.Ask, f.IsPromptEnabled(), "Select the project to promote a release of", flags.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	sourceEnvironment, err := resolveSourceEnvironment(octopus, f, flags.From.Value)
+	if err != nil {
+		return err
+	}
+	if !f.IsPromptEnabled() && len(flags.Environments.Value) == 0 {
+		return errors.New("the environment to promote to must be specified with --to")
+	}
+
+	release, err := findReleaseToPromote(octopus, project, sourceEnvironment, flags.Tenants.Value)
+	if err != nil {
+		return err
+	}
+
+	if !constants.IsProgrammaticOutputFormat(outputFormat) {
+		cmd.Printf("Promoting release %s, the latest successful deployment to %s\n", output.Cyan(release.Version), output.Cyan(sourceEnvironment.Name))
+	}
+
+	// from here on it's an ordinary deployment of that release
+	flags.Project.Value = project.GetName()
+	flags.ReleaseVersion.Value = release.Version
+	return deploy.DeployRun(cmd, f, flags.DeployFlags)
+}
+
+func resolveSourceEnvironment(octopus *octopusApiClient.Client, f factory.Factory, environmentName string) (*environments.Environment, error) {
+	if environmentName != "" {
+		return selectors.FindEnvironment(octopus, environmentName)
+	}
+	if !f.IsPromptEnabled() {
+		return nil, errors.New("the environment to promote from must be specified with --from")
+	}
+	return selectors.EnvironmentSelect(f.Ask, func() ([]*environments.Environment, error) {
+		return selectors.GetAllEnvironments(octopus)
+	}, "Select the environment to promote the latest successful release from")
+}
+
+// findReleaseToPromote returns the release of the latest successful deployment of project
+// to environment, refusing to promote it if its progression has been prevented.
+func findReleaseToPromote(octopus *octopusApiClient.Client, project *projects.Project, environment *environments.Environment, tenantNamesOrIDs []string) (*releases.Release, error) {
+	query := deploymentShared.DeploymentsQuery{
+		Projects:     []string{project.GetID()},
+		Environments: []string{environment.GetID()},
+	}
+	for _, t := range tenantNamesOrIDs {
+		tenant, err := octopus.Tenants.GetByIdentifier(t)
+		if err != nil {
+			return nil, err
+		}
+		query.Tenants = append(query.Tenants, tenant.GetID())
+	}
+
+	deployment, err := deploymentShared.FindLatestSuccessful(octopus, query)
+	if err != nil {
+		return nil, err
+	}
+	if deployment == nil {
+		return nil, fmt.Errorf("there is no successful deployment of project '%s' to environment '%s' to promote", project.GetName(), environment.Name)
+	}
+
+	release, err := octopus.Releases.GetByID(deployment.ReleaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseDefects, err := defects.GetAll(octopus, octopus.GetSpaceID(), release.GetID())
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range releaseDefects {
+		if d.Status == defects.DefectStatusUnresolved {
+			return nil, fmt.Errorf("release %s, the latest successful deployment to '%s', is prevented from progressing: %s. Use 'release progression allow' to allow it", release.Version, environment.Name, d.Description)
+		}
+	}
+	return release, nil
+}
@@ -0,0 +1,105 @@
+package promote_test
+
+import (
+	"bytes"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/defects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func TestReleasePromote(t *testing.T) {
+	const spaceID = "Spaces-1"
+	const fireProjectID = "Projects-22"
+
+	space1 := fixtures.NewSpace(spaceID, "Default Space")
+	fireProject := fixtures.NewProject(spaceID, fireProjectID, "Fire Project", "Lifecycles-1", "ProjectGroups-1", "")
+	stagingEnvironment := fixtures.NewEnvironment(spaceID, "Environments-2", "Staging")
+
+	newDeployment := func(id string, releaseID string, taskID string) *deployments.Deployment {
+		d := deployments.NewDeployment(stagingEnvironment.ID, releaseID)
+		d.ID = id
+		d.ProjectID = fireProjectID
+		d.TaskID = taskID
+		return d
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer)
+	}{
+		{"requires --from in automation mode", func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"release", "promote", "--project", fireProjectID, "--to", "Production", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/Projects-22").RespondWith(fireProject)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.EqualError(t, err, "the environment to promote from must be specified with --from")
+		}},
+
+		{"refuses to promote a release whose progression is prevented", func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"release", "promote", "--project", fireProjectID, "--from", "Staging", "--to", "Production", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/Projects-22").RespondWith(fireProject)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments?partialName=Staging").RespondWith(resources.Resources[*environments.Environment]{
+				Items: []*environments.Environment{stagingEnvironment},
+			})
+
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/deployments?take=100&projects=Projects-22&environments=Environments-2").RespondWith(resources.Resources[*deployments.Deployment]{
+				Items: []*deployments.Deployment{
+					newDeployment("Deployments-2", "Releases-2", "ServerTasks-2"),
+					newDeployment("Deployments-1", "Releases-1", "ServerTasks-1"),
+				},
+			})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/tasks?ids=ServerTasks-2%2CServerTasks-1&take=2").RespondWith(resources.Resources[*tasks.Task]{
+				Items: []*tasks.Task{
+					{Resource: resources.Resource{ID: "ServerTasks-1"}, State: "Success"},
+					{Resource: resources.Resource{ID: "ServerTasks-2"}, State: "Failed"},
+				},
+			})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/releases/Releases-1").RespondWith(fixtures.NewRelease(spaceID, "Releases-1", "1.0", fireProjectID, "Channels-1"))
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/releases/Releases-1/defects").RespondWith(resources.Resources[*defects.Defect]{
+				Items: []*defects.Defect{{Description: "broken on staging", Status: defects.DefectStatusUnresolved}},
+			})
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.EqualError(t, err, "release 1.0, the latest successful deployment to 'Staging', is prevented from progressing: broken on staging. Use 'release progression allow' to allow it")
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			fac := testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider)
+			rootCmd := cmdRoot.NewCmdRoot(fac, nil, askProvider)
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+			test.run(t, api, qa, rootCmd, stdout, stderr)
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	cmdDeploy "github.com/OctopusDeploy/cli/pkg/cmd/release/deploy"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/release/list"
 	cmdProgression "github.com/OctopusDeploy/cli/pkg/cmd/release/progression"
+	cmdPromote "github.com/OctopusDeploy/cli/pkg/cmd/release/promote"
+	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/release/view"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
@@ -26,9 +28,11 @@ func NewCmdRelease(f factory.Factory) *cobra.Command {
 
 	cmd.AddCommand(cmdCreate.NewCmdCreate(f))
 	cmd.AddCommand(cmdDeploy.NewCmdDeploy(f))
+	cmd.AddCommand(cmdPromote.NewCmdPromote(f))
 	cmd.AddCommand(cmdList.NewCmdList(f))
 	cmd.AddCommand(cmdDelete.NewCmdDelete(f))
 	cmd.AddCommand(cmdProgression.NewCmdProgression(f))
+	cmd.AddCommand(cmdView.NewCmdView(f))
 
 	return cmd
 }
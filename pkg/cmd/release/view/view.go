@@ -0,0 +1,502 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	sharedProgression "github.com/OctopusDeploy/cli/pkg/cmd/release/progression/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/releases"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/pkg/browser"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject = "project"
+	FlagVersion = "version"
+	FlagWeb     = "web"
+)
+
+type ViewFlags struct {
+	Project *flag.Flag[string]
+	Version *flag.Flag[string]
+	Web     *flag.Flag[bool]
+}
+
+func NewViewFlags() *ViewFlags {
+	return &ViewFlags{
+		Project: flag.New[string](FlagProject, false),
+		Version: flag.New[string](FlagVersion, false),
+		Web:     flag.New[bool](FlagWeb, false),
+	}
+}
+
+type ViewOptions struct {
+	*ViewFlags
+	*cmd.Dependencies
+	Command *cobra.Command
+}
+
+func NewCmdView(f factory.Factory) *cobra.Command {
+	viewFlags := NewViewFlags()
+	cmd := &cobra.Command{
+		Use:   "view [<project> [<version>]]",
+		Short: "View a release",
+		Long:  "View a release in Octopus Deploy, including its packages, build information and where it has been deployed",
+		Example: heredoc.Docf(`
+			%[1]s release view myProject 2.0
+			%[1]s release view --project myProject --version 2.0 -f json
+			%[1]s release view -p "Other Project" -v 2.0 --web
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			if viewFlags.Project.Value == "" && len(args) > 0 {
+				viewFlags.Project.Value = args[0]
+			}
+			if viewFlags.Version.Value == "" && len(args) > 1 {
+				viewFlags.Version.Value = args[1]
+			}
+
+			opts := &ViewOptions{
+				ViewFlags:    viewFlags,
+				Dependencies: cmd.NewDependencies(f, c),
+				Command:      c,
+			}
+			return viewRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&viewFlags.Project.Value, viewFlags.Project.Name, "p", "", "Name or ID of the project the release belongs to")
+	flags.StringVarP(&viewFlags.Version.Value, viewFlags.Version.Name, "v", "", "Version of the release to view")
+	flags.BoolVarP(&viewFlags.Web.Value, viewFlags.Web.Name, "w", false, "Open in web browser")
+
+	return cmd
+}
+
+type ReleaseAsJson struct {
+	Id               string                 `json:"Id"`
+	Version          string                 `json:"Version"`
+	ProjectId        string                 `json:"ProjectId"`
+	ProjectName      string                 `json:"ProjectName"`
+	ChannelId        string                 `json:"ChannelId"`
+	ChannelName      string                 `json:"ChannelName"`
+	Assembled        time.Time              `json:"Assembled"`
+	ReleaseNotes     string                 `json:"ReleaseNotes,omitempty"`
+	Packages         []PackageAsJson        `json:"Packages"`
+	GitResources     []GitResourceAsJson    `json:"GitResources"`
+	BuildInformation []BuildInformationJson `json:"BuildInformation"`
+	Deployments      []DeploymentAsJson     `json:"Deployments"`
+	WebUrl           string                 `json:"WebUrl"`
+}
+
+type PackageAsJson struct {
+	StepName             string `json:"StepName"`
+	ActionName           string `json:"ActionName"`
+	PackageReferenceName string `json:"PackageReferenceName,omitempty"`
+	Version              string `json:"Version"`
+}
+
+type GitResourceAsJson struct {
+	ActionName               string `json:"ActionName"`
+	GitResourceReferenceName string `json:"GitResourceReferenceName,omitempty"`
+	GitRef                   string `json:"GitRef"`
+	GitCommit                string `json:"GitCommit,omitempty"`
+}
+
+type BuildInformationJson struct {
+	PackageId       string           `json:"PackageId"`
+	Version         string           `json:"Version"`
+	BuildNumber     string           `json:"BuildNumber,omitempty"`
+	BuildUrl        string           `json:"BuildUrl,omitempty"`
+	Branch          string           `json:"Branch,omitempty"`
+	VcsCommitNumber string           `json:"VcsCommitNumber,omitempty"`
+	VcsCommitUrl    string           `json:"VcsCommitUrl,omitempty"`
+	Commits         []CommitAsJson   `json:"Commits"`
+	WorkItems       []WorkItemAsJson `json:"WorkItems"`
+}
+
+type CommitAsJson struct {
+	Id      string `json:"Id"`
+	Comment string `json:"Comment"`
+	LinkUrl string `json:"LinkUrl,omitempty"`
+}
+
+type WorkItemAsJson struct {
+	Id          string `json:"Id"`
+	Description string `json:"Description"`
+	LinkUrl     string `json:"LinkUrl,omitempty"`
+	Source      string `json:"Source,omitempty"`
+}
+
+type DeploymentAsJson struct {
+	Id              string     `json:"Id"`
+	EnvironmentId   string     `json:"EnvironmentId"`
+	EnvironmentName string     `json:"EnvironmentName"`
+	TenantId        string     `json:"TenantId,omitempty"`
+	TenantName      string     `json:"TenantName,omitempty"`
+	TaskId          string     `json:"TaskId"`
+	State           string     `json:"State"`
+	Created         *time.Time `json:"Created,omitempty"`
+	CompletedTime   *time.Time `json:"CompletedTime,omitempty"`
+	DeployedBy      string     `json:"DeployedBy,omitempty"`
+}
+
+func viewRun(opts *ViewOptions) error {
+	project, err := selectors.ResolveProject(opts.Client, opts.Ask, !opts.NoPrompt,
+		"Select the project containing the release you wish to view", opts.Project.Value)
+	if err != nil {
+		return err
+	}
+
+	release, err := resolveRelease(opts, project)
+	if err != nil {
+		return err
+	}
+
+	channelName := release.ChannelID
+	if channel, err := opts.Client.Channels.GetByID(release.ChannelID); err == nil && channel != nil {
+		channelName = channel.Name
+	}
+
+	history, err := getDeploymentHistory(opts, release)
+	if err != nil {
+		return err
+	}
+
+	url := util.GenerateWebURL(opts.Host, release.SpaceID, fmt.Sprintf("projects/%s/deployments/releases/%s", project.Slug, release.Version))
+	result := toJson(project, release, channelName, history, url)
+
+	outputFormat, err := opts.Command.Flags().GetString(constants.FlagOutputFormat)
+	if err != nil { // should never happen, but fallback if it does
+		outputFormat = constants.OutputFormatTable
+	}
+
+	switch strings.ToLower(outputFormat) {
+	case constants.OutputFormatJson:
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		opts.Command.Println(string(data))
+	case constants.OutputFormatBasic, constants.OutputFormatTable, "":
+		if err := printRelease(opts.Out, result); err != nil {
+			return err
+		}
+	default:
+		return usage.NewUsageError(
+			fmt.Sprintf("unsupported output format %s. Valid values are 'json', 'table', 'basic'. Defaults to table", outputFormat),
+			opts.Command)
+	}
+
+	if opts.Web.Value {
+		_ = browser.OpenURL(url)
+	}
+	return nil
+}
+
+func resolveRelease(opts *ViewOptions, project *projects.Project) (*releases.Release, error) {
+	if opts.Version.Value != "" {
+		return sharedProgression.FindRelease(opts.Client, project, opts.Version.Value)
+	}
+	if opts.NoPrompt {
+		return nil, fmt.Errorf("release version must be specified")
+	}
+
+	existingReleases, err := opts.Client.Projects.GetReleases(project)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingReleases) == 0 {
+		return nil, fmt.Errorf("project '%s' has no releases", project.GetName())
+	}
+	return question.SelectMap(opts.Ask, "Select the release you wish to view", existingReleases, func(r *releases.Release) string {
+		return r.Version
+	})
+}
+
+type deploymentHistory struct {
+	deployments      []*deployments.Deployment
+	tasks            map[string]*tasks.Task
+	environmentNames map[string]string
+	tenantNames      map[string]string
+}
+
+func getDeploymentHistory(opts *ViewOptions, release *releases.Release) (*deploymentHistory, error) {
+	history := &deploymentHistory{
+		tasks:            map[string]*tasks.Task{},
+		environmentNames: map[string]string{},
+		tenantNames:      map[string]string{},
+	}
+
+	page, err := opts.Client.Deployments.GetDeployments(release)
+	if err != nil {
+		return nil, err
+	}
+	history.deployments, err = page.GetAllPages(opts.Client.Deployments.GetClient())
+	if err != nil {
+		return nil, err
+	}
+	if len(history.deployments) == 0 {
+		return history, nil
+	}
+
+	var taskIDs, environmentIDs, tenantIDs []string
+	for _, d := range history.deployments {
+		taskIDs = append(taskIDs, d.TaskID)
+		environmentIDs = append(environmentIDs, d.EnvironmentID)
+		if d.TenantID != "" {
+			tenantIDs = append(tenantIDs, d.TenantID)
+		}
+	}
+	taskIDs = util.SliceDistinct(taskIDs)
+	environmentIDs = util.SliceDistinct(environmentIDs)
+	tenantIDs = util.SliceDistinct(tenantIDs)
+
+	foundTasks, err := opts.Client.Tasks.Get(tasks.TasksQuery{IDs: taskIDs, Take: len(taskIDs)})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range foundTasks.Items {
+		history.tasks[t.GetID()] = t
+	}
+
+	// names are best-effort; the history still makes sense with IDs in it
+	if foundEnvironments, err := opts.Client.Environments.GetByIDs(environmentIDs); err == nil {
+		for _, e := range foundEnvironments {
+			history.environmentNames[e.GetID()] = e.Name
+		}
+	}
+	if len(tenantIDs) > 0 {
+		if foundTenants, err := opts.Client.Tenants.GetByIDs(tenantIDs); err == nil {
+			for _, t := range foundTenants {
+				history.tenantNames[t.GetID()] = t.Name
+			}
+		}
+	}
+
+	return history, nil
+}
+
+func nameOrID(names map[string]string, id string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return id
+}
+
+func toJson(project *projects.Project, release *releases.Release, channelName string, history *deploymentHistory, url string) ReleaseAsJson {
+	result := ReleaseAsJson{
+		Id:               release.GetID(),
+		Version:          release.Version,
+		ProjectId:        project.GetID(),
+		ProjectName:      project.GetName(),
+		ChannelId:        release.ChannelID,
+		ChannelName:      channelName,
+		Assembled:        release.Assembled,
+		ReleaseNotes:     release.ReleaseNotes,
+		Packages:         []PackageAsJson{},
+		GitResources:     []GitResourceAsJson{},
+		BuildInformation: []BuildInformationJson{},
+		Deployments:      []DeploymentAsJson{},
+		WebUrl:           url,
+	}
+
+	for _, p := range release.SelectedPackages {
+		result.Packages = append(result.Packages, PackageAsJson{
+			StepName:             p.StepName,
+			ActionName:           p.ActionName,
+			PackageReferenceName: p.PackageReferenceName,
+			Version:              p.Version,
+		})
+	}
+
+	for _, g := range release.SelectedGitResources {
+		gitResource := GitResourceAsJson{
+			ActionName:               g.ActionName,
+			GitResourceReferenceName: g.GitResourceReferenceName,
+		}
+		if g.GitReference != nil {
+			gitResource.GitRef = g.GitReference.GitRef
+			gitResource.GitCommit = g.GitReference.GitCommit
+		}
+		result.GitResources = append(result.GitResources, gitResource)
+	}
+
+	for _, b := range release.BuildInformation {
+		buildInformation := BuildInformationJson{
+			PackageId:       b.PackageID,
+			Version:         b.Version,
+			BuildNumber:     b.BuildNumber,
+			BuildUrl:        b.BuildURL,
+			Branch:          b.Branch,
+			VcsCommitNumber: b.VcsCommitNumber,
+			VcsCommitUrl:    b.VcsCommitURL,
+			Commits:         []CommitAsJson{},
+			WorkItems:       []WorkItemAsJson{},
+		}
+		for _, c := range b.Commits {
+			buildInformation.Commits = append(buildInformation.Commits, CommitAsJson{Id: c.ID, Comment: c.Comment, LinkUrl: c.LinkURL})
+		}
+		for _, w := range b.WorkItems {
+			buildInformation.WorkItems = append(buildInformation.WorkItems, WorkItemAsJson{Id: w.ID, Description: w.Description, LinkUrl: w.LinkURL, Source: w.Source})
+		}
+		result.BuildInformation = append(result.BuildInformation, buildInformation)
+	}
+
+	for _, d := range history.deployments {
+		deployment := DeploymentAsJson{
+			Id:              d.GetID(),
+			EnvironmentId:   d.EnvironmentID,
+			EnvironmentName: nameOrID(history.environmentNames, d.EnvironmentID),
+			TenantId:        d.TenantID,
+			TaskId:          d.TaskID,
+			Created:         d.Created,
+			DeployedBy:      d.DeployedBy,
+		}
+		if d.TenantID != "" {
+			deployment.TenantName = nameOrID(history.tenantNames, d.TenantID)
+		}
+		if t, ok := history.tasks[d.TaskID]; ok {
+			deployment.State = t.State
+			deployment.CompletedTime = t.CompletedTime
+		}
+		result.Deployments = append(result.Deployments, deployment)
+	}
+
+	// group the history by environment and tenant, most recent deployment first within each
+	sort.SliceStable(result.Deployments, func(i, j int) bool {
+		a, b := result.Deployments[i], result.Deployments[j]
+		if a.EnvironmentName != b.EnvironmentName {
+			return a.EnvironmentName < b.EnvironmentName
+		}
+		if a.TenantName != b.TenantName {
+			return a.TenantName < b.TenantName
+		}
+		return a.Created != nil && b.Created != nil && a.Created.After(*b.Created)
+	})
+
+	return result
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC1123Z)
+}
+
+func printRelease(out io.Writer, release ReleaseAsJson) error {
+	fmt.Fprintf(out, "%s %s\n", output.Bold(release.Version), output.Dimf("(%s)", release.Id))
+	fmt.Fprintf(out, "Project: %s\n", release.ProjectName)
+	fmt.Fprintf(out, "Channel: %s\n", release.ChannelName)
+	fmt.Fprintf(out, "Assembled: %s\n", release.Assembled.Format(time.RFC1123Z))
+
+	if release.ReleaseNotes == "" {
+		fmt.Fprintln(out, output.Dim("No release notes provided"))
+	} else {
+		fmt.Fprintf(out, "\n%s\n%s\n", output.Bold("Release notes"), release.ReleaseNotes)
+	}
+
+	if len(release.Packages) > 0 {
+		fmt.Fprintf(out, "\n%s\n", output.Bold("Packages"))
+		t := output.NewTable(out)
+		t.AddRow(output.Bold("STEP"), output.Bold("PACKAGE"), output.Bold("VERSION"))
+		for _, p := range release.Packages {
+			packageName := p.PackageReferenceName
+			if packageName == "" {
+				packageName = output.Dim("(primary)")
+			}
+			t.AddRow(p.ActionName, packageName, output.Cyan(p.Version))
+		}
+		if err := t.Print(); err != nil {
+			return err
+		}
+	}
+
+	if len(release.GitResources) > 0 {
+		fmt.Fprintf(out, "\n%s\n", output.Bold("Git resources"))
+		t := output.NewTable(out)
+		t.AddRow(output.Bold("STEP"), output.Bold("GIT RESOURCE"), output.Bold("REF"), output.Bold("COMMIT"))
+		for _, g := range release.GitResources {
+			resourceName := g.GitResourceReferenceName
+			if resourceName == "" {
+				resourceName = output.Dim("(primary)")
+			}
+			t.AddRow(g.ActionName, resourceName, output.Cyan(g.GitRef), g.GitCommit)
+		}
+		if err := t.Print(); err != nil {
+			return err
+		}
+	}
+
+	for _, b := range release.BuildInformation {
+		fmt.Fprintf(out, "\n%s %s\n", output.Bold("Build information for"), output.Bold(fmt.Sprintf("%s %s", b.PackageId, b.Version)))
+		if b.BuildNumber != "" {
+			fmt.Fprintf(out, "Build: %s %s\n", b.BuildNumber, output.Blue(b.BuildUrl))
+		}
+		if b.Branch != "" {
+			fmt.Fprintf(out, "Branch: %s\n", b.Branch)
+		}
+		for _, c := range b.Commits {
+			fmt.Fprintf(out, "  %s %s\n", output.Yellow(shortCommit(c.Id)), firstLine(c.Comment))
+		}
+		for _, w := range b.WorkItems {
+			fmt.Fprintf(out, "  %s %s %s\n", output.Cyan(w.Id), w.Description, output.Blue(w.LinkUrl))
+		}
+	}
+
+	fmt.Fprintf(out, "\n%s\n", output.Bold("Deployments"))
+	if len(release.Deployments) == 0 {
+		fmt.Fprintln(out, output.Dim("This release has not been deployed"))
+	} else {
+		hasTenants := util.SliceContainsAny(release.Deployments, func(d DeploymentAsJson) bool { return d.TenantId != "" })
+		t := output.NewTable(out)
+		if hasTenants {
+			t.AddRow(output.Bold("ENVIRONMENT"), output.Bold("TENANT"), output.Bold("STATE"), output.Bold("CREATED"), output.Bold("COMPLETED"), output.Bold("TASK"))
+		} else {
+			t.AddRow(output.Bold("ENVIRONMENT"), output.Bold("STATE"), output.Bold("CREATED"), output.Bold("COMPLETED"), output.Bold("TASK"))
+		}
+		for _, d := range release.Deployments {
+			if hasTenants {
+				t.AddRow(d.EnvironmentName, d.TenantName, output.FormatTaskState(d.State), formatTime(d.Created), formatTime(d.CompletedTime), output.Dim(d.TaskId))
+			} else {
+				t.AddRow(d.EnvironmentName, output.FormatTaskState(d.State), formatTime(d.Created), formatTime(d.CompletedTime), output.Dim(d.TaskId))
+			}
+		}
+		if err := t.Print(); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(out, "\nView this release in Octopus Deploy: %s\n", output.Blue(release.WebUrl))
+	return err
+}
+
+func shortCommit(id string) string {
+	if len(id) > 7 {
+		return id[:7]
+	}
+	return id
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,134 @@
+package view_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/release/view"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/packages"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/releases"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func TestReleaseView(t *testing.T) {
+	const spaceID = "Spaces-1"
+	const fireProjectID = "Projects-22"
+
+	space1 := fixtures.NewSpace(spaceID, "Default Space")
+	fireProject := fixtures.NewProject(spaceID, fireProjectID, "Fire Project", "Lifecycles-1", "ProjectGroups-1", "")
+	defaultChannel := fixtures.NewChannel(spaceID, "Channels-1", "Default Channel", fireProjectID)
+	devEnvironment := fixtures.NewEnvironment(spaceID, "Environments-1", "Dev")
+	testEnvironment := fixtures.NewEnvironment(spaceID, "Environments-2", "Test")
+
+	newRelease := func() *releases.Release {
+		release := fixtures.NewRelease(spaceID, "Releases-1", "2.0", fireProjectID, defaultChannel.ID)
+		release.Links["Deployments"] = "/api/Spaces-1/releases/Releases-1/deployments"
+		release.SelectedPackages = []*packages.SelectedPackage{
+			{StepName: "Deploy web", ActionName: "Deploy web", Version: "2.0.5"},
+		}
+		return release
+	}
+
+	newDeployment := func(id string, environmentID string, taskID string, created time.Time) *deployments.Deployment {
+		d := deployments.NewDeployment(environmentID, "Releases-1")
+		d.ID = id
+		d.TaskID = taskID
+		d.Created = &created
+		return d
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer)
+	}{
+		{"requires a version in automation mode", func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"release", "view", fireProjectID, "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/Projects-22").RespondWith(fireProject)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.EqualError(t, err, "release version must be specified")
+		}},
+
+		{"prints release and deployment history as json", func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"release", "view", fireProjectID, "2.0", "--no-prompt", "-f", "json"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/Projects-22").RespondWith(fireProject)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/Projects-22/releases/2.0").RespondWith(newRelease())
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/channels/Channels-1").RespondWith(defaultChannel)
+
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/releases/Releases-1/deployments").RespondWith(resources.Resources[*deployments.Deployment]{
+				Items: []*deployments.Deployment{
+					newDeployment("Deployments-3", testEnvironment.ID, "ServerTasks-3", time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+					newDeployment("Deployments-2", devEnvironment.ID, "ServerTasks-2", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+					newDeployment("Deployments-1", devEnvironment.ID, "ServerTasks-1", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			})
+
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/tasks?ids=ServerTasks-3%2CServerTasks-2%2CServerTasks-1&take=3").RespondWith(resources.Resources[*tasks.Task]{
+				Items: []*tasks.Task{
+					{Resource: resources.Resource{ID: "ServerTasks-1"}, State: "Failed"},
+					{Resource: resources.Resource{ID: "ServerTasks-2"}, State: "Success"},
+					{Resource: resources.Resource{ID: "ServerTasks-3"}, State: "Executing"},
+				},
+			})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments?ids=Environments-2%2CEnvironments-1").RespondWith(resources.Resources[*environments.Environment]{
+				Items: []*environments.Environment{devEnvironment, testEnvironment},
+			})
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+
+			result, err := testutil.ParseJsonStrict[view.ReleaseAsJson](stdOut)
+			assert.Nil(t, err)
+
+			assert.Equal(t, "2.0", result.Version)
+			assert.Equal(t, "Default Channel", result.ChannelName)
+			assert.Equal(t, []view.PackageAsJson{{StepName: "Deploy web", ActionName: "Deploy web", Version: "2.0.5"}}, result.Packages)
+
+			var history []string
+			for _, d := range result.Deployments {
+				history = append(history, d.EnvironmentName+" "+d.State)
+			}
+			assert.Equal(t, []string{"Dev Success", "Dev Failed", "Test Executing"}, history)
+			assert.Equal(t, "", stdErr.String())
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			fac := testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider)
+			rootCmd := cmdRoot.NewCmdRoot(fac, nil, askProvider)
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+			test.run(t, api, qa, rootCmd, stdout, stderr)
+		})
+	}
+}
@@ -7,10 +7,13 @@ import (
 	buildInfoCmd "github.com/OctopusDeploy/cli/pkg/cmd/buildinformation"
 	channelCmd "github.com/OctopusDeploy/cli/pkg/cmd/channel"
 	configCmd "github.com/OctopusDeploy/cli/pkg/cmd/config"
+	deploymentCmd "github.com/OctopusDeploy/cli/pkg/cmd/deployment"
+	editCmd "github.com/OctopusDeploy/cli/pkg/cmd/edit"
 	environmentCmd "github.com/OctopusDeploy/cli/pkg/cmd/environment"
 	ephemeralEnvironmentCmd "github.com/OctopusDeploy/cli/pkg/cmd/ephemeralenvironment"
 	loginCmd "github.com/OctopusDeploy/cli/pkg/cmd/login"
 	logoutCmd "github.com/OctopusDeploy/cli/pkg/cmd/logout"
+	machinePolicyCmd "github.com/OctopusDeploy/cli/pkg/cmd/machinepolicy"
 	packageCmd "github.com/OctopusDeploy/cli/pkg/cmd/package"
 	projectCmd "github.com/OctopusDeploy/cli/pkg/cmd/project"
 	projectGroupCmd "github.com/OctopusDeploy/cli/pkg/cmd/projectgroup"
@@ -21,6 +24,8 @@ import (
 	taskCmd "github.com/OctopusDeploy/cli/pkg/cmd/task"
 	tenantCmd "github.com/OctopusDeploy/cli/pkg/cmd/tenant"
 	userCmd "github.com/OctopusDeploy/cli/pkg/cmd/user"
+	variablesCmd "github.com/OctopusDeploy/cli/pkg/cmd/variables"
+	variableSetCmd "github.com/OctopusDeploy/cli/pkg/cmd/variableset"
 	"github.com/OctopusDeploy/cli/pkg/cmd/version"
 	workerCmd "github.com/OctopusDeploy/cli/pkg/cmd/worker"
 	workerPoolCmd "github.com/OctopusDeploy/cli/pkg/cmd/workerpool"
@@ -59,12 +64,15 @@ func NewCmdRoot(f factory.Factory, clientFactory apiclient.ClientFactory, askPro
 	cmd.AddCommand(deploymentTargetCmd.NewCmdDeploymentTarget(f))
 	cmd.AddCommand(workerCmd.NewCmdWorker(f))
 	cmd.AddCommand(workerPoolCmd.NewCmdWorkerPool(f))
+	cmd.AddCommand(machinePolicyCmd.NewCmdMachinePolicy(f))
 
 	// core
 	cmd.AddCommand(projectGroupCmd.NewCmdProjectGroup(f))
 	cmd.AddCommand(projectCmd.NewCmdProject(f))
 	cmd.AddCommand(channelCmd.NewCmdChannel(f))
 	cmd.AddCommand(tenantCmd.NewCmdTenant(f))
+	cmd.AddCommand(variablesCmd.NewCmdVariables(f))
+	cmd.AddCommand(variableSetCmd.NewCmdVariableSet(f))
 	cmd.AddCommand(taskCmd.NewCmdTask(f))
 
 	// configuration
@@ -75,9 +83,11 @@ func NewCmdRoot(f factory.Factory, clientFactory apiclient.ClientFactory, askPro
 
 	cmd.AddCommand(userCmd.NewCmdUser(f))
 	cmd.AddCommand(releaseCmd.NewCmdRelease(f))
+	cmd.AddCommand(deploymentCmd.NewCmdDeployment(f))
 	cmd.AddCommand(runbookCmd.NewCmdRunbook(f))
 
 	cmd.AddCommand(apiCmd.NewCmdAPI(f))
+	cmd.AddCommand(editCmd.NewCmdEdit(f))
 
 	// ----- Configuration -----
 
@@ -0,0 +1,254 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	accountShared "github.com/OctopusDeploy/cli/pkg/cmd/account/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/discovery/shared"
+	targetShared "github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/task/wait"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagEnvironment = "environment"
+	FlagRole        = "role"
+)
+
+type CheckFlags struct {
+	*shared.OwnerFlags
+	Environment *flag.Flag[string]
+	Roles       *flag.Flag[[]string]
+	*machinescommon.MaintenanceFlags
+}
+
+func NewCheckFlags() *CheckFlags {
+	return &CheckFlags{
+		OwnerFlags:       shared.NewOwnerFlags(),
+		Environment:      flag.New[string](FlagEnvironment, false),
+		Roles:            flag.New[[]string](FlagRole, false),
+		MaintenanceFlags: machinescommon.NewMaintenanceFlags(),
+	}
+}
+
+// DiscoveredTarget is a deployment target in the environment which was discovered from the cloud.
+type DiscoveredTarget struct {
+	Id                 string   `json:"Id"`
+	Name               string   `json:"Name"`
+	CommunicationStyle string   `json:"CommunicationStyle"`
+	Roles              []string `json:"Roles"`
+	HealthStatus       string   `json:"HealthStatus"`
+}
+
+type CheckOptions struct {
+	*CheckFlags
+	*cmd.Dependencies
+	*shared.OwnerCallbacks
+	Command                   *cobra.Command
+	GetAccountCallback        accountShared.GetAccountCallback
+	GetEnvironmentMapCallback func() (map[string]string, error)
+	GetTargetsCallback        func(query machines.MachinesQuery) ([]*machines.DeploymentTarget, error)
+	StartTaskCallback         func(task *tasks.Task) (*tasks.Task, error)
+	WaitCallback              func(taskID string) error
+}
+
+func NewCheckOptions(flags *CheckFlags, dependencies *cmd.Dependencies, command *cobra.Command) *CheckOptions {
+	return &CheckOptions{
+		CheckFlags:     flags,
+		Dependencies:   dependencies,
+		OwnerCallbacks: shared.NewOwnerCallbacks(dependencies),
+		Command:        command,
+		GetAccountCallback: func(idOrName string) (accounts.IAccount, error) {
+			return accountShared.GetAccount(dependencies.Client, idOrName)
+		},
+		GetEnvironmentMapCallback: func() (map[string]string, error) {
+			return targetShared.GetEnvironmentMap(dependencies.Client)
+		},
+		GetTargetsCallback: func(query machines.MachinesQuery) ([]*machines.DeploymentTarget, error) {
+			return targetShared.GetAllTargets(*dependencies.Client, query)
+		},
+		StartTaskCallback: func(task *tasks.Task) (*tasks.Task, error) {
+			return dependencies.Client.Tasks.Add(task)
+		},
+		WaitCallback: func(taskID string) error {
+			return wait.WaitRun(wait.NewWaitOps(dependencies, []string{taskID}, flags.Timeout.Value, wait.DefaultPollInterval, false, false, command))
+		},
+	}
+}
+
+func NewCmdCheck(f factory.Factory) *cobra.Command {
+	checkFlags := NewCheckFlags()
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check cloud target discovery and the targets already discovered in an environment",
+		Long: heredoc.Docf(`
+			Check cloud target discovery in an environment of Octopus Deploy, and the targets it has
+			already discovered.
+
+			The discovery variables which apply to the environment are checked, along with their
+			accounts, and the tags the cloud resources need are shown. For a project, the variables of
+			the library variable sets it includes are taken into account as they are in a deployment.
+			A health check of the environment is then queued, and with --%[1]s the Azure, AWS and
+			Kubernetes targets already in the environment are listed once it finishes.
+
+			This does not discover new targets. Octopus discovers them when a deployment or runbook
+			run starts, so targets which have not been found yet appear after the next deployment to
+			the environment.
+		`, machinescommon.FlagWait),
+		Example: heredoc.Docf(`
+			%[1]s deployment-target discovery check --project "Web App" --environment Production --wait
+			%[1]s deployment-target discovery check --variable-set "Cloud" -e Test --role web-app
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, _ []string) error {
+			opts := NewCheckOptions(checkFlags, cmd.NewDependencies(f, c), c)
+			return CheckRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	shared.RegisterOwnerFlags(cmd, checkFlags.OwnerFlags)
+	flags.StringVarP(&checkFlags.Environment.Value, checkFlags.Environment.Name, "e", "", "Name or ID of the environment to check discovery in")
+	flags.StringArrayVar(&checkFlags.Roles.Value, checkFlags.Roles.Name, nil, "Only list targets with this role. Multiple roles can be supplied")
+	machinescommon.RegisterMaintenanceFlags(cmd, checkFlags.MaintenanceFlags, wait.DefaultTimeout)
+
+	return cmd
+}
+
+func CheckRun(opts *CheckOptions) error {
+	if opts.Environment.Value == "" {
+		return fmt.Errorf("must supply the environment to check discovery in with --%s", FlagEnvironment)
+	}
+	owner, err := shared.ResolveOwner(opts.OwnerFlags, opts.OwnerCallbacks)
+	if err != nil {
+		return err
+	}
+	environmentMap, err := opts.GetEnvironmentMapCallback()
+	if err != nil {
+		return err
+	}
+	environmentIDs, err := machinescommon.ResolveIDs([]string{opts.Environment.Value}, environmentMap, "environment")
+	if err != nil {
+		return err
+	}
+	environmentID, environmentName := environmentIDs[0], environmentMap[environmentIDs[0]]
+
+	var variableSets []*variables.VariableSet
+	for _, ownerID := range append([]string{owner.Id}, owner.IncludedIds...) {
+		variableSet, err := opts.GetVariablesCallback(ownerID)
+		if err != nil {
+			return err
+		}
+		variableSets = append(variableSets, variableSet)
+	}
+	resolved := shared.ResolveSettings(environmentID, variableSets...)
+	if len(resolved) == 0 {
+		return fmt.Errorf("cloud target discovery is not configured for environment '%s' in %s", environmentName, owner)
+	}
+
+	for _, settings := range resolved {
+		if err := settings.Validate(); err != nil {
+			return fmt.Errorf("discovery in environment '%s' is misconfigured: %w", environmentName, err)
+		}
+		description := "the instance role of the worker"
+		if settings.AccountId != "" {
+			account, err := opts.GetAccountCallback(settings.AccountId)
+			if err != nil {
+				return err
+			}
+			if err := shared.CheckAccount(settings, account); err != nil {
+				return err
+			}
+			description = shared.DescribeAccount(account)
+		}
+		fmt.Fprintf(opts.Out, "%s discovery uses %s\n", output.Bold(settings.Cloud), description)
+		if len(settings.Regions) > 0 {
+			fmt.Fprintf(opts.Out, "  Regions: %s\n", output.FormatAsList(settings.Regions))
+		}
+		if settings.AssumeRoleArn != "" {
+			fmt.Fprintf(opts.Out, "  Assumes role: %s\n", settings.AssumeRoleArn)
+		}
+	}
+	fmt.Fprintf(opts.Out, "Resources are discovered when tagged with %s\n", strings.Join(shared.RequiredTags([]string{environmentName}, opts.Roles.Value), " and "))
+
+	task := NewEnvironmentHealthTask(opts.Space.GetID(), environmentID, environmentName)
+	started, err := opts.StartTaskCallback(task)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(opts.Out, "Queued health check of environment '%s' %s.\n", environmentName, output.Dimf("(%s)", started.GetID()))
+	if !opts.Wait.Value {
+		fmt.Fprintf(opts.Out, "Use '%s task wait %s' to wait for it to finish.\n", constants.ExecutableName, started.GetID())
+		return nil
+	}
+
+	waitErr := opts.WaitCallback(started.GetID())
+	targets, err := opts.GetTargetsCallback(machines.MachinesQuery{EnvironmentIDs: []string{environmentID}, Roles: opts.Roles.Value})
+	if err != nil {
+		return err
+	}
+	discovered := []*DiscoveredTarget{}
+	for _, target := range targets {
+		style := machinescommon.GetCommunicationStyle(target.Endpoint)
+		if !shared.IsDiscoveredStyle(style) {
+			continue
+		}
+		discovered = append(discovered, &DiscoveredTarget{
+			Id:                 target.GetID(),
+			Name:               target.Name,
+			CommunicationStyle: style,
+			Roles:              target.Roles,
+			HealthStatus:       target.HealthStatus,
+		})
+	}
+
+	fmt.Fprintln(opts.Out)
+	outputFormat, _ := opts.Command.Flags().GetString(constants.FlagOutputFormat)
+	if len(discovered) == 0 && !constants.IsProgrammaticOutputFormat(outputFormat) {
+		fmt.Fprintf(opts.Out, "No cloud targets have been discovered in environment '%s'\n", environmentName)
+		return waitErr
+	}
+	err = output.PrintArray(discovered, opts.Command, output.Mappers[*DiscoveredTarget]{
+		Json: func(t *DiscoveredTarget) any {
+			return t
+		},
+		Table: output.TableDefinition[*DiscoveredTarget]{
+			Header: []string{"NAME", "TYPE", "ROLES", "HEALTH STATUS"},
+			Row: func(t *DiscoveredTarget) []string {
+				return []string{output.Bold(t.Name), machinescommon.CommunicationStyleToDescriptionMap[t.CommunicationStyle], output.FormatAsList(t.Roles), machinescommon.DescribeHealthStatus(t.HealthStatus)}
+			},
+		},
+		Basic: func(t *DiscoveredTarget) string {
+			return fmt.Sprintf("%s: %s", t.Name, t.HealthStatus)
+		},
+	})
+	if waitErr != nil {
+		return waitErr
+	}
+	return err
+}
+
+// NewEnvironmentHealthTask is the server task which checks the health of every deployment target
+// in an environment, as the web portal does.
+func NewEnvironmentHealthTask(spaceID string, environmentID string, environmentName string) *tasks.Task {
+	task := tasks.NewTask()
+	task.Name = machinescommon.HealthCheckTask.Name
+	task.SpaceID = spaceID
+	task.Description = fmt.Sprintf("Check health of %s", environmentName)
+	for k, v := range machinescommon.HealthCheckTask.Arguments {
+		task.Arguments[k] = v
+	}
+	task.Arguments["EnvironmentId"] = environmentID
+	return task
+}
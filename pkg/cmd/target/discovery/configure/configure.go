@@ -0,0 +1,289 @@
+package configure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	accountShared "github.com/OctopusDeploy/cli/pkg/cmd/account/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/discovery/shared"
+	targetShared "github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagCloud           = "cloud"
+	FlagAccount         = "account"
+	FlagRegion          = "region"
+	FlagUseInstanceRole = "use-instance-role"
+	FlagAssumeRoleArn   = "assume-role-arn"
+	FlagEnvironment     = "environment"
+	FlagRole            = "role"
+	FlagDryRun          = "dry-run"
+)
+
+type ConfigureFlags struct {
+	*shared.OwnerFlags
+	Cloud           *flag.Flag[string]
+	Account         *flag.Flag[string]
+	Regions         *flag.Flag[[]string]
+	UseInstanceRole *flag.Flag[bool]
+	AssumeRoleArn   *flag.Flag[string]
+	Environments    *flag.Flag[[]string]
+	Roles           *flag.Flag[[]string]
+	DryRun          *flag.Flag[bool]
+}
+
+func NewConfigureFlags() *ConfigureFlags {
+	return &ConfigureFlags{
+		OwnerFlags:      shared.NewOwnerFlags(),
+		Cloud:           flag.New[string](FlagCloud, false),
+		Account:         flag.New[string](FlagAccount, false),
+		Regions:         flag.New[[]string](FlagRegion, false),
+		UseInstanceRole: flag.New[bool](FlagUseInstanceRole, false),
+		AssumeRoleArn:   flag.New[string](FlagAssumeRoleArn, false),
+		Environments:    flag.New[[]string](FlagEnvironment, false),
+		Roles:           flag.New[[]string](FlagRole, false),
+		DryRun:          flag.New[bool](FlagDryRun, false),
+	}
+}
+
+type ConfigureOptions struct {
+	*ConfigureFlags
+	*cmd.Dependencies
+	*shared.OwnerCallbacks
+	GetAccountCallback        accountShared.GetAccountCallback
+	GetAccountsByTypeCallback func(accountType accounts.AccountType) ([]accounts.IAccount, error)
+	GetEnvironmentMapCallback func() (map[string]string, error)
+}
+
+func NewConfigureOptions(flags *ConfigureFlags, dependencies *cmd.Dependencies) *ConfigureOptions {
+	return &ConfigureOptions{
+		ConfigureFlags: flags,
+		Dependencies:   dependencies,
+		OwnerCallbacks: shared.NewOwnerCallbacks(dependencies),
+		GetAccountCallback: func(idOrName string) (accounts.IAccount, error) {
+			return accountShared.GetAccount(dependencies.Client, idOrName)
+		},
+		GetAccountsByTypeCallback: func(accountType accounts.AccountType) ([]accounts.IAccount, error) {
+			resources, err := dependencies.Client.Accounts.Get(accounts.AccountsQuery{AccountType: accountType})
+			if err != nil {
+				return nil, err
+			}
+			return resources.GetAllPages(dependencies.Client.Accounts.GetClient())
+		},
+		GetEnvironmentMapCallback: func() (map[string]string, error) {
+			return targetShared.GetEnvironmentMap(dependencies.Client)
+		},
+	}
+}
+
+func NewCmdConfigure(f factory.Factory) *cobra.Command {
+	configureFlags := NewConfigureFlags()
+	cmd := &cobra.Command{
+		Use:   "configure",
+		Short: "Configure cloud target discovery",
+		Long: heredoc.Docf(`
+			Configure how Octopus discovers Azure and AWS targets, including AKS and EKS clusters,
+			when a deployment or runbook run starts.
+
+			Discovery is configured by variables in a project or library variable set, which may
+			be scoped to environments. Azure discovery looks in the subscription of its account.
+			AWS discovery looks in the regions given, using an account or the instance role of the
+			worker, and can assume a role.
+
+			Octopus discovers the cloud resources tagged with %[1]s and %[2]s. The tags
+			to apply are shown once discovery is configured.
+		`, shared.TagEnvironment, shared.TagRole),
+		Example: heredoc.Docf(`
+			%[1]s deployment-target discovery configure --project "Web App" --cloud azure --account "Azure Prod" --environment Production
+			%[1]s deployment-target discovery configure --variable-set "Cloud" --cloud aws --use-instance-role --region us-east-1 --region us-west-2
+		`, constants.ExecutableName),
+		Aliases: []string{"set"},
+		RunE: func(c *cobra.Command, _ []string) error {
+			opts := NewConfigureOptions(configureFlags, cmd.NewDependencies(f, c))
+			return ConfigureRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	shared.RegisterOwnerFlags(cmd, configureFlags.OwnerFlags)
+	flags.StringVar(&configureFlags.Cloud.Value, configureFlags.Cloud.Name, "", fmt.Sprintf("The cloud to discover targets in: %s", strings.Join(shared.Clouds, ", ")))
+	flags.StringVar(&configureFlags.Account.Value, configureFlags.Account.Name, "", "Name or ID of the account to discover targets with")
+	flags.StringArrayVar(&configureFlags.Regions.Value, configureFlags.Regions.Name, nil, "An AWS region to discover targets in. Multiple regions can be supplied")
+	flags.BoolVar(&configureFlags.UseInstanceRole.Value, configureFlags.UseInstanceRole.Name, false, "Discover AWS targets with the instance role of the worker instead of an account")
+	flags.StringVar(&configureFlags.AssumeRoleArn.Value, configureFlags.AssumeRoleArn.Name, "", "The ARN of an AWS role to assume when discovering targets")
+	flags.StringArrayVarP(&configureFlags.Environments.Value, configureFlags.Environments.Name, "e", nil, "Only configure discovery in this environment. Multiple environments can be supplied")
+	flags.StringArrayVar(&configureFlags.Roles.Value, configureFlags.Roles.Name, nil, "A role to show the tags for. Multiple roles can be supplied")
+	flags.BoolVar(&configureFlags.DryRun.Value, configureFlags.DryRun.Name, false, "Show what would change without saving it")
+
+	return cmd
+}
+
+func ConfigureRun(opts *ConfigureOptions) error {
+	owner, err := shared.ResolveOwner(opts.OwnerFlags, opts.OwnerCallbacks)
+	if err != nil {
+		return err
+	}
+	if !opts.NoPrompt {
+		if err := PromptMissing(opts); err != nil {
+			return err
+		}
+	}
+
+	if opts.Cloud.Value == "" {
+		return fmt.Errorf("must supply the cloud to discover targets in with --%s", FlagCloud)
+	}
+	cloud, err := shared.ParseCloud(opts.Cloud.Value)
+	if err != nil {
+		return err
+	}
+	settings := &shared.Settings{
+		Cloud:           cloud,
+		UseInstanceRole: opts.UseInstanceRole.Value,
+		Regions:         opts.Regions.Value,
+		AssumeRoleArn:   opts.AssumeRoleArn.Value,
+	}
+
+	var account accounts.IAccount
+	if opts.Account.Value != "" {
+		if account, err = opts.GetAccountCallback(opts.Account.Value); err != nil {
+			return err
+		}
+		if err := shared.CheckAccount(settings, account); err != nil {
+			return err
+		}
+		settings.AccountId = account.GetID()
+	}
+
+	var environmentIDs []string
+	if len(opts.Environments.Value) > 0 {
+		environmentMap, err := opts.GetEnvironmentMapCallback()
+		if err != nil {
+			return err
+		}
+		if environmentIDs, err = machinescommon.ResolveIDs(opts.Environments.Value, environmentMap, "environment"); err != nil {
+			return err
+		}
+	}
+
+	variableSet, err := opts.GetVariablesCallback(owner.Id)
+	if err != nil {
+		return err
+	}
+	changes, err := shared.PlanConfigure(variableSet, settings, environmentIDs)
+	if err != nil {
+		return err
+	}
+
+	scope := ""
+	if len(opts.Environments.Value) > 0 {
+		scope = fmt.Sprintf(" [Environments: %s]", strings.Join(opts.Environments.Value, ", "))
+	}
+	fmt.Fprintf(opts.Out, "Discovery of %s targets in %s%s\n", cloud, owner, scope)
+	for _, change := range changes {
+		fmt.Fprintf(opts.Out, "  %s\n", describeChange(change, account))
+	}
+	if account != nil {
+		fmt.Fprintf(opts.Out, "Account: %s\n", shared.DescribeAccount(account))
+	}
+	fmt.Fprintf(opts.Out, "Tag the %s resources to discover with:\n", cloud)
+	for _, tag := range shared.RequiredTags(opts.Environments.Value, opts.Roles.Value) {
+		fmt.Fprintf(opts.Out, "  %s\n", tag)
+	}
+
+	if !shared.HasChanges(changes) {
+		_, err = fmt.Fprintln(opts.Out, output.Dim("Discovery is already configured this way"))
+		return err
+	}
+	if opts.DryRun.Value {
+		_, err = fmt.Fprintln(opts.Out, output.Dim("Dry run: no changes were saved"))
+		return err
+	}
+
+	if err := opts.UpdateVariablesCallback(owner.Id, variableSet); err != nil {
+		return err
+	}
+	fmt.Fprintf(opts.Out, "Successfully configured discovery of %s targets in %s\n", cloud, owner)
+
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Project, opts.VariableSet, opts.Cloud, opts.Account, opts.Regions, opts.UseInstanceRole, opts.AssumeRoleArn, opts.Environments)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+	return nil
+}
+
+func describeChange(change *shared.Change, account accounts.IAccount) string {
+	value := change.Value
+	if account != nil && value == account.GetID() {
+		value = account.GetName()
+	}
+	switch change.Action {
+	case shared.ChangeAdded:
+		return output.Green(fmt.Sprintf("+ %s = %s", change.Name, value))
+	case shared.ChangeUpdated:
+		return output.Yellow(fmt.Sprintf("~ %s = %s", change.Name, value))
+	case shared.ChangeRemoved:
+		return output.Red(fmt.Sprintf("- %s", change.Name))
+	}
+	return output.Dimf("  %s = %s (unchanged)", change.Name, value)
+}
+
+// PromptMissing asks for the cloud, the account and, for AWS, the regions when they were not given.
+func PromptMissing(opts *ConfigureOptions) error {
+	if opts.Cloud.Value == "" {
+		if err := opts.Ask(&survey.Select{
+			Message: "Which cloud do you want to discover targets in?",
+			Options: shared.Clouds,
+		}, &opts.Cloud.Value); err != nil {
+			return err
+		}
+	}
+	cloud, err := shared.ParseCloud(opts.Cloud.Value)
+	if err != nil {
+		return err
+	}
+
+	if opts.Account.Value == "" && !opts.UseInstanceRole.Value {
+		var candidates []accounts.IAccount
+		for _, accountType := range shared.AccountTypes[cloud] {
+			found, err := opts.GetAccountsByTypeCallback(accountType)
+			if err != nil {
+				return err
+			}
+			candidates = append(candidates, found...)
+		}
+		if len(candidates) == 0 {
+			return fmt.Errorf("there are no accounts which can discover %s targets", cloud)
+		}
+		account, err := selectors.ByName(opts.Ask, candidates, "Select the account to discover targets with")
+		if err != nil {
+			return err
+		}
+		opts.Account.Value = account.GetName()
+	}
+
+	if cloud == shared.CloudAws && len(opts.Regions.Value) == 0 {
+		var regions string
+		if err := opts.Ask(&survey.Input{
+			Message: "AWS regions to discover targets in, separated by commas",
+		}, &regions, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+		for _, region := range strings.Split(regions, ",") {
+			if region = strings.TrimSpace(region); region != "" {
+				opts.Regions.Value = append(opts.Regions.Value, region)
+			}
+		}
+	}
+	return nil
+}
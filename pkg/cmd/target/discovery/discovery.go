@@ -0,0 +1,29 @@
+package discovery
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	cmdCheck "github.com/OctopusDeploy/cli/pkg/cmd/target/discovery/check"
+	cmdConfigure "github.com/OctopusDeploy/cli/pkg/cmd/target/discovery/configure"
+	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/target/discovery/view"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdDiscovery(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "discovery <command>",
+		Short: "Manage cloud target discovery",
+		Long: heredoc.Doc(`
+			Manage how Octopus Deploy discovers Azure, AWS and Kubernetes deployment targets from
+			the tags of cloud resources.
+		`),
+		Example: heredoc.Docf("%s deployment-target discovery view --project \"Web App\"", constants.ExecutableName),
+	}
+
+	cmd.AddCommand(cmdConfigure.NewCmdConfigure(f))
+	cmd.AddCommand(cmdView.NewCmdView(f))
+	cmd.AddCommand(cmdCheck.NewCmdCheck(f))
+
+	return cmd
+}
@@ -0,0 +1,325 @@
+package shared
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+)
+
+const (
+	CloudAzure = "azure"
+	CloudAws   = "aws"
+
+	// The variables Octopus reads to discover cloud targets when a deployment or runbook run starts.
+	VariableAzureAccount       = "Octopus.Azure.Account"
+	VariableAwsAccount         = "Octopus.Aws.Account"
+	VariableAwsUseInstanceRole = "Octopus.Aws.UseInstanceRole"
+	VariableAwsRegions         = "Octopus.Aws.Regions"
+	VariableAwsAssumeRole      = "Octopus.Aws.AssumeRole"
+	VariableAwsAssumedRoleArn  = "Octopus.Aws.AssumedRole.Arn"
+
+	// TagEnvironment and TagRole are the tags a cloud resource needs to be discovered.
+	TagEnvironment = "octopus-environment"
+	TagRole        = "octopus-role"
+
+	ChangeAdded     = "added"
+	ChangeUpdated   = "updated"
+	ChangeRemoved   = "removed"
+	ChangeUnchanged = "unchanged"
+
+	variableTypeString     = "String"
+	variableTypeAzure      = "AzureAccount"
+	variableTypeAws        = "AmazonWebServicesAccount"
+	variableValueTrue      = "True"
+	regionSeparator        = ","
+	unscopedEnvironmentKey = ""
+)
+
+// Clouds are the clouds whose targets Octopus can discover.
+var Clouds = []string{CloudAzure, CloudAws}
+
+// CloudVariables are the variables which configure discovery in each cloud.
+var CloudVariables = map[string][]string{
+	CloudAzure: {VariableAzureAccount},
+	CloudAws:   {VariableAwsAccount, VariableAwsUseInstanceRole, VariableAwsRegions, VariableAwsAssumeRole, VariableAwsAssumedRoleArn},
+}
+
+// AccountTypes are the types of account which can discover targets in each cloud.
+var AccountTypes = map[string][]accounts.AccountType{
+	CloudAzure: {accounts.AccountTypeAzureServicePrincipal, accounts.AccountTypeAzureOIDC},
+	CloudAws:   {accounts.AccountTypeAmazonWebServicesAccount, accounts.AccountTypeAwsOIDC},
+}
+
+// DiscoveredCommunicationStyles are the communication styles of the targets Octopus discovers.
+var DiscoveredCommunicationStyles = []string{"AzureWebApp", "AzureCloudService", "AzureServiceFabricCluster", "Kubernetes", "StepPackage"}
+
+// Settings are how Octopus discovers targets in one cloud.
+type Settings struct {
+	Cloud string `json:"Cloud"`
+	// AccountId is the account discovery uses. AWS discovery can use the instance role of the
+	// worker instead.
+	AccountId       string   `json:"AccountId,omitempty"`
+	UseInstanceRole bool     `json:"UseInstanceRole,omitempty"`
+	Regions         []string `json:"Regions,omitempty"`
+	AssumeRoleArn   string   `json:"AssumeRoleArn,omitempty"`
+}
+
+// Change is what happens to one discovery variable when discovery is configured.
+type Change struct {
+	Name   string `json:"Name"`
+	Action string `json:"Action"`
+	Value  string `json:"Value,omitempty"`
+}
+
+// ParseCloud checks the cloud given, and returns it as it is named here.
+func ParseCloud(value string) (string, error) {
+	cloud, ok := machinescommon.FindIgnoringCase(Clouds, value)
+	if !ok {
+		return "", fmt.Errorf("unknown cloud '%s', must be one of %s", value, strings.Join(Clouds, ", "))
+	}
+	return cloud, nil
+}
+
+// Validate checks the settings make sense for their cloud.
+func (s *Settings) Validate() error {
+	switch s.Cloud {
+	case CloudAzure:
+		if s.AccountId == "" {
+			return fmt.Errorf("azure discovery needs an account")
+		}
+		if s.UseInstanceRole || len(s.Regions) > 0 || s.AssumeRoleArn != "" {
+			return fmt.Errorf("azure discovery uses the subscription of its account, so regions and roles cannot be given")
+		}
+	case CloudAws:
+		if s.AccountId == "" && !s.UseInstanceRole {
+			return fmt.Errorf("aws discovery needs an account or the instance role of the worker")
+		}
+		if s.AccountId != "" && s.UseInstanceRole {
+			return fmt.Errorf("aws discovery can use an account or the instance role of the worker, but not both")
+		}
+		if len(s.Regions) == 0 {
+			return fmt.Errorf("aws discovery needs at least one region")
+		}
+	default:
+		return fmt.Errorf("unknown cloud '%s', must be one of %s", s.Cloud, strings.Join(Clouds, ", "))
+	}
+	return nil
+}
+
+// values are the values of the discovery variables of the cloud, with those left out which
+// the settings do not use.
+func (s *Settings) values() map[string]string {
+	values := map[string]string{}
+	switch s.Cloud {
+	case CloudAzure:
+		values[VariableAzureAccount] = s.AccountId
+	case CloudAws:
+		if s.UseInstanceRole {
+			values[VariableAwsUseInstanceRole] = variableValueTrue
+		} else {
+			values[VariableAwsAccount] = s.AccountId
+		}
+		values[VariableAwsRegions] = strings.Join(s.Regions, regionSeparator)
+		if s.AssumeRoleArn != "" {
+			values[VariableAwsAssumeRole] = variableValueTrue
+			values[VariableAwsAssumedRoleArn] = s.AssumeRoleArn
+		}
+	}
+	return values
+}
+
+func variableType(name string) string {
+	switch name {
+	case VariableAzureAccount:
+		return variableTypeAzure
+	case VariableAwsAccount:
+		return variableTypeAws
+	}
+	return variableTypeString
+}
+
+// PlanConfigure sets the discovery variables of the cloud which are scoped to exactly the given
+// environments, adding those that do not exist and removing those the settings no longer use.
+// Variables with any other scope are left alone.
+func PlanConfigure(variableSet *variables.VariableSet, settings *Settings, environmentIDs []string) ([]*Change, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	values := settings.values()
+	key := environmentKey(environmentIDs)
+
+	var changes []*Change
+	for _, name := range CloudVariables[settings.Cloud] {
+		matching := util.SliceFilter(variableSet.Variables, func(v *variables.Variable) bool {
+			return strings.EqualFold(v.Name, name) && isEnvironmentScope(v.Scope) && environmentKey(v.Scope.Environments) == key
+		})
+		if len(matching) > 1 {
+			return nil, fmt.Errorf("there is more than one variable called '%s' with the same scope", name)
+		}
+
+		value, wanted := values[name]
+		switch {
+		case len(matching) == 0 && wanted:
+			v := variables.NewVariable(name)
+			v.Type = variableType(name)
+			v.Value = value
+			if len(environmentIDs) > 0 {
+				v.Scope = variables.VariableScope{Environments: environmentIDs}
+			}
+			variableSet.Variables = append(variableSet.Variables, v)
+			changes = append(changes, &Change{Name: name, Action: ChangeAdded, Value: value})
+		case len(matching) == 1 && !wanted:
+			variableSet.Variables = util.SliceFilter(variableSet.Variables, func(v *variables.Variable) bool { return v != matching[0] })
+			changes = append(changes, &Change{Name: matching[0].Name, Action: ChangeRemoved})
+		case len(matching) == 1 && matching[0].Value == value:
+			changes = append(changes, &Change{Name: matching[0].Name, Action: ChangeUnchanged, Value: value})
+		case len(matching) == 1:
+			matching[0].Value = value
+			matching[0].Type = variableType(name)
+			changes = append(changes, &Change{Name: matching[0].Name, Action: ChangeUpdated, Value: value})
+		}
+	}
+	return changes, nil
+}
+
+// HasChanges is whether any discovery variable changed.
+func HasChanges(changes []*Change) bool {
+	return util.SliceContainsAny(changes, func(c *Change) bool { return c.Action != ChangeUnchanged })
+}
+
+// IsDiscoveryVariable is whether a variable configures discovery.
+func IsDiscoveryVariable(v *variables.Variable) bool {
+	for _, names := range CloudVariables {
+		if _, ok := machinescommon.FindIgnoringCase(names, v.Name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveSettings works out how targets are discovered in an environment from the discovery
+// variables of a project and the library variable sets it includes, given in that order. As in a
+// deployment, variables scoped to the environment are preferred over those with no scope, and at
+// the same scope an earlier variable set is preferred over a later one. Variables with any scope
+// besides environments are not considered.
+func ResolveSettings(environmentID string, variableSets ...*variables.VariableSet) []*Settings {
+	values := map[string]string{}
+	scoped := map[string]bool{}
+	for i := len(variableSets) - 1; i >= 0; i-- {
+		for _, v := range variableSets[i].Variables {
+			if !IsDiscoveryVariable(v) || !isEnvironmentScope(v.Scope) {
+				continue
+			}
+			name := canonicalName(v.Name)
+			forEnvironment := util.SliceContainsAny(v.Scope.Environments, func(id string) bool { return strings.EqualFold(id, environmentID) })
+			switch {
+			case forEnvironment:
+				values[name] = v.Value
+				scoped[name] = true
+			case len(v.Scope.Environments) == 0 && !scoped[name]:
+				values[name] = v.Value
+			}
+		}
+	}
+
+	var resolved []*Settings
+	if account := values[VariableAzureAccount]; account != "" {
+		resolved = append(resolved, &Settings{Cloud: CloudAzure, AccountId: account})
+	}
+	account, useInstanceRole := values[VariableAwsAccount], strings.EqualFold(values[VariableAwsUseInstanceRole], variableValueTrue)
+	if account != "" || useInstanceRole {
+		settings := &Settings{Cloud: CloudAws, AccountId: account, UseInstanceRole: useInstanceRole}
+		for _, region := range strings.Split(values[VariableAwsRegions], regionSeparator) {
+			if region = strings.TrimSpace(region); region != "" {
+				settings.Regions = append(settings.Regions, region)
+			}
+		}
+		if strings.EqualFold(values[VariableAwsAssumeRole], variableValueTrue) {
+			settings.AssumeRoleArn = values[VariableAwsAssumedRoleArn]
+		}
+		resolved = append(resolved, settings)
+	}
+	return resolved
+}
+
+// CheckAccount checks an account can discover targets in the cloud of the settings.
+func CheckAccount(settings *Settings, account accounts.IAccount) error {
+	for _, accountType := range AccountTypes[settings.Cloud] {
+		if account.GetAccountType() == accountType {
+			return nil
+		}
+	}
+	return fmt.Errorf("account '%s' is a %s account, which cannot discover %s targets", account.GetName(), account.GetAccountType(), settings.Cloud)
+}
+
+// RequiredTags are the tags a cloud resource needs to be discovered as a target in the
+// environments with the roles given.
+func RequiredTags(environments []string, roles []string) []string {
+	var tags []string
+	if len(environments) == 0 {
+		environments = []string{"<environment>"}
+	}
+	for _, environment := range environments {
+		tags = append(tags, fmt.Sprintf("%s=%s", TagEnvironment, environment))
+	}
+	if len(roles) == 0 {
+		roles = []string{"<role>"}
+	}
+	for _, role := range roles {
+		tags = append(tags, fmt.Sprintf("%s=%s", TagRole, role))
+	}
+	return tags
+}
+
+// DescribeAccount names an account, with the subscription an Azure account discovers targets in.
+func DescribeAccount(account accounts.IAccount) string {
+	switch a := account.(type) {
+	case *accounts.AzureServicePrincipalAccount:
+		if a.SubscriptionID != nil {
+			return fmt.Sprintf("%s (subscription %s)", a.GetName(), a.SubscriptionID)
+		}
+	case *accounts.AzureOIDCAccount:
+		if a.SubscriptionID != nil {
+			return fmt.Sprintf("%s (subscription %s)", a.GetName(), a.SubscriptionID)
+		}
+	}
+	return account.GetName()
+}
+
+// IsDiscoveredStyle is whether targets with a communication style can be discovered.
+func IsDiscoveredStyle(communicationStyle string) bool {
+	_, ok := machinescommon.FindIgnoringCase(DiscoveredCommunicationStyles, communicationStyle)
+	return ok
+}
+
+func canonicalName(name string) string {
+	for _, names := range CloudVariables {
+		if found, ok := machinescommon.FindIgnoringCase(names, name); ok {
+			return found
+		}
+	}
+	return name
+}
+
+func isEnvironmentScope(scope variables.VariableScope) bool {
+	return len(scope.Channels) == 0 && len(scope.Machines) == 0 && len(scope.Actions) == 0 &&
+		len(scope.Roles) == 0 && len(scope.TenantTags) == 0 && len(scope.ProcessOwners) == 0
+}
+
+// environmentKey is the same for the same environments, whatever order they are in.
+func environmentKey(environmentIDs []string) string {
+	if len(environmentIDs) == 0 {
+		return unscopedEnvironmentKey
+	}
+	sorted := make([]string, len(environmentIDs))
+	for i, id := range environmentIDs {
+		sorted[i] = strings.ToLower(id)
+	}
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
@@ -0,0 +1,116 @@
+package shared_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/discovery/shared"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVariable(name string, value string, environments ...string) *variables.Variable {
+	v := variables.NewVariable(name)
+	v.Value = value
+	v.Scope = variables.VariableScope{Environments: environments}
+	return v
+}
+
+func TestPlanConfigure_AddsAndUpdatesVariablesWithTheSameScope(t *testing.T) {
+	variableSet := &variables.VariableSet{Variables: []*variables.Variable{
+		newVariable(shared.VariableAwsAccount, "Accounts-1", "Environments-1"),
+		newVariable(shared.VariableAwsRegions, "us-east-1"),
+	}}
+
+	changes, err := shared.PlanConfigure(variableSet, &shared.Settings{Cloud: shared.CloudAws, AccountId: "Accounts-2", Regions: []string{"us-east-1", "us-west-2"}}, []string{"Environments-1"})
+	require.Nil(t, err)
+	require.True(t, shared.HasChanges(changes))
+
+	assert.Equal(t, []*shared.Change{
+		{Name: shared.VariableAwsAccount, Action: shared.ChangeUpdated, Value: "Accounts-2"},
+		{Name: shared.VariableAwsRegions, Action: shared.ChangeAdded, Value: "us-east-1,us-west-2"},
+	}, changes)
+	assert.Equal(t, "Accounts-2", variableSet.Variables[0].Value)
+	assert.Equal(t, "AmazonWebServicesAccount", variableSet.Variables[0].Type)
+	// the unscoped regions are left alone
+	assert.Equal(t, "us-east-1", variableSet.Variables[1].Value)
+	assert.Equal(t, []string{"Environments-1"}, variableSet.Variables[2].Scope.Environments)
+
+	changes, err = shared.PlanConfigure(variableSet, &shared.Settings{Cloud: shared.CloudAws, AccountId: "Accounts-2", Regions: []string{"us-east-1", "us-west-2"}}, []string{"Environments-1"})
+	require.Nil(t, err)
+	assert.False(t, shared.HasChanges(changes))
+}
+
+func TestPlanConfigure_RemovesVariablesNoLongerUsed(t *testing.T) {
+	variableSet := &variables.VariableSet{Variables: []*variables.Variable{
+		newVariable(shared.VariableAwsAccount, "Accounts-1"),
+		newVariable(shared.VariableAwsRegions, "us-east-1"),
+	}}
+
+	changes, err := shared.PlanConfigure(variableSet, &shared.Settings{Cloud: shared.CloudAws, UseInstanceRole: true, Regions: []string{"us-east-1"}}, nil)
+	require.Nil(t, err)
+
+	assert.Equal(t, []*shared.Change{
+		{Name: shared.VariableAwsAccount, Action: shared.ChangeRemoved},
+		{Name: shared.VariableAwsUseInstanceRole, Action: shared.ChangeAdded, Value: "True"},
+		{Name: shared.VariableAwsRegions, Action: shared.ChangeUnchanged, Value: "us-east-1"},
+	}, changes)
+	assert.Len(t, variableSet.Variables, 2)
+}
+
+func TestPlanConfigure_InvalidSettings(t *testing.T) {
+	variableSet := &variables.VariableSet{}
+
+	_, err := shared.PlanConfigure(variableSet, &shared.Settings{Cloud: shared.CloudAws, AccountId: "Accounts-1"}, nil)
+	assert.EqualError(t, err, "aws discovery needs at least one region")
+
+	_, err = shared.PlanConfigure(variableSet, &shared.Settings{Cloud: shared.CloudAzure, AccountId: "Accounts-1", Regions: []string{"us-east-1"}}, nil)
+	assert.EqualError(t, err, "azure discovery uses the subscription of its account, so regions and roles cannot be given")
+}
+
+func TestResolveSettings_PrefersVariablesScopedToTheEnvironment(t *testing.T) {
+	variableSet := &variables.VariableSet{Variables: []*variables.Variable{
+		newVariable(shared.VariableAzureAccount, "Accounts-1"),
+		newVariable(shared.VariableAzureAccount, "Accounts-2", "Environments-2"),
+		newVariable(shared.VariableAwsUseInstanceRole, "True"),
+		newVariable(shared.VariableAwsRegions, "us-east-1, us-west-2", "Environments-1"),
+	}}
+
+	settings := shared.ResolveSettings("Environments-1", variableSet)
+	assert.Equal(t, []*shared.Settings{
+		{Cloud: shared.CloudAzure, AccountId: "Accounts-1"},
+		{Cloud: shared.CloudAws, UseInstanceRole: true, Regions: []string{"us-east-1", "us-west-2"}},
+	}, settings)
+
+	settings = shared.ResolveSettings("Environments-2", variableSet)
+	assert.Equal(t, "Accounts-2", settings[0].AccountId)
+	assert.Nil(t, settings[1].Regions)
+}
+
+func TestResolveSettings_IncludesLibraryVariableSets(t *testing.T) {
+	project := &variables.VariableSet{Variables: []*variables.Variable{
+		newVariable(shared.VariableAzureAccount, "Accounts-1"),
+	}}
+	library := &variables.VariableSet{Variables: []*variables.Variable{
+		newVariable(shared.VariableAzureAccount, "Accounts-2"),
+		newVariable(shared.VariableAzureAccount, "Accounts-3", "Environments-2"),
+		newVariable(shared.VariableAwsUseInstanceRole, "True"),
+		newVariable(shared.VariableAwsRegions, "us-east-1"),
+	}}
+
+	// the project wins at the same scope, and its library variable sets still add the aws settings
+	settings := shared.ResolveSettings("Environments-1", project, library)
+	assert.Equal(t, []*shared.Settings{
+		{Cloud: shared.CloudAzure, AccountId: "Accounts-1"},
+		{Cloud: shared.CloudAws, UseInstanceRole: true, Regions: []string{"us-east-1"}},
+	}, settings)
+
+	// a variable scoped to the environment wins wherever it comes from
+	settings = shared.ResolveSettings("Environments-2", project, library)
+	assert.Equal(t, "Accounts-3", settings[0].AccountId)
+}
+
+func TestRequiredTags(t *testing.T) {
+	assert.Equal(t, []string{"octopus-environment=Production", "octopus-role=web-app", "octopus-role=api"}, shared.RequiredTags([]string{"Production"}, []string{"web-app", "api"}))
+	assert.Equal(t, []string{"octopus-environment=<environment>", "octopus-role=<role>"}, shared.RequiredTags(nil, nil))
+}
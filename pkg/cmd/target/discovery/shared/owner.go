@@ -0,0 +1,117 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	tenantShared "github.com/OctopusDeploy/cli/pkg/cmd/tenant/shared"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagProject     = "project"
+	FlagVariableSet = "variable-set"
+
+	OwnerProject     = "project"
+	OwnerVariableSet = "library variable set"
+)
+
+// OwnerFlags choose the project or library variable set whose variables configure discovery.
+type OwnerFlags struct {
+	Project     *flag.Flag[string]
+	VariableSet *flag.Flag[string]
+}
+
+func NewOwnerFlags() *OwnerFlags {
+	return &OwnerFlags{
+		Project:     flag.New[string](FlagProject, false),
+		VariableSet: flag.New[string](FlagVariableSet, false),
+	}
+}
+
+func RegisterOwnerFlags(cmd *cobra.Command, flags *OwnerFlags) {
+	cmd.Flags().StringVarP(&flags.Project.Value, flags.Project.Name, "p", "", "Name or ID of the project whose variables configure discovery")
+	cmd.Flags().StringVar(&flags.VariableSet.Value, flags.VariableSet.Name, "", "Name or ID of the library variable set whose variables configure discovery")
+}
+
+// Owner is the project or library variable set whose variables configure discovery.
+type Owner struct {
+	Id   string
+	Name string
+	Kind string
+	// IncludedIds are the library variable sets a project includes, whose discovery variables
+	// also apply to its deployments.
+	IncludedIds []string
+}
+
+func (o *Owner) String() string {
+	return fmt.Sprintf("%s '%s'", o.Kind, o.Name)
+}
+
+type OwnerCallbacks struct {
+	ResolveProjectCallback            func(identifier string) (*projects.Project, error)
+	GetAllLibraryVariableSetsCallback func() ([]*variables.LibraryVariableSet, error)
+	GetVariablesCallback              func(ownerID string) (*variables.VariableSet, error)
+	UpdateVariablesCallback           func(ownerID string, variableSet *variables.VariableSet) error
+}
+
+func NewOwnerCallbacks(dependencies *cmd.Dependencies) *OwnerCallbacks {
+	return &OwnerCallbacks{
+		ResolveProjectCallback: func(identifier string) (*projects.Project, error) {
+			return selectors.ResolveProject(dependencies.Client, dependencies.Ask, !dependencies.NoPrompt,
+				"Select the project whose variables configure discovery", identifier)
+		},
+		GetAllLibraryVariableSetsCallback: func() ([]*variables.LibraryVariableSet, error) {
+			return tenantShared.GetAllLibraryVariableSets(dependencies.Client)
+		},
+		GetVariablesCallback: func(ownerID string) (*variables.VariableSet, error) {
+			variableSet, err := dependencies.Client.Variables.GetAll(ownerID)
+			if err != nil {
+				return nil, err
+			}
+			return &variableSet, nil
+		},
+		UpdateVariablesCallback: func(ownerID string, variableSet *variables.VariableSet) error {
+			_, err := dependencies.Client.Variables.Update(ownerID, *variableSet)
+			return err
+		},
+	}
+}
+
+// ResolveOwner finds the library variable set or project given, prompting for a project when
+// neither was given. The variables of a version controlled project are kept in git, so
+// discovery cannot be configured in one.
+func ResolveOwner(flags *OwnerFlags, callbacks *OwnerCallbacks) (*Owner, error) {
+	if flags.Project.Value != "" && flags.VariableSet.Value != "" {
+		return nil, fmt.Errorf("cannot use --%s and --%s together", FlagProject, FlagVariableSet)
+	}
+
+	if flags.VariableSet.Value != "" {
+		libraryVariableSets, err := callbacks.GetAllLibraryVariableSetsCallback()
+		if err != nil {
+			return nil, err
+		}
+		matching := util.SliceFilter(libraryVariableSets, func(item *variables.LibraryVariableSet) bool {
+			return strings.EqualFold(item.Name, flags.VariableSet.Value) || strings.EqualFold(item.GetID(), flags.VariableSet.Value)
+		})
+		if len(matching) == 0 {
+			return nil, fmt.Errorf("cannot find library variable set '%s'", flags.VariableSet.Value)
+		}
+		return &Owner{Id: matching[0].GetID(), Name: matching[0].Name, Kind: OwnerVariableSet}, nil
+	}
+
+	project, err := callbacks.ResolveProjectCallback(flags.Project.Value)
+	if err != nil {
+		return nil, err
+	}
+	if project.IsVersionControlled {
+		return nil, fmt.Errorf("the variables of project '%s' are version controlled, so configure discovery in a library variable set it includes with --%s", project.GetName(), FlagVariableSet)
+	}
+	return &Owner{Id: project.GetID(), Name: project.GetName(), Kind: OwnerProject, IncludedIds: project.IncludedLibraryVariableSets}, nil
+}
@@ -0,0 +1,151 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	accountShared "github.com/OctopusDeploy/cli/pkg/cmd/account/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/discovery/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/spf13/cobra"
+)
+
+type ViewOptions struct {
+	*shared.OwnerFlags
+	*cmd.Dependencies
+	*shared.OwnerCallbacks
+	Command            *cobra.Command
+	GetAccountCallback accountShared.GetAccountCallback
+}
+
+// DiscoveryVariable is a variable which configures discovery, with the environments it is scoped to.
+type DiscoveryVariable struct {
+	Name         string   `json:"Name"`
+	Value        string   `json:"Value"`
+	Environments []string `json:"Environments"`
+}
+
+func NewCmdView(f factory.Factory) *cobra.Command {
+	ownerFlags := shared.NewOwnerFlags()
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "View cloud target discovery",
+		Long:  "View the variables of a project or library variable set which configure cloud target discovery",
+		Example: heredoc.Docf(`
+			%[1]s deployment-target discovery view --project "Web App"
+			%[1]s deployment-target discovery view --variable-set "Cloud" -f json
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, _ []string) error {
+			dependencies := cmd.NewDependencies(f, c)
+			opts := &ViewOptions{
+				OwnerFlags:     ownerFlags,
+				Dependencies:   dependencies,
+				OwnerCallbacks: shared.NewOwnerCallbacks(dependencies),
+				Command:        c,
+				GetAccountCallback: func(idOrName string) (accounts.IAccount, error) {
+					return accountShared.GetAccount(dependencies.Client, idOrName)
+				},
+			}
+			return ViewRun(opts)
+		},
+	}
+
+	shared.RegisterOwnerFlags(cmd, ownerFlags)
+
+	return cmd
+}
+
+func ViewRun(opts *ViewOptions) error {
+	owner, err := shared.ResolveOwner(opts.OwnerFlags, opts.OwnerCallbacks)
+	if err != nil {
+		return err
+	}
+	variableSet, err := opts.GetVariablesCallback(owner.Id)
+	if err != nil {
+		return err
+	}
+
+	var environmentReferences []*resources.ReferenceDataItem
+	if variableSet.ScopeValues != nil {
+		environmentReferences = variableSet.ScopeValues.Environments
+	}
+	accountNames := map[string]string{}
+	discoveryVariables := []*DiscoveryVariable{}
+	for _, v := range util.SliceFilter(variableSet.Variables, shared.IsDiscoveryVariable) {
+		value := v.Value
+		if isAccountVariable(v) {
+			if _, ok := accountNames[value]; !ok {
+				accountNames[value] = value
+				if account, err := opts.GetAccountCallback(value); err == nil {
+					accountNames[value] = account.GetName()
+				}
+			}
+			value = accountNames[value]
+		}
+		discoveryVariables = append(discoveryVariables, &DiscoveryVariable{
+			Name:         v.Name,
+			Value:        value,
+			Environments: environmentNames(v.Scope.Environments, environmentReferences),
+		})
+	}
+
+	outputFormat, _ := opts.Command.Flags().GetString(constants.FlagOutputFormat)
+	if len(discoveryVariables) == 0 && !constants.IsProgrammaticOutputFormat(outputFormat) {
+		_, err = fmt.Fprintf(opts.Out, "Cloud target discovery is not configured in %s\n", owner)
+		return err
+	}
+
+	err = output.PrintArray(discoveryVariables, opts.Command, output.Mappers[*DiscoveryVariable]{
+		Json: func(v *DiscoveryVariable) any {
+			return v
+		},
+		Table: output.TableDefinition[*DiscoveryVariable]{
+			Header: []string{"NAME", "VALUE", "ENVIRONMENTS"},
+			Row: func(v *DiscoveryVariable) []string {
+				return []string{output.Bold(v.Name), v.Value, describeEnvironments(v.Environments)}
+			},
+		},
+		Basic: func(v *DiscoveryVariable) string {
+			return fmt.Sprintf("%s = %s %s", v.Name, v.Value, output.Dimf("(%s)", describeEnvironments(v.Environments)))
+		},
+	})
+	if err != nil || constants.IsProgrammaticOutputFormat(outputFormat) {
+		return err
+	}
+	_, err = fmt.Fprintf(opts.Out, "\nOctopus discovers the cloud resources tagged with %s\n", strings.Join(shared.RequiredTags(nil, nil), " and "))
+	return err
+}
+
+func isAccountVariable(v *variables.Variable) bool {
+	return strings.EqualFold(v.Name, shared.VariableAzureAccount) || strings.EqualFold(v.Name, shared.VariableAwsAccount)
+}
+
+func environmentNames(ids []string, references []*resources.ReferenceDataItem) []string {
+	names := []string{}
+	for _, id := range ids {
+		name := id
+		for _, reference := range references {
+			if strings.EqualFold(reference.ID, id) {
+				name = reference.Name
+				break
+			}
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func describeEnvironments(environments []string) string {
+	if len(environments) == 0 {
+		return "All environments"
+	}
+	return output.FormatAsList(environments)
+}
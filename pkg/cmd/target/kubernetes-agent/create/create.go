@@ -0,0 +1,326 @@
+package create
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/executionscommon"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FlagName                 = "name"
+	FlagNamespace            = "namespace"
+	FlagReleaseName          = "release-name"
+	FlagDefaultNamespace     = "default-namespace"
+	FlagServerCommsAddress   = "server-comms-address"
+	FlagChartVersion         = "chart-version"
+	FlagValuesFile           = "values-file"
+	FlagAcceptEula           = "accept-eula"
+	defaultSpaceName         = "Default"
+	resourceDescription      = "Kubernetes agent"
+	eulaUrl                  = "https://octopus.com/company/legal"
+	acceptedEula             = "Y"
+	valuesFilePermissions    = 0644
+	valuesFileCommentPattern = "# Values for the Octopus Kubernetes agent %s.\n# The API key is not included, it is given when installing with --set agent.serverApiKey.\n"
+)
+
+type CreateFlags struct {
+	Name               *flag.Flag[string]
+	Namespace          *flag.Flag[string]
+	ReleaseName        *flag.Flag[string]
+	DefaultNamespace   *flag.Flag[string]
+	ServerCommsAddress *flag.Flag[string]
+	ChartVersion       *flag.Flag[string]
+	ValuesFile         *flag.Flag[string]
+	AcceptEula         *flag.Flag[bool]
+	*shared.CreateTargetEnvironmentFlags
+	*shared.CreateTargetRoleFlags
+	*machinescommon.CreateTargetMachinePolicyFlags
+	*shared.CreateTargetTenantFlags
+}
+
+type CreateOptions struct {
+	*CreateFlags
+	*shared.CreateTargetEnvironmentOptions
+	*shared.CreateTargetRoleOptions
+	*machinescommon.CreateTargetMachinePolicyOptions
+	*shared.CreateTargetTenantOptions
+	*cmd.Dependencies
+	Command *cobra.Command
+	// WriteFileCallback writes the values file.
+	WriteFileCallback func(path string, content []byte) error
+}
+
+func NewCreateFlags() *CreateFlags {
+	return &CreateFlags{
+		Name:                           flag.New[string](FlagName, false),
+		Namespace:                      flag.New[string](FlagNamespace, false),
+		ReleaseName:                    flag.New[string](FlagReleaseName, false),
+		DefaultNamespace:               flag.New[string](FlagDefaultNamespace, false),
+		ServerCommsAddress:             flag.New[string](FlagServerCommsAddress, false),
+		ChartVersion:                   flag.New[string](FlagChartVersion, false),
+		ValuesFile:                     flag.New[string](FlagValuesFile, false),
+		AcceptEula:                     flag.New[bool](FlagAcceptEula, false),
+		CreateTargetEnvironmentFlags:   shared.NewCreateTargetEnvironmentFlags(),
+		CreateTargetRoleFlags:          shared.NewCreateTargetRoleFlags(),
+		CreateTargetMachinePolicyFlags: machinescommon.NewCreateTargetMachinePolicyFlags(),
+		CreateTargetTenantFlags:        shared.NewCreateTargetTenantFlags(),
+	}
+}
+
+func NewCreateOptions(createFlags *CreateFlags, dependencies *cmd.Dependencies, command *cobra.Command) *CreateOptions {
+	return &CreateOptions{
+		CreateFlags:                      createFlags,
+		Dependencies:                     dependencies,
+		Command:                          command,
+		CreateTargetEnvironmentOptions:   shared.NewCreateTargetEnvironmentOptions(dependencies),
+		CreateTargetRoleOptions:          shared.NewCreateTargetRoleOptions(dependencies),
+		CreateTargetMachinePolicyOptions: machinescommon.NewCreateTargetMachinePolicyOptions(dependencies),
+		CreateTargetTenantOptions:        shared.NewCreateTargetTenantOptions(dependencies),
+		WriteFileCallback: func(path string, content []byte) error {
+			return os.WriteFile(path, content, valuesFilePermissions)
+		},
+	}
+}
+
+func NewCmdCreate(f factory.Factory) *cobra.Command {
+	createFlags := NewCreateFlags()
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create the Helm install of a Kubernetes agent",
+		Long: heredoc.Docf(`
+			Create the Helm command, and optionally the values file, which installs the Octopus
+			Kubernetes agent into a cluster. The agent registers itself as a deployment target when it
+			is installed.
+
+			The API key the agent registers with is read from the %[1]s environment variable of the
+			shell running the Helm command, so it is never written to the values file.
+		`, ApiKeyEnvironmentVariable),
+		Example: heredoc.Docf(`
+			%[1]s deployment-target kubernetes-agent create
+			%[1]s deployment-target kubernetes-agent create --name prod-cluster --environment Production --role k8s --accept-eula --no-prompt
+			%[1]s deployment-target kubernetes-agent create --name prod-cluster --environment Production --role k8s --accept-eula --values-file agent-values.yaml --no-prompt
+		`, constants.ExecutableName),
+		Aliases: []string{"new"},
+		RunE: func(c *cobra.Command, _ []string) error {
+			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c), c)
+
+			return createRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&createFlags.Name.Value, createFlags.Name.Name, "n", "", "A short, memorable, unique name for this Kubernetes agent.")
+	flags.StringVar(&createFlags.Namespace.Value, createFlags.Namespace.Name, "", "The Kubernetes namespace to install the agent into. Defaults to octopus-agent-<release name>")
+	flags.StringVar(&createFlags.ReleaseName.Value, createFlags.ReleaseName.Name, "", "The name of the Helm release. Defaults to the name of the agent")
+	flags.StringVar(&createFlags.DefaultNamespace.Value, createFlags.DefaultNamespace.Name, "", "The Kubernetes namespace deployments to this agent use when a step does not set one")
+	flags.StringVar(&createFlags.ServerCommsAddress.Value, createFlags.ServerCommsAddress.Name, "", fmt.Sprintf("The address the agent polls the Octopus Server at. Defaults to port %s of the server", pollingPort))
+	flags.StringVar(&createFlags.ChartVersion.Value, createFlags.ChartVersion.Name, DefaultChartVersion, "The version of the Kubernetes agent Helm chart")
+	flags.StringVar(&createFlags.ValuesFile.Value, createFlags.ValuesFile.Name, "", "Write the Helm values to this file, rather than giving them on the command line")
+	flags.BoolVar(&createFlags.AcceptEula.Value, createFlags.AcceptEula.Name, false, fmt.Sprintf("Accept the Octopus Deploy customer agreement, see %s", eulaUrl))
+	shared.RegisterCreateTargetEnvironmentFlags(cmd, createFlags.CreateTargetEnvironmentFlags)
+	shared.RegisterCreateTargetRoleFlags(cmd, createFlags.CreateTargetRoleFlags)
+	machinescommon.RegisterCreateTargetMachinePolicyFlags(cmd, createFlags.CreateTargetMachinePolicyFlags)
+	shared.RegisterCreateTargetTenantFlags(cmd, createFlags.CreateTargetTenantFlags)
+
+	return cmd
+}
+
+func createRun(opts *CreateOptions) error {
+	if !opts.NoPrompt {
+		if err := PromptMissing(opts); err != nil {
+			return err
+		}
+	}
+	if !opts.AcceptEula.Value {
+		return fmt.Errorf("the Octopus Deploy customer agreement must be accepted with --%s to install the Kubernetes agent, see %s", FlagAcceptEula, eulaUrl)
+	}
+	if opts.Name.Value == "" {
+		return fmt.Errorf("the name of the Kubernetes agent must be given with --%s", FlagName)
+	}
+
+	envs, err := executionscommon.FindEnvironments(opts.Client, opts.Environments.Value)
+	if err != nil {
+		return err
+	}
+	if len(envs) == 0 {
+		return fmt.Errorf("at least one environment must be given with --%s", shared.FlagEnvironment)
+	}
+	combinedRoles, err := shared.CombineRolesAndTags(opts.Client, opts.Roles.Value, opts.Tags.Value)
+	if err != nil {
+		return err
+	}
+	if len(combinedRoles) == 0 {
+		return fmt.Errorf("at least one role must be given with --%s or --%s", shared.FlagRole, shared.FlagTag)
+	}
+
+	values := &HelmValues{Agent: &AgentValues{
+		AcceptEula:                            acceptedEula,
+		TargetName:                            opts.Name.Value,
+		ServerUrl:                             opts.Host,
+		Space:                                 opts.GetSpaceNameOrEmpty(),
+		TargetEnvironments:                    util.SliceTransform(envs, func(e *environments.Environment) string { return e.Name }),
+		TargetRoles:                           util.SliceDistinct(combinedRoles),
+		TargetTenantedDeploymentParticipation: opts.TenantedDeploymentMode.Value,
+		TargetTenantTags:                      opts.TenantTags.Value,
+		DefaultNamespace:                      opts.DefaultNamespace.Value,
+	}}
+	if values.Agent.Space == "" {
+		values.Agent.Space = defaultSpaceName
+	}
+
+	commsAddress := opts.ServerCommsAddress.Value
+	if commsAddress == "" {
+		if commsAddress, err = DefaultServerCommsAddress(opts.Host); err != nil {
+			return err
+		}
+	}
+	values.Agent.ServerCommsAddresses = []string{commsAddress}
+
+	if opts.MachinePolicy.Value != "" {
+		machinePolicy, err := machinescommon.FindMachinePolicy(opts.GetAllMachinePoliciesCallback, opts.MachinePolicy.Value)
+		if err != nil {
+			return err
+		}
+		values.Agent.MachinePolicyName = machinePolicy.Name
+	}
+
+	if len(opts.Tenants.Value) > 0 {
+		if values.Agent.TargetTenants, err = findTenantNames(opts.GetAllTenantsCallback, opts.Tenants.Value); err != nil {
+			return err
+		}
+	}
+
+	install := &HelmInstall{
+		ReleaseName:  opts.ReleaseName.Value,
+		Namespace:    opts.Namespace.Value,
+		ChartVersion: opts.ChartVersion.Value,
+		ValuesFile:   opts.ValuesFile.Value,
+		Values:       values,
+	}
+	if install.ReleaseName == "" {
+		install.ReleaseName = DefaultReleaseName(opts.Name.Value)
+	}
+	if install.Namespace == "" {
+		install.Namespace = DefaultNamespace(install.ReleaseName)
+	}
+
+	if install.ValuesFile != "" {
+		content, err := yaml.Marshal(values)
+		if err != nil {
+			return err
+		}
+		content = append([]byte(fmt.Sprintf(valuesFileCommentPattern, opts.Name.Value)), content...)
+		if err := opts.WriteFileCallback(install.ValuesFile, content); err != nil {
+			return err
+		}
+	}
+
+	if err := printInstall(opts, install); err != nil {
+		return err
+	}
+
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Name, opts.Environments, opts.Roles, opts.Tags, opts.MachinePolicy, opts.TenantedDeploymentMode, opts.Tenants, opts.TenantTags, opts.Namespace, opts.ReleaseName, opts.DefaultNamespace, opts.ServerCommsAddress, opts.ValuesFile, opts.AcceptEula)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+
+	return nil
+}
+
+func printInstall(opts *CreateOptions, install *HelmInstall) error {
+	outputFormat, _ := opts.Command.Flags().GetString(constants.FlagOutputFormat)
+	if strings.EqualFold(outputFormat, constants.OutputFormatJson) {
+		data, err := json.MarshalIndent(struct {
+			ReleaseName string
+			Namespace   string
+			ValuesFile  string `json:",omitempty"`
+			Values      *HelmValues
+			Command     string
+		}{install.ReleaseName, install.Namespace, install.ValuesFile, install.Values, install.Command()}, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(opts.Out, string(data))
+		return err
+	}
+
+	if install.ValuesFile != "" {
+		fmt.Fprintf(opts.Out, "Wrote the Helm values for Kubernetes agent '%s' to %s.\n", opts.Name.Value, install.ValuesFile)
+	}
+	fmt.Fprintf(opts.Out, "Install the Kubernetes agent '%s' by running this command with %s set:\n\n", opts.Name.Value, ApiKeyEnvironmentVariable)
+	fmt.Fprintln(opts.Out, output.Cyan(install.Command()))
+	return nil
+}
+
+func findTenantNames(getAllTenants func() ([]*tenants.Tenant, error), namesOrIds []string) ([]string, error) {
+	allTenants, err := getAllTenants()
+	if err != nil {
+		return nil, err
+	}
+	tenantMap := make(map[string]string, len(allTenants))
+	for _, t := range allTenants {
+		tenantMap[t.GetID()] = t.Name
+	}
+	ids, err := machinescommon.ResolveIDs(namesOrIds, tenantMap, "tenant")
+	if err != nil {
+		return nil, err
+	}
+	return util.SliceTransform(ids, func(id string) string { return tenantMap[id] }), nil
+}
+
+func PromptMissing(opts *CreateOptions) error {
+	err := question.AskName(opts.Ask, "", resourceDescription, &opts.Name.Value)
+	if err != nil {
+		return err
+	}
+
+	err = shared.PromptForEnvironments(opts.CreateTargetEnvironmentOptions, opts.CreateTargetEnvironmentFlags)
+	if err != nil {
+		return err
+	}
+
+	err = shared.PromptForRoles(opts.CreateTargetRoleOptions, opts.CreateTargetRoleFlags)
+	if err != nil {
+		return err
+	}
+
+	err = machinescommon.PromptForMachinePolicy(opts.CreateTargetMachinePolicyOptions, opts.CreateTargetMachinePolicyFlags)
+	if err != nil {
+		return err
+	}
+
+	err = shared.PromptForTenant(opts.CreateTargetTenantOptions, opts.CreateTargetTenantFlags)
+	if err != nil {
+		return err
+	}
+
+	if !opts.AcceptEula.Value {
+		if err := opts.Ask(&survey.Confirm{
+			Message: "Do you accept the Octopus Deploy customer agreement?",
+			Help:    fmt.Sprintf("The Kubernetes agent can only be installed once the customer agreement at %s is accepted.", eulaUrl),
+		}, &opts.AcceptEula.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
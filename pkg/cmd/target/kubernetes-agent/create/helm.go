@@ -0,0 +1,148 @@
+package create
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	// AgentChart is the Helm chart the Octopus Kubernetes agent is installed from.
+	AgentChart = "oci://registry-1.docker.io/octopusdeploy/kubernetes-agent"
+	// DefaultChartVersion follows the latest release of the current major version of the chart.
+	DefaultChartVersion = "2.*.*"
+
+	// ApiKeyEnvironmentVariable is read by the shell running the Helm command, so that the API key
+	// is never written into the command or the values file.
+	ApiKeyEnvironmentVariable = "OCTOPUS_API_KEY"
+
+	pollingPort          = "10943"
+	octopusCloudSuffix   = ".octopus.app"
+	maxReleaseNameLength = 53
+	maxNamespaceLength   = 63
+)
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// HelmValues are the values of the Kubernetes agent chart which register the agent as a deployment
+// target when it is installed.
+type HelmValues struct {
+	Agent *AgentValues `yaml:"agent" json:"agent"`
+}
+
+type AgentValues struct {
+	AcceptEula                            string   `yaml:"acceptEula" json:"acceptEula"`
+	TargetName                            string   `yaml:"targetName" json:"targetName"`
+	ServerUrl                             string   `yaml:"serverUrl" json:"serverUrl"`
+	ServerCommsAddresses                  []string `yaml:"serverCommsAddresses" json:"serverCommsAddresses"`
+	Space                                 string   `yaml:"space" json:"space"`
+	TargetEnvironments                    []string `yaml:"targetEnvironments" json:"targetEnvironments"`
+	TargetRoles                           []string `yaml:"targetRoles" json:"targetRoles"`
+	TargetTenantedDeploymentParticipation string   `yaml:"targetTenantedDeploymentParticipation,omitempty" json:"targetTenantedDeploymentParticipation,omitempty"`
+	TargetTenants                         []string `yaml:"targetTenants,omitempty" json:"targetTenants,omitempty"`
+	TargetTenantTags                      []string `yaml:"targetTenantTags,omitempty" json:"targetTenantTags,omitempty"`
+	MachinePolicyName                     string   `yaml:"machinePolicyName,omitempty" json:"machinePolicyName,omitempty"`
+	DefaultNamespace                      string   `yaml:"defaultNamespace,omitempty" json:"defaultNamespace,omitempty"`
+}
+
+// HelmInstall is how the agent is installed into a cluster.
+type HelmInstall struct {
+	ReleaseName  string
+	Namespace    string
+	ChartVersion string
+	// ValuesFile is the file the values were written to, or empty to give them on the command line.
+	ValuesFile string
+	Values     *HelmValues
+}
+
+// DefaultServerCommsAddress is the address agents poll the Octopus Server at: the polling
+// subdomain for Octopus Cloud, and the polling port of the server otherwise.
+func DefaultServerCommsAddress(serverUrl string) (string, error) {
+	u, err := url.Parse(serverUrl)
+	if err != nil {
+		return "", err
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("cannot work out the polling address from the server URL '%s'", serverUrl)
+	}
+	if strings.HasSuffix(u.Hostname(), octopusCloudSuffix) {
+		return fmt.Sprintf("https://polling.%s/", u.Hostname()), nil
+	}
+	return fmt.Sprintf("https://%s:%s/", u.Hostname(), pollingPort), nil
+}
+
+// DefaultReleaseName turns the name of the agent into a valid Helm release name.
+func DefaultReleaseName(name string) string {
+	releaseName := strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(releaseName) > maxReleaseNameLength {
+		releaseName = strings.TrimRight(releaseName[:maxReleaseNameLength], "-")
+	}
+	return releaseName
+}
+
+// DefaultNamespace is the namespace the agent is installed into when none is given.
+func DefaultNamespace(releaseName string) string {
+	namespace := "octopus-agent-" + releaseName
+	if len(namespace) > maxNamespaceLength {
+		namespace = strings.TrimRight(namespace[:maxNamespaceLength], "-")
+	}
+	return namespace
+}
+
+// Command is the Helm command which installs the agent, split over lines for a shell.
+func (h *HelmInstall) Command() string {
+	args := []string{
+		"helm upgrade --install --atomic",
+		"--create-namespace --namespace " + shellQuote(h.Namespace),
+		"--version " + shellQuote(h.ChartVersion),
+	}
+	if h.ValuesFile != "" {
+		args = append(args, "--values "+shellQuote(h.ValuesFile))
+	} else {
+		agent := h.Values.Agent
+		args = append(args, setJson("agent.acceptEula", agent.AcceptEula))
+		args = append(args, setJson("agent.targetName", agent.TargetName))
+		args = append(args, setJson("agent.serverUrl", agent.ServerUrl))
+		args = append(args, setList("agent.serverCommsAddresses", agent.ServerCommsAddresses))
+		args = append(args, setJson("agent.space", agent.Space))
+		args = append(args, setList("agent.targetEnvironments", agent.TargetEnvironments))
+		args = append(args, setList("agent.targetRoles", agent.TargetRoles))
+		if agent.TargetTenantedDeploymentParticipation != "" {
+			args = append(args, setJson("agent.targetTenantedDeploymentParticipation", agent.TargetTenantedDeploymentParticipation))
+		}
+		if len(agent.TargetTenants) > 0 {
+			args = append(args, setList("agent.targetTenants", agent.TargetTenants))
+		}
+		if len(agent.TargetTenantTags) > 0 {
+			args = append(args, setList("agent.targetTenantTags", agent.TargetTenantTags))
+		}
+		if agent.MachinePolicyName != "" {
+			args = append(args, setJson("agent.machinePolicyName", agent.MachinePolicyName))
+		}
+		if agent.DefaultNamespace != "" {
+			args = append(args, setJson("agent.defaultNamespace", agent.DefaultNamespace))
+		}
+	}
+	args = append(args, fmt.Sprintf(`--set agent.serverApiKey="$%s"`, ApiKeyEnvironmentVariable))
+	args = append(args, shellQuote(h.ReleaseName), AgentChart)
+	return strings.Join(args, " \\\n  ")
+}
+
+// setJson uses --set-json, as values given with --set cannot contain commas.
+func setJson(key string, value any) string {
+	b, _ := json.Marshal(value)
+	return fmt.Sprintf("--set-json %s", shellQuote(key+"="+string(b)))
+}
+
+func setList(key string, values []string) string {
+	if values == nil {
+		values = []string{}
+	}
+	return setJson(key, values)
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,56 @@
+package create_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/kubernetes-agent/create"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultServerCommsAddress(t *testing.T) {
+	address, err := create.DefaultServerCommsAddress("https://example.octopus.app")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://polling.example.octopus.app/", address)
+
+	address, err = create.DefaultServerCommsAddress("https://octopus.internal:8443/")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://octopus.internal:10943/", address)
+
+	_, err = create.DefaultServerCommsAddress("not a url")
+	assert.Error(t, err)
+}
+
+func TestDefaultReleaseName(t *testing.T) {
+	assert.Equal(t, "prod-cluster-eu", create.DefaultReleaseName("Prod Cluster (EU)"))
+	assert.Equal(t, "octopus-agent-prod-cluster-eu", create.DefaultNamespace("prod-cluster-eu"))
+}
+
+func TestHelmInstall_Command(t *testing.T) {
+	install := &create.HelmInstall{
+		ReleaseName:  "prod-cluster",
+		Namespace:    "octopus-agent-prod-cluster",
+		ChartVersion: create.DefaultChartVersion,
+		Values: &create.HelmValues{Agent: &create.AgentValues{
+			AcceptEula:           "Y",
+			TargetName:           "prod cluster",
+			ServerUrl:            "https://octopus.internal/",
+			ServerCommsAddresses: []string{"https://octopus.internal:10943/"},
+			Space:                "Default",
+			TargetEnvironments:   []string{"Production"},
+			TargetRoles:          []string{"k8s", "web,api"},
+		}},
+	}
+
+	command := install.Command()
+
+	assert.Contains(t, command, `--set-json 'agent.targetName="prod cluster"'`)
+	assert.Contains(t, command, `--set-json 'agent.targetRoles=["k8s","web,api"]'`)
+	assert.Contains(t, command, `--set agent.serverApiKey="$OCTOPUS_API_KEY"`)
+	assert.NotContains(t, command, "machinePolicyName")
+
+	install.ValuesFile = "agent-values.yaml"
+	command = install.Command()
+
+	assert.Contains(t, command, "--values 'agent-values.yaml'")
+	assert.NotContains(t, command, "--set-json")
+}
@@ -0,0 +1,25 @@
+package kubernetes_agent
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	cmdCreate "github.com/OctopusDeploy/cli/pkg/cmd/target/kubernetes-agent/create"
+	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/target/kubernetes-agent/list"
+	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/target/kubernetes-agent/view"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdKubernetesAgent(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "kubernetes-agent <command>",
+		Short:   "Manage Kubernetes agent deployment targets",
+		Long:    "Manage Kubernetes agent deployment targets in Octopus Deploy",
+		Example: heredoc.Docf("%s deployment-target kubernetes-agent list", constants.ExecutableName),
+	}
+
+	cmd.AddCommand(cmdList.NewCmdList(f))
+	cmd.AddCommand(cmdCreate.NewCmdCreate(f))
+	cmd.AddCommand(cmdView.NewCmdView(f))
+	return cmd
+}
@@ -0,0 +1,31 @@
+package list
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/list"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdList(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List Kubernetes agent deployment targets",
+		Long:  "List Kubernetes agent deployment targets in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s deployment-target kubernetes-agent list
+			%[1]s deployment-target kubernetes-agent ls
+		`, constants.ExecutableName),
+		Aliases: []string{"ls"},
+		RunE: func(c *cobra.Command, args []string) error {
+			dependencies := cmd.NewDependencies(f, c)
+			options := list.NewListOptions(dependencies, c, machines.MachinesQuery{DeploymentTargetTypes: []string{"KubernetesTentacle"}})
+			return list.ListRun(options)
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,66 @@
+package view
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdView(f factory.Factory) *cobra.Command {
+	flags := shared.NewViewFlags()
+	cmd := &cobra.Command{
+		Args:  usage.ExactArgs(1),
+		Use:   "view {<name> | <id>}",
+		Short: "View a Kubernetes agent deployment target",
+		Long:  "View a Kubernetes agent deployment target in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s deployment-target kubernetes-agent view 'prod-cluster'
+			%[1]s deployment-target kubernetes-agent view Machines-100
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := shared.NewViewOptions(flags, cmd.NewDependencies(f, c), args, c)
+			return ViewRun(opts)
+		},
+	}
+
+	machinescommon.RegisterWebFlag(cmd, flags.WebFlags)
+
+	return cmd
+}
+
+func ViewRun(opts *shared.ViewOptions) error {
+	return shared.ViewRun(opts, contributeEndpoint, "Kubernetes agent")
+}
+
+func contributeEndpoint(opts *shared.ViewOptions, targetEndpoint machines.IEndpoint) ([]*output.DataRow, error) {
+	data := []*output.DataRow{}
+
+	endpoint, err := machinescommon.EndpointAs[*machines.KubernetesTentacleEndpoint](targetEndpoint, machinescommon.DeploymentTargetNoun, "Kubernetes agent")
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.TentacleEndpointConfiguration != nil {
+		data = append(data, output.NewDataRow("URI", machinescommon.FormatUri(endpoint.TentacleEndpointConfiguration.URI)))
+		data = append(data, output.NewDataRow("Communication mode", endpoint.TentacleEndpointConfiguration.CommunicationMode))
+	}
+	if endpoint.DefaultNamespace != "" {
+		data = append(data, output.NewDataRow("Default namespace", endpoint.DefaultNamespace))
+	}
+	if details := endpoint.KubernetesAgentDetails; details != nil {
+		data = append(data, output.NewDataRow("Agent version", details.AgentVersion))
+		data = append(data, output.NewDataRow("Tentacle version", details.TentacleVersion))
+		data = append(data, output.NewDataRow("Upgrade status", details.UpgradeStatus))
+		data = append(data, output.NewDataRow("Helm release", details.HelmReleaseName))
+		data = append(data, output.NewDataRow("Namespace", details.KubernetesNamespace))
+	}
+
+	return data, nil
+}
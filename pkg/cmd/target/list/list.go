@@ -8,10 +8,40 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/machinescommon"
 	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
 	"github.com/spf13/cobra"
 )
 
+const (
+	FlagEnvironment = "environment"
+	FlagRole        = "role"
+	FlagTag         = "tag"
+	FlagTenant      = "tenant"
+	FlagTenantTag   = "tenant-tag"
+)
+
+type ListFlags struct {
+	Environments *flag.Flag[[]string]
+	Roles        *flag.Flag[[]string]
+	Tags         *flag.Flag[[]string]
+	Tenants      *flag.Flag[[]string]
+	TenantTags   *flag.Flag[[]string]
+	*machinescommon.MachineFilterFlags
+}
+
+func NewListFlags() *ListFlags {
+	return &ListFlags{
+		Environments:       flag.New[[]string](FlagEnvironment, false),
+		Roles:              flag.New[[]string](FlagRole, false),
+		Tags:               flag.New[[]string](FlagTag, false),
+		Tenants:            flag.New[[]string](FlagTenant, false),
+		TenantTags:         flag.New[[]string](FlagTenantTag, false),
+		MachineFilterFlags: machinescommon.NewMachineFilterFlags(),
+	}
+}
+
 type ListOptions struct {
 	*cobra.Command
 	*cmd.Dependencies
@@ -32,23 +62,106 @@ func NewListOptions(dependencies *cmd.Dependencies, command *cobra.Command, quer
 }
 
 func NewCmdList(f factory.Factory) *cobra.Command {
+	listFlags := NewListFlags()
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List deployment targets",
-		Long:  "List deployment targets in Octopus Deploy",
+		Long: heredoc.Doc(`
+			List deployment targets in Octopus Deploy.
+
+			The filters are applied by Octopus, so only the matching deployment targets are fetched.
+			Each filter can be given more than once to match any of the values given.
+		`),
 		Example: heredoc.Docf(`
 			%[1]s deployment-target list
 			%[1]s deployment-target ls
+			%[1]s deployment-target list --environment Production --role web-server
+			%[1]s deployment-target list --health-status Unhealthy --health-status Unavailable
+			%[1]s deployment-target list --tenant "Acme Corp" --communication-style TentacleActive --disabled=false
 		`, constants.ExecutableName),
 		Aliases: []string{"ls"},
 		RunE: func(c *cobra.Command, args []string) error {
-			return ListRun(NewListOptions(cmd.NewDependencies(f, c), c, machines.MachinesQuery{}))
+			dependencies := cmd.NewDependencies(f, c)
+			listFlags.DisabledSet = c.Flags().Changed(machinescommon.FlagDisabled)
+			query, err := BuildQuery(listFlags, machines.MachinesQuery{},
+				func() (map[string]string, error) { return shared.GetEnvironmentMap(dependencies.Client) },
+				func() (map[string]string, error) { return shared.GetTenantMap(dependencies.Client) })
+			if err != nil {
+				return err
+			}
+
+			opts := NewListOptions(dependencies, c, query)
+			if listFlags.DisabledSet {
+				getTargets := opts.GetTargetsCallback
+				opts.GetTargetsCallback = func() ([]*machines.DeploymentTarget, error) {
+					targets, err := getTargets()
+					if err != nil {
+						return nil, err
+					}
+					return util.SliceFilter(targets, func(t *machines.DeploymentTarget) bool { return listFlags.IncludeDisabledState(t.IsDisabled) }), nil
+				}
+			}
+			return ListRun(opts)
 		},
 	}
 
+	registerListFlags(cmd, listFlags)
 	return cmd
 }
 
+func registerListFlags(cmd *cobra.Command, listFlags *ListFlags) {
+	flags := cmd.Flags()
+	flags.StringArrayVarP(&listFlags.Environments.Value, listFlags.Environments.Name, "e", nil, "Only list deployment targets in this environment")
+	flags.StringArrayVar(&listFlags.Roles.Value, listFlags.Roles.Name, nil, "Only list deployment targets with this role")
+	flags.StringArrayVar(&listFlags.Tags.Value, listFlags.Tags.Name, nil, "Only list deployment targets with this target tag, the same as --role")
+	flags.StringArrayVar(&listFlags.Tenants.Value, listFlags.Tenants.Name, nil, "Only list deployment targets associated with this tenant")
+	flags.StringArrayVar(&listFlags.TenantTags.Value, listFlags.TenantTags.Name, nil, "Only list deployment targets associated with this tenant tag, in the format 'tag set name/tag name'")
+	machinescommon.RegisterMachineFilterFlags(cmd, listFlags.MachineFilterFlags, "deployment target")
+}
+
+// BuildQuery adds the filters given as flags to a query. The environments and tenants are looked
+// up only when they are filtered on.
+func BuildQuery(flags *ListFlags, query machines.MachinesQuery, getEnvironmentMap func() (map[string]string, error), getTenantMap func() (map[string]string, error)) (machines.MachinesQuery, error) {
+	if len(flags.Environments.Value) > 0 {
+		environmentMap, err := getEnvironmentMap()
+		if err != nil {
+			return query, err
+		}
+		if query.EnvironmentIDs, err = machinescommon.ResolveIDs(flags.Environments.Value, environmentMap, "environment"); err != nil {
+			return query, err
+		}
+	}
+	if len(flags.Tenants.Value) > 0 {
+		tenantMap, err := getTenantMap()
+		if err != nil {
+			return query, err
+		}
+		if query.TenantIDs, err = machinescommon.ResolveIDs(flags.Tenants.Value, tenantMap, "tenant"); err != nil {
+			return query, err
+		}
+	}
+
+	healthStatuses, err := machinescommon.ParseHealthStatuses(flags.HealthStatuses.Value)
+	if err != nil {
+		return query, err
+	}
+	communicationStyles, err := machinescommon.ParseCommunicationStyles(flags.CommunicationStyles.Value)
+	if err != nil {
+		return query, err
+	}
+
+	// target tags are how newer versions of Octopus name roles, so both are matched alike
+	query.Roles = append(append(query.Roles, flags.Roles.Value...), flags.Tags.Value...)
+	query.TenantTags = append(query.TenantTags, flags.TenantTags.Value...)
+	query.HealthStatuses = append(query.HealthStatuses, healthStatuses...)
+	query.CommunicationStyles = append(query.CommunicationStyles, communicationStyles...)
+	query.IsDisabled = flags.DisabledSet && flags.Disabled.Value
+	if flags.PartialName.Value != "" {
+		query.PartialName = flags.PartialName.Value
+	}
+	return query, nil
+}
+
 func ListRun(opts *ListOptions) error {
 	allTargets, err := opts.GetTargetsCallback()
 	if err != nil {
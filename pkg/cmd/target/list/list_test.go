@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -28,3 +29,59 @@ func TestDescribeTargetType_EndpointMissing(t *testing.T) {
 		assert.Equal(t, "Unknown", describeTargetType(target))
 	})
 }
+
+func TestBuildQuery(t *testing.T) {
+	flags := NewListFlags()
+	flags.Environments.Value = []string{"production"}
+	flags.Roles.Value = []string{"web-server"}
+	flags.TenantTags.Value = []string{"Region/us-east"}
+	flags.HealthStatuses.Value = []string{"unhealthy"}
+	flags.CommunicationStyles.Value = []string{"TentacleActive"}
+	flags.PartialName.Value = "web"
+	flags.DisabledSet = true
+	flags.Disabled.Value = true
+
+	query, err := BuildQuery(flags, machines.MachinesQuery{DeploymentTargetTypes: []string{"TentacleActive"}},
+		func() (map[string]string, error) {
+			return map[string]string{"Environments-1": "Production"}, nil
+		},
+		func() (map[string]string, error) {
+			t.Fatal("tenants should only be looked up when filtered on")
+			return nil, nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, machines.MachinesQuery{
+		DeploymentTargetTypes: []string{"TentacleActive"},
+		EnvironmentIDs:        []string{"Environments-1"},
+		Roles:                 []string{"web-server"},
+		TenantTags:            []string{"Region/us-east"},
+		HealthStatuses:        []string{"Unhealthy"},
+		CommunicationStyles:   []string{"TentacleActive"},
+		PartialName:           "web",
+		IsDisabled:            true,
+	}, query)
+}
+
+func TestBuildQuery_RolesAndTags(t *testing.T) {
+	flags := NewListFlags()
+	cmd := &cobra.Command{}
+	registerListFlags(cmd, flags)
+	require.NoError(t, cmd.ParseFlags([]string{"--role", "web-server", "--tag", "Region/us-east", "--role", "db-server"}))
+
+	query, err := BuildQuery(flags, machines.MachinesQuery{}, nil, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web-server", "db-server", "Region/us-east"}, query.Roles)
+}
+
+func TestBuildQuery_UnknownTenant(t *testing.T) {
+	flags := NewListFlags()
+	flags.Tenants.Value = []string{"Globex"}
+
+	_, err := BuildQuery(flags, machines.MachinesQuery{}, nil, func() (map[string]string, error) {
+		return map[string]string{"Tenants-1": "Acme Corp"}, nil
+	})
+
+	assert.EqualError(t, err, "cannot find tenant 'Globex'")
+}
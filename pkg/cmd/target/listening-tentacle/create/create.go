@@ -36,11 +36,13 @@ type CreateFlags struct {
 	*machinescommon.CreateTargetMachinePolicyFlags
 	*shared.CreateTargetTenantFlags
 	*machinescommon.WebFlags
+	*machinescommon.TentacleCheckFlags
 }
 
 type CreateOptions struct {
 	*CreateFlags
 	*machinescommon.CreateTargetProxyOptions
+	*machinescommon.TentacleCheckOptions
 	*shared.CreateTargetEnvironmentOptions
 	*shared.CreateTargetRoleOptions
 	*machinescommon.CreateTargetMachinePolicyOptions
@@ -59,6 +61,7 @@ func NewCreateFlags() *CreateFlags {
 		CreateTargetEnvironmentFlags:   shared.NewCreateTargetEnvironmentFlags(),
 		CreateTargetTenantFlags:        shared.NewCreateTargetTenantFlags(),
 		WebFlags:                       machinescommon.NewWebFlags(),
+		TentacleCheckFlags:             machinescommon.NewTentacleCheckFlags(),
 	}
 }
 
@@ -68,6 +71,7 @@ func NewCreateOptions(createFlags *CreateFlags, dependencies *cmd.Dependencies)
 		Dependencies:                     dependencies,
 		CreateTargetRoleOptions:          shared.NewCreateTargetRoleOptions(dependencies),
 		CreateTargetProxyOptions:         machinescommon.NewCreateTargetProxyOptions(dependencies),
+		TentacleCheckOptions:             machinescommon.NewTentacleCheckOptions(dependencies),
 		CreateTargetMachinePolicyOptions: machinescommon.NewCreateTargetMachinePolicyOptions(dependencies),
 		CreateTargetEnvironmentOptions:   shared.NewCreateTargetEnvironmentOptions(dependencies),
 		CreateTargetTenantOptions:        shared.NewCreateTargetTenantOptions(dependencies),
@@ -78,10 +82,24 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 	createFlags := NewCreateFlags()
 
 	cmd := &cobra.Command{
-		Use:     "create",
-		Short:   "Create a Listening Tentacle deployment target",
-		Long:    "Create a Listening Tentacle deployment target in Octopus Deploy",
-		Example: heredoc.Docf("%s deployment-target listening-tentacle create", constants.ExecutableName),
+		Use:   "create",
+		Short: "Create a Listening Tentacle deployment target",
+		Long: heredoc.Docf(`
+			Create a Listening Tentacle deployment target in Octopus Deploy.
+
+			With --%[1]s the certificate thumbprint is read from the Tentacle
+			instead of being given with --%[2]s, and must be confirmed when prompting, or
+			trusted with --%[4]s when not.
+
+			With --%[3]s the Tentacle is resolved, connected to and asked for its certificate
+			before the target is saved, so that DNS, network and TLS problems are reported
+			straight away. Both connect from this machine, so cannot be used with a proxy.
+		`, machinescommon.FlagDiscoverThumbprint, FlagThumbprint, machinescommon.FlagPreflight, machinescommon.FlagTrustDiscoveredThumbprint),
+		Example: heredoc.Docf(`
+			%[1]s deployment-target listening-tentacle create
+			%[1]s deployment-target listening-tentacle create --name "web-01" --url https://web-01:10933 --discover-thumbprint --environment Production --role web-app
+			%[1]s deployment-target listening-tentacle create --name "web-02" --url https://web-02:10933 --thumbprint 1A2B3C4D5E6F708192A3B4C5D6E7F8091A2B3C4D --preflight --environment Production --role web-app --no-prompt
+		`, constants.ExecutableName),
 		Aliases: []string{"new"},
 		RunE: func(c *cobra.Command, _ []string) error {
 			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c))
@@ -99,6 +117,7 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 	machinescommon.RegisterCreateTargetProxyFlags(cmd, createFlags.CreateTargetProxyFlags, "Listening Tentacle")
 	machinescommon.RegisterCreateTargetMachinePolicyFlags(cmd, createFlags.CreateTargetMachinePolicyFlags)
 	shared.RegisterCreateTargetTenantFlags(cmd, createFlags.CreateTargetTenantFlags)
+	machinescommon.RegisterTentacleCheckFlags(cmd, createFlags.TentacleCheckFlags, "Tentacle")
 	machinescommon.RegisterWebFlag(cmd, createFlags.WebFlags)
 
 	return cmd
@@ -111,7 +130,12 @@ func createRun(opts *CreateOptions) error {
 		}
 	}
 
-	url, err := url.Parse(opts.URL.Value)
+	err := machinescommon.CheckTentacle(opts.TentacleCheckOptions, opts.TentacleCheckFlags, opts.URL.Value, opts.Thumbprint, opts.Proxy.Value)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := NewEndpoint(opts.URL.Value, opts.Thumbprint.Value)
 	if err != nil {
 		return err
 	}
@@ -127,7 +151,6 @@ func createRun(opts *CreateOptions) error {
 		return err
 	}
 
-	endpoint := machines.NewListeningTentacleEndpoint(url, opts.Thumbprint.Value)
 	if opts.Proxy.Value != "" {
 		proxy, err := machinescommon.FindProxy(opts.CreateTargetProxyOptions, opts.CreateTargetProxyFlags)
 		if err != nil {
@@ -154,7 +177,7 @@ func createRun(opts *CreateOptions) error {
 
 	fmt.Fprintf(opts.Out, "Successfully created listening tenatcle '%s'.\n", deploymentTarget.Name)
 	if !opts.NoPrompt {
-		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Name, opts.URL, opts.Thumbprint, opts.Environments, opts.Roles, opts.Tags, opts.Proxy, opts.MachinePolicy, opts.TenantedDeploymentMode, opts.Tenants, opts.TenantTags)
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Name, opts.URL, opts.Thumbprint, opts.Preflight, opts.Environments, opts.Roles, opts.Tags, opts.Proxy, opts.MachinePolicy, opts.TenantedDeploymentMode, opts.Tenants, opts.TenantTags)
 		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
 	}
 
@@ -163,6 +186,15 @@ func createRun(opts *CreateOptions) error {
 	return nil
 }
 
+// NewEndpoint is the endpoint of a Listening Tentacle at the URL given.
+func NewEndpoint(tentacleUrl string, thumbprint string) (*machines.ListeningTentacleEndpoint, error) {
+	uri, err := url.Parse(tentacleUrl)
+	if err != nil {
+		return nil, err
+	}
+	return machines.NewListeningTentacleEndpoint(uri, thumbprint), nil
+}
+
 func PromptMissing(opts *CreateOptions) error {
 	err := question.AskName(opts.Ask, "", "Listening Tentacle", &opts.Name.Value)
 	if err != nil {
@@ -179,7 +211,7 @@ func PromptMissing(opts *CreateOptions) error {
 		return err
 	}
 
-	if opts.Thumbprint.Value == "" {
+	if opts.Thumbprint.Value == "" && !opts.DiscoverThumbprint.Value {
 		if err := opts.Ask(&survey.Input{
 			Message: "Thumbprint",
 			Help:    "The X509 certificate thumbprint that securely identifies the Tentacle.",
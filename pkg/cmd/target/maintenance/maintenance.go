@@ -0,0 +1,173 @@
+package maintenance
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/task/wait"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagEnvironment = "environment"
+	FlagRole        = "role"
+
+	resourceDescription = "deployment target"
+)
+
+type MaintenanceFlags struct {
+	Environments *flag.Flag[[]string]
+	Roles        *flag.Flag[[]string]
+	*machinescommon.MaintenanceFlags
+}
+
+func NewMaintenanceFlags() *MaintenanceFlags {
+	return &MaintenanceFlags{
+		Environments:     flag.New[[]string](FlagEnvironment, false),
+		Roles:            flag.New[[]string](FlagRole, false),
+		MaintenanceFlags: machinescommon.NewMaintenanceFlags(),
+	}
+}
+
+type MaintenanceOptions struct {
+	*MaintenanceFlags
+	*machinescommon.MaintenanceOptions
+	GetEnvironmentMapCallback func() (map[string]string, error)
+	GetTargetsCallback        func(query machines.MachinesQuery) ([]*machines.DeploymentTarget, error)
+}
+
+func NewMaintenanceOptions(flags *MaintenanceFlags, dependencies *cmd.Dependencies, command *cobra.Command, task *machinescommon.MaintenanceTask) *MaintenanceOptions {
+	getTargets := func(query machines.MachinesQuery) ([]*machines.DeploymentTarget, error) {
+		return shared.GetAllTargets(*dependencies.Client, query)
+	}
+	return &MaintenanceOptions{
+		MaintenanceFlags: flags,
+		MaintenanceOptions: &machinescommon.MaintenanceOptions{
+			MaintenanceFlags: flags.MaintenanceFlags,
+			Dependencies:     dependencies,
+			Command:          command,
+			Task:             task,
+			Description:      resourceDescription,
+			StartTaskCallback: func(task *tasks.Task) (*tasks.Task, error) {
+				return dependencies.Client.Tasks.Add(task)
+			},
+			WaitCallback: func(taskID string) error {
+				return wait.WaitRun(wait.NewWaitOps(dependencies, []string{taskID}, flags.Timeout.Value, wait.DefaultPollInterval, false, false, command))
+			},
+			GetHealthCallback: func(machineIDs []string) ([]*machinescommon.MachineHealth, error) {
+				targets, err := getTargets(machines.MachinesQuery{IDs: machineIDs})
+				if err != nil {
+					return nil, err
+				}
+				return util.SliceTransform(targets, func(t *machines.DeploymentTarget) *machinescommon.MachineHealth {
+					return &machinescommon.MachineHealth{Id: t.GetID(), Name: t.Name, HealthStatus: t.HealthStatus, StatusSummary: t.StatusSummary}
+				}), nil
+			},
+		},
+		GetEnvironmentMapCallback: func() (map[string]string, error) {
+			return shared.GetEnvironmentMap(dependencies.Client)
+		},
+		GetTargetsCallback: getTargets,
+	}
+}
+
+func NewCmdHealthCheck(f factory.Factory) *cobra.Command {
+	return newCmdMaintenance(f, machinescommon.HealthCheckTask, &cobra.Command{
+		Use:   "health-check [<name> | <id>]...",
+		Short: "Check the health of deployment targets",
+		Long: heredoc.Doc(`
+			Check the health of deployment targets in Octopus Deploy.
+
+			The deployment targets are given by name or ID, or selected by environment or role.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s deployment-target health-check web-01 web-02 --wait
+			%[1]s deployment-target health-check --environment Production --role web-server
+		`, constants.ExecutableName),
+		Aliases: []string{"health"},
+	})
+}
+
+func NewCmdUpgradeTentacle(f factory.Factory) *cobra.Command {
+	return newCmdMaintenance(f, machinescommon.UpgradeTentacleTask, &cobra.Command{
+		Use:   "upgrade-tentacle [<name> | <id>]...",
+		Short: "Upgrade Tentacle on deployment targets",
+		Long: heredoc.Doc(`
+			Upgrade Tentacle on deployment targets in Octopus Deploy to the version bundled with the
+			Octopus Server.
+
+			The deployment targets are given by name or ID, or selected by environment or role.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s deployment-target upgrade-tentacle web-01
+			%[1]s deployment-target upgrade-tentacle --environment Test --wait --timeout 1800
+		`, constants.ExecutableName),
+	})
+}
+
+func NewCmdUpdateCalamari(f factory.Factory) *cobra.Command {
+	return newCmdMaintenance(f, machinescommon.UpdateCalamariTask, &cobra.Command{
+		Use:   "update-calamari [<name> | <id>]...",
+		Short: "Update Calamari on deployment targets",
+		Long: heredoc.Doc(`
+			Update Calamari on deployment targets in Octopus Deploy to the version bundled with the
+			Octopus Server.
+
+			The deployment targets are given by name or ID, or selected by environment or role.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s deployment-target update-calamari web-01
+			%[1]s deployment-target update-calamari --role web-server --wait
+		`, constants.ExecutableName),
+	})
+}
+
+func newCmdMaintenance(f factory.Factory, task *machinescommon.MaintenanceTask, command *cobra.Command) *cobra.Command {
+	maintenanceFlags := NewMaintenanceFlags()
+	command.RunE = func(c *cobra.Command, args []string) error {
+		opts := NewMaintenanceOptions(maintenanceFlags, cmd.NewDependencies(f, c), c, task)
+		return MaintenanceRun(opts, args)
+	}
+
+	flags := command.Flags()
+	flags.StringArrayVarP(&maintenanceFlags.Environments.Value, maintenanceFlags.Environments.Name, "e", nil, "Select the deployment targets in this environment")
+	flags.StringArrayVar(&maintenanceFlags.Roles.Value, maintenanceFlags.Roles.Name, nil, "Select the deployment targets with this role")
+	machinescommon.RegisterMaintenanceFlags(command, maintenanceFlags.MaintenanceFlags, wait.DefaultTimeout)
+
+	return command
+}
+
+func MaintenanceRun(opts *MaintenanceOptions, identifiers []string) error {
+	query := machines.MachinesQuery{Roles: opts.Roles.Value}
+	if len(opts.Environments.Value) > 0 {
+		environmentMap, err := opts.GetEnvironmentMapCallback()
+		if err != nil {
+			return err
+		}
+		if query.EnvironmentIDs, err = machinescommon.ResolveIDs(opts.Environments.Value, environmentMap, "environment"); err != nil {
+			return err
+		}
+	}
+	filtered := len(query.EnvironmentIDs) > 0 || len(query.Roles) > 0
+
+	selector := &machinescommon.MachineSelector[*machines.DeploymentTarget]{
+		Description:         resourceDescription,
+		GetMachinesCallback: func() ([]*machines.DeploymentTarget, error) { return opts.GetTargetsCallback(query) },
+		GetID:               func(t *machines.DeploymentTarget) string { return t.GetID() },
+		GetName:             func(t *machines.DeploymentTarget) string { return t.Name },
+	}
+	targets, err := selector.Select(opts.Ask, opts.NoPrompt, fmt.Sprintf("Select the %ss to run the %s on", resourceDescription, opts.Task.Title), identifiers, filtered)
+	if err != nil {
+		return err
+	}
+	return machinescommon.RunMaintenance(opts.MaintenanceOptions, util.SliceTransform(targets, selector.GetID))
+}
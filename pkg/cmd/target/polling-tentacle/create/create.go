@@ -0,0 +1,220 @@
+package create
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/executionscommon"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagName           = "name"
+	FlagThumbprint     = "thumbprint"
+	FlagSubscriptionId = machinescommon.FlagSubscriptionId
+)
+
+type CreateFlags struct {
+	Name           *flag.Flag[string]
+	Thumbprint     *flag.Flag[string]
+	SubscriptionId *flag.Flag[string]
+	*shared.CreateTargetEnvironmentFlags
+	*shared.CreateTargetRoleFlags
+	*machinescommon.CreateTargetMachinePolicyFlags
+	*shared.CreateTargetTenantFlags
+	*machinescommon.WebFlags
+}
+
+type CreateOptions struct {
+	*CreateFlags
+	*shared.CreateTargetEnvironmentOptions
+	*shared.CreateTargetRoleOptions
+	*machinescommon.CreateTargetMachinePolicyOptions
+	*shared.CreateTargetTenantOptions
+	*cmd.Dependencies
+}
+
+func NewCreateFlags() *CreateFlags {
+	return &CreateFlags{
+		Name:                           flag.New[string](FlagName, false),
+		Thumbprint:                     flag.New[string](FlagThumbprint, true),
+		SubscriptionId:                 flag.New[string](FlagSubscriptionId, false),
+		CreateTargetRoleFlags:          shared.NewCreateTargetRoleFlags(),
+		CreateTargetMachinePolicyFlags: machinescommon.NewCreateTargetMachinePolicyFlags(),
+		CreateTargetEnvironmentFlags:   shared.NewCreateTargetEnvironmentFlags(),
+		CreateTargetTenantFlags:        shared.NewCreateTargetTenantFlags(),
+		WebFlags:                       machinescommon.NewWebFlags(),
+	}
+}
+
+func NewCreateOptions(createFlags *CreateFlags, dependencies *cmd.Dependencies) *CreateOptions {
+	return &CreateOptions{
+		CreateFlags:                      createFlags,
+		Dependencies:                     dependencies,
+		CreateTargetRoleOptions:          shared.NewCreateTargetRoleOptions(dependencies),
+		CreateTargetMachinePolicyOptions: machinescommon.NewCreateTargetMachinePolicyOptions(dependencies),
+		CreateTargetEnvironmentOptions:   shared.NewCreateTargetEnvironmentOptions(dependencies),
+		CreateTargetTenantOptions:        shared.NewCreateTargetTenantOptions(dependencies),
+	}
+}
+
+func NewCmdCreate(f factory.Factory) *cobra.Command {
+	createFlags := NewCreateFlags()
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a Polling Tentacle deployment target",
+		Long: heredoc.Docf(`
+			Create a Polling Tentacle deployment target in Octopus Deploy.
+
+			The Tentacle must be installed first. Its subscription ID and thumbprint are shown by
+			'Tentacle show-configuration' and 'Tentacle show-thumbprint'. Once registered, the
+			Tentacle connects to the Octopus Server to pick up work.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s deployment-target polling-tentacle create
+			%[1]s deployment-target polling-tentacle create --name web-01 --subscription-id abcdefghij1234567890 --thumbprint 1234567890ABCDEF1234567890ABCDEF12345678 --environment Production --role web-server --no-prompt
+		`, constants.ExecutableName),
+		Aliases: []string{"new"},
+		RunE: func(c *cobra.Command, _ []string) error {
+			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c))
+
+			return createRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&createFlags.Name.Value, createFlags.Name.Name, "n", "", "A short, memorable, unique name for this Polling Tentacle.")
+	flags.StringVar(&createFlags.Thumbprint.Value, createFlags.Thumbprint.Name, "", "The X509 certificate thumbprint that securely identifies the Tentacle.")
+	flags.StringVar(&createFlags.SubscriptionId.Value, createFlags.SubscriptionId.Name, "", "The subscription ID the Tentacle polls the Octopus Server with.")
+	shared.RegisterCreateTargetEnvironmentFlags(cmd, createFlags.CreateTargetEnvironmentFlags)
+	shared.RegisterCreateTargetRoleFlags(cmd, createFlags.CreateTargetRoleFlags)
+	machinescommon.RegisterCreateTargetMachinePolicyFlags(cmd, createFlags.CreateTargetMachinePolicyFlags)
+	shared.RegisterCreateTargetTenantFlags(cmd, createFlags.CreateTargetTenantFlags)
+	machinescommon.RegisterWebFlag(cmd, createFlags.WebFlags)
+
+	return cmd
+}
+
+func createRun(opts *CreateOptions) error {
+	if !opts.NoPrompt {
+		if err := PromptMissing(opts); err != nil {
+			return err
+		}
+	}
+
+	endpoint, err := NewEndpoint(opts.SubscriptionId.Value, opts.Thumbprint.Value)
+	if err != nil {
+		return err
+	}
+
+	envs, err := executionscommon.FindEnvironments(opts.Client, opts.Environments.Value)
+	if err != nil {
+		return err
+	}
+	environmentIds := util.SliceTransform(envs, func(e *environments.Environment) string { return e.ID })
+
+	combinedRoles, err := shared.CombineRolesAndTags(opts.Client, opts.Roles.Value, opts.Tags.Value)
+	if err != nil {
+		return err
+	}
+
+	deploymentTarget := machines.NewDeploymentTarget(opts.Name.Value, endpoint, environmentIds, util.SliceDistinct(combinedRoles))
+	machinePolicy, err := machinescommon.FindMachinePolicy(opts.GetAllMachinePoliciesCallback, opts.MachinePolicy.Value)
+	if err != nil {
+		return err
+	}
+	deploymentTarget.MachinePolicyID = machinePolicy.GetID()
+	err = shared.ConfigureTenant(deploymentTarget, opts.CreateTargetTenantFlags, opts.CreateTargetTenantOptions)
+	if err != nil {
+		return err
+	}
+
+	createdTarget, err := opts.Client.Machines.Add(deploymentTarget)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.Out, "Successfully created Polling Tentacle '%s'.\n", deploymentTarget.Name)
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Name, opts.SubscriptionId, opts.Thumbprint, opts.Environments, opts.Roles, opts.Tags, opts.MachinePolicy, opts.TenantedDeploymentMode, opts.Tenants, opts.TenantTags)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+
+	machinescommon.DoWebForTargets(createdTarget, opts.Dependencies, opts.WebFlags, "polling tentacle")
+
+	return nil
+}
+
+// NewEndpoint is the endpoint of a Polling Tentacle with the subscription ID given.
+func NewEndpoint(subscriptionId string, thumbprint string) (*machines.PollingTentacleEndpoint, error) {
+	uri, err := machinescommon.PollingSubscriptionUri(subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	return machines.NewPollingTentacleEndpoint(uri, thumbprint), nil
+}
+
+func PromptMissing(opts *CreateOptions) error {
+	err := question.AskName(opts.Ask, "", "Polling Tentacle", &opts.Name.Value)
+	if err != nil {
+		return err
+	}
+
+	err = shared.PromptForEnvironments(opts.CreateTargetEnvironmentOptions, opts.CreateTargetEnvironmentFlags)
+	if err != nil {
+		return err
+	}
+
+	err = shared.PromptForRoles(opts.CreateTargetRoleOptions, opts.CreateTargetRoleFlags)
+	if err != nil {
+		return err
+	}
+
+	if opts.Thumbprint.Value == "" {
+		if err := opts.Ask(&survey.Input{
+			Message: "Thumbprint",
+			Help:    "The X509 certificate thumbprint that securely identifies the Tentacle.",
+		}, &opts.Thumbprint.Value, survey.WithValidator(survey.ComposeValidators(
+			survey.MinLength(40),
+			survey.MaxLength(40),
+		))); err != nil {
+			return err
+		}
+	}
+
+	if opts.SubscriptionId.Value == "" {
+		if err := opts.Ask(&survey.Input{
+			Message: "Subscription ID",
+			Help:    "The subscription ID the Tentacle polls the Octopus Server with, as shown by 'Tentacle show-configuration'.",
+		}, &opts.SubscriptionId.Value, survey.WithValidator(survey.ComposeValidators(
+			survey.Required,
+			machinescommon.ValidateSubscriptionId,
+		))); err != nil {
+			return err
+		}
+	}
+
+	err = machinescommon.PromptForMachinePolicy(opts.CreateTargetMachinePolicyOptions, opts.CreateTargetMachinePolicyFlags)
+	if err != nil {
+		return err
+	}
+
+	err = shared.PromptForTenant(opts.CreateTargetTenantOptions, opts.CreateTargetTenantFlags)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
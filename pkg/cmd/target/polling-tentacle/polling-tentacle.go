@@ -2,6 +2,7 @@ package polling_tentacle
 
 import (
 	"github.com/MakeNowJust/heredoc/v2"
+	cmdCreate "github.com/OctopusDeploy/cli/pkg/cmd/target/polling-tentacle/create"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/target/polling-tentacle/list"
 	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/target/polling-tentacle/view"
 	"github.com/OctopusDeploy/cli/pkg/constants"
@@ -18,6 +19,7 @@ func NewCmdPollingTentacle(f factory.Factory) *cobra.Command {
 	}
 
 	cmd.AddCommand(cmdList.NewCmdList(f))
+	cmd.AddCommand(cmdCreate.NewCmdCreate(f))
 	cmd.AddCommand(cmdView.NewCmdView(f))
 	return cmd
 }
@@ -0,0 +1,135 @@
+package reconcile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	TargetTypeListeningTentacle = "listening-tentacle"
+	TargetTypePollingTentacle   = "polling-tentacle"
+	TargetTypeSsh               = "ssh"
+	TargetTypeCloudRegion       = "cloud-region"
+)
+
+var targetTypes = []string{TargetTypeListeningTentacle, TargetTypePollingTentacle, TargetTypeSsh, TargetTypeCloudRegion}
+
+var tenantedDeploymentModes = []string{shared.Untenanted, shared.Tenanted, shared.TenantedOrUntenanted}
+
+// Inventory is the deployment targets which should be registered in a space.
+type Inventory struct {
+	Targets []*DeclaredTarget `yaml:"targets"`
+}
+
+// DeclaredTarget is a deployment target as it is written in the inventory. Which of the endpoint
+// settings are needed depends on the type of the deployment target.
+type DeclaredTarget struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+
+	// URL is the address of a Listening Tentacle.
+	URL string `yaml:"url,omitempty"`
+	// SubscriptionId is the subscription ID a Polling Tentacle polls the server with.
+	SubscriptionId string `yaml:"subscriptionId,omitempty"`
+	Thumbprint     string `yaml:"thumbprint,omitempty"`
+
+	Host        string `yaml:"host,omitempty"`
+	Port        int    `yaml:"port,omitempty"`
+	Fingerprint string `yaml:"fingerprint,omitempty"`
+	Account     string `yaml:"account,omitempty"`
+	// Platform runs Calamari self-contained on this platform, rather than on Mono.
+	Platform string `yaml:"platform,omitempty"`
+
+	Roles         []string `yaml:"roles"`
+	Environments  []string `yaml:"environments"`
+	Tenants       []string `yaml:"tenants,omitempty"`
+	TenantedMode  string   `yaml:"tenantedMode,omitempty"`
+	MachinePolicy string   `yaml:"machinePolicy,omitempty"`
+}
+
+// ReadInventory parses and checks an inventory, so that every problem in it is reported before
+// anything is compared.
+func ReadInventory(content []byte) (*Inventory, error) {
+	inventory := &Inventory{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(inventory); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("cannot read the inventory: %w", err)
+	}
+
+	var problems []string
+	names := map[string]bool{}
+	for i, target := range inventory.Targets {
+		if target == nil {
+			problems = append(problems, fmt.Sprintf("target %d is empty", i+1))
+			continue
+		}
+		if target.Name == "" {
+			problems = append(problems, fmt.Sprintf("target %d has no name", i+1))
+			continue
+		}
+		if names[strings.ToLower(target.Name)] {
+			problems = append(problems, fmt.Sprintf("target '%s' is declared more than once", target.Name))
+		}
+		names[strings.ToLower(target.Name)] = true
+		problems = append(problems, checkTarget(target)...)
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("the inventory is not valid:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return inventory, nil
+}
+
+// checkTarget returns the problems with a declared target, normalising its type and tenanted mode.
+func checkTarget(target *DeclaredTarget) []string {
+	var problems []string
+	missing := func(field string, value string) {
+		if value == "" {
+			problems = append(problems, fmt.Sprintf("target '%s' needs a %s", target.Name, field))
+		}
+	}
+
+	targetType, ok := machinescommon.FindIgnoringCase(targetTypes, target.Type)
+	if !ok {
+		problems = append(problems, fmt.Sprintf("target '%s' has unknown type '%s', must be one of %s", target.Name, target.Type, output.FormatAsList(targetTypes)))
+	}
+	target.Type = targetType
+	switch target.Type {
+	case TargetTypeListeningTentacle:
+		missing("url", target.URL)
+		missing("thumbprint", target.Thumbprint)
+	case TargetTypePollingTentacle:
+		missing("subscriptionId", target.SubscriptionId)
+		missing("thumbprint", target.Thumbprint)
+	case TargetTypeSsh:
+		missing("host", target.Host)
+		missing("fingerprint", target.Fingerprint)
+		missing("account", target.Account)
+	}
+
+	if len(target.Roles) == 0 {
+		problems = append(problems, fmt.Sprintf("target '%s' needs at least one role", target.Name))
+	}
+	if len(target.Environments) == 0 {
+		problems = append(problems, fmt.Sprintf("target '%s' needs at least one environment", target.Name))
+	}
+	if target.TenantedMode != "" {
+		mode, ok := machinescommon.FindIgnoringCase(tenantedDeploymentModes, target.TenantedMode)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("target '%s' has unknown tenantedMode '%s', must be one of %s", target.Name, target.TenantedMode, output.FormatAsList(tenantedDeploymentModes)))
+		}
+		target.TenantedMode = mode
+		if mode == shared.Untenanted && len(target.Tenants) > 0 {
+			problems = append(problems, fmt.Sprintf("target '%s' is untenanted, so cannot have tenants", target.Name))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,279 @@
+package reconcile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	listeningTentacleCreate "github.com/OctopusDeploy/cli/pkg/cmd/target/listening-tentacle/create"
+	pollingTentacleCreate "github.com/OctopusDeploy/cli/pkg/cmd/target/polling-tentacle/create"
+	sshCreate "github.com/OctopusDeploy/cli/pkg/cmd/target/ssh/create"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+)
+
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDisable = "disable"
+)
+
+// Change is what it takes to make one registered deployment target match the inventory.
+type Change struct {
+	Action      string
+	Name        string
+	ID          string   `json:",omitempty"`
+	Differences []string `json:",omitempty"`
+	// Problem is why the change cannot be made, such as a deployment target changing type.
+	Problem string `json:",omitempty"`
+	// Target is the deployment target to create, or the registered one with the change applied.
+	Target *machines.DeploymentTarget `json:"-"`
+}
+
+// Lookups are maps of IDs to names, used to resolve the names in the inventory and to describe
+// the differences by name.
+type Lookups struct {
+	EnvironmentMap         map[string]string
+	TenantMap              map[string]string
+	MachinePolicyMap       map[string]string
+	AccountMap             map[string]string
+	DefaultMachinePolicyID string
+}
+
+// NewTarget builds the deployment target declared in the inventory, using the endpoints the create
+// commands build. The machine policy is empty when the inventory does not give one.
+func NewTarget(declared *DeclaredTarget, lookups *Lookups) (*machines.DeploymentTarget, error) {
+	var endpoint machines.IEndpoint
+	switch declared.Type {
+	case TargetTypeListeningTentacle:
+		listeningEndpoint, err := listeningTentacleCreate.NewEndpoint(declared.URL, declared.Thumbprint)
+		if err != nil {
+			return nil, err
+		}
+		endpoint = listeningEndpoint
+	case TargetTypePollingTentacle:
+		pollingEndpoint, err := pollingTentacleCreate.NewEndpoint(declared.SubscriptionId, declared.Thumbprint)
+		if err != nil {
+			return nil, err
+		}
+		endpoint = pollingEndpoint
+	case TargetTypeSsh:
+		accountIDs, err := machinescommon.ResolveIDs([]string{declared.Account}, lookups.AccountMap, "account")
+		if err != nil {
+			return nil, err
+		}
+		endpoint = sshCreate.NewEndpoint(declared.Host, declared.Port, declared.Fingerprint, accountIDs[0], declared.Platform)
+	case TargetTypeCloudRegion:
+		endpoint = machines.NewCloudRegionEndpoint()
+	default:
+		return nil, fmt.Errorf("unknown type '%s'", declared.Type)
+	}
+
+	environmentIDs, err := machinescommon.ResolveIDs(declared.Environments, lookups.EnvironmentMap, "environment")
+	if err != nil {
+		return nil, err
+	}
+	target := machines.NewDeploymentTarget(declared.Name, endpoint, util.SliceDistinct(environmentIDs), util.SliceDistinct(declared.Roles))
+	if target.TenantIDs, err = machinescommon.ResolveIDs(declared.Tenants, lookups.TenantMap, "tenant"); err != nil {
+		return nil, err
+	}
+	target.TenantIDs = util.SliceDistinct(target.TenantIDs)
+	target.TenantedDeploymentMode = core.TenantedDeploymentMode(declared.TenantedMode)
+	if declared.MachinePolicy != "" {
+		policyIDs, err := machinescommon.ResolveIDs([]string{declared.MachinePolicy}, lookups.MachinePolicyMap, "machine policy")
+		if err != nil {
+			return nil, err
+		}
+		target.MachinePolicyID = policyIDs[0]
+	}
+	return target, nil
+}
+
+// Plan compares the inventory with the registered deployment targets. Registered deployment
+// targets which are not declared are disabled when inScope reports they are managed by the
+// inventory.
+func Plan(inventory *Inventory, registered []*machines.DeploymentTarget, inScope func(target *machines.DeploymentTarget) bool, lookups *Lookups) ([]*Change, error) {
+	changes := []*Change{}
+	declaredNames := map[string]bool{}
+	for _, declared := range inventory.Targets {
+		declaredNames[strings.ToLower(declared.Name)] = true
+		desired, err := NewTarget(declared, lookups)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve target '%s': %w", declared.Name, err)
+		}
+
+		current := findTarget(registered, declared.Name)
+		if current == nil {
+			if desired.MachinePolicyID == "" {
+				desired.MachinePolicyID = lookups.DefaultMachinePolicyID
+			}
+			if desired.TenantedDeploymentMode == "" {
+				desired.TenantedDeploymentMode = core.TenantedDeploymentModeUntenanted
+				if len(desired.TenantIDs) > 0 {
+					desired.TenantedDeploymentMode = core.TenantedDeploymentModeTenanted
+				}
+			}
+			changes = append(changes, &Change{Action: ActionCreate, Name: declared.Name, Target: desired})
+			continue
+		}
+
+		differences, problem := ApplyTarget(current, desired, lookups)
+		if len(differences) > 0 || problem != "" {
+			changes = append(changes, &Change{Action: ActionUpdate, Name: current.Name, ID: current.GetID(), Differences: differences, Problem: problem, Target: current})
+		}
+	}
+
+	for _, target := range registered {
+		if declaredNames[strings.ToLower(target.Name)] || target.IsDisabled || !inScope(target) {
+			continue
+		}
+		target.IsDisabled = true
+		changes = append(changes, &Change{Action: ActionDisable, Name: target.Name, ID: target.GetID(), Target: target})
+	}
+	return changes, nil
+}
+
+// ApplyTarget changes a registered deployment target to match the desired one, returning the
+// differences, or a problem when the change cannot be made.
+func ApplyTarget(current *machines.DeploymentTarget, desired *machines.DeploymentTarget, lookups *Lookups) ([]string, string) {
+	differences, problem := applyEndpoint(current.Endpoint, desired.Endpoint, lookups)
+
+	if difference := describeSetChange("roles", current.Roles, desired.Roles, nil); difference != "" {
+		differences = append(differences, difference)
+		current.Roles = desired.Roles
+	}
+	if difference := describeSetChange("environments", current.EnvironmentIDs, desired.EnvironmentIDs, lookups.EnvironmentMap); difference != "" {
+		differences = append(differences, difference)
+		current.EnvironmentIDs = desired.EnvironmentIDs
+	}
+	if difference := describeSetChange("tenants", current.TenantIDs, desired.TenantIDs, lookups.TenantMap); difference != "" {
+		differences = append(differences, difference)
+		current.TenantIDs = desired.TenantIDs
+	}
+
+	mode := desired.TenantedDeploymentMode
+	if mode == "" {
+		// keep the mode the deployment target has, unless it must now be tenanted
+		mode = current.TenantedDeploymentMode
+		if len(desired.TenantIDs) > 0 && (mode == "" || mode == core.TenantedDeploymentModeUntenanted) {
+			mode = core.TenantedDeploymentModeTenanted
+		}
+	}
+	if mode != current.TenantedDeploymentMode {
+		differences = append(differences, describeValueChange("tenanted mode", string(current.TenantedDeploymentMode), string(mode)))
+		current.TenantedDeploymentMode = mode
+	}
+
+	if desired.MachinePolicyID != "" && !strings.EqualFold(desired.MachinePolicyID, current.MachinePolicyID) {
+		differences = append(differences, describeValueChange("machine policy", nameOf(current.MachinePolicyID, lookups.MachinePolicyMap), nameOf(desired.MachinePolicyID, lookups.MachinePolicyMap)))
+		current.MachinePolicyID = desired.MachinePolicyID
+	}
+	if current.IsDisabled {
+		differences = append(differences, "enable")
+		current.IsDisabled = false
+	}
+	return differences, problem
+}
+
+func applyEndpoint(current machines.IEndpoint, desired machines.IEndpoint, lookups *Lookups) ([]string, string) {
+	currentStyle := machinescommon.GetCommunicationStyle(current)
+	desiredStyle := machinescommon.GetCommunicationStyle(desired)
+	if currentStyle != desiredStyle {
+		return nil, fmt.Sprintf("cannot change a %s into a %s, delete the deployment target so it is created again",
+			machinescommon.DescribeCommunicationStyle(current, machinescommon.CommunicationStyleToDescriptionMap),
+			machinescommon.DescribeCommunicationStyle(desired, machinescommon.CommunicationStyleToDescriptionMap))
+	}
+
+	var differences []string
+	change := func(description string, currentValue *string, desiredValue string) {
+		if *currentValue != desiredValue {
+			differences = append(differences, describeValueChange(description, *currentValue, desiredValue))
+			*currentValue = desiredValue
+		}
+	}
+	switch d := desired.(type) {
+	case *machines.ListeningTentacleEndpoint:
+		c := current.(*machines.ListeningTentacleEndpoint)
+		if machinescommon.FormatUri(c.URI) != machinescommon.FormatUri(d.URI) {
+			differences = append(differences, describeValueChange("URL", machinescommon.FormatUri(c.URI), machinescommon.FormatUri(d.URI)))
+			c.URI = d.URI
+		}
+		change("thumbprint", &c.Thumbprint, d.Thumbprint)
+	case *machines.PollingTentacleEndpoint:
+		c := current.(*machines.PollingTentacleEndpoint)
+		if machinescommon.FormatUri(c.URI) != machinescommon.FormatUri(d.URI) {
+			differences = append(differences, describeValueChange("subscription", machinescommon.FormatUri(c.URI), machinescommon.FormatUri(d.URI)))
+			c.URI = d.URI
+		}
+		change("thumbprint", &c.Thumbprint, d.Thumbprint)
+	case *machines.SSHEndpoint:
+		c := current.(*machines.SSHEndpoint)
+		change("host", &c.Host, d.Host)
+		if c.Port != d.Port {
+			differences = append(differences, describeValueChange("port", strconv.Itoa(c.Port), strconv.Itoa(d.Port)))
+			c.Port = d.Port
+		}
+		c.URI = d.URI
+		change("fingerprint", &c.Fingerprint, d.Fingerprint)
+		if !strings.EqualFold(c.AccountID, d.AccountID) {
+			differences = append(differences, describeValueChange("account", nameOf(c.AccountID, lookups.AccountMap), nameOf(d.AccountID, lookups.AccountMap)))
+			c.AccountID = d.AccountID
+		}
+		if c.DotNetCorePlatform != d.DotNetCorePlatform {
+			differences = append(differences, describeValueChange("platform", formatPlatform(c.DotNetCorePlatform), formatPlatform(d.DotNetCorePlatform)))
+			c.DotNetCorePlatform = d.DotNetCorePlatform
+		}
+	}
+	return differences, ""
+}
+
+func formatPlatform(platform string) string {
+	if platform == "" {
+		return machinescommon.MonoCalamari
+	}
+	return platform
+}
+
+func findTarget(targets []*machines.DeploymentTarget, name string) *machines.DeploymentTarget {
+	for _, target := range targets {
+		if strings.EqualFold(target.Name, name) {
+			return target
+		}
+	}
+	return nil
+}
+
+// describeSetChange describes the values added and removed, ignoring case and order. Values are
+// shown by name when a lookup is given.
+func describeSetChange(description string, current []string, desired []string, lookup map[string]string) string {
+	var parts []string
+	for _, value := range machinescommon.RemoveValues(desired, current) {
+		parts = append(parts, "+"+nameOf(value, lookup))
+	}
+	for _, value := range machinescommon.RemoveValues(current, desired) {
+		parts = append(parts, "-"+nameOf(value, lookup))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s", description, strings.Join(parts, " "))
+}
+
+func describeValueChange(description string, current string, desired string) string {
+	if current == "" {
+		current = "none"
+	}
+	if desired == "" {
+		desired = "none"
+	}
+	return fmt.Sprintf("%s: %s -> %s", description, current, desired)
+}
+
+func nameOf(id string, lookup map[string]string) string {
+	if name, ok := lookup[id]; ok {
+		return name
+	}
+	return id
+}
@@ -0,0 +1,340 @@
+package reconcile
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	sharedTenants "github.com/OctopusDeploy/cli/pkg/cmd/tenant/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagFile        = "file"
+	FlagEnvironment = "environment"
+	FlagRole        = "role"
+	FlagCreate      = "create"
+	FlagUpdate      = "update"
+	FlagDisable     = "disable"
+)
+
+type ReconcileFlags struct {
+	File         *flag.Flag[string]
+	Environments *flag.Flag[[]string]
+	Roles        *flag.Flag[[]string]
+	Create       *flag.Flag[bool]
+	Update       *flag.Flag[bool]
+	Disable      *flag.Flag[bool]
+}
+
+func NewReconcileFlags() *ReconcileFlags {
+	return &ReconcileFlags{
+		File:         flag.New[string](FlagFile, false),
+		Environments: flag.New[[]string](FlagEnvironment, false),
+		Roles:        flag.New[[]string](FlagRole, false),
+		Create:       flag.New[bool](FlagCreate, false),
+		Update:       flag.New[bool](FlagUpdate, false),
+		Disable:      flag.New[bool](FlagDisable, false),
+	}
+}
+
+type ReconcileOptions struct {
+	*ReconcileFlags
+	*cmd.Dependencies
+	Command                   *cobra.Command
+	ReadFileCallback          func(path string) ([]byte, error)
+	GetTargetsCallback        func(query machines.MachinesQuery) ([]*machines.DeploymentTarget, error)
+	GetEnvironmentMapCallback func() (map[string]string, error)
+	GetAllTenantsCallback     sharedTenants.GetAllTenantsCallback
+	machinescommon.GetAllMachinePoliciesCallback
+	machinescommon.GetAllAccountsForSshMachine
+	AddTargetCallback    func(target *machines.DeploymentTarget) (*machines.DeploymentTarget, error)
+	UpdateTargetCallback func(target *machines.DeploymentTarget) (*machines.DeploymentTarget, error)
+}
+
+func NewReconcileOptions(flags *ReconcileFlags, dependencies *cmd.Dependencies, command *cobra.Command) *ReconcileOptions {
+	return &ReconcileOptions{
+		ReconcileFlags:   flags,
+		Dependencies:     dependencies,
+		Command:          command,
+		ReadFileCallback: os.ReadFile,
+		GetTargetsCallback: func(query machines.MachinesQuery) ([]*machines.DeploymentTarget, error) {
+			return shared.GetAllTargets(*dependencies.Client, query)
+		},
+		GetEnvironmentMapCallback: func() (map[string]string, error) {
+			return shared.GetEnvironmentMap(dependencies.Client)
+		},
+		GetAllTenantsCallback:         shared.NewCreateTargetTenantOptions(dependencies).GetAllTenantsCallback,
+		GetAllMachinePoliciesCallback: machinescommon.NewCreateTargetMachinePolicyOptions(dependencies).GetAllMachinePoliciesCallback,
+		GetAllAccountsForSshMachine:   machinescommon.NewSshCommonOpts(dependencies).GetAllAccountsForSshMachine,
+		AddTargetCallback: func(target *machines.DeploymentTarget) (*machines.DeploymentTarget, error) {
+			return dependencies.Client.Machines.Add(target)
+		},
+		UpdateTargetCallback: func(target *machines.DeploymentTarget) (*machines.DeploymentTarget, error) {
+			return dependencies.Client.Machines.Update(target)
+		},
+	}
+}
+
+func NewCmdReconcile(f factory.Factory) *cobra.Command {
+	reconcileFlags := NewReconcileFlags()
+
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Compare an inventory of deployment targets with Octopus Deploy",
+		Long: heredoc.Docf(`
+			Compare the deployment targets declared in an inventory file with the deployment targets
+			registered in Octopus Deploy, and print the differences.
+
+			Nothing is changed unless --%[1]s, --%[2]s or --%[3]s are given. Deployment targets which
+			are registered but not declared are disabled with --%[3]s; use --%[4]s and --%[5]s to
+			limit this to the deployment targets the inventory manages.
+
+			The inventory is a YAML file with a list of targets:
+
+			  targets:
+			    - name: web-01
+			      type: listening-tentacle
+			      url: https://web-01.example.com:10933/
+			      thumbprint: 1234567890ABCDEF1234567890ABCDEF12345678
+			      roles: [web-server]
+			      environments: [Production]
+			      tenants: [Acme]
+
+			The type is one of %[6]s. Polling Tentacles are given a
+			subscriptionId and thumbprint, and SSH targets a host, port, fingerprint, account and
+			optionally a platform.
+		`, FlagCreate, FlagUpdate, FlagDisable, FlagEnvironment, FlagRole, output.FormatAsList(targetTypes)),
+		Example: heredoc.Docf(`
+			%[1]s deployment-target reconcile --file inventory.yaml
+			%[1]s deployment-target reconcile --file inventory.yaml --create --update
+			%[1]s deployment-target reconcile --file inventory.yaml --environment Production --create --update --disable --no-prompt
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, _ []string) error {
+			opts := NewReconcileOptions(reconcileFlags, cmd.NewDependencies(f, c), c)
+			return ReconcileRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&reconcileFlags.File.Value, reconcileFlags.File.Name, "", "The inventory file declaring the deployment targets")
+	flags.StringArrayVarP(&reconcileFlags.Environments.Value, reconcileFlags.Environments.Name, "e", nil, "Only disable undeclared deployment targets in this environment")
+	flags.StringArrayVar(&reconcileFlags.Roles.Value, reconcileFlags.Roles.Name, nil, "Only disable undeclared deployment targets with this role")
+	flags.BoolVar(&reconcileFlags.Create.Value, reconcileFlags.Create.Name, false, "Create the declared deployment targets which are not registered")
+	flags.BoolVar(&reconcileFlags.Update.Value, reconcileFlags.Update.Name, false, "Update the registered deployment targets which differ from the inventory")
+	flags.BoolVar(&reconcileFlags.Disable.Value, reconcileFlags.Disable.Name, false, "Disable the registered deployment targets which are not in the inventory")
+
+	return cmd
+}
+
+func ReconcileRun(opts *ReconcileOptions) error {
+	if opts.File.Value == "" {
+		return fmt.Errorf("must supply the inventory with --%s", FlagFile)
+	}
+	content, err := opts.ReadFileCallback(opts.File.Value)
+	if err != nil {
+		return err
+	}
+	inventory, err := ReadInventory(content)
+	if err != nil {
+		return fmt.Errorf("cannot read '%s': %w", opts.File.Value, err)
+	}
+
+	lookups, err := loadLookups(opts, inventory)
+	if err != nil {
+		return err
+	}
+	scopeEnvironmentIDs, err := machinescommon.ResolveIDs(opts.Environments.Value, lookups.EnvironmentMap, "environment")
+	if err != nil {
+		return err
+	}
+	registered, err := opts.GetTargetsCallback(machines.MachinesQuery{})
+	if err != nil {
+		return err
+	}
+
+	inScope := func(target *machines.DeploymentTarget) bool {
+		return matchesAny(target.EnvironmentIDs, scopeEnvironmentIDs) && matchesAny(target.Roles, opts.Roles.Value)
+	}
+	changes, err := Plan(inventory, registered, inScope, lookups)
+	if err != nil {
+		return err
+	}
+
+	if err := printChanges(opts, changes); err != nil {
+		return err
+	}
+
+	selected := selectChanges(opts, changes)
+	if len(selected) == 0 {
+		return nil
+	}
+	for _, change := range selected {
+		if change.Problem != "" {
+			return fmt.Errorf("cannot %s deployment target '%s': %s", change.Action, change.Name, change.Problem)
+		}
+	}
+
+	if !opts.NoPrompt {
+		confirmed := false
+		if err := opts.Ask(&survey.Confirm{
+			Message: fmt.Sprintf("Make %d changes to the deployment targets?", len(selected)),
+			Default: false,
+		}, &confirmed); err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	for _, change := range selected {
+		var target *machines.DeploymentTarget
+		if change.Action == ActionCreate {
+			target, err = opts.AddTargetCallback(change.Target)
+		} else {
+			target, err = opts.UpdateTargetCallback(change.Target)
+		}
+		if err != nil {
+			return fmt.Errorf("cannot %s deployment target '%s': %w", change.Action, change.Name, err)
+		}
+		if !constants.IsProgrammaticOutputFormat(outputFormat(opts)) {
+			fmt.Fprintf(opts.Out, "Successfully %sd deployment target '%s' %s.\n", change.Action, target.Name, output.Dimf("(%s)", target.GetID()))
+		}
+	}
+
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.File, opts.Environments, opts.Roles, opts.Create, opts.Update, opts.Disable)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+	return nil
+}
+
+func loadLookups(opts *ReconcileOptions, inventory *Inventory) (*Lookups, error) {
+	lookups := &Lookups{TenantMap: map[string]string{}, MachinePolicyMap: map[string]string{}, AccountMap: map[string]string{}}
+
+	var err error
+	if lookups.EnvironmentMap, err = opts.GetEnvironmentMapCallback(); err != nil {
+		return nil, err
+	}
+	allTenants, err := opts.GetAllTenantsCallback()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range allTenants {
+		lookups.TenantMap[t.GetID()] = t.Name
+	}
+	allPolicies, err := opts.GetAllMachinePoliciesCallback()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range allPolicies {
+		lookups.MachinePolicyMap[p.GetID()] = p.Name
+		if p.IsDefault {
+			lookups.DefaultMachinePolicyID = p.GetID()
+		}
+	}
+
+	for _, target := range inventory.Targets {
+		if target.Type == TargetTypeSsh {
+			var allAccounts []accounts.IAccount
+			if allAccounts, err = opts.GetAllAccountsForSshMachine(); err != nil {
+				return nil, err
+			}
+			for _, a := range allAccounts {
+				lookups.AccountMap[a.GetID()] = a.GetName()
+			}
+			break
+		}
+	}
+	return lookups, nil
+}
+
+func selectChanges(opts *ReconcileOptions, changes []*Change) []*Change {
+	var selected []*Change
+	for _, change := range changes {
+		switch {
+		case change.Action == ActionCreate && opts.Create.Value,
+			change.Action == ActionUpdate && opts.Update.Value,
+			change.Action == ActionDisable && opts.Disable.Value:
+			selected = append(selected, change)
+		}
+	}
+	return selected
+}
+
+func printChanges(opts *ReconcileOptions, changes []*Change) error {
+	if !constants.IsProgrammaticOutputFormat(outputFormat(opts)) && len(changes) == 0 {
+		_, err := fmt.Fprintln(opts.Out, "The deployment targets match the inventory.")
+		return err
+	}
+
+	return output.PrintArray(changes, opts.Command, output.Mappers[*Change]{
+		Json: func(c *Change) any {
+			return c
+		},
+		Table: output.TableDefinition[*Change]{
+			Header: []string{"ACTION", "NAME", "ID", "CHANGES"},
+			Row: func(c *Change) []string {
+				changes := strings.Join(c.Differences, ", ")
+				if c.Problem != "" {
+					changes = output.Red(c.Problem)
+				}
+				return []string{formatAction(c.Action), c.Name, c.ID, changes}
+			},
+		},
+		Basic: func(c *Change) string {
+			lines := []string{fmt.Sprintf("%s %s", actionSymbols[c.Action], c.Name)}
+			for _, difference := range c.Differences {
+				lines = append(lines, "    "+difference)
+			}
+			if c.Problem != "" {
+				lines = append(lines, "    "+c.Problem)
+			}
+			return strings.Join(lines, "\n")
+		},
+	})
+}
+
+var actionSymbols = map[string]string{ActionCreate: "+", ActionUpdate: "~", ActionDisable: "-"}
+
+func formatAction(action string) string {
+	switch action {
+	case ActionCreate:
+		return output.Green(action)
+	case ActionDisable:
+		return output.Red(action)
+	default:
+		return output.Yellow(action)
+	}
+}
+
+func outputFormat(opts *ReconcileOptions) string {
+	format, _ := opts.Command.Flags().GetString(constants.FlagOutputFormat)
+	return format
+}
+
+// matchesAny reports whether values contains any of the filters, ignoring case. No filters match
+// everything.
+func matchesAny(values []string, filters []string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, filter := range filters {
+		if _, ok := machinescommon.FindIgnoringCase(values, filter); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package reconcile_test
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/reconcile"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+const inventoryYaml = `
+targets:
+  - name: web-01
+    type: Listening-Tentacle
+    url: https://web-01:10933/
+    thumbprint: AAAA
+    roles: [web-server, api]
+    environments: [Production]
+    tenants: [contoso]
+  - name: web-02
+    type: polling-tentacle
+    subscriptionId: abcdefghij1234567890
+    thumbprint: BBBB
+    roles: [web-server]
+    environments: [production]
+`
+
+var lookups = &reconcile.Lookups{
+	EnvironmentMap:         map[string]string{"Environments-1": "Staging", "Environments-2": "Production"},
+	TenantMap:              map[string]string{"Tenants-1": "Contoso"},
+	MachinePolicyMap:       map[string]string{"MachinePolicies-1": "Default Machine Policy"},
+	DefaultMachinePolicyID: "MachinePolicies-1",
+}
+
+func newListeningTarget(id string, name string, tentacleUrl string, environmentIDs []string, roles []string) *machines.DeploymentTarget {
+	uri, _ := url.Parse(tentacleUrl)
+	target := machines.NewDeploymentTarget(name, machines.NewListeningTentacleEndpoint(uri, "AAAA"), environmentIDs, roles)
+	target.ID = id
+	target.TenantedDeploymentMode = core.TenantedDeploymentModeUntenanted
+	return target
+}
+
+func TestReadInventory_ReportsEveryProblem(t *testing.T) {
+	_, err := reconcile.ReadInventory([]byte(`
+targets:
+  - name: web-01
+    type: listening-tentacle
+    roles: [web-server]
+  - name: WEB-01
+    type: winrm
+    roles: [web-server]
+    environments: [Production]
+`))
+
+	assert.EqualError(t, err, `the inventory is not valid:
+  target 'web-01' needs a url
+  target 'web-01' needs a thumbprint
+  target 'web-01' needs at least one environment
+  target 'WEB-01' is declared more than once
+  target 'WEB-01' has unknown type 'winrm', must be one of listening-tentacle, polling-tentacle, ssh, cloud-region`)
+}
+
+func TestReadInventory_RejectsUnknownFields(t *testing.T) {
+	_, err := reconcile.ReadInventory([]byte("targets:\n  - name: web-01\n    role: web-server\n"))
+
+	assert.ErrorContains(t, err, "field role not found")
+}
+
+func TestPlan_DescribesDifferences(t *testing.T) {
+	inventory, err := reconcile.ReadInventory([]byte(inventoryYaml))
+	assert.NoError(t, err)
+	web1 := newListeningTarget("Machines-1", "WEB-01", "https://old-web-01:10933/", []string{"Environments-1"}, []string{"web-server", "legacy"})
+	web1.IsDisabled = true
+	db1 := newListeningTarget("Machines-3", "db-01", "https://db-01:10933/", []string{"Environments-1"}, []string{"database"})
+	old := newListeningTarget("Machines-4", "web-old", "https://web-old:10933/", []string{"Environments-2"}, []string{"web-server"})
+
+	inScope := func(target *machines.DeploymentTarget) bool {
+		_, ok := machinescommon.FindIgnoringCase(target.Roles, "web-server")
+		return ok
+	}
+	changes, err := reconcile.Plan(inventory, []*machines.DeploymentTarget{web1, db1, old}, inScope, lookups)
+
+	assert.NoError(t, err)
+	assert.Len(t, changes, 3)
+
+	assert.Equal(t, reconcile.ActionUpdate, changes[0].Action)
+	assert.Equal(t, "Machines-1", changes[0].ID)
+	assert.Equal(t, []string{
+		"URL: https://old-web-01:10933/ -> https://web-01:10933/",
+		"roles: +api -legacy",
+		"environments: +Production -Staging",
+		"tenants: +Contoso",
+		"tenanted mode: Untenanted -> Tenanted",
+		"enable",
+	}, changes[0].Differences)
+	assert.False(t, web1.IsDisabled)
+	assert.Equal(t, []string{"Tenants-1"}, web1.TenantIDs)
+
+	assert.Equal(t, reconcile.ActionCreate, changes[1].Action)
+	assert.Equal(t, "web-02", changes[1].Name)
+	assert.Equal(t, "poll://abcdefghij1234567890/", changes[1].Target.Endpoint.(*machines.PollingTentacleEndpoint).URI.String())
+	assert.Equal(t, "MachinePolicies-1", changes[1].Target.MachinePolicyID)
+	assert.Equal(t, []string{"Environments-2"}, changes[1].Target.EnvironmentIDs)
+
+	assert.Equal(t, reconcile.ActionDisable, changes[2].Action)
+	assert.Equal(t, "web-old", changes[2].Name)
+	assert.True(t, old.IsDisabled)
+}
+
+func TestPlan_CannotChangeType(t *testing.T) {
+	inventory, err := reconcile.ReadInventory([]byte(inventoryYaml))
+	assert.NoError(t, err)
+	web2 := newListeningTarget("Machines-2", "web-02", "https://web-02:10933/", []string{"Environments-2"}, []string{"web-server"})
+
+	changes, err := reconcile.Plan(inventory, []*machines.DeploymentTarget{web2}, func(*machines.DeploymentTarget) bool { return false }, lookups)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "cannot change a Listening Tentacle into a Polling Tentacle, delete the deployment target so it is created again", changes[1].Problem)
+}
+
+func TestReconcileRun_OnlyMakesSelectedChanges(t *testing.T) {
+	out := &bytes.Buffer{}
+	command := &cobra.Command{}
+	command.SetOut(out)
+	flags := reconcile.NewReconcileFlags()
+	flags.File.Value = "inventory.yaml"
+	flags.Create.Value = true
+	opts := reconcile.NewReconcileOptions(flags, &cmd.Dependencies{NoPrompt: true, Out: out}, command)
+	opts.ReadFileCallback = func(path string) ([]byte, error) {
+		return []byte(inventoryYaml), nil
+	}
+	web1 := newListeningTarget("Machines-1", "web-01", "https://old-web-01:10933/", []string{"Environments-2"}, []string{"web-server", "api"})
+	opts.GetTargetsCallback = func(query machines.MachinesQuery) ([]*machines.DeploymentTarget, error) {
+		return []*machines.DeploymentTarget{web1}, nil
+	}
+	opts.GetEnvironmentMapCallback = func() (map[string]string, error) { return lookups.EnvironmentMap, nil }
+	opts.GetAllTenantsCallback = func() ([]*tenants.Tenant, error) {
+		tenant := tenants.NewTenant("Contoso")
+		tenant.ID = "Tenants-1"
+		return []*tenants.Tenant{tenant}, nil
+	}
+	opts.GetAllMachinePoliciesCallback = func() ([]*machines.MachinePolicy, error) {
+		policy := machines.NewMachinePolicy("Default Machine Policy")
+		policy.ID = "MachinePolicies-1"
+		policy.IsDefault = true
+		return []*machines.MachinePolicy{policy}, nil
+	}
+	var added []*machines.DeploymentTarget
+	opts.AddTargetCallback = func(target *machines.DeploymentTarget) (*machines.DeploymentTarget, error) {
+		added = append(added, target)
+		target.ID = "Machines-2"
+		return target, nil
+	}
+	opts.UpdateTargetCallback = func(target *machines.DeploymentTarget) (*machines.DeploymentTarget, error) {
+		t.Fatalf("updated '%s' without --update", target.Name)
+		return nil, nil
+	}
+
+	err := reconcile.ReconcileRun(opts)
+
+	assert.NoError(t, err)
+	assert.Len(t, added, 1)
+	assert.Equal(t, "web-02", added[0].Name)
+	assert.Contains(t, out.String(), "Successfully created deployment target 'web-02' (Machines-2).")
+}
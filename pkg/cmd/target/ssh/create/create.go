@@ -79,10 +79,21 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 	createFlags := NewCreateFlags()
 
 	cmd := &cobra.Command{
-		Use:     "create",
-		Short:   "Create a SSH deployment target",
-		Long:    "Create a SSH deployment target in Octopus Deploy",
-		Example: heredoc.Docf("%s deployment-target ssh create", constants.ExecutableName),
+		Use:   "create",
+		Short: "Create a SSH deployment target",
+		Long: heredoc.Docf(`
+			Create a SSH deployment target in Octopus Deploy.
+
+			With --%[1]s the host key fingerprint is read by connecting to the host, and must be
+			confirmed before it is trusted. Without prompting, a fingerprint which was not given is
+			only trusted with --%[3]s. With --%[2]s a deployment target is created for each host in
+			a file, each line of which has the form host[:port] [name] [fingerprint].
+		`, machinescommon.FlagDiscoverFingerprint, machinescommon.FlagHostsFile, machinescommon.FlagTrustDiscoveredFingerprint),
+		Example: heredoc.Docf(`
+			%[1]s deployment-target ssh create
+			%[1]s deployment-target ssh create --name web-01 --host web-01.example.com --discover-fingerprint --account "Deploy Key" --environment Test --role web
+			%[1]s deployment-target ssh create --hosts-file hosts.txt --discover-fingerprint --account "Deploy Key" --environment Test --role web
+		`, constants.ExecutableName),
 		Aliases: []string{"new"},
 		RunE: func(c *cobra.Command, _ []string) error {
 			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c))
@@ -106,6 +117,9 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 }
 
 func createRun(opts *CreateOptions) error {
+	if err := machinescommon.CheckHostsFileFlags(opts.SshCommonFlags, opts.Name.Value); err != nil {
+		return err
+	}
 	if !opts.NoPrompt {
 		if err := PromptMissing(opts); err != nil {
 			return err
@@ -128,37 +142,67 @@ func createRun(opts *CreateOptions) error {
 		return err
 	}
 
-	port := opts.Port.Value
-	if port == 0 {
-		port = 22
-	}
-	endpoint := machines.NewSSHEndpoint(opts.HostName.Value, port, opts.Fingerprint.Value)
-	endpoint.AccountID = account.GetID()
-
+	platform := ""
 	if opts.Runtime.Value == machinescommon.SelfContainedCalamari {
-		endpoint.DotNetCorePlatform = opts.Platform.Value
+		platform = opts.Platform.Value
 	}
 
+	proxyID := ""
 	if opts.Proxy.Value != "" {
 		proxy, err := machinescommon.FindProxy(opts.CreateTargetProxyOptions, opts.CreateTargetProxyFlags)
 		if err != nil {
 			return err
 		}
-		endpoint.ProxyID = proxy.GetID()
+		proxyID = proxy.GetID()
 	}
 
-	deploymentTarget := machines.NewDeploymentTarget(opts.Name.Value, endpoint, environmentIds, util.SliceDistinct(combinedRoles))
 	machinePolicy, err := machinescommon.FindMachinePolicy(opts.GetAllMachinePoliciesCallback, opts.MachinePolicy.Value)
 	if err != nil {
 		return err
 	}
-	deploymentTarget.MachinePolicyID = machinePolicy.GetID()
 
-	err = shared.ConfigureTenant(deploymentTarget, opts.CreateTargetTenantFlags, opts.CreateTargetTenantOptions)
+	// the tenants are looked up once, and copied to each target
+	tenanted := &machines.DeploymentTarget{}
+	err = shared.ConfigureTenant(tenanted, opts.CreateTargetTenantFlags, opts.CreateTargetTenantOptions)
 	if err != nil {
 		return err
 	}
 
+	newTarget := func(name string, host string, port int, fingerprint string) *machines.DeploymentTarget {
+		endpoint := NewEndpoint(host, port, fingerprint, account.GetID(), platform)
+		endpoint.ProxyID = proxyID
+		deploymentTarget := machines.NewDeploymentTarget(name, endpoint, environmentIds, util.SliceDistinct(combinedRoles))
+		deploymentTarget.MachinePolicyID = machinePolicy.GetID()
+		deploymentTarget.TenantedDeploymentMode = tenanted.TenantedDeploymentMode
+		deploymentTarget.TenantIDs = tenanted.TenantIDs
+		deploymentTarget.TenantTags = tenanted.TenantTags
+		return deploymentTarget
+	}
+
+	if opts.HostsFile.Value != "" {
+		content, err := opts.ReadFileCallback(opts.HostsFile.Value)
+		if err != nil {
+			return err
+		}
+		hosts, err := machinescommon.ReadSshHosts(content)
+		if err != nil {
+			return err
+		}
+		err = machinescommon.RegisterSshHosts(opts.SshCommonOptions, opts.SshCommonFlags, hosts, "SSH deployment target", func(host *machinescommon.SshHost) error {
+			_, err := opts.Client.Machines.Add(newTarget(host.Name, host.Host, host.Port, host.Fingerprint))
+			return err
+		})
+		if !opts.NoPrompt {
+			autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.HostsFile, opts.DiscoverFingerprint, opts.TrustDiscoveredFingerprint, opts.Port, opts.Runtime, opts.Platform, opts.Environments, opts.Roles, opts.Tags, opts.Account, opts.Proxy, opts.MachinePolicy, opts.TenantedDeploymentMode, opts.Tenants, opts.TenantTags)
+			fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+		}
+		return err
+	}
+
+	if err := machinescommon.ResolveSshFingerprint(opts.SshCommonOptions, opts.SshCommonFlags); err != nil {
+		return err
+	}
+	deploymentTarget := newTarget(opts.Name.Value, opts.HostName.Value, opts.Port.Value, opts.Fingerprint.Value)
 	createdTarget, err := opts.Client.Machines.Add(deploymentTarget)
 	if err != nil {
 		return err
@@ -175,13 +219,28 @@ func createRun(opts *CreateOptions) error {
 	return nil
 }
 
+// NewEndpoint is the endpoint of an SSH deployment target. The port defaults to 22, and an empty
+// platform runs Calamari on Mono.
+func NewEndpoint(host string, port int, fingerprint string, accountID string, platform string) *machines.SSHEndpoint {
+	if port == 0 {
+		port = machinescommon.DefaultPort
+	}
+	endpoint := machines.NewSSHEndpoint(host, port, fingerprint)
+	endpoint.AccountID = accountID
+	endpoint.DotNetCorePlatform = platform
+	return endpoint
+}
+
 func PromptMissing(opts *CreateOptions) error {
-	err := question.AskName(opts.Ask, "", "SSH", &opts.Name.Value)
-	if err != nil {
-		return err
+	// the name and endpoint of each target come from the hosts file
+	batch := opts.HostsFile.Value != ""
+	if !batch {
+		if err := question.AskName(opts.Ask, "", "SSH", &opts.Name.Value); err != nil {
+			return err
+		}
 	}
 
-	err = shared.PromptForEnvironments(opts.CreateTargetEnvironmentOptions, opts.CreateTargetEnvironmentFlags)
+	err := shared.PromptForEnvironments(opts.CreateTargetEnvironmentOptions, opts.CreateTargetEnvironmentFlags)
 	if err != nil {
 		return err
 	}
@@ -201,9 +260,11 @@ func PromptMissing(opts *CreateOptions) error {
 		return err
 	}
 
-	err = machinescommon.PromptForSshEndpoint(opts.SshCommonOptions, opts.SshCommonFlags, "SSH target")
-	if err != nil {
-		return err
+	if !batch {
+		err = machinescommon.PromptForSshEndpoint(opts.SshCommonOptions, opts.SshCommonFlags, "SSH target")
+		if err != nil {
+			return err
+		}
 	}
 
 	err = machinescommon.PromptForProxy(opts.CreateTargetProxyOptions, opts.CreateTargetProxyFlags, "SSH target")
@@ -5,11 +5,16 @@ import (
 	cmdAzureWebApp "github.com/OctopusDeploy/cli/pkg/cmd/target/azure-web-app"
 	cmdCloudRegion "github.com/OctopusDeploy/cli/pkg/cmd/target/cloud-region"
 	cmdDelete "github.com/OctopusDeploy/cli/pkg/cmd/target/delete"
+	cmdDiscovery "github.com/OctopusDeploy/cli/pkg/cmd/target/discovery"
 	cmdKubernetes "github.com/OctopusDeploy/cli/pkg/cmd/target/kubernetes"
+	cmdKubernetesAgent "github.com/OctopusDeploy/cli/pkg/cmd/target/kubernetes-agent"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/target/list"
 	cmdListeningTentacle "github.com/OctopusDeploy/cli/pkg/cmd/target/listening-tentacle"
+	cmdMaintenance "github.com/OctopusDeploy/cli/pkg/cmd/target/maintenance"
 	cmdPollingTentacle "github.com/OctopusDeploy/cli/pkg/cmd/target/polling-tentacle"
+	cmdReconcile "github.com/OctopusDeploy/cli/pkg/cmd/target/reconcile"
 	cmdSsh "github.com/OctopusDeploy/cli/pkg/cmd/target/ssh"
+	cmdUpdate "github.com/OctopusDeploy/cli/pkg/cmd/target/update"
 	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/target/view"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
@@ -34,9 +39,16 @@ func NewCmdDeploymentTarget(f factory.Factory) *cobra.Command {
 	cmd.AddCommand(cmdCloudRegion.NewCmdCloudRegion(f))
 	cmd.AddCommand(cmdAzureWebApp.NewCmdAzureWebApp(f))
 	cmd.AddCommand(cmdKubernetes.NewCmdKubernetes(f))
+	cmd.AddCommand(cmdKubernetesAgent.NewCmdKubernetesAgent(f))
 	cmd.AddCommand(cmdDelete.NewCmdDelete(f))
 	cmd.AddCommand(cmdList.NewCmdList(f))
 	cmd.AddCommand(cmdView.NewCmdView(f))
+	cmd.AddCommand(cmdUpdate.NewCmdUpdate(f))
+	cmd.AddCommand(cmdReconcile.NewCmdReconcile(f))
+	cmd.AddCommand(cmdDiscovery.NewCmdDiscovery(f))
+	cmd.AddCommand(cmdMaintenance.NewCmdHealthCheck(f))
+	cmd.AddCommand(cmdMaintenance.NewCmdUpgradeTentacle(f))
+	cmd.AddCommand(cmdMaintenance.NewCmdUpdateCalamari(f))
 
 	return cmd
 }
@@ -0,0 +1,323 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	sharedTenants "github.com/OctopusDeploy/cli/pkg/cmd/tenant/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/proxies"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tagsets"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagEnvironment       = "environment"
+	FlagRole              = "role"
+	FlagAddRole           = "add-role"
+	FlagRemoveRole        = "remove-role"
+	FlagAddEnvironment    = "add-environment"
+	FlagRemoveEnvironment = "remove-environment"
+	FlagAddTenant         = "add-tenant"
+	FlagRemoveTenant      = "remove-tenant"
+	FlagAddTenantTag      = "add-tenant-tag"
+	FlagRemoveTenantTag   = "remove-tenant-tag"
+
+	resourceDescription = "deployment target"
+)
+
+var tenantedDeploymentModes = []string{shared.Untenanted, shared.Tenanted, shared.TenantedOrUntenanted}
+
+type UpdateFlags struct {
+	Environments       *flag.Flag[[]string]
+	Roles              *flag.Flag[[]string]
+	AddRoles           *flag.Flag[[]string]
+	RemoveRoles        *flag.Flag[[]string]
+	AddEnvironments    *flag.Flag[[]string]
+	RemoveEnvironments *flag.Flag[[]string]
+	TenantedMode       *flag.Flag[string]
+	AddTenants         *flag.Flag[[]string]
+	RemoveTenants      *flag.Flag[[]string]
+	AddTenantTags      *flag.Flag[[]string]
+	RemoveTenantTags   *flag.Flag[[]string]
+	*machinescommon.UpdateMachineFlags
+}
+
+func NewUpdateFlags() *UpdateFlags {
+	return &UpdateFlags{
+		Environments:       flag.New[[]string](FlagEnvironment, false),
+		Roles:              flag.New[[]string](FlagRole, false),
+		AddRoles:           flag.New[[]string](FlagAddRole, false),
+		RemoveRoles:        flag.New[[]string](FlagRemoveRole, false),
+		AddEnvironments:    flag.New[[]string](FlagAddEnvironment, false),
+		RemoveEnvironments: flag.New[[]string](FlagRemoveEnvironment, false),
+		TenantedMode:       flag.New[string](shared.FlagTenantedDeployment, false),
+		AddTenants:         flag.New[[]string](FlagAddTenant, false),
+		RemoveTenants:      flag.New[[]string](FlagRemoveTenant, false),
+		AddTenantTags:      flag.New[[]string](FlagAddTenantTag, false),
+		RemoveTenantTags:   flag.New[[]string](FlagRemoveTenantTag, false),
+		UpdateMachineFlags: machinescommon.NewUpdateMachineFlags(),
+	}
+}
+
+type UpdateOptions struct {
+	*UpdateFlags
+	*cmd.Dependencies
+	IsFlagSet                 flag.IsSetFunc
+	GetTargetsCallback        func(query machines.MachinesQuery) ([]*machines.DeploymentTarget, error)
+	GetEnvironmentMapCallback func() (map[string]string, error)
+	GetAllTenantsCallback     sharedTenants.GetAllTenantsCallback
+	GetAllTagsCallback        shared.GetAllTagsCallback
+	machinescommon.GetAllMachinePoliciesCallback
+	machinescommon.GetAllProxiesCallback
+	UpdateTargetCallback func(target *machines.DeploymentTarget) (*machines.DeploymentTarget, error)
+}
+
+func NewUpdateOptions(flags *UpdateFlags, dependencies *cmd.Dependencies, isFlagSet flag.IsSetFunc) *UpdateOptions {
+	tenantOptions := shared.NewCreateTargetTenantOptions(dependencies)
+	return &UpdateOptions{
+		UpdateFlags:  flags,
+		Dependencies: dependencies,
+		IsFlagSet:    isFlagSet,
+		GetTargetsCallback: func(query machines.MachinesQuery) ([]*machines.DeploymentTarget, error) {
+			return shared.GetAllTargets(*dependencies.Client, query)
+		},
+		GetEnvironmentMapCallback: func() (map[string]string, error) {
+			return shared.GetEnvironmentMap(dependencies.Client)
+		},
+		GetAllTenantsCallback:         tenantOptions.GetAllTenantsCallback,
+		GetAllTagsCallback:            tenantOptions.GetAllTagsCallback,
+		GetAllMachinePoliciesCallback: machinescommon.NewCreateTargetMachinePolicyOptions(dependencies).GetAllMachinePoliciesCallback,
+		GetAllProxiesCallback: func() ([]*proxies.Proxy, error) {
+			return dependencies.Client.Proxies.GetAll()
+		},
+		UpdateTargetCallback: func(target *machines.DeploymentTarget) (*machines.DeploymentTarget, error) {
+			return dependencies.Client.Machines.Update(target)
+		},
+	}
+}
+
+func NewCmdUpdate(f factory.Factory) *cobra.Command {
+	updateFlags := NewUpdateFlags()
+
+	cmd := &cobra.Command{
+		Use:   "update [<name> | <id>]...",
+		Short: "Update deployment targets",
+		Long: heredoc.Docf(`
+			Update the roles, environments, tenants, machine policy, proxy or enabled state of
+			deployment targets in Octopus Deploy.
+
+			The deployment targets are given by name or ID, or selected with --%[1]s and --%[2]s to
+			change many deployment targets at once. Only the settings given are changed.
+		`, FlagEnvironment, FlagRole),
+		Example: heredoc.Docf(`
+			%[1]s deployment-target update web-01 --add-role web-server --remove-role legacy
+			%[1]s deployment-target update --environment Staging --add-environment Test
+			%[1]s deployment-target update --role web-server --tenanted-mode TenantedOrUntenanted --add-tenant-tag "Regions/Europe"
+			%[1]s deployment-target update web-01 web-02 --machine-policy "Transient machines" --proxy ""
+			%[1]s deployment-target update --environment Production --disable
+		`, constants.ExecutableName),
+		Aliases: []string{"edit"},
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := NewUpdateOptions(updateFlags, cmd.NewDependencies(f, c), c.Flags().Changed)
+			return UpdateRun(opts, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringArrayVarP(&updateFlags.Environments.Value, updateFlags.Environments.Name, "e", nil, "Select the deployment targets in this environment")
+	flags.StringArrayVar(&updateFlags.Roles.Value, updateFlags.Roles.Name, nil, "Select the deployment targets with this role")
+	flags.StringArrayVar(&updateFlags.AddRoles.Value, updateFlags.AddRoles.Name, nil, "Add a role to the deployment targets")
+	flags.StringArrayVar(&updateFlags.RemoveRoles.Value, updateFlags.RemoveRoles.Name, nil, "Remove a role from the deployment targets")
+	flags.StringArrayVar(&updateFlags.AddEnvironments.Value, updateFlags.AddEnvironments.Name, nil, "Add the deployment targets to an environment")
+	flags.StringArrayVar(&updateFlags.RemoveEnvironments.Value, updateFlags.RemoveEnvironments.Name, nil, "Remove the deployment targets from an environment")
+	flags.StringVar(&updateFlags.TenantedMode.Value, updateFlags.TenantedMode.Name, "", fmt.Sprintf("Change the kind of deployments the deployment targets are included in: %s", output.FormatAsList(tenantedDeploymentModes)))
+	flags.StringArrayVar(&updateFlags.AddTenants.Value, updateFlags.AddTenants.Name, nil, "Associate the deployment targets with a tenant")
+	flags.StringArrayVar(&updateFlags.RemoveTenants.Value, updateFlags.RemoveTenants.Name, nil, "Stop associating the deployment targets with a tenant")
+	flags.StringArrayVar(&updateFlags.AddTenantTags.Value, updateFlags.AddTenantTags.Name, nil, "Associate the deployment targets with a tenant tag, in the format 'tag set name/tag name'")
+	flags.StringArrayVar(&updateFlags.RemoveTenantTags.Value, updateFlags.RemoveTenantTags.Name, nil, "Stop associating the deployment targets with a tenant tag")
+	machinescommon.RegisterUpdateMachineFlags(cmd, updateFlags.UpdateMachineFlags, resourceDescription)
+
+	return cmd
+}
+
+// TargetUpdate is a change to deployment targets, with names resolved to IDs.
+type TargetUpdate struct {
+	AddRoles             []string
+	RemoveRoles          []string
+	AddEnvironmentIDs    []string
+	RemoveEnvironmentIDs []string
+	// TenantedMode is empty when it is not changed.
+	TenantedMode     string
+	AddTenantIDs     []string
+	RemoveTenantIDs  []string
+	AddTenantTags    []string
+	RemoveTenantTags []string
+	*machinescommon.MachineUpdate
+}
+
+func UpdateRun(opts *UpdateOptions, identifiers []string) error {
+	update, err := ResolveUpdate(opts)
+	if err != nil {
+		return err
+	}
+	if update.IsEmpty() {
+		return fmt.Errorf("nothing to change, give at least one of the flags which change deployment targets")
+	}
+
+	query := machines.MachinesQuery{Roles: opts.Roles.Value}
+	if len(opts.Environments.Value) > 0 {
+		environmentMap, err := opts.GetEnvironmentMapCallback()
+		if err != nil {
+			return err
+		}
+		if query.EnvironmentIDs, err = machinescommon.ResolveIDs(opts.Environments.Value, environmentMap, "environment"); err != nil {
+			return err
+		}
+	}
+	selector := &machinescommon.MachineSelector[*machines.DeploymentTarget]{
+		Description:         resourceDescription,
+		GetMachinesCallback: func() ([]*machines.DeploymentTarget, error) { return opts.GetTargetsCallback(query) },
+		GetID:               func(t *machines.DeploymentTarget) string { return t.GetID() },
+		GetName:             func(t *machines.DeploymentTarget) string { return t.Name },
+	}
+	targets, err := selector.Select(opts.Ask, opts.NoPrompt, "Select the deployment targets to update", identifiers, len(query.EnvironmentIDs) > 0 || len(query.Roles) > 0)
+	if err != nil {
+		return err
+	}
+
+	// check every deployment target can take the change before changing any of them
+	for _, target := range targets {
+		if err := ApplyUpdate(target, update); err != nil {
+			return fmt.Errorf("cannot update deployment target '%s': %w", target.Name, err)
+		}
+	}
+	for _, target := range targets {
+		updated, err := opts.UpdateTargetCallback(target)
+		if err != nil {
+			return fmt.Errorf("cannot update deployment target '%s': %w", target.Name, err)
+		}
+		fmt.Fprintf(opts.Out, "Successfully updated deployment target '%s' %s.\n", updated.Name, output.Dimf("(%s)", updated.GetID()))
+	}
+
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.AddRoles, opts.RemoveRoles, opts.AddEnvironments, opts.RemoveEnvironments, opts.TenantedMode, opts.AddTenants, opts.RemoveTenants, opts.AddTenantTags, opts.RemoveTenantTags, opts.MachinePolicy, opts.Proxy, opts.Enable, opts.Disable)
+		for _, target := range targets {
+			autoCmd += fmt.Sprintf(" '%s'", strings.ReplaceAll(target.Name, "'", "'\\''"))
+		}
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+	return nil
+}
+
+// ResolveUpdate checks the flags given and turns them into a TargetUpdate.
+func ResolveUpdate(opts *UpdateOptions) (*TargetUpdate, error) {
+	machineUpdate, err := machinescommon.ResolveMachineUpdate(opts.UpdateMachineFlags, opts.IsFlagSet, opts.GetAllMachinePoliciesCallback, opts.GetAllProxiesCallback)
+	if err != nil {
+		return nil, err
+	}
+	update := &TargetUpdate{
+		AddRoles:         opts.AddRoles.Value,
+		RemoveRoles:      opts.RemoveRoles.Value,
+		AddTenantTags:    opts.AddTenantTags.Value,
+		RemoveTenantTags: opts.RemoveTenantTags.Value,
+		MachineUpdate:    machineUpdate,
+	}
+
+	if opts.TenantedMode.Value != "" {
+		var ok bool
+		if update.TenantedMode, ok = machinescommon.FindIgnoringCase(tenantedDeploymentModes, opts.TenantedMode.Value); !ok {
+			return nil, fmt.Errorf("unknown tenanted mode '%s', must be one of %s", opts.TenantedMode.Value, output.FormatAsList(tenantedDeploymentModes))
+		}
+	}
+
+	if len(opts.AddEnvironments.Value) > 0 || len(opts.RemoveEnvironments.Value) > 0 {
+		environmentMap, err := opts.GetEnvironmentMapCallback()
+		if err != nil {
+			return nil, err
+		}
+		if update.AddEnvironmentIDs, err = machinescommon.ResolveIDs(opts.AddEnvironments.Value, environmentMap, "environment"); err != nil {
+			return nil, err
+		}
+		if update.RemoveEnvironmentIDs, err = machinescommon.ResolveIDs(opts.RemoveEnvironments.Value, environmentMap, "environment"); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(opts.AddTenants.Value) > 0 || len(opts.RemoveTenants.Value) > 0 {
+		allTenants, err := opts.GetAllTenantsCallback()
+		if err != nil {
+			return nil, err
+		}
+		tenantMap := make(map[string]string, len(allTenants))
+		for _, t := range allTenants {
+			tenantMap[t.GetID()] = t.Name
+		}
+		if update.AddTenantIDs, err = machinescommon.ResolveIDs(opts.AddTenants.Value, tenantMap, "tenant"); err != nil {
+			return nil, err
+		}
+		if update.RemoveTenantIDs, err = machinescommon.ResolveIDs(opts.RemoveTenants.Value, tenantMap, "tenant"); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(opts.AddTenantTags.Value) > 0 {
+		allTags, err := opts.GetAllTagsCallback()
+		if err != nil {
+			return nil, err
+		}
+		canonicalTags := util.SliceTransform(allTags, func(t *tagsets.Tag) string { return t.CanonicalTagName })
+		for i, tag := range update.AddTenantTags {
+			canonicalTag, ok := machinescommon.FindIgnoringCase(canonicalTags, tag)
+			if !ok {
+				return nil, fmt.Errorf("cannot find tenant tag '%s', it should be in the format 'tag set name/tag name'", tag)
+			}
+			update.AddTenantTags[i] = canonicalTag
+		}
+	}
+
+	return update, nil
+}
+
+// IsEmpty reports whether the update changes nothing.
+func (u *TargetUpdate) IsEmpty() bool {
+	return u.MachineUpdate.IsEmpty() && u.TenantedMode == "" &&
+		util.Empty(u.AddRoles) && util.Empty(u.RemoveRoles) &&
+		util.Empty(u.AddEnvironmentIDs) && util.Empty(u.RemoveEnvironmentIDs) &&
+		util.Empty(u.AddTenantIDs) && util.Empty(u.RemoveTenantIDs) &&
+		util.Empty(u.AddTenantTags) && util.Empty(u.RemoveTenantTags)
+}
+
+// ApplyUpdate changes a deployment target, checking it is still valid afterwards.
+func ApplyUpdate(target *machines.DeploymentTarget, update *TargetUpdate) error {
+	target.Roles = machinescommon.RemoveValues(machinescommon.AddValues(target.Roles, update.AddRoles), update.RemoveRoles)
+	if len(target.Roles) == 0 && len(update.RemoveRoles) > 0 {
+		return fmt.Errorf("a deployment target must have at least one role")
+	}
+
+	target.EnvironmentIDs = machinescommon.RemoveValues(machinescommon.AddValues(target.EnvironmentIDs, update.AddEnvironmentIDs), update.RemoveEnvironmentIDs)
+	if len(target.EnvironmentIDs) == 0 && len(update.RemoveEnvironmentIDs) > 0 {
+		return fmt.Errorf("a deployment target must be in at least one environment")
+	}
+
+	if update.TenantedMode != "" {
+		target.TenantedDeploymentMode = core.TenantedDeploymentMode(update.TenantedMode)
+	}
+	target.TenantIDs = machinescommon.RemoveValues(machinescommon.AddValues(target.TenantIDs, update.AddTenantIDs), update.RemoveTenantIDs)
+	target.TenantTags = machinescommon.RemoveValues(machinescommon.AddValues(target.TenantTags, update.AddTenantTags), update.RemoveTenantTags)
+	if target.TenantedDeploymentMode == core.TenantedDeploymentMode(shared.Untenanted) && (len(target.TenantIDs) > 0 || len(target.TenantTags) > 0) {
+		return fmt.Errorf("an untenanted deployment target cannot be associated with tenants, use --%s to change that", shared.FlagTenantedDeployment)
+	}
+
+	return machinescommon.ApplyMachineUpdate(update.MachineUpdate, &target.MachinePolicyID, &target.IsDisabled, target.Endpoint)
+}
@@ -0,0 +1,140 @@
+package update_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/update"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/proxies"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tagsets"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTarget(id string, name string, environmentIDs []string, roles []string) *machines.DeploymentTarget {
+	target := machines.NewDeploymentTarget(name, machines.NewListeningTentacleEndpoint(nil, "1234"), environmentIDs, roles)
+	target.ID = id
+	return target
+}
+
+func newTestOptions(flags *update.UpdateFlags, given ...string) *update.UpdateOptions {
+	opts := update.NewUpdateOptions(flags, &cmd.Dependencies{NoPrompt: true, Out: &bytes.Buffer{}}, func(name string) bool {
+		for _, g := range given {
+			if g == name {
+				return true
+			}
+		}
+		return false
+	})
+	opts.GetEnvironmentMapCallback = func() (map[string]string, error) {
+		return map[string]string{"Environments-1": "Staging", "Environments-2": "Production"}, nil
+	}
+	opts.GetAllTenantsCallback = func() ([]*tenants.Tenant, error) {
+		tenant := tenants.NewTenant("Contoso")
+		tenant.ID = "Tenants-1"
+		return []*tenants.Tenant{tenant}, nil
+	}
+	opts.GetAllTagsCallback = func() ([]*tagsets.Tag, error) {
+		return []*tagsets.Tag{{Name: "Europe", CanonicalTagName: "Regions/Europe"}}, nil
+	}
+	opts.GetAllProxiesCallback = func() ([]*proxies.Proxy, error) {
+		return nil, nil
+	}
+	return opts
+}
+
+func TestUpdateRun_ChangesSelectedTargets(t *testing.T) {
+	web1 := newTarget("Machines-1", "web-01", []string{"Environments-1"}, []string{"web-server", "legacy"})
+	web2 := newTarget("Machines-2", "web-02", []string{"Environments-1"}, []string{"web-server"})
+
+	flags := update.NewUpdateFlags()
+	flags.Environments.Value = []string{"staging"}
+	flags.AddRoles.Value = []string{"api"}
+	flags.RemoveRoles.Value = []string{"LEGACY"}
+	flags.AddEnvironments.Value = []string{"Production"}
+	flags.TenantedMode.Value = "tenantedoruntenanted"
+	flags.AddTenants.Value = []string{"contoso"}
+	flags.AddTenantTags.Value = []string{"regions/europe"}
+	flags.Disable.Value = true
+	opts := newTestOptions(flags)
+
+	var query machines.MachinesQuery
+	opts.GetTargetsCallback = func(q machines.MachinesQuery) ([]*machines.DeploymentTarget, error) {
+		query = q
+		return []*machines.DeploymentTarget{web1, web2}, nil
+	}
+	var updated []string
+	opts.UpdateTargetCallback = func(target *machines.DeploymentTarget) (*machines.DeploymentTarget, error) {
+		updated = append(updated, target.GetID())
+		return target, nil
+	}
+
+	err := update.UpdateRun(opts, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Environments-1"}, query.EnvironmentIDs)
+	assert.Equal(t, []string{"Machines-1", "Machines-2"}, updated)
+	for _, target := range []*machines.DeploymentTarget{web1, web2} {
+		assert.Equal(t, []string{"Environments-1", "Environments-2"}, target.EnvironmentIDs)
+		assert.Equal(t, core.TenantedDeploymentModeTenantedOrUntenanted, target.TenantedDeploymentMode)
+		assert.Equal(t, []string{"Tenants-1"}, target.TenantIDs)
+		assert.Equal(t, []string{"Regions/Europe"}, target.TenantTags)
+		assert.True(t, target.IsDisabled)
+	}
+	assert.Equal(t, []string{"web-server", "api"}, web1.Roles)
+	assert.Equal(t, []string{"web-server", "api"}, web2.Roles)
+}
+
+func TestUpdateRun_ChecksAllTargetsBeforeChangingAny(t *testing.T) {
+	web1 := newTarget("Machines-1", "web-01", []string{"Environments-1", "Environments-2"}, []string{"web-server"})
+	web2 := newTarget("Machines-2", "web-02", []string{"Environments-1"}, []string{"web-server"})
+
+	flags := update.NewUpdateFlags()
+	flags.RemoveEnvironments.Value = []string{"Staging"}
+	opts := newTestOptions(flags)
+	opts.GetTargetsCallback = func(q machines.MachinesQuery) ([]*machines.DeploymentTarget, error) {
+		return []*machines.DeploymentTarget{web1, web2}, nil
+	}
+	opts.UpdateTargetCallback = func(target *machines.DeploymentTarget) (*machines.DeploymentTarget, error) {
+		assert.Fail(t, "should not update any deployment target")
+		return target, nil
+	}
+
+	err := update.UpdateRun(opts, []string{"web-01", "web-02"})
+
+	assert.EqualError(t, err, "cannot update deployment target 'web-02': a deployment target must be in at least one environment")
+}
+
+func TestUpdateRun_NothingToChange(t *testing.T) {
+	opts := newTestOptions(update.NewUpdateFlags())
+
+	err := update.UpdateRun(opts, []string{"web-01"})
+
+	assert.EqualError(t, err, "nothing to change, give at least one of the flags which change deployment targets")
+}
+
+func TestResolveUpdate_RemovesProxyWhenEmpty(t *testing.T) {
+	flags := update.NewUpdateFlags()
+	opts := newTestOptions(flags, "proxy")
+	target := newTarget("Machines-1", "web-01", []string{"Environments-1"}, []string{"web-server"})
+	target.Endpoint.(*machines.ListeningTentacleEndpoint).ProxyID = "Proxies-1"
+
+	targetUpdate, err := update.ResolveUpdate(opts)
+	assert.NoError(t, err)
+	assert.False(t, targetUpdate.IsEmpty())
+
+	assert.NoError(t, update.ApplyUpdate(target, targetUpdate))
+	assert.Equal(t, "", target.Endpoint.(*machines.ListeningTentacleEndpoint).ProxyID)
+}
+
+func TestResolveUpdate_UnknownTenantedMode(t *testing.T) {
+	flags := update.NewUpdateFlags()
+	flags.TenantedMode.Value = "sometimes"
+
+	_, err := update.ResolveUpdate(newTestOptions(flags))
+
+	assert.EqualError(t, err, "unknown tenanted mode 'sometimes', must be one of Untenanted, Tenanted, TenantedOrUntenanted")
+}
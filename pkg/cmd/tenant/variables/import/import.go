@@ -0,0 +1,218 @@
+package importcmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	targetShared "github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/tenant/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	sharedVariable "github.com/OctopusDeploy/cli/pkg/question/shared/variables"
+	"github.com/OctopusDeploy/cli/pkg/util/featuretoggle"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagFile   = "file"
+	FlagFormat = "format"
+	FlagDryRun = "dry-run"
+)
+
+type ImportFlags struct {
+	File   *flag.Flag[string]
+	Format *flag.Flag[string]
+	DryRun *flag.Flag[bool]
+}
+
+func NewImportFlags() *ImportFlags {
+	return &ImportFlags{
+		File:   flag.New[string](FlagFile, false),
+		Format: flag.New[string](FlagFormat, false),
+		DryRun: flag.New[bool](FlagDryRun, false),
+	}
+}
+
+type SavePlanCallback func(plan *TenantPlan) error
+
+type ImportOptions struct {
+	*ImportFlags
+	*cmd.Dependencies
+	shared.GetTenantCallback
+	GetEnvironmentMapCallback func() (map[string]string, error)
+	*sharedVariable.VariableCallbacks
+	FeatureToggleCallback func(name string) (bool, error)
+	SavePlanCallback
+}
+
+func NewImportOptions(flags *ImportFlags, dependencies *cmd.Dependencies) *ImportOptions {
+	return &ImportOptions{
+		ImportFlags:  flags,
+		Dependencies: dependencies,
+		GetTenantCallback: func(identifier string) (*tenants.Tenant, error) {
+			return shared.GetTenant(dependencies.Client, identifier)
+		},
+		GetEnvironmentMapCallback: func() (map[string]string, error) { return targetShared.GetEnvironmentMap(dependencies.Client) },
+		VariableCallbacks:         sharedVariable.NewVariableCallbacks(dependencies),
+		FeatureToggleCallback: func(name string) (bool, error) {
+			return featuretoggle.IsToggleEnabled(dependencies.Client, name)
+		},
+		SavePlanCallback: func(plan *TenantPlan) error {
+			if plan.Variables != nil {
+				_, err := dependencies.Client.Tenants.UpdateVariables(plan.Tenant, plan.Variables)
+				return err
+			}
+			if plan.ProjectVariables != nil {
+				if _, err := tenants.UpdateProjectVariables(dependencies.Client, plan.Tenant.SpaceID, plan.Tenant.ID, plan.ProjectVariables); err != nil {
+					return err
+				}
+			}
+			if plan.CommonVariables != nil {
+				if _, err := tenants.UpdateCommonVariables(dependencies.Client, plan.Tenant.SpaceID, plan.Tenant.ID, plan.CommonVariables); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func NewCmdImport(f factory.Factory) *cobra.Command {
+	importFlags := NewImportFlags()
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import the values of tenant variables",
+		Long: heredoc.Doc(`
+			Set the values of many tenant variables in Octopus Deploy from a CSV or JSON file.
+
+			Each row names a tenant, either a project or a library variable set, the environments
+			the value applies to, the variable and its value. CSV files need a header row naming these
+			columns; several environments can be given in one cell separated by semicolons.
+
+			Every row is checked before anything is saved, and the variables of each tenant are saved
+			together.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s tenant variables import --file tenants.csv
+			%[1]s tenant variables import --file tenants.json --dry-run
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := NewImportOptions(importFlags, cmd.NewDependencies(f, c))
+
+			toggleValue, _ := opts.FeatureToggleCallback("CommonVariableScopingFeatureToggle")
+
+			if toggleValue {
+				return importRun(opts, PlanTenant)
+			}
+
+			return importRun(opts, PlanTenantV1)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&importFlags.File.Value, importFlags.File.Name, "", "The file to read the tenant variables from")
+	flags.StringVar(&importFlags.Format.Value, importFlags.Format.Name, "", fmt.Sprintf("The format of the file, one of %s. Inferred from the file extension when not given", output.FormatAsList(Formats)))
+	flags.BoolVar(&importFlags.DryRun.Value, importFlags.DryRun.Name, false, "Check every row without saving any changes")
+
+	return cmd
+}
+
+type planTenantFunc func(opts *ImportOptions, tenant *tenants.Tenant, rows []*Row, environmentMap map[string]string) (*TenantPlan, []string)
+
+func importRun(opts *ImportOptions, planTenant planTenantFunc) error {
+	if opts.File.Value == "" {
+		return fmt.Errorf("must supply the file to import with --%s", FlagFile)
+	}
+
+	format := opts.Format.Value
+	if format == "" {
+		format = FormatFromFileName(opts.File.Value)
+	}
+	if format == "" {
+		return fmt.Errorf("cannot infer the format of '%s'; supply --%s", opts.File.Value, FlagFormat)
+	}
+
+	data, err := os.ReadFile(opts.File.Value)
+	if err != nil {
+		return err
+	}
+	rows, err := ParseRows(data, format)
+	if err != nil {
+		return fmt.Errorf("cannot read '%s': %w", opts.File.Value, err)
+	}
+
+	plans, err := PlanImport(opts, rows, planTenant)
+	if err != nil {
+		return err
+	}
+
+	if opts.DryRun.Value {
+		for _, plan := range plans {
+			fmt.Fprintf(opts.Out, "%s: %d variables to update\n", plan.Tenant.Name, plan.Rows)
+		}
+		_, err = fmt.Fprintln(opts.Out, output.Dim("Dry run: every row is valid, no changes were saved"))
+		return err
+	}
+
+	for _, plan := range plans {
+		if err := opts.SavePlanCallback(plan); err != nil {
+			return fmt.Errorf("cannot save the variables of tenant '%s': %w", plan.Tenant.Name, err)
+		}
+		fmt.Fprintf(opts.Out, "Updated %d variables for tenant '%s'\n", plan.Rows, plan.Tenant.Name)
+	}
+
+	_, err = fmt.Fprintf(opts.Out, "Successfully imported %d variables for %d tenants\n", len(rows), len(plans))
+	return err
+}
+
+// PlanImport groups rows by tenant, in the order each tenant first appears, and plans the
+// changes to every tenant. Nothing is returned unless every row is valid.
+func PlanImport(opts *ImportOptions, rows []*Row, planTenant planTenantFunc) ([]*TenantPlan, error) {
+	environmentMap, err := opts.GetEnvironmentMapCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	cachedOpts := *opts
+	cachedOpts.VariableCallbacks = cacheLookups(opts.VariableCallbacks)
+
+	var tenantKeys []string
+	rowsByTenant := map[string][]*Row{}
+	for _, r := range rows {
+		key := strings.ToLower(r.Tenant)
+		if _, ok := rowsByTenant[key]; !ok {
+			tenantKeys = append(tenantKeys, key)
+		}
+		rowsByTenant[key] = append(rowsByTenant[key], r)
+	}
+
+	var plans []*TenantPlan
+	var problems []string
+	for _, key := range tenantKeys {
+		tenantRows := rowsByTenant[key]
+		tenant, err := opts.GetTenantCallback(tenantRows[0].Tenant)
+		if err != nil {
+			for _, r := range tenantRows {
+				problems = append(problems, fmt.Sprintf("row %d: cannot find tenant '%s'", r.Line, r.Tenant))
+			}
+			continue
+		}
+
+		plan, tenantProblems := planTenant(&cachedOpts, tenant, tenantRows, environmentMap)
+		problems = append(problems, tenantProblems...)
+		if plan != nil {
+			plans = append(plans, plan)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, invalidRowsError(problems)
+	}
+	return plans, nil
+}
@@ -0,0 +1,181 @@
+package importcmd_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	importcmd "github.com/OctopusDeploy/cli/pkg/cmd/tenant/variables/import"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/actiontemplates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRows_CsvKeepsSpacesInValues(t *testing.T) {
+	data := " Tenant , Project , Name , Value\n" +
+		" Bobs Fish Shack , Awesome Web Site ,  motd ,\"  Welcome aboard  \"\n"
+
+	rows, err := importcmd.ParseRows([]byte(data), importcmd.FormatCsv)
+	require.Nil(t, err)
+
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Bobs Fish Shack", rows[0].Tenant)
+	assert.Equal(t, "Awesome Web Site", rows[0].Project)
+	assert.Equal(t, "motd", rows[0].Name)
+	assert.Equal(t, "  Welcome aboard  ", rows[0].Value)
+}
+
+func TestParseRows_Csv(t *testing.T) {
+	data := "Tenant,Project,Library Variable Set,Environments,Name,Value\n" +
+		"Bobs Fish Shack,Awesome Web Site,,Dev;Test,site-name,Bob's Fish Shack\n" +
+		"Bobs Fish Shack,,Shared Variables,,dbPassword,12345\n"
+
+	rows, err := importcmd.ParseRows([]byte(data), importcmd.FormatCsv)
+	require.Nil(t, err)
+
+	assert.Equal(t, []*importcmd.Row{
+		{Line: 2, Tenant: "Bobs Fish Shack", Project: "Awesome Web Site", Environments: []string{"Dev", "Test"}, Name: "site-name", Value: "Bob's Fish Shack"},
+		{Line: 3, Tenant: "Bobs Fish Shack", LibraryVariableSet: "Shared Variables", Name: "dbPassword", Value: "12345"},
+	}, rows)
+}
+
+func TestParseRows_ReportsEveryInvalidRow(t *testing.T) {
+	data := `[
+		{"Tenant": "Bobs Fish Shack", "Name": "site-name", "Value": "x"},
+		{"Tenant": "Bobs Fish Shack", "Project": "Awesome Web Site", "Name": "site-name", "Value": "x"},
+		{"Project": "Awesome Web Site", "LibraryVariableSet": "Shared Variables", "Name": "site-name", "Value": "x"}
+	]`
+
+	_, err := importcmd.ParseRows([]byte(data), importcmd.FormatJson)
+	assert.EqualError(t, err, "2 rows are not valid, so no variables were imported:\n"+
+		"row 1: either a project or a library variable set is required\n"+
+		"row 3: a tenant is required")
+}
+
+func newImportOptions() *importcmd.ImportOptions {
+	opts := importcmd.NewImportOptions(importcmd.NewImportFlags(), &cmd.Dependencies{})
+	opts.GetTenantCallback = func(identifier string) (*tenants.Tenant, error) {
+		if identifier != "Bobs Fish Shack" {
+			return nil, errors.New("not found")
+		}
+		tenant := tenants.NewTenant("Bobs Fish Shack")
+		tenant.ID = "Tenants-1"
+		tenant.ProjectEnvironments = map[string][]string{"Projects-1": {"Environments-1", "Environments-2"}}
+		return tenant, nil
+	}
+	opts.GetEnvironmentMapCallback = func() (map[string]string, error) {
+		return map[string]string{"Environments-1": "Dev", "Environments-2": "Test", "Environments-3": "Prod"}, nil
+	}
+	return opts
+}
+
+func TestPlanImport_UpdatesMatchingScopeAndAddsNewScope(t *testing.T) {
+	opts := newImportOptions()
+	opts.GetTenantProjectVariables = func(tenant *tenants.Tenant, includeMissingVariables bool) (*variables.GetTenantProjectVariablesResponse, error) {
+		return &variables.GetTenantProjectVariablesResponse{
+			Variables: []variables.TenantProjectVariable{
+				createProjectVariable("TenantVariables-1", "Templates-1", "site-name", "old", []string{"Environments-1"}),
+				createProjectVariable("TenantVariables-2", "Templates-2", "site-url", "https://old", []string{"Environments-1"}),
+			},
+		}, nil
+	}
+	rows := []*importcmd.Row{
+		{Line: 2, Tenant: "Bobs Fish Shack", Project: "Awesome Web Site", Environments: []string{"dev"}, Name: "site-name", Value: "new"},
+		{Line: 3, Tenant: "Bobs Fish Shack", Project: "Awesome Web Site", Environments: []string{"Test"}, Name: "site-name", Value: "test"},
+	}
+
+	plans, err := importcmd.PlanImport(opts, rows, importcmd.PlanTenant)
+	require.Nil(t, err)
+	require.Len(t, plans, 1)
+	assert.Nil(t, plans[0].CommonVariables)
+
+	assert.Equal(t, []variables.TenantProjectVariablePayload{
+		{ID: "TenantVariables-1", ProjectID: "Projects-1", TemplateID: "Templates-1", Value: core.PropertyValue{Value: "new"}, Scope: variables.TenantVariableScope{EnvironmentIds: []string{"Environments-1"}}},
+		{ID: "TenantVariables-2", ProjectID: "Projects-1", TemplateID: "Templates-2", Value: core.PropertyValue{Value: "https://old"}, Scope: variables.TenantVariableScope{EnvironmentIds: []string{"Environments-1"}}},
+		{ProjectID: "Projects-1", TemplateID: "Templates-1", Value: core.PropertyValue{Value: "test"}, Scope: variables.TenantVariableScope{EnvironmentIds: []string{"Environments-2"}}},
+	}, plans[0].ProjectVariables.Variables)
+}
+
+func TestPlanImport_ReportsProblemsAcrossTenants(t *testing.T) {
+	opts := newImportOptions()
+	opts.GetTenantProjectVariables = func(tenant *tenants.Tenant, includeMissingVariables bool) (*variables.GetTenantProjectVariablesResponse, error) {
+		return &variables.GetTenantProjectVariablesResponse{
+			Variables: []variables.TenantProjectVariable{
+				createProjectVariable("TenantVariables-1", "Templates-1", "site-name", "old", []string{"Environments-1", "Environments-2"}),
+			},
+		}, nil
+	}
+	rows := []*importcmd.Row{
+		{Line: 2, Tenant: "Bobs Fish Shack", Project: "Awesome Web Site", Environments: []string{"Dev"}, Name: "site-name", Value: "new"},
+		{Line: 3, Tenant: "Bobs Fish Shack", Project: "Awesome Web Site", Environments: []string{"Prod"}, Name: "site-name", Value: "new"},
+		{Line: 4, Tenant: "Bobs Fish Shack", Project: "Awesome Web Site", Name: "site-colour", Value: "blue"},
+		{Line: 5, Tenant: "Sallys Tackle Truck", Project: "Awesome Web Site", Name: "site-name", Value: "new"},
+	}
+
+	_, err := importcmd.PlanImport(opts, rows, importcmd.PlanTenant)
+	assert.EqualError(t, err, "4 rows are not valid, so no variables were imported:\n"+
+		"row 2: 'site-name' already has a value for some of these environments in another scope\n"+
+		"row 3: tenant 'Bobs Fish Shack' is not connected to 'Awesome Web Site' in Prod\n"+
+		"row 4: 'Awesome Web Site' has no tenant variable called 'site-colour'\n"+
+		"row 5: cannot find tenant 'Sallys Tackle Truck'")
+}
+
+func TestPlanImportV1_SetsProjectAndCommonValues(t *testing.T) {
+	opts := newImportOptions()
+	siteName := createTemplate("Templates-1", "site-name")
+	dbPassword := createTemplate("Templates-2", "dbPassword")
+	opts.GetTenantVariables = func(tenant *tenants.Tenant) (*variables.TenantVariables, error) {
+		return &variables.TenantVariables{
+			ProjectVariables: map[string]variables.ProjectVariable{
+				"Projects-1": {
+					ProjectID:   "Projects-1",
+					ProjectName: "Awesome Web Site",
+					Templates:   []*actiontemplates.ActionTemplateParameter{siteName},
+					Variables:   map[string]map[string]core.PropertyValue{"Environments-1": {}},
+				},
+			},
+			LibraryVariables: map[string]variables.LibraryVariable{
+				"LibraryVariableSets-1": {
+					LibraryVariableSetID:   "LibraryVariableSets-1",
+					LibraryVariableSetName: "Shared Variables",
+					Templates:              []*actiontemplates.ActionTemplateParameter{dbPassword},
+				},
+			},
+		}, nil
+	}
+	rows := []*importcmd.Row{
+		{Line: 2, Tenant: "Bobs Fish Shack", Project: "Awesome Web Site", Environments: []string{"Dev"}, Name: "site-name", Value: "new"},
+		{Line: 3, Tenant: "Bobs Fish Shack", LibraryVariableSet: "Shared Variables", Name: "dbPassword", Value: "12345"},
+	}
+
+	plans, err := importcmd.PlanImport(opts, rows, importcmd.PlanTenantV1)
+	require.Nil(t, err)
+	require.Len(t, plans, 1)
+
+	assert.Equal(t, core.PropertyValue{Value: "new"}, plans[0].Variables.ProjectVariables["Projects-1"].Variables["Environments-1"]["Templates-1"])
+	assert.Equal(t, core.PropertyValue{Value: "12345"}, plans[0].Variables.LibraryVariables["LibraryVariableSets-1"].Variables["Templates-2"])
+}
+
+func createTemplate(id string, name string) *actiontemplates.ActionTemplateParameter {
+	template := actiontemplates.NewActionTemplateParameter()
+	template.ID = id
+	template.Name = name
+	template.DisplaySettings = map[string]string{"Octopus.ControlType": string(resources.ControlTypeSingleLineText)}
+	return template
+}
+
+func createProjectVariable(id string, templateID string, templateName string, value string, scope []string) variables.TenantProjectVariable {
+	return variables.TenantProjectVariable{
+		Resource:    resources.Resource{ID: id},
+		ProjectID:   "Projects-1",
+		ProjectName: "Awesome Web Site",
+		TemplateID:  templateID,
+		Template:    *createTemplate(templateID, templateName),
+		Value:       core.PropertyValue{Value: value},
+		Scope:       variables.TenantVariableScope{EnvironmentIds: scope},
+	}
+}
@@ -0,0 +1,371 @@
+package importcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/tenant/variables/update"
+	sharedVariable "github.com/OctopusDeploy/cli/pkg/question/shared/variables"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/actiontemplates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/certificates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+)
+
+// TenantPlan holds everything to be written for one tenant, so that each tenant is
+// updated with a single request per kind of variable however many rows it has.
+type TenantPlan struct {
+	Tenant *tenants.Tenant
+	Rows   int
+
+	// set when common variable scoping is enabled
+	ProjectVariables *variables.ModifyTenantProjectVariablesCommand
+	CommonVariables  *variables.ModifyTenantCommonVariablesCommand
+
+	// set otherwise
+	Variables *variables.TenantVariables
+}
+
+// tenantVariable is a project or common tenant variable, so both can be matched the same way.
+type tenantVariable struct {
+	ID             string
+	OwnerID        string
+	OwnerName      string
+	TemplateID     string
+	Template       actiontemplates.ActionTemplateParameter
+	Value          core.PropertyValue
+	EnvironmentIDs []string
+}
+
+// PlanTenant works out the project and common variables of tenant once rows are applied,
+// for servers with common variable scoping. Every row is checked, and all the problems
+// found are returned rather than just the first.
+func PlanTenant(opts *ImportOptions, tenant *tenants.Tenant, rows []*Row, environmentMap map[string]string) (*TenantPlan, []string) {
+	plan := &TenantPlan{Tenant: tenant, Rows: len(rows)}
+	var problems []string
+
+	var projectRows, commonRows []*Row
+	for _, r := range rows {
+		if r.Project != "" {
+			projectRows = append(projectRows, r)
+		} else {
+			commonRows = append(commonRows, r)
+		}
+	}
+
+	if len(projectRows) > 0 {
+		response, err := opts.GetTenantProjectVariables(tenant, true)
+		if err != nil {
+			return nil, []string{fmt.Sprintf("cannot load the project variables of tenant '%s': %v", tenant.Name, err)}
+		}
+		current := util.SliceTransform(response.Variables, fromProjectVariable)
+		templates := util.SliceTransform(append(response.Variables, response.MissingVariables...), fromProjectVariable)
+		current, rowProblems := applyRows(opts, tenant, projectRows, current, templates, environmentMap)
+		problems = append(problems, rowProblems...)
+
+		plan.ProjectVariables = &variables.ModifyTenantProjectVariablesCommand{}
+		for _, v := range current {
+			plan.ProjectVariables.Variables = append(plan.ProjectVariables.Variables, variables.TenantProjectVariablePayload{
+				ID:         v.ID,
+				ProjectID:  v.OwnerID,
+				TemplateID: v.TemplateID,
+				Value:      v.Value,
+				Scope:      variables.TenantVariableScope{EnvironmentIds: v.EnvironmentIDs},
+			})
+		}
+	}
+
+	if len(commonRows) > 0 {
+		response, err := opts.GetTenantCommonVariables(tenant, true)
+		if err != nil {
+			return nil, []string{fmt.Sprintf("cannot load the common variables of tenant '%s': %v", tenant.Name, err)}
+		}
+		current := util.SliceTransform(response.Variables, fromCommonVariable)
+		templates := util.SliceTransform(append(response.Variables, response.MissingVariables...), fromCommonVariable)
+		current, rowProblems := applyRows(opts, tenant, commonRows, current, templates, environmentMap)
+		problems = append(problems, rowProblems...)
+
+		plan.CommonVariables = &variables.ModifyTenantCommonVariablesCommand{}
+		for _, v := range current {
+			plan.CommonVariables.Variables = append(plan.CommonVariables.Variables, variables.TenantCommonVariablePayload{
+				ID:                   v.ID,
+				LibraryVariableSetId: v.OwnerID,
+				TemplateID:           v.TemplateID,
+				Value:                v.Value,
+				Scope:                variables.TenantVariableScope{EnvironmentIds: v.EnvironmentIDs},
+			})
+		}
+	}
+
+	return plan, problems
+}
+
+// applyRows sets the value of an existing variable whose scope is exactly the environments
+// of a row, or adds a variable with that scope. All the tenant's existing variables are
+// returned, as any left out of the update would be deleted.
+func applyRows(opts *ImportOptions, tenant *tenants.Tenant, rows []*Row, current []*tenantVariable, templates []*tenantVariable, environmentMap map[string]string) ([]*tenantVariable, []string) {
+	var problems []string
+	for _, r := range rows {
+		owner := r.Project
+		if owner == "" {
+			owner = r.LibraryVariableSet
+		}
+
+		environmentIDs, err := resolveEnvironments(r.Environments, environmentMap)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: %v", r.Line, err))
+			continue
+		}
+
+		ownerTemplates := util.SliceFilter(templates, func(v *tenantVariable) bool {
+			return strings.EqualFold(v.OwnerName, owner) || strings.EqualFold(v.OwnerID, owner)
+		})
+		if len(ownerTemplates) == 0 {
+			problems = append(problems, fmt.Sprintf("row %d: tenant '%s' has no variables from '%s'", r.Line, tenant.Name, owner))
+			continue
+		}
+		template := util.SliceFilter(ownerTemplates, func(v *tenantVariable) bool { return strings.EqualFold(v.Template.Name, r.Name) })
+		if len(template) == 0 {
+			problems = append(problems, fmt.Sprintf("row %d: '%s' has no tenant variable called '%s'", r.Line, owner, r.Name))
+			continue
+		}
+
+		if r.Project != "" {
+			if missing := environmentsNotConnected(tenant, template[0].OwnerID, environmentIDs); len(missing) > 0 {
+				problems = append(problems, fmt.Sprintf("row %d: tenant '%s' is not connected to '%s' in %s", r.Line, tenant.Name, owner, strings.Join(util.SliceTransform(missing, func(id string) string { return environmentMap[id] }), ", ")))
+				continue
+			}
+		}
+
+		value, err := update.ConvertValue(opts.VariableCallbacks, &template[0].Template, r.Value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: %v", r.Line, err))
+			continue
+		}
+
+		sameTemplate := util.SliceFilter(current, func(v *tenantVariable) bool {
+			return v.OwnerID == template[0].OwnerID && v.TemplateID == template[0].TemplateID
+		})
+		if existing := util.SliceFilter(sameTemplate, func(v *tenantVariable) bool { return sameEnvironments(v.EnvironmentIDs, environmentIDs) }); len(existing) > 0 {
+			existing[0].Value = *value
+			continue
+		}
+		if overlapping := util.SliceFilter(sameTemplate, func(v *tenantVariable) bool { return overlaps(v.EnvironmentIDs, environmentIDs) }); len(overlapping) > 0 {
+			problems = append(problems, fmt.Sprintf("row %d: '%s' already has a value for some of these environments in another scope", r.Line, r.Name))
+			continue
+		}
+
+		current = append(current, &tenantVariable{
+			OwnerID:        template[0].OwnerID,
+			OwnerName:      template[0].OwnerName,
+			TemplateID:     template[0].TemplateID,
+			Template:       template[0].Template,
+			Value:          *value,
+			EnvironmentIDs: environmentIDs,
+		})
+	}
+	return current, problems
+}
+
+// PlanTenantV1 works out the variables of tenant once rows are applied, for servers without
+// common variable scoping, where project variables have a value per environment and common
+// variables have a single value.
+func PlanTenantV1(opts *ImportOptions, tenant *tenants.Tenant, rows []*Row, environmentMap map[string]string) (*TenantPlan, []string) {
+	tenantVariables, err := opts.GetTenantVariables(tenant)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("cannot load the variables of tenant '%s': %v", tenant.Name, err)}
+	}
+
+	var problems []string
+	for _, r := range rows {
+		environmentIDs, err := resolveEnvironments(r.Environments, environmentMap)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: %v", r.Line, err))
+			continue
+		}
+
+		if r.Project != "" {
+			if len(environmentIDs) != 1 {
+				problems = append(problems, fmt.Sprintf("row %d: project variables need exactly one environment on this Octopus Server", r.Line))
+				continue
+			}
+			if err := applyProjectRowV1(opts, tenant, tenantVariables, r, environmentIDs[0], environmentMap); err != nil {
+				problems = append(problems, fmt.Sprintf("row %d: %v", r.Line, err))
+			}
+		} else {
+			if len(environmentIDs) > 0 {
+				problems = append(problems, fmt.Sprintf("row %d: common variables cannot be scoped to environments on this Octopus Server", r.Line))
+				continue
+			}
+			if err := applyCommonRowV1(opts, tenant, tenantVariables, r); err != nil {
+				problems = append(problems, fmt.Sprintf("row %d: %v", r.Line, err))
+			}
+		}
+	}
+
+	return &TenantPlan{Tenant: tenant, Rows: len(rows), Variables: tenantVariables}, problems
+}
+
+func applyProjectRowV1(opts *ImportOptions, tenant *tenants.Tenant, tenantVariables *variables.TenantVariables, r *Row, environmentID string, environmentMap map[string]string) error {
+	for _, p := range tenantVariables.ProjectVariables {
+		if !strings.EqualFold(p.ProjectName, r.Project) && !strings.EqualFold(p.ProjectID, r.Project) {
+			continue
+		}
+		template, err := findTemplate(p.Templates, r.Project, r.Name)
+		if err != nil {
+			return err
+		}
+		environmentValues, ok := p.Variables[environmentID]
+		if !ok {
+			return fmt.Errorf("tenant '%s' is not connected to '%s' in %s", tenant.Name, r.Project, environmentMap[environmentID])
+		}
+		value, err := update.ConvertValue(opts.VariableCallbacks, template, r.Value)
+		if err != nil {
+			return err
+		}
+		environmentValues[template.GetID()] = *value
+		return nil
+	}
+	return fmt.Errorf("tenant '%s' has no variables from '%s'", tenant.Name, r.Project)
+}
+
+func applyCommonRowV1(opts *ImportOptions, tenant *tenants.Tenant, tenantVariables *variables.TenantVariables, r *Row) error {
+	for id, l := range tenantVariables.LibraryVariables {
+		if !strings.EqualFold(l.LibraryVariableSetName, r.LibraryVariableSet) && !strings.EqualFold(l.LibraryVariableSetID, r.LibraryVariableSet) {
+			continue
+		}
+		template, err := findTemplate(l.Templates, r.LibraryVariableSet, r.Name)
+		if err != nil {
+			return err
+		}
+		value, err := update.ConvertValue(opts.VariableCallbacks, template, r.Value)
+		if err != nil {
+			return err
+		}
+		if l.Variables == nil {
+			l.Variables = map[string]core.PropertyValue{}
+			tenantVariables.LibraryVariables[id] = l
+		}
+		l.Variables[template.GetID()] = *value
+		return nil
+	}
+	return fmt.Errorf("tenant '%s' has no variables from '%s'", tenant.Name, r.LibraryVariableSet)
+}
+
+func findTemplate(templates []*actiontemplates.ActionTemplateParameter, owner string, name string) (*actiontemplates.ActionTemplateParameter, error) {
+	for _, t := range templates {
+		if strings.EqualFold(t.Name, name) {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("'%s' has no tenant variable called '%s'", owner, name)
+}
+
+func fromProjectVariable(v variables.TenantProjectVariable) *tenantVariable {
+	return &tenantVariable{
+		ID:             v.GetID(),
+		OwnerID:        v.ProjectID,
+		OwnerName:      v.ProjectName,
+		TemplateID:     v.TemplateID,
+		Template:       v.Template,
+		Value:          v.Value,
+		EnvironmentIDs: v.Scope.EnvironmentIds,
+	}
+}
+
+func fromCommonVariable(v variables.TenantCommonVariable) *tenantVariable {
+	return &tenantVariable{
+		ID:             v.GetID(),
+		OwnerID:        v.LibraryVariableSetId,
+		OwnerName:      v.LibraryVariableSetName,
+		TemplateID:     v.TemplateID,
+		Template:       v.Template,
+		Value:          v.Value,
+		EnvironmentIDs: v.Scope.EnvironmentIds,
+	}
+}
+
+func resolveEnvironments(environmentNamesOrIDs []string, environmentMap map[string]string) ([]string, error) {
+	environmentIDs := []string{}
+	for _, e := range environmentNamesOrIDs {
+		found := ""
+		for id, name := range environmentMap {
+			if strings.EqualFold(id, e) || strings.EqualFold(name, e) {
+				found = id
+				break
+			}
+		}
+		if found == "" {
+			return nil, fmt.Errorf("cannot find environment '%s'", e)
+		}
+		if !util.SliceContains(environmentIDs, found) {
+			environmentIDs = append(environmentIDs, found)
+		}
+	}
+	return environmentIDs, nil
+}
+
+func environmentsNotConnected(tenant *tenants.Tenant, projectID string, environmentIDs []string) []string {
+	connected := tenant.ProjectEnvironments[projectID]
+	return util.SliceFilter(environmentIDs, func(id string) bool { return !util.SliceContains(connected, id) })
+}
+
+func sameEnvironments(a []string, b []string) bool {
+	return len(a) == len(b) && util.SliceContainsSlice(a, b)
+}
+
+func overlaps(a []string, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	return util.SliceContainsAny(a, func(id string) bool { return util.SliceContains(b, id) })
+}
+
+// cacheLookups stops the accounts, worker pools and certificates being loaded again for
+// every row that refers to one.
+func cacheLookups(callbacks *sharedVariable.VariableCallbacks) *sharedVariable.VariableCallbacks {
+	cached := *callbacks
+
+	var workerPools []*workerpools.WorkerPoolListResult
+	cached.GetAllWorkerPools = func() ([]*workerpools.WorkerPoolListResult, error) {
+		if workerPools == nil {
+			result, err := callbacks.GetAllWorkerPools()
+			if err != nil {
+				return nil, err
+			}
+			workerPools = result
+		}
+		return workerPools, nil
+	}
+
+	var allCertificates []*certificates.CertificateResource
+	cached.GetAllCertificates = func() ([]*certificates.CertificateResource, error) {
+		if allCertificates == nil {
+			result, err := callbacks.GetAllCertificates()
+			if err != nil {
+				return nil, err
+			}
+			allCertificates = result
+		}
+		return allCertificates, nil
+	}
+
+	accountsByType := map[accounts.AccountType][]accounts.IAccount{}
+	cached.GetAccountsByType = func(accountType accounts.AccountType) ([]accounts.IAccount, error) {
+		if result, ok := accountsByType[accountType]; ok {
+			return result, nil
+		}
+		result, err := callbacks.GetAccountsByType(accountType)
+		if err != nil {
+			return nil, err
+		}
+		accountsByType[accountType] = result
+		return result, nil
+	}
+
+	return &cached
+}
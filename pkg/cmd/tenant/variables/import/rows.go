@@ -0,0 +1,182 @@
+package importcmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/output"
+)
+
+const (
+	FormatCsv  = "csv"
+	FormatJson = "json"
+
+	// environments in a single CSV cell are separated by this
+	environmentSeparator = ";"
+)
+
+var Formats = []string{FormatCsv, FormatJson}
+
+// Row is a single tenant variable value to import. Each row sets the value of a project
+// variable (Project is set) or a common variable from a library variable set
+// (LibraryVariableSet is set) for one tenant.
+type Row struct {
+	Line               int      `json:"-"`
+	Tenant             string   `json:"Tenant"`
+	Project            string   `json:"Project,omitempty"`
+	LibraryVariableSet string   `json:"LibraryVariableSet,omitempty"`
+	Environments       []string `json:"Environments,omitempty"`
+	Name               string   `json:"Name"`
+	Value              string   `json:"Value"`
+}
+
+func (r *Row) validate() error {
+	if r.Tenant == "" {
+		return fmt.Errorf("row %d: a tenant is required", r.Line)
+	}
+	if r.Name == "" {
+		return fmt.Errorf("row %d: a variable name is required", r.Line)
+	}
+	if r.Project == "" && r.LibraryVariableSet == "" {
+		return fmt.Errorf("row %d: either a project or a library variable set is required", r.Line)
+	}
+	if r.Project != "" && r.LibraryVariableSet != "" {
+		return fmt.Errorf("row %d: a row cannot have both a project and a library variable set", r.Line)
+	}
+	return nil
+}
+
+// FormatFromFileName infers the format of a file from its extension, returning an empty
+// string when the extension is not recognised.
+func FormatFromFileName(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return FormatCsv
+	case ".json":
+		return FormatJson
+	}
+	return ""
+}
+
+// ParseRows reads the rows to import. CSV files need a header row naming the tenant,
+// project, library-variable-set, environment, name and value columns, in any order;
+// several environments can be given in one cell separated by semicolons. JSON files
+// hold an array of rows.
+func ParseRows(data []byte, format string) ([]*Row, error) {
+	var rows []*Row
+	var err error
+	switch strings.ToLower(format) {
+	case FormatCsv:
+		rows, err = parseCsv(data)
+	case FormatJson:
+		rows, err = parseJson(data)
+	default:
+		return nil, fmt.Errorf("unsupported format '%s'. Valid values are %s", format, output.FormatAsList(Formats))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	for _, r := range rows {
+		if err := r.validate(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return nil, invalidRowsError(problems)
+	}
+	return rows, nil
+}
+
+func parseJson(data []byte) ([]*Row, error) {
+	var rows []*Row
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+	for i, r := range rows {
+		r.Line = i + 1
+	}
+	return rows, nil
+}
+
+func parseCsv(data []byte) ([]*Row, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, h := range header {
+		columns[normalizeColumn(h)] = i
+	}
+	for _, required := range []string{"tenant", "name", "value"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("the header row must have a '%s' column", required)
+		}
+	}
+
+	var rows []*Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		// values are kept exactly as they are, as spaces around them can be meant
+		cell := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		name := func(column string) string {
+			return strings.TrimSpace(cell(column))
+		}
+
+		row := &Row{
+			Line:               line,
+			Tenant:             name("tenant"),
+			Project:            name("project"),
+			LibraryVariableSet: name("libraryvariableset"),
+			Name:               name("name"),
+			Value:              cell("value"),
+		}
+		for _, e := range strings.Split(cell("environment"), environmentSeparator) {
+			if e = strings.TrimSpace(e); e != "" {
+				row.Environments = append(row.Environments, e)
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// normalizeColumn lets headers such as "Library Variable Set", "library_variable_set"
+// and "environments" name the same column.
+func normalizeColumn(header string) string {
+	normalized := strings.ToLower(strings.TrimSpace(header))
+	for _, c := range []string{" ", "-", "_"} {
+		normalized = strings.ReplaceAll(normalized, c, "")
+	}
+	return strings.TrimSuffix(normalized, "s")
+}
+
+func invalidRowsError(problems []string) error {
+	if len(problems) == 1 {
+		return fmt.Errorf("1 row is not valid, so no variables were imported:\n%s", problems[0])
+	}
+	return fmt.Errorf("%d rows are not valid, so no variables were imported:\n%s", len(problems), strings.Join(problems, "\n"))
+}
@@ -1044,26 +1044,32 @@ func updateCommonVariableValueV1(opts *UpdateOptions, vars *variables.TenantVari
 }
 
 func convertValue(opts *UpdateOptions, t *actiontemplates.ActionTemplateParameter) (*core.PropertyValue, error) {
+	return ConvertValue(opts.VariableCallbacks, t, opts.Value.Value)
+}
+
+// ConvertValue turns the value given for a tenant variable into the value stored for it,
+// looking up accounts, worker pools, certificates and select options by name.
+func ConvertValue(callbacks *sharedVariable.VariableCallbacks, t *actiontemplates.ActionTemplateParameter, input string) (*core.PropertyValue, error) {
 	variableType := resources.ControlType(t.DisplaySettings["Octopus.ControlType"])
-	value := opts.Value.Value
+	value := input
 	var err error
 	switch variableType {
 	case resources.ControlTypeAwsAccount:
-		value, err = findAccount(opts, accounts.AccountTypeAmazonWebServicesAccount)
+		value, err = findAccount(callbacks, input, accounts.AccountTypeAmazonWebServicesAccount)
 	case resources.ControlTypeGoogleCloudAccount:
-		value, err = findAccount(opts, accounts.AccountTypeGoogleCloudPlatformAccount)
+		value, err = findAccount(callbacks, input, accounts.AccountTypeGoogleCloudPlatformAccount)
 	case resources.ControlTypeAzureAccount:
-		value, err = findAccount(opts, accounts.AccountTypeAzureServicePrincipal)
+		value, err = findAccount(callbacks, input, accounts.AccountTypeAzureServicePrincipal)
 	case resources.ControlTypeWorkerPool:
-		allWorkerPools, err := opts.GetAllWorkerPools()
+		allWorkerPools, err := callbacks.GetAllWorkerPools()
 		if err != nil {
 			return nil, err
 		}
 		matchedWorkerPools := util.SliceFilter(allWorkerPools, func(p *workerpools.WorkerPoolListResult) bool {
-			return strings.EqualFold(p.Name, opts.Value.Value) || strings.EqualFold(p.ID, opts.Value.Value) || strings.EqualFold(p.Slug, opts.Value.Value)
+			return strings.EqualFold(p.Name, input) || strings.EqualFold(p.ID, input) || strings.EqualFold(p.Slug, input)
 		})
 		if util.Empty(matchedWorkerPools) {
-			return nil, fmt.Errorf("cannot find worker pool '%s'", opts.Value.Value)
+			return nil, fmt.Errorf("cannot find worker pool '%s'", input)
 		}
 		if len(matchedWorkerPools) > 1 {
 			return nil, fmt.Errorf("matched multiple worker pools")
@@ -1071,15 +1077,15 @@ func convertValue(opts *UpdateOptions, t *actiontemplates.ActionTemplateParamete
 
 		value, err = matchedWorkerPools[0].ID, nil
 	case resources.ControlTypeCertificate:
-		allCertificates, err := opts.GetAllCertificates()
+		allCertificates, err := callbacks.GetAllCertificates()
 		if err != nil {
 			return nil, err
 		}
 		matchedCertificate := util.SliceFilter(allCertificates, func(p *certificates.CertificateResource) bool {
-			return !p.IsExpired && (strings.EqualFold(p.Name, opts.Value.Value) || strings.EqualFold(p.ID, opts.Value.Value))
+			return !p.IsExpired && (strings.EqualFold(p.Name, input) || strings.EqualFold(p.ID, input))
 		})
 		if util.Empty(matchedCertificate) {
-			return nil, fmt.Errorf("cannot find certifcate '%s'", opts.Value.Value)
+			return nil, fmt.Errorf("cannot find certifcate '%s'", input)
 		}
 		if len(matchedCertificate) > 1 {
 			return nil, fmt.Errorf("matched multiple certificates")
@@ -1089,14 +1095,14 @@ func convertValue(opts *UpdateOptions, t *actiontemplates.ActionTemplateParamete
 	case resources.ControlTypeSelect:
 		selectionOptions := sharedVariable.GetSelectOptions(t)
 		for _, o := range selectionOptions {
-			if strings.EqualFold(o.Display, opts.Value.Value) || strings.EqualFold(o.Value, opts.Value.Value) {
+			if strings.EqualFold(o.Display, input) || strings.EqualFold(o.Value, input) {
 				value, err = o.Value, nil
 				break
 			}
 		}
 
 		if value == "" {
-			err = fmt.Errorf("cannot match selection value  '%s'", opts.Value.Value)
+			err = fmt.Errorf("cannot match selection value  '%s'", input)
 		}
 	case resources.ControlTypeSensitive:
 		propertyValue := core.NewPropertyValue(value, true)
@@ -1111,18 +1117,18 @@ func convertValue(opts *UpdateOptions, t *actiontemplates.ActionTemplateParamete
 	return nil, fmt.Errorf("unable to convert value to correct variable type '%s'", variableType)
 }
 
-func findAccount(opts *UpdateOptions, accountType accounts.AccountType) (string, error) {
-	accounts, err := opts.GetAccountsByType(accountType)
+func findAccount(callbacks *sharedVariable.VariableCallbacks, input string, accountType accounts.AccountType) (string, error) {
+	accounts, err := callbacks.GetAccountsByType(accountType)
 	if err != nil {
 		return "", err
 	}
 	for _, a := range accounts {
-		if strings.EqualFold(a.GetName(), opts.Value.Value) || strings.EqualFold(a.GetID(), opts.Value.Value) || strings.EqualFold(a.GetSlug(), opts.Value.Value) {
+		if strings.EqualFold(a.GetName(), input) || strings.EqualFold(a.GetID(), input) || strings.EqualFold(a.GetSlug(), input) {
 			return a.GetID(), nil
 		}
 	}
 
-	return "", fmt.Errorf("cannot find %s account with called '%s'", accountType, opts.Value.Value)
+	return "", fmt.Errorf("cannot find %s account with called '%s'", accountType, input)
 }
 
 func getEnvironmentMap(getAllEnvironments selectors.GetAllEnvironmentsCallback) (map[string]string, error) {
@@ -2,6 +2,7 @@ package variables
 
 import (
 	"github.com/MakeNowJust/heredoc/v2"
+	cmdImport "github.com/OctopusDeploy/cli/pkg/cmd/tenant/variables/import"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/tenant/variables/list"
 	cmdUpdate "github.com/OctopusDeploy/cli/pkg/cmd/tenant/variables/update"
 	"github.com/OctopusDeploy/cli/pkg/constants"
@@ -19,6 +20,7 @@ func NewCmdVariables(f factory.Factory) *cobra.Command {
 		Example: heredoc.Docf(`
 			%[1]s tenant variables list "Bobs Wood Shop"
 			%[1]s tenant variables update --tenant "Bobs Fish Shack" --name "site-name" --value "Bob's Fish Shack" --project "Awesome Web Site" --environment "Test"
+			%[1]s tenant variables import --file tenants.csv
 		`, constants.ExecutableName),
 		Annotations: map[string]string{
 			annotations.IsCore: "true",
@@ -29,6 +31,7 @@ func NewCmdVariables(f factory.Factory) *cobra.Command {
 	//cmd.AddCommand(cmdCreate.NewCreateCmd(f))
 	cmd.AddCommand(cmdList.NewCmdList(f))
 	cmd.AddCommand(cmdUpdate.NewCmdUpdate(f))
+	cmd.AddCommand(cmdImport.NewCmdImport(f))
 	//cmd.AddCommand(cmdView.NewCmdView(f))
 	//cmd.AddCommand(cmdDelete.NewDeleteCmd(f))
 	//cmd.AddCommand(cmdInclude.NewIncludeVariableSetCmd(f))
@@ -0,0 +1,110 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/OctopusDeploy/cli/pkg/util"
+)
+
+const (
+	MatchedName      = "name"
+	MatchedValue     = "value"
+	MatchedReference = "reference"
+
+	// the number of characters of a value shown before it is masked
+	unmaskedLength = 4
+)
+
+// Matcher decides whether a variable matches the search, by its name or value or, when
+// searching references, by the variables its value refers to with #{...}.
+type Matcher struct {
+	pattern    *regexp.Regexp
+	references bool
+}
+
+// NewMatcher compiles pattern as a case insensitive regular expression.
+func NewMatcher(pattern string, references bool) (*Matcher, error) {
+	compiled, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a valid regular expression: %w", pattern, err)
+	}
+	return &Matcher{pattern: compiled, references: references}, nil
+}
+
+// Match returns what the variable matched on, and for references the names of the variables
+// referred to, or an empty string when it does not match. Sensitive values are never searched.
+func (m *Matcher) Match(name string, value string, isSensitive bool) (string, []string) {
+	if m.references {
+		if isSensitive {
+			return "", nil
+		}
+		matched := util.SliceFilter(FindReferences(value), func(reference string) bool { return m.pattern.MatchString(reference) })
+		if len(matched) > 0 {
+			return MatchedReference, matched
+		}
+		return "", nil
+	}
+
+	if m.pattern.MatchString(name) {
+		return MatchedName, nil
+	}
+	if !isSensitive && m.pattern.MatchString(value) {
+		return MatchedValue, nil
+	}
+	return "", nil
+}
+
+var substitution = regexp.MustCompile(`#\{([^}]*)\}`)
+
+// expression keywords that come before the variable an expression is about
+var expressionKeywords = []string{"if ", "unless ", "each "}
+
+// FindReferences returns the names of the variables a value refers to, such as Name in
+// #{Name}, #{Name | ToUpper}, #{if Name}...#{/if} and #{each item in Name}.
+func FindReferences(value string) []string {
+	var references []string
+	for _, match := range substitution.FindAllStringSubmatch(value, -1) {
+		expression := strings.TrimSpace(strings.SplitN(match[1], "|", 2)[0])
+		lower := strings.ToLower(expression)
+		for _, keyword := range expressionKeywords {
+			if strings.HasPrefix(lower, keyword) {
+				expression = strings.TrimSpace(expression[len(keyword):])
+				if keyword == "each " {
+					if i := strings.Index(strings.ToLower(expression), " in "); i >= 0 {
+						expression = strings.TrimSpace(expression[i+len(" in "):])
+					}
+				}
+				break
+			}
+		}
+		// comparisons such as #{if Name == "value"} refer to the left hand side
+		if fields := strings.Fields(expression); len(fields) > 0 {
+			expression = fields[0]
+		}
+		if expression == "" || strings.HasPrefix(expression, "/") || strings.EqualFold(expression, "else") {
+			continue
+		}
+		if !util.SliceContains(references, expression) {
+			references = append(references, expression)
+		}
+	}
+	return references
+}
+
+// MaskValue shows only the start of a value, so search results can be shared without
+// giving away whole values. Sensitive values are never shown.
+func MaskValue(value string, isSensitive bool) string {
+	if isSensitive {
+		return "***"
+	}
+	if value == "" {
+		return ""
+	}
+	if utf8.RuneCountInString(value) <= unmaskedLength {
+		return strings.Repeat("*", utf8.RuneCountInString(value))
+	}
+	return string([]rune(value)[:unmaskedLength]) + "****"
+}
@@ -0,0 +1,53 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/variables/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindReferences(t *testing.T) {
+	value := `Server=#{DatabaseHost};Port=#{ DatabasePort | ToLower }` +
+		`#{if UseSsl == "True"};Encrypt=#{Ssl.Mode}#{else};Encrypt=false#{/if}` +
+		`#{each host in ReplicaHosts}#{host}#{/each}#{DatabaseHost}`
+
+	assert.Equal(t, []string{"DatabaseHost", "DatabasePort", "UseSsl", "Ssl.Mode", "ReplicaHosts", "host"}, search.FindReferences(value))
+}
+
+func TestMatcher_NameOrValue(t *testing.T) {
+	matcher, err := search.NewMatcher(`sql-01\.example`, false)
+	require.Nil(t, err)
+
+	matchedOn, _ := matcher.Match("DatabaseHost", "SQL-01.example.com", false)
+	assert.Equal(t, search.MatchedValue, matchedOn)
+	matchedOn, _ = matcher.Match("sql-01.example.password", "", true)
+	assert.Equal(t, search.MatchedName, matchedOn)
+	matchedOn, _ = matcher.Match("Password", "sql-01.example.com", true)
+	assert.Equal(t, "", matchedOn)
+}
+
+func TestMatcher_References(t *testing.T) {
+	matcher, err := search.NewMatcher(`^DatabaseHost$`, true)
+	require.Nil(t, err)
+
+	matchedOn, references := matcher.Match("ConnectionString", "Server=#{DatabaseHost};Port=#{DatabasePort}", false)
+	assert.Equal(t, search.MatchedReference, matchedOn)
+	assert.Equal(t, []string{"DatabaseHost"}, references)
+
+	matchedOn, _ = matcher.Match("DatabaseHost", "sql-01", false)
+	assert.Equal(t, "", matchedOn)
+}
+
+func TestNewMatcher_InvalidPattern(t *testing.T) {
+	_, err := search.NewMatcher(`Database(`, false)
+	assert.ErrorContains(t, err, "'Database(' is not a valid regular expression")
+}
+
+func TestMaskValue(t *testing.T) {
+	assert.Equal(t, "sql-****", search.MaskValue("sql-01.example.com", false))
+	assert.Equal(t, "***", search.MaskValue("sql", false))
+	assert.Equal(t, "***", search.MaskValue("", true))
+	assert.Equal(t, "", search.MaskValue("", false))
+}
@@ -0,0 +1,335 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	sharedProjectVariable "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/tenant/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	sharedVariable "github.com/OctopusDeploy/cli/pkg/question/shared/variables"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/featuretoggle"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagReferences = "references"
+	FlagShowValues = "show-values"
+
+	LocationProject            = "project"
+	LocationLibraryVariableSet = "library variable set"
+	LocationTenant             = "tenant"
+)
+
+type SearchFlags struct {
+	References *flag.Flag[bool]
+	ShowValues *flag.Flag[bool]
+}
+
+func NewSearchFlags() *SearchFlags {
+	return &SearchFlags{
+		References: flag.New[bool](FlagReferences, false),
+		ShowValues: flag.New[bool](FlagShowValues, false),
+	}
+}
+
+type SearchOptions struct {
+	*SearchFlags
+	Pattern string
+	Command *cobra.Command
+	*cmd.Dependencies
+	*sharedVariable.VariableCallbacks
+	shared.GetAllProjectsCallback
+	shared.GetAllLibraryVariableSetsCallback
+	shared.GetAllTenantsCallback
+	selectors.GetAllEnvironmentsCallback
+	FeatureToggleCallback func(name string) (bool, error)
+}
+
+func NewSearchOptions(flags *SearchFlags, dependencies *cmd.Dependencies, cmd *cobra.Command) *SearchOptions {
+	return &SearchOptions{
+		SearchFlags:            flags,
+		Command:                cmd,
+		Dependencies:           dependencies,
+		VariableCallbacks:      sharedVariable.NewVariableCallbacks(dependencies),
+		GetAllProjectsCallback: func() ([]*projects.Project, error) { return shared.GetAllProjects(dependencies.Client) },
+		GetAllLibraryVariableSetsCallback: func() ([]*variables.LibraryVariableSet, error) {
+			return shared.GetAllLibraryVariableSets(dependencies.Client)
+		},
+		GetAllTenantsCallback:      func() ([]*tenants.Tenant, error) { return shared.GetAllTenants(dependencies.Client) },
+		GetAllEnvironmentsCallback: func() ([]*environments.Environment, error) { return selectors.GetAllEnvironments(dependencies.Client) },
+		FeatureToggleCallback: func(name string) (bool, error) {
+			return featuretoggle.IsToggleEnabled(dependencies.Client, name)
+		},
+	}
+}
+
+func NewCmdSearch(f factory.Factory) *cobra.Command {
+	searchFlags := NewSearchFlags()
+	cmd := &cobra.Command{
+		Use:   "search <pattern>",
+		Short: "Search the variables of a space",
+		Long: heredoc.Doc(`
+			Search the variables of every project, library variable set and tenant in a space for those
+			whose name or value matches a regular expression, ignoring case. Sensitive values are never
+			searched, and values are masked unless --show-values is given.
+
+			With --references, find the variables whose values refer to a variable matching the
+			pattern with #{...}, to see what would be affected by renaming or removing it.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s variables search DatabaseHost
+			%[1]s variables search "sql-01\.example\.com" --show-values
+			%[1]s variables search "^DatabaseHost$" --references
+		`, constants.ExecutableName),
+		Args: usage.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := NewSearchOptions(searchFlags, cmd.NewDependencies(f, c), c)
+			opts.Pattern = args[0]
+			return searchRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&searchFlags.References.Value, searchFlags.References.Name, false, "Find variables whose values refer to variables matching the pattern")
+	flags.BoolVar(&searchFlags.ShowValues.Value, searchFlags.ShowValues.Name, false, "Show values in full instead of masking them. Sensitive values are never shown")
+
+	return cmd
+}
+
+// Result is a variable that matched the search.
+type Result struct {
+	Location string `json:"Location"`
+	Owner    string `json:"Owner"`
+	// the project or library variable set a tenant value belongs to
+	TemplateOwner string   `json:"TemplateOwner,omitempty"`
+	Name          string   `json:"Name"`
+	Scope         string   `json:"Scope"`
+	Value         string   `json:"Value"`
+	IsSensitive   bool     `json:"IsSensitive"`
+	MatchedOn     string   `json:"MatchedOn"`
+	ReferencesTo  []string `json:"References,omitempty"`
+}
+
+func searchRun(opts *SearchOptions) error {
+	matcher, err := NewMatcher(opts.Pattern, opts.References.Value)
+	if err != nil {
+		return err
+	}
+
+	var results []*Result
+	add := func(result *Result) {
+		result.MatchedOn, result.ReferencesTo = matcher.Match(result.Name, result.Value, result.IsSensitive)
+		if result.MatchedOn == "" {
+			return
+		}
+		if !opts.ShowValues.Value || result.IsSensitive {
+			result.Value = MaskValue(result.Value, result.IsSensitive)
+		}
+		results = append(results, result)
+	}
+
+	allProjects, err := opts.GetAllProjectsCallback()
+	if err != nil {
+		return err
+	}
+	for _, project := range allProjects {
+		projectVariables, err := getProjectVariables(opts, project)
+		if err != nil {
+			return fmt.Errorf("cannot read the variables of project '%s': %w", project.GetName(), err)
+		}
+		for _, set := range projectVariables {
+			for _, v := range set.Variables {
+				add(&Result{Location: LocationProject, Owner: project.GetName(), Name: v.Name, Value: v.Value, IsSensitive: v.IsSensitive, Scope: describeScope(v.Scope, set.ScopeValues)})
+			}
+		}
+	}
+
+	libraryVariableSets, err := opts.GetAllLibraryVariableSetsCallback()
+	if err != nil {
+		return err
+	}
+	for _, libraryVariableSet := range libraryVariableSets {
+		set, err := opts.GetProjectVariables(libraryVariableSet.GetID())
+		if err != nil {
+			return fmt.Errorf("cannot read the variables of library variable set '%s': %w", libraryVariableSet.Name, err)
+		}
+		for _, v := range set.Variables {
+			add(&Result{Location: LocationLibraryVariableSet, Owner: libraryVariableSet.Name, Name: v.Name, Value: v.Value, IsSensitive: v.IsSensitive, Scope: describeScope(v.Scope, set.ScopeValues)})
+		}
+	}
+
+	if err := searchTenantVariables(opts, add); err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		_, err = fmt.Fprintf(opts.Out, "No variables match '%s'\n", opts.Pattern)
+		return err
+	}
+
+	return output.PrintArray(results, opts.Command, output.Mappers[*Result]{
+		Json: func(item *Result) any {
+			return item
+		},
+		Table: output.TableDefinition[*Result]{
+			Header: []string{"LOCATION", "NAME", "SCOPE", "VALUE", "MATCHED"},
+			Row: func(item *Result) []string {
+				matched := item.MatchedOn
+				if len(item.ReferencesTo) > 0 {
+					matched = fmt.Sprintf("%s to %s", item.MatchedOn, strings.Join(item.ReferencesTo, ", "))
+				}
+				return []string{item.describeLocation(), output.Bold(item.Name), item.Scope, item.Value, output.Dim(matched)}
+			},
+		},
+		Basic: func(item *Result) string {
+			return fmt.Sprintf("%s: %s", item.describeLocation(), item.Name)
+		},
+	})
+}
+
+// getProjectVariables returns the variables of a project. Version controlled projects keep
+// their sensitive variables in the database and the rest on the default branch.
+func getProjectVariables(opts *SearchOptions, project *projects.Project) ([]*variables.VariableSet, error) {
+	projectVariables, err := sharedProjectVariable.GetProjectVariables(opts.VariableCallbacks, opts.Space.GetID(), project, "")
+	if err != nil {
+		return nil, err
+	}
+	if projectVariables.Git == nil {
+		return []*variables.VariableSet{projectVariables.Database}, nil
+	}
+	return []*variables.VariableSet{projectVariables.Database, projectVariables.Git}, nil
+}
+
+func (r *Result) describeLocation() string {
+	if r.TemplateOwner != "" {
+		return fmt.Sprintf("%s '%s' for '%s'", r.Location, r.Owner, r.TemplateOwner)
+	}
+	return fmt.Sprintf("%s '%s'", r.Location, r.Owner)
+}
+
+type addResultFunc func(result *Result)
+
+// searchTenantVariables searches the values tenants give to project and common variable templates.
+func searchTenantVariables(opts *SearchOptions, add addResultFunc) error {
+	allTenants, err := opts.GetAllTenantsCallback()
+	if err != nil {
+		return err
+	}
+	if len(allTenants) == 0 {
+		return nil
+	}
+
+	allEnvironments, err := opts.GetAllEnvironmentsCallback()
+	if err != nil {
+		return err
+	}
+	environmentNames := map[string]string{}
+	for _, e := range allEnvironments {
+		environmentNames[e.GetID()] = e.GetName()
+	}
+	environmentScope := func(environmentIDs []string) string {
+		if len(environmentIDs) == 0 {
+			return ""
+		}
+		return "Environments: " + strings.Join(util.SliceTransform(environmentIDs, func(id string) string { return nameOrID(id, environmentNames) }), ", ")
+	}
+
+	toggleValue, _ := opts.FeatureToggleCallback("CommonVariableScopingFeatureToggle")
+	for _, tenant := range allTenants {
+		if !toggleValue {
+			tenantVariables, err := opts.GetTenantVariables(tenant)
+			if err != nil {
+				return fmt.Errorf("cannot read the variables of tenant '%s': %w", tenant.Name, err)
+			}
+			for _, p := range tenantVariables.ProjectVariables {
+				for environmentID, values := range p.Variables {
+					for _, t := range p.Templates {
+						if value, ok := values[t.GetID()]; ok {
+							addTenantValue(add, tenant, p.ProjectName, t.Name, value, environmentScope([]string{environmentID}))
+						}
+					}
+				}
+			}
+			for _, l := range tenantVariables.LibraryVariables {
+				for _, t := range l.Templates {
+					if value, ok := l.Variables[t.GetID()]; ok {
+						addTenantValue(add, tenant, l.LibraryVariableSetName, t.Name, value, "")
+					}
+				}
+			}
+			continue
+		}
+
+		projectVariables, err := opts.GetTenantProjectVariables(tenant, false)
+		if err != nil {
+			return fmt.Errorf("cannot read the variables of tenant '%s': %w", tenant.Name, err)
+		}
+		for _, v := range projectVariables.Variables {
+			addTenantValue(add, tenant, v.ProjectName, v.Template.Name, v.Value, environmentScope(v.Scope.EnvironmentIds))
+		}
+		commonVariables, err := opts.GetTenantCommonVariables(tenant, false)
+		if err != nil {
+			return fmt.Errorf("cannot read the variables of tenant '%s': %w", tenant.Name, err)
+		}
+		for _, v := range commonVariables.Variables {
+			addTenantValue(add, tenant, v.LibraryVariableSetName, v.Template.Name, v.Value, environmentScope(v.Scope.EnvironmentIds))
+		}
+	}
+	return nil
+}
+
+func addTenantValue(add addResultFunc, tenant *tenants.Tenant, templateOwner string, name string, value core.PropertyValue, scope string) {
+	add(&Result{Location: LocationTenant, Owner: tenant.Name, TemplateOwner: templateOwner, Name: name, Value: value.Value, IsSensitive: value.IsSensitive, Scope: scope})
+}
+
+func describeScope(scope variables.VariableScope, scopeValues *variables.VariableScopeValues) string {
+	if scopeValues == nil {
+		scopeValues = &variables.VariableScopeValues{}
+	}
+	names := map[string]string{}
+	for _, items := range [][]*resources.ReferenceDataItem{scopeValues.Environments, scopeValues.Channels, scopeValues.Machines, scopeValues.Actions} {
+		for _, item := range items {
+			names[item.ID] = item.Name
+		}
+	}
+	for _, item := range scopeValues.Processes {
+		names[item.ID] = item.Name
+	}
+
+	var parts []string
+	add := func(label string, ids []string) {
+		if len(ids) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %s", label, strings.Join(util.SliceTransform(ids, func(id string) string { return nameOrID(id, names) }), ", ")))
+		}
+	}
+	add("Environments", scope.Environments)
+	add("Channels", scope.Channels)
+	add("Targets", scope.Machines)
+	add("Steps", scope.Actions)
+	add("Roles", scope.Roles)
+	add("Tags", scope.TenantTags)
+	add("Processes", scope.ProcessOwners)
+	return strings.Join(parts, "; ")
+}
+
+func nameOrID(id string, names map[string]string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return id
+}
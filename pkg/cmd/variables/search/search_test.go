@@ -0,0 +1,99 @@
+package search_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/constants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = newRootResource()
+
+func newRootResource() *octopusApiClient.RootResource {
+	root := testutil.NewRootResource()
+	root.Links[constants.LinkVariables] = "/api/Spaces-1/variables{/id}{?ids}"
+	root.Links[constants.LinkLibraryVariables] = "/api/Spaces-1/libraryvariablesets{/id}{?skip,contentType,take,ids,partialName}"
+	return root
+}
+
+func newVariableSet(ownerID string, vars ...*variables.Variable) *variables.VariableSet {
+	variableSet := variables.NewVariableSet()
+	variableSet.ID = "variableset-" + ownerID
+	variableSet.OwnerID = ownerID
+	variableSet.Variables = vars
+	variableSet.ScopeValues = &variables.VariableScopeValues{}
+	return variableSet
+}
+
+func newVariable(name string, value string) *variables.Variable {
+	v := variables.NewVariable(name)
+	v.Value = value
+	return v
+}
+
+func TestSearch(t *testing.T) {
+	const spaceID = "Spaces-1"
+
+	space1 := fixtures.NewSpace(spaceID, "Default Space")
+
+	project := fixtures.NewProject(spaceID, "Projects-22", "Deploy Web App", "Lifecycles-1", "ProjectGroups-1", "")
+
+	libraryVariableSet := variables.NewLibraryVariableSet("Common")
+	libraryVariableSet.ID = "LibraryVariableSets-1"
+	libraryVariableSet.VariableSetID = "variableset-LibraryVariableSets-1"
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer)
+	}{
+		{"search includes the variables of library variable sets", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"variables", "search", "DatabaseHost", "--no-prompt", "-f", "basic"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/all").RespondWith([]*projects.Project{project})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/variables/variableset-Projects-22").RespondWith(newVariableSet(project.GetID(), newVariable("ConnectionString", "Server=#{DatabaseHost}")))
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/libraryvariablesets/all").RespondWith([]*variables.LibraryVariableSet{libraryVariableSet})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/variables/variableset-LibraryVariableSets-1").RespondWith(newVariableSet(libraryVariableSet.ID, newVariable("DatabaseHost", "sql-01")))
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/tenants/all").RespondWith([]*tenants.Tenant{})
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+
+			assert.Equal(t, heredoc.Doc(`
+				project 'Deploy Web App': ConnectionString
+				library variable set 'Common': DatabaseHost
+				`), stdOut.String())
+			assert.Equal(t, "", stdErr.String())
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			fac := testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider)
+			rootCmd := cmdRoot.NewCmdRoot(fac, nil, askProvider)
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+			test.run(t, api, rootCmd, stdout, stderr)
+		})
+	}
+}
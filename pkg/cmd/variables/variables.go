@@ -0,0 +1,30 @@
+package variables
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	cmdSearch "github.com/OctopusDeploy/cli/pkg/cmd/variables/search"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdVariables(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "variables <command>",
+		Aliases: []string{"variable"},
+		Short:   "Work with variables across a space",
+		Long:    "Work with the variables of all projects, library variable sets and tenants in a space in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s variables search DatabaseHost
+			%[1]s variables search "sql-01\.example\.com"
+			%[1]s variables search DatabaseHost --references
+		`, constants.ExecutableName),
+		Annotations: map[string]string{
+			annotations.IsCore: "true",
+		},
+	}
+
+	cmd.AddCommand(cmdSearch.NewCmdSearch(f))
+	return cmd
+}
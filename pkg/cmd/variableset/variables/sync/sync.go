@@ -0,0 +1,202 @@
+package sync
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	sharedProjectVariable "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/tenant/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	sharedVariable "github.com/OctopusDeploy/cli/pkg/question/shared/variables"
+	"github.com/OctopusDeploy/cli/pkg/secrets"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagVariableSet = "variable-set"
+	FlagFrom        = "from"
+	FlagEnvironment = "environment"
+	FlagDryRun      = "dry-run"
+	FlagStateFile   = "state-file"
+)
+
+type SyncFlags struct {
+	VariableSet  *flag.Flag[string]
+	From         *flag.Flag[string]
+	Environments *flag.Flag[[]string]
+	DryRun       *flag.Flag[bool]
+	StateFile    *flag.Flag[string]
+}
+
+func NewSyncFlags() *SyncFlags {
+	return &SyncFlags{
+		VariableSet:  flag.New[string](FlagVariableSet, false),
+		From:         flag.New[string](FlagFrom, false),
+		Environments: flag.New[[]string](FlagEnvironment, false),
+		DryRun:       flag.New[bool](FlagDryRun, false),
+		StateFile:    flag.New[string](FlagStateFile, false),
+	}
+}
+
+type UpdateVariablesCallback func(ownerID string, variableSet *variables.VariableSet) error
+
+type SyncOptions struct {
+	*SyncFlags
+	*cmd.Dependencies
+	*sharedVariable.VariableCallbacks
+	shared.GetAllLibraryVariableSetsCallback
+	NewSourceCallback func(from string) (secrets.Source, error)
+	UpdateVariablesCallback
+}
+
+func NewSyncOptions(flags *SyncFlags, dependencies *cmd.Dependencies) *SyncOptions {
+	return &SyncOptions{
+		SyncFlags:         flags,
+		Dependencies:      dependencies,
+		VariableCallbacks: sharedVariable.NewVariableCallbacks(dependencies),
+		GetAllLibraryVariableSetsCallback: func() ([]*variables.LibraryVariableSet, error) {
+			return shared.GetAllLibraryVariableSets(dependencies.Client)
+		},
+		NewSourceCallback: secrets.NewSource,
+		UpdateVariablesCallback: func(ownerID string, variableSet *variables.VariableSet) error {
+			_, err := dependencies.Client.Variables.Update(ownerID, *variableSet)
+			return err
+		},
+	}
+}
+
+func NewCmdSync(f factory.Factory) *cobra.Command {
+	syncFlags := NewSyncFlags()
+	cmd := &cobra.Command{
+		Use:   "sync [<variable-set>]",
+		Short: "Sync sensitive variables of a library variable set from a secret store",
+		Long: heredoc.Docf(`
+			Set the values of sensitive variables in a library variable set from the secrets in a file or
+			secret store, adding a variable for any secret that does not have one. The values are never
+			shown.
+
+			The source is given as <kind>:<location>, where kind is one of:
+			  dotenv  a file of KEY=value lines, such as .env
+			  sops    a YAML file encrypted with SOPS, decrypted with the sops executable
+			  vault   a secret in the HashiCorp Vault key/value engine, such as vault:secret/my-app,
+			          using the %[1]s and %[2]s environment variables
+
+			Nested keys in YAML and Vault secrets become variable names joined with dots.
+
+			Octopus never returns sensitive values, so every value is saved unless --%[3]s is given.
+			The state file keeps a keyed fingerprint of each value synced, on this machine only, to
+			tell which values have changed since. Keep it as private as the secrets themselves.
+		`, secrets.EnvVaultAddress, secrets.EnvVaultToken, FlagStateFile),
+		Example: heredoc.Docf(`
+			%[1]s variable-set variables sync "Shared Secrets" --from dotenv:.env
+			%[1]s variable-set variables sync --variable-set "Shared Secrets" --from vault:secret/shared --environment Production
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if syncFlags.VariableSet.Value == "" && len(args) > 0 {
+				syncFlags.VariableSet.Value = args[0]
+			}
+
+			opts := NewSyncOptions(syncFlags, cmd.NewDependencies(f, c))
+			return syncRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&syncFlags.VariableSet.Value, syncFlags.VariableSet.Name, "", "Name or ID of the library variable set")
+	flags.StringVar(&syncFlags.From.Value, syncFlags.From.Name, "", fmt.Sprintf("The source of the secrets, as <kind>:<location> where kind is one of %s", strings.Join(secrets.Schemes(), ", ")))
+	flags.StringSliceVarP(&syncFlags.Environments.Value, syncFlags.Environments.Name, "e", nil, "Sync the variables scoped to exactly these environments. Multiple environments can be supplied")
+	flags.BoolVar(&syncFlags.DryRun.Value, syncFlags.DryRun.Name, false, "Show what would change without saving it")
+	flags.StringVar(&syncFlags.StateFile.Value, syncFlags.StateFile.Name, "", "A `file` on this machine recording what was last synced, so that only changed values are saved")
+
+	return cmd
+}
+
+func syncRun(opts *SyncOptions) error {
+	if opts.From.Value == "" {
+		return fmt.Errorf("must supply the source of the secrets with --%s", FlagFrom)
+	}
+	source, err := opts.NewSourceCallback(opts.From.Value)
+	if err != nil {
+		return err
+	}
+
+	libraryVariableSet, err := resolveVariableSet(opts)
+	if err != nil {
+		return err
+	}
+
+	values, err := source.Load()
+	if err != nil {
+		return err
+	}
+
+	variableSet, err := opts.GetProjectVariables(libraryVariableSet.GetID())
+	if err != nil {
+		return err
+	}
+
+	var state *sharedProjectVariable.SyncState
+	if opts.StateFile.Value != "" {
+		if state, err = sharedProjectVariable.ReadSyncState(opts.StateFile.Value); err != nil {
+			return err
+		}
+	}
+
+	plan, err := sharedProjectVariable.PlanSync(variableSet, values, opts.Environments.Value, state)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(opts.Out, plan.Format(source.Description()))
+
+	if !plan.HasChanges() {
+		return nil
+	}
+	if opts.DryRun.Value {
+		_, err = fmt.Fprintln(opts.Out, output.Dim("Dry run: no changes were saved"))
+		return err
+	}
+
+	if err := opts.UpdateVariablesCallback(libraryVariableSet.GetID(), plan.VariableSet); err != nil {
+		return err
+	}
+	if state != nil {
+		if err := state.Write(opts.StateFile.Value); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(opts.Out, "Successfully synced the variables of '%s'\n", libraryVariableSet.Name)
+	return err
+}
+
+func resolveVariableSet(opts *SyncOptions) (*variables.LibraryVariableSet, error) {
+	if opts.VariableSet.Value == "" {
+		if opts.NoPrompt {
+			return nil, fmt.Errorf("must supply the library variable set with --%s", FlagVariableSet)
+		}
+		return selectors.Select(opts.Ask, "Select the library variable set whose variables you wish to sync",
+			opts.GetAllLibraryVariableSetsCallback,
+			func(item *variables.LibraryVariableSet) string { return item.Name })
+	}
+
+	libraryVariableSets, err := opts.GetAllLibraryVariableSetsCallback()
+	if err != nil {
+		return nil, err
+	}
+	matching := util.SliceFilter(libraryVariableSets, func(item *variables.LibraryVariableSet) bool {
+		return strings.EqualFold(item.Name, opts.VariableSet.Value) || strings.EqualFold(item.GetID(), opts.VariableSet.Value)
+	})
+	if len(matching) == 0 {
+		return nil, fmt.Errorf("cannot find library variable set '%s'", opts.VariableSet.Value)
+	}
+	return matching[0], nil
+}
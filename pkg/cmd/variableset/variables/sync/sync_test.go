@@ -0,0 +1,132 @@
+package sync_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sharedProjectVariable "github.com/OctopusDeploy/cli/pkg/cmd/project/variables/shared"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/constants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rootResource = newRootResource()
+
+func newRootResource() *octopusApiClient.RootResource {
+	root := testutil.NewRootResource()
+	root.Links[constants.LinkVariables] = "/api/Spaces-1/variables{/id}{?ids}"
+	root.Links[constants.LinkLibraryVariables] = "/api/Spaces-1/libraryvariablesets{/id}{?skip,contentType,take,ids,partialName}"
+	return root
+}
+
+func TestSync(t *testing.T) {
+	const spaceID = "Spaces-1"
+
+	space1 := fixtures.NewSpace(spaceID, "Default Space")
+
+	libraryVariableSet := variables.NewLibraryVariableSet("Shared Secrets")
+	libraryVariableSet.ID = "LibraryVariableSets-1"
+	libraryVariableSet.VariableSetID = "variableset-LibraryVariableSets-1"
+
+	newVariableSet := func() *variables.VariableSet {
+		variableSet := variables.NewVariableSet()
+		variableSet.ID = libraryVariableSet.VariableSetID
+		variableSet.OwnerID = libraryVariableSet.ID
+		variableSet.ScopeValues = &variables.VariableScopeValues{}
+		return variableSet
+	}
+
+	writeFile := func(t *testing.T, name string, contents string) string {
+		path := filepath.Join(t.TempDir(), name)
+		require.Nil(t, os.WriteFile(path, []byte(contents), 0644))
+		return path
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer)
+	}{
+		{"sync saves the variables of the library variable set", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			file := writeFile(t, ".env", "ApiKey=abc\n")
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"variable-set", "variables", "sync", "Shared Secrets", "--from", "dotenv:" + file, "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/libraryvariablesets/all").RespondWith([]*variables.LibraryVariableSet{libraryVariableSet})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/variables/variableset-LibraryVariableSets-1").RespondWith(newVariableSet())
+
+			req := api.ExpectRequest(t, "PUT", "/api/Spaces-1/variables/variableset-LibraryVariableSets-1")
+			body, err := testutil.ReadJson[variables.VariableSet](req.Request.Body)
+			require.Nil(t, err)
+			require.Len(t, body.Variables, 1)
+			assert.Equal(t, "ApiKey", body.Variables[0].Name)
+			assert.Equal(t, "abc", body.Variables[0].Value)
+			assert.True(t, body.Variables[0].IsSensitive)
+			req.RespondWith(body)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/variables/variableset-LibraryVariableSets-1").RespondWith(body)
+
+			_, err = testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+			assert.NotContains(t, stdOut.String(), "abc")
+			assert.Contains(t, stdOut.String(), "Successfully synced the variables of 'Shared Secrets'")
+		}},
+
+		{"sync leaves unchanged values alone with a state file", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			file := writeFile(t, ".env", "ApiKey=abc\n")
+			variableSet := newVariableSet()
+			apiKey := variables.NewVariable("ApiKey")
+			apiKey.IsSensitive = true
+			variableSet.Variables = []*variables.Variable{apiKey}
+
+			// the state left by the last sync of the same value
+			stateFile := filepath.Join(t.TempDir(), "sync-state.json")
+			state, err := sharedProjectVariable.ReadSyncState(stateFile)
+			require.Nil(t, err)
+			_, err = sharedProjectVariable.PlanSync(newVariableSet(), map[string]string{"ApiKey": "abc"}, nil, state)
+			require.Nil(t, err)
+			require.Nil(t, state.Write(stateFile))
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"variable-set", "variables", "sync", "--variable-set", "LibraryVariableSets-1", "--from", "dotenv:" + file, "--state-file", stateFile, "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api/").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/libraryvariablesets/all").RespondWith([]*variables.LibraryVariableSet{libraryVariableSet})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/variables/variableset-LibraryVariableSets-1").RespondWith(variableSet)
+
+			_, err = testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+			assert.Contains(t, stdOut.String(), "  ApiKey = ********: unchanged")
+			assert.NotContains(t, stdOut.String(), "Successfully synced")
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			fac := testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider)
+			rootCmd := cmdRoot.NewCmdRoot(fac, nil, askProvider)
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+			test.run(t, api, rootCmd, stdout, stderr)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package variables
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	cmdSync "github.com/OctopusDeploy/cli/pkg/cmd/variableset/variables/sync"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdVariables(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "variables <command>",
+		Aliases: []string{"variable"},
+		Short:   "Manage the variables of library variable sets",
+		Long:    "Manage the variables of library variable sets in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s variable-set variables sync "Shared Secrets" --from dotenv:.env
+		`, constants.ExecutableName),
+		Annotations: map[string]string{
+			annotations.IsCore: "true",
+		},
+	}
+
+	cmd.AddCommand(cmdSync.NewCmdSync(f))
+	return cmd
+}
@@ -0,0 +1,28 @@
+package variableset
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	cmdVariables "github.com/OctopusDeploy/cli/pkg/cmd/variableset/variables"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdVariableSet(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "variable-set <command>",
+		Aliases: []string{"library-variable-set"},
+		Short:   "Manage library variable sets",
+		Long:    "Manage library variable sets in Octopus Deploy",
+		Example: heredoc.Docf(`
+			%[1]s variable-set variables sync "Shared Secrets" --from vault:secret/shared
+		`, constants.ExecutableName),
+		Annotations: map[string]string{
+			annotations.IsCore: "true",
+		},
+	}
+
+	cmd.AddCommand(cmdVariables.NewCmdVariables(f))
+	return cmd
+}
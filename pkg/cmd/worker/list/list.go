@@ -10,10 +10,26 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/machinescommon"
 	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
 	"github.com/spf13/cobra"
 )
 
+const FlagWorkerPool = "worker-pool"
+
+type ListFlags struct {
+	WorkerPools *flag.Flag[[]string]
+	*machinescommon.MachineFilterFlags
+}
+
+func NewListFlags() *ListFlags {
+	return &ListFlags{
+		WorkerPools:        flag.New[[]string](FlagWorkerPool, false),
+		MachineFilterFlags: machinescommon.NewMachineFilterFlags(),
+	}
+}
+
 type ListOptions struct {
 	*cobra.Command
 	*cmd.Dependencies
@@ -29,20 +45,81 @@ func NewListOptions(dependencies *cmd.Dependencies, command *cobra.Command, filt
 }
 
 func NewCmdList(f factory.Factory) *cobra.Command {
+	listFlags := NewListFlags()
 	cmd := &cobra.Command{
-		Use:     "list",
-		Short:   "List workers",
-		Long:    "List workers in Octopus Deploy",
+		Use:   "list",
+		Short: "List workers",
+		Long: heredoc.Doc(`
+			List workers in Octopus Deploy.
+
+			The filters are applied by Octopus, so only the matching workers are fetched. Each filter
+			can be given more than once to match any of the values given.
+		`),
 		Aliases: []string{"ls"},
-		Example: heredoc.Docf("%s worker list", constants.ExecutableName),
+		Example: heredoc.Docf(`
+			%[1]s worker list
+			%[1]s worker list --worker-pool "Linux Workers" --health-status Unhealthy
+			%[1]s worker list --communication-style Ssh --disabled
+		`, constants.ExecutableName),
 		RunE: func(c *cobra.Command, args []string) error {
-			return ListRun(NewListOptions(cmd.NewDependencies(f, c), c, nil))
+			dependencies := cmd.NewDependencies(f, c)
+			listFlags.DisabledSet = c.Flags().Changed(machinescommon.FlagDisabled)
+			query, err := BuildQuery(listFlags, func() (map[string]string, error) {
+				return getWorkerPoolMap(dependencies.Client)
+			})
+			if err != nil {
+				return err
+			}
+
+			var filter func(*machines.Worker) bool
+			if listFlags.DisabledSet {
+				filter = func(w *machines.Worker) bool { return listFlags.IncludeDisabledState(w.IsDisabled) }
+			}
+			return ListRun(&ListOptions{
+				Command:           c,
+				Dependencies:      dependencies,
+				GetWorkersOptions: shared.NewGetWorkersOptionsForQuery(dependencies, query, filter),
+			})
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.StringArrayVarP(&listFlags.WorkerPools.Value, listFlags.WorkerPools.Name, "p", nil, "Only list workers in this worker pool")
+	machinescommon.RegisterMachineFilterFlags(cmd, listFlags.MachineFilterFlags, "worker")
+
 	return cmd
 }
 
+// BuildQuery turns the filters given as flags into a query. The worker pools are looked up only
+// when they are filtered on.
+func BuildQuery(flags *ListFlags, getWorkerPoolMap func() (map[string]string, error)) (machines.WorkersQuery, error) {
+	query := machines.WorkersQuery{}
+	if len(flags.WorkerPools.Value) > 0 {
+		workerPoolMap, err := getWorkerPoolMap()
+		if err != nil {
+			return query, err
+		}
+		if query.WorkerPoolIDs, err = machinescommon.ResolveIDs(flags.WorkerPools.Value, workerPoolMap, "worker pool"); err != nil {
+			return query, err
+		}
+	}
+
+	healthStatuses, err := machinescommon.ParseHealthStatuses(flags.HealthStatuses.Value)
+	if err != nil {
+		return query, err
+	}
+	communicationStyles, err := machinescommon.ParseCommunicationStyles(flags.CommunicationStyles.Value)
+	if err != nil {
+		return query, err
+	}
+
+	query.HealthStatuses = healthStatuses
+	query.CommunicationStyles = communicationStyles
+	query.IsDisabled = flags.DisabledSet && flags.Disabled.Value
+	query.PartialName = flags.PartialName.Value
+	return query, nil
+}
+
 func ListRun(opts *ListOptions) error {
 	allTargets, err := opts.GetWorkersCallback()
 	if err != nil {
@@ -188,8 +265,12 @@ func getProxy(opts *ListOptions, end machines.IEndpoint) string {
 }
 
 func GetWorkerPoolMap(opts *ListOptions) (map[string]string, error) {
+	return getWorkerPoolMap(opts.Client)
+}
+
+func getWorkerPoolMap(octopus *client.Client) (map[string]string, error) {
 	workerPoolMap := make(map[string]string)
-	allEnvs, err := opts.Client.WorkerPools.GetAll()
+	allEnvs, err := octopus.WorkerPools.GetAll()
 	if err != nil {
 		return nil, err
 	}
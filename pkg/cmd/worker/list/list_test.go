@@ -47,3 +47,21 @@ func TestGetEndpointUriAndVersion_EndpointMissing(t *testing.T) {
 		assert.Equal(t, "", getRuntimeArchitecture(nil))
 	})
 }
+
+func TestBuildQuery(t *testing.T) {
+	flags := NewListFlags()
+	flags.WorkerPools.Value = []string{"Linux Workers"}
+	flags.HealthStatuses.Value = []string{"Unavailable"}
+	flags.DisabledSet = true
+	flags.Disabled.Value = false
+
+	query, err := BuildQuery(flags, func() (map[string]string, error) {
+		return map[string]string{"WorkerPools-1": "Default Worker Pool", "WorkerPools-2": "Linux Workers"}, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"WorkerPools-2"}, query.WorkerPoolIDs)
+	assert.Equal(t, []string{"Unavailable"}, query.HealthStatuses)
+	// the server cannot filter for enabled workers, so they are filtered afterwards
+	assert.False(t, query.IsDisabled)
+}
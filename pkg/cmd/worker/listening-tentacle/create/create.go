@@ -31,11 +31,13 @@ type CreateFlags struct {
 	*machinescommon.CreateTargetMachinePolicyFlags
 	*shared.WorkerPoolFlags
 	*machinescommon.WebFlags
+	*machinescommon.TentacleCheckFlags
 }
 
 type CreateOptions struct {
 	*CreateFlags
 	*machinescommon.CreateTargetProxyOptions
+	*machinescommon.TentacleCheckOptions
 	*machinescommon.CreateTargetMachinePolicyOptions
 	*shared.WorkerPoolOptions
 	*cmd.Dependencies
@@ -50,6 +52,7 @@ func NewCreateFlags() *CreateFlags {
 		CreateTargetMachinePolicyFlags: machinescommon.NewCreateTargetMachinePolicyFlags(),
 		WorkerPoolFlags:                shared.NewWorkerPoolFlags(),
 		WebFlags:                       machinescommon.NewWebFlags(),
+		TentacleCheckFlags:             machinescommon.NewTentacleCheckFlags(),
 	}
 }
 
@@ -58,6 +61,7 @@ func NewCreateOptions(createFlags *CreateFlags, dependencies *cmd.Dependencies)
 		CreateFlags:                      createFlags,
 		Dependencies:                     dependencies,
 		CreateTargetProxyOptions:         machinescommon.NewCreateTargetProxyOptions(dependencies),
+		TentacleCheckOptions:             machinescommon.NewTentacleCheckOptions(dependencies),
 		CreateTargetMachinePolicyOptions: machinescommon.NewCreateTargetMachinePolicyOptions(dependencies),
 		WorkerPoolOptions:                shared.NewWorkerPoolOptions(dependencies),
 	}
@@ -69,9 +73,21 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a listening tentacle worker",
-		Long:  "Create a listening tentacle worker in Octopus Deploy",
+		Long: heredoc.Docf(`
+			Create a listening tentacle worker in Octopus Deploy.
+
+			With --%[1]s the certificate thumbprint is read from the Tentacle
+			instead of being given with --%[2]s, and must be confirmed when prompting, or
+			trusted with --%[4]s when not.
+
+			With --%[3]s the Tentacle is resolved, connected to and asked for its certificate
+			before the worker is saved, so that DNS, network and TLS problems are reported
+			straight away. Both connect from this machine, so cannot be used with a proxy.
+		`, machinescommon.FlagDiscoverThumbprint, FlagThumbprint, machinescommon.FlagPreflight, machinescommon.FlagTrustDiscoveredThumbprint),
 		Example: heredoc.Docf(`
-			%s worker listening-tentacle create
+			%[1]s worker listening-tentacle create
+			%[1]s worker listening-tentacle create --name "worker-01" --url https://worker-01:10933 --discover-thumbprint --worker-pool "Default Worker Pool"
+			%[1]s worker listening-tentacle create --name "worker-02" --url https://worker-02:10933 --thumbprint 1A2B3C4D5E6F708192A3B4C5D6E7F8091A2B3C4D --preflight --worker-pool "Default Worker Pool" --no-prompt
 		`, constants.ExecutableName),
 		RunE: func(c *cobra.Command, _ []string) error {
 			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c))
@@ -87,6 +103,7 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 	machinescommon.RegisterCreateTargetProxyFlags(cmd, createFlags.CreateTargetProxyFlags, "Listening Tentacle")
 	machinescommon.RegisterCreateTargetMachinePolicyFlags(cmd, createFlags.CreateTargetMachinePolicyFlags)
 	shared.RegisterCreateWorkerWorkerPoolFlags(cmd, createFlags.WorkerPoolFlags)
+	machinescommon.RegisterTentacleCheckFlags(cmd, createFlags.TentacleCheckFlags, "Tentacle")
 	machinescommon.RegisterWebFlag(cmd, createFlags.WebFlags)
 
 	return cmd
@@ -99,6 +116,11 @@ func createRun(opts *CreateOptions) error {
 		}
 	}
 
+	err := machinescommon.CheckTentacle(opts.TentacleCheckOptions, opts.TentacleCheckFlags, opts.URL.Value, opts.Thumbprint, opts.Proxy.Value)
+	if err != nil {
+		return err
+	}
+
 	url, err := url.Parse(opts.URL.Value)
 	if err != nil {
 		return err
@@ -133,7 +155,7 @@ func createRun(opts *CreateOptions) error {
 
 	fmt.Fprintf(opts.Out, "Successfully created Listening Tentacle worker '%s'.\n", worker.Name)
 	if !opts.NoPrompt {
-		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Name, opts.URL, opts.Thumbprint, opts.Proxy, opts.MachinePolicy, opts.WorkerPools)
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Name, opts.URL, opts.Thumbprint, opts.Preflight, opts.Proxy, opts.MachinePolicy, opts.WorkerPools)
 		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
 	}
 
@@ -158,7 +180,7 @@ func PromptMissing(opts *CreateOptions) error {
 		return err
 	}
 
-	if opts.Thumbprint.Value == "" {
+	if opts.Thumbprint.Value == "" && !opts.DiscoverThumbprint.Value {
 		if err := opts.Ask(&survey.Input{
 			Message: "Thumbprint",
 			Help:    "The X509 certificate thumbprint that securely identifies the Listening Tentacle.",
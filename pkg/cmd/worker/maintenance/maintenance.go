@@ -0,0 +1,177 @@
+package maintenance
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/task/wait"
+	"github.com/OctopusDeploy/cli/pkg/cmd/worker/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagWorkerPool = "worker-pool"
+
+	resourceDescription = "worker"
+)
+
+type MaintenanceFlags struct {
+	WorkerPools *flag.Flag[[]string]
+	*machinescommon.MaintenanceFlags
+}
+
+func NewMaintenanceFlags() *MaintenanceFlags {
+	return &MaintenanceFlags{
+		WorkerPools:      flag.New[[]string](FlagWorkerPool, false),
+		MaintenanceFlags: machinescommon.NewMaintenanceFlags(),
+	}
+}
+
+type MaintenanceOptions struct {
+	*MaintenanceFlags
+	*machinescommon.MaintenanceOptions
+	GetWorkerPoolMapCallback func() (map[string]string, error)
+	GetWorkersCallback       func(query machines.WorkersQuery) ([]*machines.Worker, error)
+}
+
+func NewMaintenanceOptions(flags *MaintenanceFlags, dependencies *cmd.Dependencies, command *cobra.Command, task *machinescommon.MaintenanceTask) *MaintenanceOptions {
+	getWorkers := func(query machines.WorkersQuery) ([]*machines.Worker, error) {
+		return shared.GetWorkersByQuery(*dependencies.Client, query, nil)
+	}
+	return &MaintenanceOptions{
+		MaintenanceFlags: flags,
+		MaintenanceOptions: &machinescommon.MaintenanceOptions{
+			MaintenanceFlags: flags.MaintenanceFlags,
+			Dependencies:     dependencies,
+			Command:          command,
+			Task:             task,
+			Description:      resourceDescription,
+			StartTaskCallback: func(task *tasks.Task) (*tasks.Task, error) {
+				return dependencies.Client.Tasks.Add(task)
+			},
+			WaitCallback: func(taskID string) error {
+				return wait.WaitRun(wait.NewWaitOps(dependencies, []string{taskID}, flags.Timeout.Value, wait.DefaultPollInterval, false, false, command))
+			},
+			GetHealthCallback: func(machineIDs []string) ([]*machinescommon.MachineHealth, error) {
+				workers, err := getWorkers(machines.WorkersQuery{IDs: machineIDs})
+				if err != nil {
+					return nil, err
+				}
+				return util.SliceTransform(workers, func(w *machines.Worker) *machinescommon.MachineHealth {
+					return &machinescommon.MachineHealth{Id: w.GetID(), Name: w.Name, HealthStatus: w.HealthStatus, StatusSummary: w.StatusSummary}
+				}), nil
+			},
+		},
+		GetWorkerPoolMapCallback: func() (map[string]string, error) {
+			workerPoolMap := make(map[string]string)
+			workerPools, err := dependencies.Client.WorkerPools.GetAll()
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range workerPools {
+				workerPoolMap[p.ID] = p.Name
+			}
+			return workerPoolMap, nil
+		},
+		GetWorkersCallback: getWorkers,
+	}
+}
+
+func NewCmdHealthCheck(f factory.Factory) *cobra.Command {
+	return newCmdMaintenance(f, machinescommon.HealthCheckTask, &cobra.Command{
+		Use:   "health-check [<name> | <id>]...",
+		Short: "Check the health of workers",
+		Long: heredoc.Doc(`
+			Check the health of workers in Octopus Deploy.
+
+			The workers are given by name or ID, or selected by worker pool.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s worker health-check worker-01 worker-02 --wait
+			%[1]s worker health-check --worker-pool "Linux Workers"
+		`, constants.ExecutableName),
+		Aliases: []string{"health"},
+	})
+}
+
+func NewCmdUpgradeTentacle(f factory.Factory) *cobra.Command {
+	return newCmdMaintenance(f, machinescommon.UpgradeTentacleTask, &cobra.Command{
+		Use:   "upgrade-tentacle [<name> | <id>]...",
+		Short: "Upgrade Tentacle on workers",
+		Long: heredoc.Doc(`
+			Upgrade Tentacle on workers in Octopus Deploy to the version bundled with the
+			Octopus Server.
+
+			The workers are given by name or ID, or selected by worker pool.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s worker upgrade-tentacle worker-01
+			%[1]s worker upgrade-tentacle --worker-pool "Linux Workers" --wait --timeout 1800
+		`, constants.ExecutableName),
+	})
+}
+
+func NewCmdUpdateCalamari(f factory.Factory) *cobra.Command {
+	return newCmdMaintenance(f, machinescommon.UpdateCalamariTask, &cobra.Command{
+		Use:   "update-calamari [<name> | <id>]...",
+		Short: "Update Calamari on workers",
+		Long: heredoc.Doc(`
+			Update Calamari on workers in Octopus Deploy to the version bundled with the
+			Octopus Server.
+
+			The workers are given by name or ID, or selected by worker pool.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s worker update-calamari worker-01
+			%[1]s worker update-calamari --worker-pool "Linux Workers" --wait
+		`, constants.ExecutableName),
+	})
+}
+
+func newCmdMaintenance(f factory.Factory, task *machinescommon.MaintenanceTask, command *cobra.Command) *cobra.Command {
+	maintenanceFlags := NewMaintenanceFlags()
+	command.RunE = func(c *cobra.Command, args []string) error {
+		opts := NewMaintenanceOptions(maintenanceFlags, cmd.NewDependencies(f, c), c, task)
+		return MaintenanceRun(opts, args)
+	}
+
+	flags := command.Flags()
+	flags.StringArrayVarP(&maintenanceFlags.WorkerPools.Value, maintenanceFlags.WorkerPools.Name, "p", nil, "Select the workers in this worker pool")
+	machinescommon.RegisterMaintenanceFlags(command, maintenanceFlags.MaintenanceFlags, wait.DefaultTimeout)
+
+	return command
+}
+
+func MaintenanceRun(opts *MaintenanceOptions, identifiers []string) error {
+	query := machines.WorkersQuery{}
+	if len(opts.WorkerPools.Value) > 0 {
+		workerPoolMap, err := opts.GetWorkerPoolMapCallback()
+		if err != nil {
+			return err
+		}
+		if query.WorkerPoolIDs, err = machinescommon.ResolveIDs(opts.WorkerPools.Value, workerPoolMap, "worker pool"); err != nil {
+			return err
+		}
+	}
+	filtered := len(query.WorkerPoolIDs) > 0
+
+	selector := &machinescommon.MachineSelector[*machines.Worker]{
+		Description:         resourceDescription,
+		GetMachinesCallback: func() ([]*machines.Worker, error) { return opts.GetWorkersCallback(query) },
+		GetID:               func(w *machines.Worker) string { return w.GetID() },
+		GetName:             func(w *machines.Worker) string { return w.Name },
+	}
+	workers, err := selector.Select(opts.Ask, opts.NoPrompt, fmt.Sprintf("Select the %ss to run the %s on", resourceDescription, opts.Task.Title), identifiers, filtered)
+	if err != nil {
+		return err
+	}
+	return machinescommon.RunMaintenance(opts.MaintenanceOptions, util.SliceTransform(workers, selector.GetID))
+}
@@ -0,0 +1,180 @@
+package create
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/worker/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagName           = "name"
+	FlagThumbprint     = "thumbprint"
+	FlagSubscriptionId = machinescommon.FlagSubscriptionId
+)
+
+type CreateFlags struct {
+	Name           *flag.Flag[string]
+	Thumbprint     *flag.Flag[string]
+	SubscriptionId *flag.Flag[string]
+	*machinescommon.CreateTargetMachinePolicyFlags
+	*shared.WorkerPoolFlags
+	*machinescommon.WebFlags
+}
+
+type CreateOptions struct {
+	*CreateFlags
+	*machinescommon.CreateTargetMachinePolicyOptions
+	*shared.WorkerPoolOptions
+	*cmd.Dependencies
+}
+
+func NewCreateFlags() *CreateFlags {
+	return &CreateFlags{
+		Name:                           flag.New[string](FlagName, false),
+		Thumbprint:                     flag.New[string](FlagThumbprint, true),
+		SubscriptionId:                 flag.New[string](FlagSubscriptionId, false),
+		CreateTargetMachinePolicyFlags: machinescommon.NewCreateTargetMachinePolicyFlags(),
+		WorkerPoolFlags:                shared.NewWorkerPoolFlags(),
+		WebFlags:                       machinescommon.NewWebFlags(),
+	}
+}
+
+func NewCreateOptions(createFlags *CreateFlags, dependencies *cmd.Dependencies) *CreateOptions {
+	return &CreateOptions{
+		CreateFlags:                      createFlags,
+		Dependencies:                     dependencies,
+		CreateTargetMachinePolicyOptions: machinescommon.NewCreateTargetMachinePolicyOptions(dependencies),
+		WorkerPoolOptions:                shared.NewWorkerPoolOptions(dependencies),
+	}
+}
+
+func NewCmdCreate(f factory.Factory) *cobra.Command {
+	createFlags := NewCreateFlags()
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a Polling Tentacle worker",
+		Long: heredoc.Docf(`
+			Create a Polling Tentacle worker in Octopus Deploy.
+
+			The Tentacle must be installed first. Its subscription ID and thumbprint are shown by
+			'Tentacle show-configuration' and 'Tentacle show-thumbprint'. Once registered, the
+			Tentacle connects to the Octopus Server to pick up work.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s worker polling-tentacle create
+			%[1]s worker polling-tentacle create --name worker-01 --subscription-id abcdefghij1234567890 --thumbprint 1234567890ABCDEF1234567890ABCDEF12345678 --worker-pool "Linux Workers" --no-prompt
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, _ []string) error {
+			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c))
+
+			return createRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&createFlags.Name.Value, createFlags.Name.Name, "n", "", "A short, memorable, unique name for this Polling Tentacle worker.")
+	flags.StringVar(&createFlags.Thumbprint.Value, createFlags.Thumbprint.Name, "", "The X509 certificate thumbprint that securely identifies the Tentacle.")
+	flags.StringVar(&createFlags.SubscriptionId.Value, createFlags.SubscriptionId.Name, "", "The subscription ID the Polling Tentacle polls the Octopus Server with.")
+	machinescommon.RegisterCreateTargetMachinePolicyFlags(cmd, createFlags.CreateTargetMachinePolicyFlags)
+	shared.RegisterCreateWorkerWorkerPoolFlags(cmd, createFlags.WorkerPoolFlags)
+	machinescommon.RegisterWebFlag(cmd, createFlags.WebFlags)
+
+	return cmd
+}
+
+func createRun(opts *CreateOptions) error {
+	if !opts.NoPrompt {
+		if err := PromptMissing(opts); err != nil {
+			return err
+		}
+	}
+
+	uri, err := machinescommon.PollingSubscriptionUri(opts.SubscriptionId.Value)
+	if err != nil {
+		return err
+	}
+
+	endpoint := machines.NewPollingTentacleEndpoint(uri, opts.Thumbprint.Value)
+
+	workerPoolIds, err := shared.FindWorkerPoolIds(opts.WorkerPoolOptions, opts.WorkerPoolFlags)
+	if err != nil {
+		return err
+	}
+
+	worker := machines.NewWorker(opts.Name.Value, endpoint)
+	worker.WorkerPoolIDs = workerPoolIds
+	machinePolicy, err := machinescommon.FindMachinePolicy(opts.GetAllMachinePoliciesCallback, opts.MachinePolicy.Value)
+	if err != nil {
+		return err
+	}
+	worker.MachinePolicyID = machinePolicy.GetID()
+
+	createdWorker, err := opts.Client.Workers.Add(worker)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.Out, "Successfully created Polling Tentacle worker '%s'.\n", worker.Name)
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.Name, opts.SubscriptionId, opts.Thumbprint, opts.MachinePolicy, opts.WorkerPools)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+
+	machinescommon.DoWebForWorkers(createdWorker, opts.Dependencies, opts.WebFlags, "polling tentacle worker")
+
+	return nil
+}
+
+func PromptMissing(opts *CreateOptions) error {
+	err := question.AskName(opts.Ask, "", "Polling Tentacle", &opts.Name.Value)
+	if err != nil {
+		return err
+	}
+
+	err = shared.PromptForWorkerPools(opts.WorkerPoolOptions, opts.WorkerPoolFlags)
+	if err != nil {
+		return err
+	}
+
+	err = machinescommon.PromptForMachinePolicy(opts.CreateTargetMachinePolicyOptions, opts.CreateTargetMachinePolicyFlags)
+	if err != nil {
+		return err
+	}
+
+	if opts.Thumbprint.Value == "" {
+		if err := opts.Ask(&survey.Input{
+			Message: "Thumbprint",
+			Help:    "The X509 certificate thumbprint that securely identifies the Polling Tentacle.",
+		}, &opts.Thumbprint.Value, survey.WithValidator(survey.ComposeValidators(
+			survey.MinLength(40),
+			survey.MaxLength(40),
+		))); err != nil {
+			return err
+		}
+	}
+
+	if opts.SubscriptionId.Value == "" {
+		if err := opts.Ask(&survey.Input{
+			Message: "Subscription ID",
+			Help:    "The subscription ID the Polling Tentacle polls the Octopus Server with, as shown by 'Tentacle show-configuration'.",
+		}, &opts.SubscriptionId.Value, survey.WithValidator(survey.ComposeValidators(
+			survey.Required,
+			machinescommon.ValidateSubscriptionId,
+		))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
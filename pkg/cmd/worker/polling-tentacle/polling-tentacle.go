@@ -3,6 +3,7 @@ package polling_tentacle
 import (
 	"fmt"
 
+	cmdCreate "github.com/OctopusDeploy/cli/pkg/cmd/worker/polling-tentacle/create"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/worker/polling-tentacle/list"
 	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/worker/polling-tentacle/view"
 	"github.com/OctopusDeploy/cli/pkg/constants"
@@ -19,6 +20,7 @@ func NewCmdPollingTentacle(f factory.Factory) *cobra.Command {
 	}
 
 	cmd.AddCommand(cmdList.NewCmdList(f))
+	cmd.AddCommand(cmdCreate.NewCmdCreate(f))
 	cmd.AddCommand(cmdView.NewCmdView(f))
 
 	return cmd
@@ -1,6 +1,8 @@
 package shared
 
 import (
+	"math"
+
 	"github.com/OctopusDeploy/cli/pkg/cmd"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
@@ -33,6 +35,40 @@ func NewGetWorkersOptionsForAllWorkers(dependencies *cmd.Dependencies) *GetWorke
 	}
 }
 
+func NewGetWorkersOptionsForQuery(dependencies *cmd.Dependencies, query machines.WorkersQuery, filter func(*machines.Worker) bool) *GetWorkersOptions {
+	return &GetWorkersOptions{
+		GetWorkersCallback: func() ([]*machines.Worker, error) {
+			return GetWorkersByQuery(*dependencies.Client, query, filter)
+		},
+		GetWorkerCallback: func(identifier string) (*machines.Worker, error) {
+			return GetWorker(*dependencies.Client, identifier)
+		},
+	}
+}
+
+// GetWorkersByQuery fetches the workers matching a query, which the server filters on, and then
+// applies the filter, if any.
+func GetWorkersByQuery(client client.Client, query machines.WorkersQuery, filter func(*machines.Worker) bool) ([]*machines.Worker, error) {
+	query.Skip = 0
+	query.Take = math.MaxInt32
+	res, err := client.Workers.Get(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter == nil {
+		return res.Items, nil
+	}
+
+	var workers []*machines.Worker
+	for _, w := range res.Items {
+		if filter(w) {
+			workers = append(workers, w)
+		}
+	}
+	return workers, nil
+}
+
 func GetWorkers(client client.Client, filter func(*machines.Worker) bool) ([]*machines.Worker, error) {
 	allWorkers, err := client.Workers.GetAll()
 	if err != nil {
@@ -63,10 +63,21 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 	createFlags := NewCreateFlags()
 
 	cmd := &cobra.Command{
-		Use:     "create",
-		Short:   "Create a SSH worker",
-		Long:    "Create a SSH worker in Octopus Deploy",
-		Example: heredoc.Docf("%s worker ssh create", constants.ExecutableName),
+		Use:   "create",
+		Short: "Create a SSH worker",
+		Long: heredoc.Docf(`
+			Create a SSH worker in Octopus Deploy.
+
+			With --%[1]s the host key fingerprint is read by connecting to the host, and must be
+			confirmed before it is trusted. Without prompting, a fingerprint which was not given is
+			only trusted with --%[3]s. With --%[2]s a worker is created for each host in a file,
+			each line of which has the form host[:port] [name] [fingerprint].
+		`, machinescommon.FlagDiscoverFingerprint, machinescommon.FlagHostsFile, machinescommon.FlagTrustDiscoveredFingerprint),
+		Example: heredoc.Docf(`
+			%[1]s worker ssh create
+			%[1]s worker ssh create --name build-01 --host build-01.example.com --discover-fingerprint --account "Build Key" --worker-pool Linux
+			%[1]s worker ssh create --hosts-file workers.txt --discover-fingerprint --trust-discovered-fingerprint --account "Build Key" --worker-pool Linux --no-prompt
+		`, constants.ExecutableName),
 		RunE: func(c *cobra.Command, _ []string) error {
 			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c))
 
@@ -86,6 +97,9 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 }
 
 func createRun(opts *CreateOptions) error {
+	if err := machinescommon.CheckHostsFileFlags(opts.SshCommonFlags, opts.Name.Value); err != nil {
+		return err
+	}
 	if !opts.NoPrompt {
 		if err := PromptMissing(opts); err != nil {
 			return err
@@ -97,23 +111,13 @@ func createRun(opts *CreateOptions) error {
 		return err
 	}
 
-	port := opts.Port.Value
-	if port == 0 {
-		port = machinescommon.DefaultPort
-	}
-	endpoint := machines.NewSSHEndpoint(opts.HostName.Value, port, opts.Fingerprint.Value)
-	endpoint.AccountID = account.GetID()
-
-	if opts.Runtime.Value == machinescommon.SelfContainedCalamari {
-		endpoint.DotNetCorePlatform = opts.Platform.Value
-	}
-
+	proxyID := ""
 	if opts.Proxy.Value != "" {
 		proxy, err := machinescommon.FindProxy(opts.CreateTargetProxyOptions, opts.CreateTargetProxyFlags)
 		if err != nil {
 			return err
 		}
-		endpoint.ProxyID = proxy.GetID()
+		proxyID = proxy.GetID()
 	}
 
 	workerPoolIds, err := shared.FindWorkerPoolIds(opts.WorkerPoolOptions, opts.WorkerPoolFlags)
@@ -121,15 +125,52 @@ func createRun(opts *CreateOptions) error {
 		return err
 	}
 
-	worker := machines.NewWorker(opts.Name.Value, endpoint)
-	worker.WorkerPoolIDs = workerPoolIds
 	machinePolicy, err := machinescommon.FindMachinePolicy(opts.GetAllMachinePoliciesCallback, opts.MachinePolicy.Value)
 	if err != nil {
 		return err
 	}
-	worker.MachinePolicyID = machinePolicy.GetID()
 
-	createdWorker, err := opts.Client.Workers.Add(worker)
+	newWorker := func(name string, host string, port int, fingerprint string) *machines.Worker {
+		if port == 0 {
+			port = machinescommon.DefaultPort
+		}
+		endpoint := machines.NewSSHEndpoint(host, port, fingerprint)
+		endpoint.AccountID = account.GetID()
+		if opts.Runtime.Value == machinescommon.SelfContainedCalamari {
+			endpoint.DotNetCorePlatform = opts.Platform.Value
+		}
+		endpoint.ProxyID = proxyID
+
+		worker := machines.NewWorker(name, endpoint)
+		worker.WorkerPoolIDs = workerPoolIds
+		worker.MachinePolicyID = machinePolicy.GetID()
+		return worker
+	}
+
+	if opts.HostsFile.Value != "" {
+		content, err := opts.ReadFileCallback(opts.HostsFile.Value)
+		if err != nil {
+			return err
+		}
+		hosts, err := machinescommon.ReadSshHosts(content)
+		if err != nil {
+			return err
+		}
+		err = machinescommon.RegisterSshHosts(opts.SshCommonOptions, opts.SshCommonFlags, hosts, "SSH worker", func(host *machinescommon.SshHost) error {
+			_, err := opts.Client.Workers.Add(newWorker(host.Name, host.Host, host.Port, host.Fingerprint))
+			return err
+		})
+		if !opts.NoPrompt {
+			autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.HostsFile, opts.DiscoverFingerprint, opts.TrustDiscoveredFingerprint, opts.Port, opts.Runtime, opts.Platform, opts.WorkerPools, opts.Account, opts.Proxy, opts.MachinePolicy)
+			fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+		}
+		return err
+	}
+
+	if err := machinescommon.ResolveSshFingerprint(opts.SshCommonOptions, opts.SshCommonFlags); err != nil {
+		return err
+	}
+	createdWorker, err := opts.Client.Workers.Add(newWorker(opts.Name.Value, opts.HostName.Value, opts.Port.Value, opts.Fingerprint.Value))
 	if err != nil {
 		return err
 	}
@@ -146,12 +187,15 @@ func createRun(opts *CreateOptions) error {
 }
 
 func PromptMissing(opts *CreateOptions) error {
-	err := question.AskName(opts.Ask, "", "SSH", &opts.Name.Value)
-	if err != nil {
-		return err
+	// the name and endpoint of each worker come from the hosts file
+	batch := opts.HostsFile.Value != ""
+	if !batch {
+		if err := question.AskName(opts.Ask, "", "SSH", &opts.Name.Value); err != nil {
+			return err
+		}
 	}
 
-	err = shared.PromptForWorkerPools(opts.WorkerPoolOptions, opts.WorkerPoolFlags)
+	err := shared.PromptForWorkerPools(opts.WorkerPoolOptions, opts.WorkerPoolFlags)
 	if err != nil {
 		return err
 	}
@@ -166,9 +210,11 @@ func PromptMissing(opts *CreateOptions) error {
 		return err
 	}
 
-	err = machinescommon.PromptForSshEndpoint(opts.SshCommonOptions, opts.SshCommonFlags, "worker")
-	if err != nil {
-		return err
+	if !batch {
+		err = machinescommon.PromptForSshEndpoint(opts.SshCommonOptions, opts.SshCommonFlags, "worker")
+		if err != nil {
+			return err
+		}
 	}
 
 	err = machinescommon.PromptForProxy(opts.CreateTargetProxyOptions, opts.CreateTargetProxyFlags, "SSH worker")
@@ -0,0 +1,211 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/worker/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/proxies"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagWorkerPool       = "worker-pool"
+	FlagAddWorkerPool    = "add-worker-pool"
+	FlagRemoveWorkerPool = "remove-worker-pool"
+
+	resourceDescription = "worker"
+)
+
+type UpdateFlags struct {
+	WorkerPools       *flag.Flag[[]string]
+	AddWorkerPools    *flag.Flag[[]string]
+	RemoveWorkerPools *flag.Flag[[]string]
+	*machinescommon.UpdateMachineFlags
+}
+
+func NewUpdateFlags() *UpdateFlags {
+	return &UpdateFlags{
+		WorkerPools:        flag.New[[]string](FlagWorkerPool, false),
+		AddWorkerPools:     flag.New[[]string](FlagAddWorkerPool, false),
+		RemoveWorkerPools:  flag.New[[]string](FlagRemoveWorkerPool, false),
+		UpdateMachineFlags: machinescommon.NewUpdateMachineFlags(),
+	}
+}
+
+type UpdateOptions struct {
+	*UpdateFlags
+	*cmd.Dependencies
+	IsFlagSet                flag.IsSetFunc
+	GetWorkersCallback       func(query machines.WorkersQuery) ([]*machines.Worker, error)
+	GetWorkerPoolMapCallback func() (map[string]string, error)
+	machinescommon.GetAllMachinePoliciesCallback
+	machinescommon.GetAllProxiesCallback
+	UpdateWorkerCallback func(worker *machines.Worker) (*machines.Worker, error)
+}
+
+func NewUpdateOptions(flags *UpdateFlags, dependencies *cmd.Dependencies, isFlagSet flag.IsSetFunc) *UpdateOptions {
+	return &UpdateOptions{
+		UpdateFlags:  flags,
+		Dependencies: dependencies,
+		IsFlagSet:    isFlagSet,
+		GetWorkersCallback: func(query machines.WorkersQuery) ([]*machines.Worker, error) {
+			return shared.GetWorkersByQuery(*dependencies.Client, query, nil)
+		},
+		GetWorkerPoolMapCallback: func() (map[string]string, error) {
+			workerPoolMap := make(map[string]string)
+			workerPools, err := dependencies.Client.WorkerPools.GetAll()
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range workerPools {
+				workerPoolMap[p.ID] = p.Name
+			}
+			return workerPoolMap, nil
+		},
+		GetAllMachinePoliciesCallback: machinescommon.NewCreateTargetMachinePolicyOptions(dependencies).GetAllMachinePoliciesCallback,
+		GetAllProxiesCallback: func() ([]*proxies.Proxy, error) {
+			return dependencies.Client.Proxies.GetAll()
+		},
+		UpdateWorkerCallback: func(worker *machines.Worker) (*machines.Worker, error) {
+			return dependencies.Client.Workers.Update(worker)
+		},
+	}
+}
+
+func NewCmdUpdate(f factory.Factory) *cobra.Command {
+	updateFlags := NewUpdateFlags()
+
+	cmd := &cobra.Command{
+		Use:   "update [<name> | <id>]...",
+		Short: "Update workers",
+		Long: heredoc.Docf(`
+			Update the worker pools, machine policy, proxy or enabled state of workers in Octopus Deploy.
+
+			The workers are given by name or ID, or selected with --%[1]s to change many workers at
+			once. Only the settings given are changed.
+		`, FlagWorkerPool),
+		Example: heredoc.Docf(`
+			%[1]s worker update worker-01 --add-worker-pool "Linux Workers"
+			%[1]s worker update --worker-pool "Old Workers" --add-worker-pool "Linux Workers" --remove-worker-pool "Old Workers"
+			%[1]s worker update worker-01 worker-02 --machine-policy "Transient machines" --proxy ""
+			%[1]s worker update --worker-pool "Linux Workers" --disable
+		`, constants.ExecutableName),
+		Aliases: []string{"edit"},
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := NewUpdateOptions(updateFlags, cmd.NewDependencies(f, c), c.Flags().Changed)
+			return UpdateRun(opts, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringArrayVarP(&updateFlags.WorkerPools.Value, updateFlags.WorkerPools.Name, "p", nil, "Select the workers in this worker pool")
+	flags.StringArrayVar(&updateFlags.AddWorkerPools.Value, updateFlags.AddWorkerPools.Name, nil, "Add the workers to a worker pool")
+	flags.StringArrayVar(&updateFlags.RemoveWorkerPools.Value, updateFlags.RemoveWorkerPools.Name, nil, "Remove the workers from a worker pool")
+	machinescommon.RegisterUpdateMachineFlags(cmd, updateFlags.UpdateMachineFlags, resourceDescription)
+
+	return cmd
+}
+
+// WorkerUpdate is a change to workers, with names resolved to IDs.
+type WorkerUpdate struct {
+	AddWorkerPoolIDs    []string
+	RemoveWorkerPoolIDs []string
+	*machinescommon.MachineUpdate
+}
+
+func UpdateRun(opts *UpdateOptions, identifiers []string) error {
+	var workerPoolMap map[string]string
+	if len(opts.WorkerPools.Value) > 0 || len(opts.AddWorkerPools.Value) > 0 || len(opts.RemoveWorkerPools.Value) > 0 {
+		var err error
+		if workerPoolMap, err = opts.GetWorkerPoolMapCallback(); err != nil {
+			return err
+		}
+	}
+
+	update, err := ResolveUpdate(opts, workerPoolMap)
+	if err != nil {
+		return err
+	}
+	if update.IsEmpty() {
+		return fmt.Errorf("nothing to change, give at least one of the flags which change workers")
+	}
+
+	query := machines.WorkersQuery{}
+	if query.WorkerPoolIDs, err = machinescommon.ResolveIDs(opts.WorkerPools.Value, workerPoolMap, "worker pool"); err != nil {
+		return err
+	}
+	selector := &machinescommon.MachineSelector[*machines.Worker]{
+		Description:         resourceDescription,
+		GetMachinesCallback: func() ([]*machines.Worker, error) { return opts.GetWorkersCallback(query) },
+		GetID:               func(w *machines.Worker) string { return w.GetID() },
+		GetName:             func(w *machines.Worker) string { return w.Name },
+	}
+	workers, err := selector.Select(opts.Ask, opts.NoPrompt, "Select the workers to update", identifiers, len(query.WorkerPoolIDs) > 0)
+	if err != nil {
+		return err
+	}
+
+	// check every worker can take the change before changing any of them
+	for _, worker := range workers {
+		if err := ApplyUpdate(worker, update); err != nil {
+			return fmt.Errorf("cannot update worker '%s': %w", worker.Name, err)
+		}
+	}
+	for _, worker := range workers {
+		updated, err := opts.UpdateWorkerCallback(worker)
+		if err != nil {
+			return fmt.Errorf("cannot update worker '%s': %w", worker.Name, err)
+		}
+		fmt.Fprintf(opts.Out, "Successfully updated worker '%s' %s.\n", updated.Name, output.Dimf("(%s)", updated.GetID()))
+	}
+
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.AddWorkerPools, opts.RemoveWorkerPools, opts.MachinePolicy, opts.Proxy, opts.Enable, opts.Disable)
+		for _, worker := range workers {
+			autoCmd += fmt.Sprintf(" '%s'", strings.ReplaceAll(worker.Name, "'", "'\\''"))
+		}
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+	return nil
+}
+
+// ResolveUpdate checks the flags given and turns them into a WorkerUpdate.
+func ResolveUpdate(opts *UpdateOptions, workerPoolMap map[string]string) (*WorkerUpdate, error) {
+	machineUpdate, err := machinescommon.ResolveMachineUpdate(opts.UpdateMachineFlags, opts.IsFlagSet, opts.GetAllMachinePoliciesCallback, opts.GetAllProxiesCallback)
+	if err != nil {
+		return nil, err
+	}
+	update := &WorkerUpdate{MachineUpdate: machineUpdate}
+	if update.AddWorkerPoolIDs, err = machinescommon.ResolveIDs(opts.AddWorkerPools.Value, workerPoolMap, "worker pool"); err != nil {
+		return nil, err
+	}
+	if update.RemoveWorkerPoolIDs, err = machinescommon.ResolveIDs(opts.RemoveWorkerPools.Value, workerPoolMap, "worker pool"); err != nil {
+		return nil, err
+	}
+	return update, nil
+}
+
+// IsEmpty reports whether the update changes nothing.
+func (u *WorkerUpdate) IsEmpty() bool {
+	return u.MachineUpdate.IsEmpty() && util.Empty(u.AddWorkerPoolIDs) && util.Empty(u.RemoveWorkerPoolIDs)
+}
+
+// ApplyUpdate changes a worker, checking it is still valid afterwards.
+func ApplyUpdate(worker *machines.Worker, update *WorkerUpdate) error {
+	worker.WorkerPoolIDs = machinescommon.RemoveValues(machinescommon.AddValues(worker.WorkerPoolIDs, update.AddWorkerPoolIDs), update.RemoveWorkerPoolIDs)
+	if len(worker.WorkerPoolIDs) == 0 && len(update.RemoveWorkerPoolIDs) > 0 {
+		return fmt.Errorf("a worker must be in at least one worker pool")
+	}
+
+	return machinescommon.ApplyMachineUpdate(update.MachineUpdate, &worker.MachinePolicyID, &worker.IsDisabled, worker.Endpoint)
+}
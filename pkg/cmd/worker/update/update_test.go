@@ -0,0 +1,71 @@
+package update_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/worker/update"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWorker(id string, name string, workerPoolIDs ...string) *machines.Worker {
+	worker := machines.NewWorker(name, machines.NewSSHEndpoint("host", 22, "thumbprint"))
+	worker.ID = id
+	worker.WorkerPoolIDs = workerPoolIDs
+	return worker
+}
+
+func newTestOptions(flags *update.UpdateFlags) *update.UpdateOptions {
+	opts := update.NewUpdateOptions(flags, &cmd.Dependencies{NoPrompt: true, Out: &bytes.Buffer{}}, func(name string) bool { return false })
+	opts.GetWorkerPoolMapCallback = func() (map[string]string, error) {
+		return map[string]string{"WorkerPools-1": "Old Workers", "WorkerPools-2": "Linux Workers"}, nil
+	}
+	return opts
+}
+
+func TestUpdateRun_MovesWorkersBetweenPools(t *testing.T) {
+	worker1 := newWorker("Workers-1", "worker-01", "WorkerPools-1")
+	worker2 := newWorker("Workers-2", "worker-02", "WorkerPools-1", "WorkerPools-2")
+
+	flags := update.NewUpdateFlags()
+	flags.WorkerPools.Value = []string{"Old Workers"}
+	flags.AddWorkerPools.Value = []string{"Linux Workers"}
+	flags.RemoveWorkerPools.Value = []string{"old workers"}
+	flags.Enable.Value = true
+	opts := newTestOptions(flags)
+
+	var query machines.WorkersQuery
+	opts.GetWorkersCallback = func(q machines.WorkersQuery) ([]*machines.Worker, error) {
+		query = q
+		return []*machines.Worker{worker1, worker2}, nil
+	}
+	var updated []string
+	opts.UpdateWorkerCallback = func(worker *machines.Worker) (*machines.Worker, error) {
+		updated = append(updated, worker.GetID())
+		return worker, nil
+	}
+
+	err := update.UpdateRun(opts, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"WorkerPools-1"}, query.WorkerPoolIDs)
+	assert.Equal(t, []string{"Workers-1", "Workers-2"}, updated)
+	assert.Equal(t, []string{"WorkerPools-2"}, worker1.WorkerPoolIDs)
+	assert.Equal(t, []string{"WorkerPools-2"}, worker2.WorkerPoolIDs)
+	assert.False(t, worker1.IsDisabled)
+}
+
+func TestUpdateRun_WorkerMustStayInAPool(t *testing.T) {
+	flags := update.NewUpdateFlags()
+	flags.RemoveWorkerPools.Value = []string{"Old Workers"}
+	opts := newTestOptions(flags)
+	opts.GetWorkersCallback = func(q machines.WorkersQuery) ([]*machines.Worker, error) {
+		return []*machines.Worker{newWorker("Workers-1", "worker-01", "WorkerPools-1")}, nil
+	}
+
+	err := update.UpdateRun(opts, []string{"worker-01"})
+
+	assert.EqualError(t, err, "cannot update worker 'worker-01': a worker must be in at least one worker pool")
+}
@@ -5,8 +5,10 @@ import (
 	cmdDelete "github.com/OctopusDeploy/cli/pkg/cmd/worker/delete"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/worker/list"
 	listeningTentacle "github.com/OctopusDeploy/cli/pkg/cmd/worker/listening-tentacle"
+	cmdMaintenance "github.com/OctopusDeploy/cli/pkg/cmd/worker/maintenance"
 	pollingTentacle "github.com/OctopusDeploy/cli/pkg/cmd/worker/polling-tentacle"
 	"github.com/OctopusDeploy/cli/pkg/cmd/worker/ssh"
+	cmdUpdate "github.com/OctopusDeploy/cli/pkg/cmd/worker/update"
 	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/worker/view"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
@@ -34,6 +36,10 @@ func NewCmdWorker(f factory.Factory) *cobra.Command {
 	cmd.AddCommand(cmdList.NewCmdList(f))
 	cmd.AddCommand(cmdDelete.NewCmdDelete(f))
 	cmd.AddCommand(cmdView.NewCmdView(f))
+	cmd.AddCommand(cmdUpdate.NewCmdUpdate(f))
+	cmd.AddCommand(cmdMaintenance.NewCmdHealthCheck(f))
+	cmd.AddCommand(cmdMaintenance.NewCmdUpgradeTentacle(f))
+	cmd.AddCommand(cmdMaintenance.NewCmdUpdateCalamari(f))
 
 	return cmd
 }
@@ -0,0 +1,232 @@
+package shared
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+)
+
+const (
+	UsageDeploymentStep = "Deployment process step"
+	UsageRunbookStep    = "Runbook step"
+
+	// PropertyRunOnServer is the step property which is true when a step runs on a worker.
+	PropertyRunOnServer = "Octopus.Action.RunOnServer"
+	// VariableTypeWorkerPool is the type of a variable whose value is a worker pool.
+	VariableTypeWorkerPool = "WorkerPool"
+
+	ViaDefaultPool = "default worker pool"
+)
+
+type GetProcessesCallback func() ([]*Process, error)
+
+// Process is the steps of a deployment process or runbook, with the worker pool variables which
+// the steps can use.
+type Process struct {
+	Kind    string
+	Project string
+	Runbook string
+	Steps   []*deployments.DeploymentStep
+	// WorkerPoolVariables are the values of the worker pool variables of the project and its
+	// library variable sets, by variable name.
+	WorkerPoolVariables map[string][]string
+}
+
+// Usage is a step which runs on a worker pool.
+type Usage struct {
+	WorkerPoolId string `json:"WorkerPoolId"`
+	WorkerPool   string `json:"WorkerPool"`
+	Kind         string `json:"Kind"`
+	Project      string `json:"Project"`
+	Runbook      string `json:"Runbook,omitempty"`
+	Step         string `json:"Step"`
+	// Via is how the step chose the worker pool, when it was not chosen directly.
+	Via string `json:"Via,omitempty"`
+}
+
+// FindUsages finds the steps which run on the worker pools given, in the order of the pools. A
+// step uses a pool when it names the pool, when it names a worker pool variable which can have
+// the pool as its value, or when it runs on a worker without naming a pool and the pool is the
+// default worker pool.
+func FindUsages(pools []workerpools.IWorkerPool, processes []*Process) []*Usage {
+	usages := []*Usage{}
+	for _, pool := range pools {
+		for _, process := range processes {
+			for _, step := range process.Steps {
+				for _, action := range step.Actions {
+					via, ok := usesPool(action, pool, process.WorkerPoolVariables)
+					if !ok {
+						continue
+					}
+					name := step.Name
+					if len(step.Actions) > 1 {
+						name = fmt.Sprintf("%s / %s", step.Name, action.Name)
+					}
+					usages = append(usages, &Usage{
+						WorkerPoolId: pool.GetID(),
+						WorkerPool:   pool.GetName(),
+						Kind:         process.Kind,
+						Project:      process.Project,
+						Runbook:      process.Runbook,
+						Step:         name,
+						Via:          via,
+					})
+				}
+			}
+		}
+	}
+	return usages
+}
+
+func usesPool(action *deployments.DeploymentAction, pool workerpools.IWorkerPool, variables map[string][]string) (string, bool) {
+	if action.WorkerPool != "" {
+		return "", strings.EqualFold(action.WorkerPool, pool.GetID())
+	}
+	if action.WorkerPoolVariable != "" {
+		for _, value := range variables[action.WorkerPoolVariable] {
+			if strings.EqualFold(value, pool.GetID()) {
+				return fmt.Sprintf("variable %s", action.WorkerPoolVariable), true
+			}
+		}
+		return "", false
+	}
+	if pool.GetIsDefault() && strings.EqualFold(action.Properties[PropertyRunOnServer].Value, "true") {
+		return ViaDefaultPool, true
+	}
+	return "", false
+}
+
+// DescribeUsage is a usage as a line of text.
+func DescribeUsage(usage *Usage) string {
+	owner := usage.Project
+	if usage.Runbook != "" {
+		owner = fmt.Sprintf("%s / %s", usage.Project, usage.Runbook)
+	}
+	description := fmt.Sprintf("%s: %s %s %s", usage.Kind, owner, output.Dim("/"), usage.Step)
+	if usage.Via != "" {
+		description += " " + output.Dimf("(via %s)", usage.Via)
+	}
+	return description
+}
+
+// GetProcesses reads the deployment process and runbooks of every project. The deployment process
+// of a version controlled project, and its runbooks when they are kept in git, are read from its
+// default branch.
+func GetProcesses(octopus *client.Client, spaceID string) ([]*Process, error) {
+	allProjects, err := octopus.Projects.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	allRunbooks, err := octopus.Runbooks.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	libraryVariables := map[string]map[string][]string{}
+	getVariables := func(ownerID string) (map[string][]string, error) {
+		if variables, ok := libraryVariables[ownerID]; ok {
+			return variables, nil
+		}
+		variableSet, err := octopus.Variables.GetAll(ownerID)
+		if err != nil {
+			return nil, err
+		}
+		variables := map[string][]string{}
+		for _, v := range variableSet.Variables {
+			if v.Type == VariableTypeWorkerPool {
+				variables[v.Name] = append(variables[v.Name], v.Value)
+			}
+		}
+		libraryVariables[ownerID] = variables
+		return variables, nil
+	}
+
+	processes := []*Process{}
+	for _, project := range allProjects {
+		variables := map[string][]string{}
+		for _, ownerID := range append([]string{project.GetID()}, project.IncludedLibraryVariableSets...) {
+			ownerVariables, err := getVariables(ownerID)
+			if err != nil {
+				return nil, err
+			}
+			for name, values := range ownerVariables {
+				variables[name] = append(variables[name], values...)
+			}
+		}
+
+		process, err := getDeploymentProcess(octopus, spaceID, project)
+		if err != nil {
+			return nil, err
+		}
+		if process != nil {
+			processes = append(processes, &Process{Kind: UsageDeploymentStep, Project: project.GetName(), Steps: process.Steps, WorkerPoolVariables: variables})
+		}
+
+		runbookProcesses, err := getRunbookProcesses(octopus, spaceID, project, allRunbooks)
+		if err != nil {
+			return nil, err
+		}
+		for _, runbookProcess := range runbookProcesses {
+			processes = append(processes, &Process{Kind: UsageRunbookStep, Project: project.GetName(), Runbook: runbookProcess.runbook, Steps: runbookProcess.steps, WorkerPoolVariables: variables})
+		}
+	}
+	return processes, nil
+}
+
+type runbookProcess struct {
+	runbook string
+	steps   []*deployments.DeploymentStep
+}
+
+// getRunbookProcesses reads the processes of the runbooks of a project. The runbooks of a version
+// controlled project which keeps its runbooks in git are read from its default branch.
+func getRunbookProcesses(octopus *client.Client, spaceID string, project *projects.Project, allRunbooks []*runbooks.Runbook) ([]*runbookProcess, error) {
+	var runbookProcesses []*runbookProcess
+	if settings, ok := project.PersistenceSettings.(projects.GitPersistenceSettings); ok && project.IsVersionControlled && settings.RunbooksAreInGit() {
+		gitRunbooks, err := runbooks.ListGitRunbooks(octopus, spaceID, project.GetID(), settings.DefaultBranch(), "", math.MaxInt32)
+		if err != nil {
+			return nil, err
+		}
+		for _, runbook := range gitRunbooks.Items {
+			process, err := runbooks.GetGitRunbookProcess(octopus, spaceID, project.GetID(), runbook.GetID(), settings.DefaultBranch())
+			if err != nil {
+				return nil, err
+			}
+			runbookProcesses = append(runbookProcesses, &runbookProcess{runbook: runbook.Name, steps: process.Steps})
+		}
+		return runbookProcesses, nil
+	}
+
+	for _, runbook := range allRunbooks {
+		if runbook.ProjectID != project.GetID() || runbook.RunbookProcessID == "" {
+			continue
+		}
+		process, err := runbooks.GetProcess(octopus, spaceID, project.GetID(), runbook.RunbookProcessID)
+		if err != nil {
+			return nil, err
+		}
+		runbookProcesses = append(runbookProcesses, &runbookProcess{runbook: runbook.Name, steps: process.Steps})
+	}
+	return runbookProcesses, nil
+}
+
+func getDeploymentProcess(octopus *client.Client, spaceID string, project *projects.Project) (*deployments.DeploymentProcess, error) {
+	if project.IsVersionControlled {
+		settings, ok := project.PersistenceSettings.(projects.GitPersistenceSettings)
+		if !ok {
+			return nil, nil
+		}
+		return deployments.GetDeploymentProcessByGitRef(octopus, spaceID, project, settings.DefaultBranch())
+	}
+	if project.DeploymentProcessID == "" {
+		return nil, nil
+	}
+	return deployments.GetDeploymentProcessByID(octopus, spaceID, project.DeploymentProcessID)
+}
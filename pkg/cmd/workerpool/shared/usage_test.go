@@ -0,0 +1,65 @@
+package shared_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/workerpool/shared"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStep(name string, actions ...*deployments.DeploymentAction) *deployments.DeploymentStep {
+	step := deployments.NewDeploymentStep(name)
+	step.Actions = actions
+	return step
+}
+
+func newAction(name string, workerPool string, workerPoolVariable string, runOnServer bool) *deployments.DeploymentAction {
+	action := deployments.NewDeploymentAction(name, "Octopus.Script")
+	action.WorkerPool = workerPool
+	action.WorkerPoolVariable = workerPoolVariable
+	if runOnServer {
+		action.Properties[shared.PropertyRunOnServer] = core.NewPropertyValue("true", false)
+	}
+	return action
+}
+
+func TestFindUsages(t *testing.T) {
+	defaultPool := workerpools.NewStaticWorkerPool("Default Worker Pool")
+	defaultPool.ID = "WorkerPools-1"
+	defaultPool.IsDefault = true
+	windowsPool := workerpools.NewStaticWorkerPool("Windows workers")
+	windowsPool.ID = "WorkerPools-2"
+
+	processes := []*shared.Process{
+		{
+			Kind:    shared.UsageDeploymentStep,
+			Project: "Web",
+			Steps: []*deployments.DeploymentStep{
+				newStep("Build", newAction("Build", "WorkerPools-2", "", true)),
+				newStep("Migrate", newAction("Migrate", "", "", true)),
+				newStep("Deploy", newAction("Deploy", "", "", false)),
+			},
+		},
+		{
+			Kind:                shared.UsageRunbookStep,
+			Project:             "Web",
+			Runbook:             "Rotate logs",
+			WorkerPoolVariables: map[string][]string{"Pool": {"WorkerPools-3", "WorkerPools-2"}},
+			Steps: []*deployments.DeploymentStep{
+				newStep("Archive", newAction("Compress", "", "Pool", true), newAction("Upload", "WorkerPools-1", "", true)),
+			},
+		},
+	}
+
+	usages := shared.FindUsages([]workerpools.IWorkerPool{defaultPool, windowsPool}, processes)
+
+	assert.Equal(t, []*shared.Usage{
+		{WorkerPoolId: "WorkerPools-1", WorkerPool: "Default Worker Pool", Kind: shared.UsageDeploymentStep, Project: "Web", Step: "Migrate", Via: shared.ViaDefaultPool},
+		{WorkerPoolId: "WorkerPools-1", WorkerPool: "Default Worker Pool", Kind: shared.UsageRunbookStep, Project: "Web", Runbook: "Rotate logs", Step: "Archive / Upload"},
+		{WorkerPoolId: "WorkerPools-2", WorkerPool: "Windows workers", Kind: shared.UsageDeploymentStep, Project: "Web", Step: "Build"},
+		{WorkerPoolId: "WorkerPools-2", WorkerPool: "Windows workers", Kind: shared.UsageRunbookStep, Project: "Web", Runbook: "Rotate logs", Step: "Archive / Compress", Via: "variable Pool"},
+	}, usages)
+}
@@ -1,7 +1,11 @@
 package shared
 
 import (
+	"fmt"
+
 	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
 )
@@ -42,3 +46,22 @@ func GetWorker(client client.Client, identifier string) (workerpools.IWorkerPool
 
 	return workerPool, nil
 }
+
+// ResolveWorkerPool finds the named worker pool, or prompts for one when no name was given.
+func ResolveWorkerPool(ask question.Asker, noPrompt bool, identifier string, message string, callbacks *GetWorkerPoolsOptions) (workerpools.IWorkerPool, error) {
+	if identifier != "" {
+		return callbacks.GetWorkerPoolCallback(identifier)
+	}
+
+	if noPrompt {
+		return nil, fmt.Errorf("must supply worker pool identifier")
+	}
+
+	selected, err := selectors.Select(ask, message, callbacks.GetWorkerPoolsCallback, func(pool *workerpools.WorkerPoolListResult) string {
+		return pool.Name
+	})
+	if err != nil {
+		return nil, err
+	}
+	return callbacks.GetWorkerPoolCallback(selected.ID)
+}
@@ -0,0 +1,233 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/workerpool/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagWorkerPool  = "worker-pool"
+	FlagName        = "name"
+	FlagDescription = "description"
+	FlagDefault     = "default"
+	FlagWorkerType  = "worker-type"
+)
+
+type UpdateFlags struct {
+	WorkerPool  *flag.Flag[string]
+	Name        *flag.Flag[string]
+	Description *flag.Flag[string]
+	Default     *flag.Flag[bool]
+	WorkerType  *flag.Flag[string]
+	*machinescommon.WebFlags
+}
+
+func NewUpdateFlags() *UpdateFlags {
+	return &UpdateFlags{
+		WorkerPool:  flag.New[string](FlagWorkerPool, false),
+		Name:        flag.New[string](FlagName, false),
+		Description: flag.New[string](FlagDescription, false),
+		Default:     flag.New[bool](FlagDefault, false),
+		WorkerType:  flag.New[string](FlagWorkerType, false),
+		WebFlags:    machinescommon.NewWebFlags(),
+	}
+}
+
+type UpdateOptions struct {
+	*UpdateFlags
+	*cmd.Dependencies
+	*shared.GetWorkerPoolsOptions
+	IsFlagSet                         flag.IsSetFunc
+	GetDynamicWorkerPoolTypesCallback func() ([]*workerpools.DynamicWorkerPoolType, error)
+	UpdateWorkerPoolCallback          func(pool workerpools.IWorkerPool) (workerpools.IWorkerPool, error)
+}
+
+func NewUpdateOptions(flags *UpdateFlags, dependencies *cmd.Dependencies, isFlagSet flag.IsSetFunc) *UpdateOptions {
+	return &UpdateOptions{
+		UpdateFlags:           flags,
+		Dependencies:          dependencies,
+		GetWorkerPoolsOptions: shared.NewGetWorkerPoolsOptions(dependencies),
+		IsFlagSet:             isFlagSet,
+		GetDynamicWorkerPoolTypesCallback: func() ([]*workerpools.DynamicWorkerPoolType, error) {
+			return dependencies.Client.WorkerPools.GetDynamicWorkerTypes()
+		},
+		UpdateWorkerPoolCallback: func(pool workerpools.IWorkerPool) (workerpools.IWorkerPool, error) {
+			return workerpools.Update(dependencies.Client, pool)
+		},
+	}
+}
+
+func NewCmdUpdate(f factory.Factory) *cobra.Command {
+	updateFlags := NewUpdateFlags()
+
+	cmd := &cobra.Command{
+		Use:   "update [<name> | <id>]",
+		Short: "Update a worker pool",
+		Long: heredoc.Docf(`
+			Update a static or dynamic worker pool in Octopus Deploy.
+
+			Only the settings given as flags are changed. --%[1]s only applies to dynamic worker
+			pools. Making a worker pool the default takes the default from the current one.
+		`, FlagWorkerType),
+		Example: heredoc.Docf(`
+			%[1]s worker-pool update "Windows workers" --description "Windows Server 2022 workers"
+			%[1]s worker-pool update --worker-pool WorkerPools-2 --name "Linux workers" --default
+			%[1]s worker-pool update "Hosted Ubuntu" --worker-type Ubuntu2204
+		`, constants.ExecutableName),
+		Aliases: []string{"edit"},
+		Args:    usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if updateFlags.WorkerPool.Value == "" && len(args) > 0 {
+				updateFlags.WorkerPool.Value = args[0]
+			}
+
+			opts := NewUpdateOptions(updateFlags, cmd.NewDependencies(f, c), c.Flags().Changed)
+			return updateRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&updateFlags.WorkerPool.Value, updateFlags.WorkerPool.Name, "", "Name or ID of the worker pool to update")
+	flags.StringVarP(&updateFlags.Name.Value, updateFlags.Name.Name, "n", "", "New name of the worker pool")
+	flags.StringVarP(&updateFlags.Description.Value, updateFlags.Description.Name, "d", "", "Description of the worker pool")
+	flags.BoolVar(&updateFlags.Default.Value, updateFlags.Default.Name, false, "Make this the default worker pool")
+	flags.StringVar(&updateFlags.WorkerType.Value, updateFlags.WorkerType.Name, "", "The worker type to use for all leased workers, for dynamic worker pools")
+	machinescommon.RegisterWebFlag(cmd, updateFlags.WebFlags)
+
+	return cmd
+}
+
+func updateRun(opts *UpdateOptions) error {
+	pool, err := shared.ResolveWorkerPool(opts.Ask, opts.NoPrompt, opts.WorkerPool.Value, "Select the worker pool you wish to update", opts.GetWorkerPoolsOptions)
+	if err != nil {
+		return err
+	}
+	opts.WorkerPool.Value = pool.GetName()
+
+	if !opts.NoPrompt {
+		if err := PromptMissing(opts, pool); err != nil {
+			return err
+		}
+	}
+
+	if err := ApplyFlags(opts, pool); err != nil {
+		return err
+	}
+
+	updated, err := opts.UpdateWorkerPoolCallback(pool)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.Out, "Successfully updated worker pool '%s' (%s).\n", updated.GetName(), updated.GetID())
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.GetSpaceNameOrEmpty(), opts.WorkerPool, opts.Name, opts.Description, opts.Default, opts.WorkerType)
+		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+
+	machinescommon.DoWebForWorkerPools(updated, opts.Dependencies, opts.WebFlags)
+
+	return nil
+}
+
+// ApplyFlags changes the worker pool for each flag which was given, returning an error when none
+// were given or a change cannot be made.
+func ApplyFlags(opts *UpdateOptions, pool workerpools.IWorkerPool) error {
+	set := util.SliceFilter([]string{FlagName, FlagDescription, FlagDefault, FlagWorkerType}, opts.IsFlagSet)
+	if len(set) == 0 {
+		return fmt.Errorf("nothing to update, give the settings to change as flags")
+	}
+
+	if opts.IsFlagSet(FlagName) && strings.TrimSpace(opts.Name.Value) == "" {
+		return fmt.Errorf("the name of a worker pool cannot be empty")
+	}
+	if opts.IsFlagSet(FlagDefault) && !opts.Default.Value && pool.GetIsDefault() {
+		return fmt.Errorf("worker pool '%s' is the default worker pool; make another worker pool the default instead", pool.GetName())
+	}
+	if opts.IsFlagSet(FlagWorkerType) {
+		dynamicPool, ok := pool.(*workerpools.DynamicWorkerPool)
+		if !ok {
+			return fmt.Errorf("--%s can only be given for a dynamic worker pool, and '%s' is a static worker pool", FlagWorkerType, pool.GetName())
+		}
+		workerTypes, err := opts.GetDynamicWorkerPoolTypesCallback()
+		if err != nil {
+			return err
+		}
+		workerType, err := FindWorkerType(workerTypes, opts.WorkerType.Value)
+		if err != nil {
+			return err
+		}
+		dynamicPool.WorkerType = workerType
+	}
+
+	if opts.IsFlagSet(FlagName) {
+		pool.SetName(opts.Name.Value)
+	}
+	if opts.IsFlagSet(FlagDescription) {
+		pool.SetDescription(opts.Description.Value)
+	}
+	if opts.IsFlagSet(FlagDefault) {
+		pool.SetIsDefault(opts.Default.Value)
+	}
+	return nil
+}
+
+// FindWorkerType matches a dynamic worker type by its ID or type, ignoring case.
+func FindWorkerType(workerTypes []*workerpools.DynamicWorkerPoolType, value string) (string, error) {
+	var known []string
+	for _, workerType := range workerTypes {
+		if strings.EqualFold(workerType.ID, value) || strings.EqualFold(workerType.Type, value) {
+			return workerType.ID, nil
+		}
+		known = append(known, workerType.ID)
+	}
+	return "", fmt.Errorf("unknown worker type '%s', must be one of %s", value, output.FormatAsList(known))
+}
+
+// PromptMissing asks for the name and description when they were not given, offering the current
+// values as the defaults. Changed answers are treated as if they were given as flags.
+func PromptMissing(opts *UpdateOptions, pool workerpools.IWorkerPool) error {
+	prompted := flag.NewPrompted(opts.IsFlagSet)
+	if !opts.IsFlagSet(FlagName) {
+		if err := opts.Ask(&survey.Input{
+			Message: "Name",
+			Help:    "A short, memorable, unique name for this worker pool.",
+			Default: pool.GetName(),
+		}, &opts.Name.Value, survey.WithValidator(survey.ComposeValidators(
+			survey.MaxLength(200),
+			survey.MinLength(1),
+			survey.Required,
+		))); err != nil {
+			return err
+		}
+		prompted.Record(opts.Name, pool.GetName())
+	}
+
+	if !opts.IsFlagSet(FlagDescription) {
+		if err := opts.Ask(&survey.Input{
+			Message: "Description",
+			Help:    "A short, memorable, description for this worker pool.",
+			Default: pool.GetDescription(),
+		}, &opts.Description.Value); err != nil {
+			return err
+		}
+		prompted.Record(opts.Description, pool.GetDescription())
+	}
+
+	opts.IsFlagSet = prompted.IsSet
+	return nil
+}
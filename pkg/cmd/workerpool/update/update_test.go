@@ -0,0 +1,62 @@
+package update_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/workerpool/update"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOptions(flags *update.UpdateFlags, given ...string) *update.UpdateOptions {
+	opts := update.NewUpdateOptions(flags, &cmd.Dependencies{}, func(name string) bool {
+		for _, g := range given {
+			if g == name {
+				return true
+			}
+		}
+		return false
+	})
+	opts.GetDynamicWorkerPoolTypesCallback = func() ([]*workerpools.DynamicWorkerPoolType, error) {
+		return []*workerpools.DynamicWorkerPoolType{
+			{ID: "Ubuntu2204", Type: "Ubuntu2204", Description: "Ubuntu 22.04"},
+			{ID: "Windows2022", Type: "Windows2022", Description: "Windows Server 2022"},
+		}, nil
+	}
+	return opts
+}
+
+func TestApplyFlags_OnlyChangesGivenFlags(t *testing.T) {
+	pool := workerpools.NewDynamicWorkerPool("Hosted Ubuntu", "Ubuntu2204")
+	pool.Description = "Hosted workers"
+	flags := update.NewUpdateFlags()
+	flags.Name.Value = "Hosted Windows"
+	flags.WorkerType.Value = "windows2022"
+
+	err := update.ApplyFlags(newOptions(flags, update.FlagName, update.FlagWorkerType), pool)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Hosted Windows", pool.Name)
+	assert.Equal(t, "Hosted workers", pool.Description)
+	assert.Equal(t, "Windows2022", pool.WorkerType)
+	assert.False(t, pool.IsDefault)
+}
+
+func TestApplyFlags_Problems(t *testing.T) {
+	flags := update.NewUpdateFlags()
+	flags.WorkerType.Value = "Ubuntu2204"
+	static := workerpools.NewStaticWorkerPool("Windows workers")
+	assert.EqualError(t, update.ApplyFlags(newOptions(flags, update.FlagWorkerType), static),
+		"--worker-type can only be given for a dynamic worker pool, and 'Windows workers' is a static worker pool")
+
+	flags.WorkerType.Value = "Debian"
+	assert.EqualError(t, update.ApplyFlags(newOptions(flags, update.FlagWorkerType), workerpools.NewDynamicWorkerPool("Hosted", "Ubuntu2204")),
+		"unknown worker type 'Debian', must be one of Ubuntu2204, Windows2022")
+
+	static.IsDefault = true
+	assert.EqualError(t, update.ApplyFlags(newOptions(update.NewUpdateFlags(), update.FlagDefault), static),
+		"worker pool 'Windows workers' is the default worker pool; make another worker pool the default instead")
+
+	assert.EqualError(t, update.ApplyFlags(newOptions(update.NewUpdateFlags()), static), "nothing to update, give the settings to change as flags")
+}
@@ -0,0 +1,140 @@
+package usages
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/workerpool/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"github.com/spf13/cobra"
+)
+
+const FlagWorkerPool = "worker-pool"
+
+type UsagesFlags struct {
+	WorkerPool *flag.Flag[string]
+}
+
+func NewUsagesFlags() *UsagesFlags {
+	return &UsagesFlags{
+		WorkerPool: flag.New[string](FlagWorkerPool, false),
+	}
+}
+
+type UsagesOptions struct {
+	*UsagesFlags
+	*cmd.Dependencies
+	*shared.GetWorkerPoolsOptions
+	Command              *cobra.Command
+	GetProcessesCallback shared.GetProcessesCallback
+}
+
+func NewUsagesOptions(flags *UsagesFlags, dependencies *cmd.Dependencies, command *cobra.Command) *UsagesOptions {
+	return &UsagesOptions{
+		UsagesFlags:           flags,
+		Dependencies:          dependencies,
+		GetWorkerPoolsOptions: shared.NewGetWorkerPoolsOptions(dependencies),
+		Command:               command,
+		GetProcessesCallback: func() ([]*shared.Process, error) {
+			return shared.GetProcesses(dependencies.Client, dependencies.Space.GetID())
+		},
+	}
+}
+
+func NewCmdUsages(f factory.Factory) *cobra.Command {
+	usagesFlags := NewUsagesFlags()
+
+	cmd := &cobra.Command{
+		Use:   "usage [<name> | <id>]",
+		Short: "List the steps which run on worker pools",
+		Long: heredoc.Doc(`
+			List the deployment process and runbook steps which run on a worker pool in Octopus Deploy,
+			so you can see what is affected before changing or migrating the pool. When no worker pool
+			is given, the steps of every worker pool are listed.
+
+			A step runs on a pool when it names the pool, when it names a worker pool variable which
+			can have the pool as its value, or when it runs on a worker without naming a pool and the
+			pool is the default worker pool. Version controlled deployment processes are read from the
+			default branch of the project.
+		`),
+		Example: heredoc.Docf(`
+			%[1]s worker-pool usage
+			%[1]s worker-pool usage "Windows workers"
+			%[1]s worker-pool usage --worker-pool WorkerPools-2 --output-format json
+		`, constants.ExecutableName),
+		Aliases: []string{"usages"},
+		Args:    usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if usagesFlags.WorkerPool.Value == "" && len(args) > 0 {
+				usagesFlags.WorkerPool.Value = args[0]
+			}
+
+			return UsagesRun(NewUsagesOptions(usagesFlags, cmd.NewDependencies(f, c), c))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&usagesFlags.WorkerPool.Value, usagesFlags.WorkerPool.Name, "", "Name or ID of the worker pool, all worker pools when not given")
+
+	return cmd
+}
+
+func UsagesRun(opts *UsagesOptions) error {
+	var pools []workerpools.IWorkerPool
+	if opts.WorkerPool.Value != "" {
+		pool, err := opts.GetWorkerPoolCallback(opts.WorkerPool.Value)
+		if err != nil {
+			return err
+		}
+		pools = []workerpools.IWorkerPool{pool}
+	} else {
+		allPools, err := opts.GetWorkerPoolsCallback()
+		if err != nil {
+			return err
+		}
+		for _, p := range allPools {
+			pool, err := opts.GetWorkerPoolCallback(p.ID)
+			if err != nil {
+				return err
+			}
+			pools = append(pools, pool)
+		}
+	}
+
+	processes, err := opts.GetProcessesCallback()
+	if err != nil {
+		return err
+	}
+	usages := shared.FindUsages(pools, processes)
+
+	outputFormat, _ := opts.Command.Flags().GetString(constants.FlagOutputFormat)
+	if len(usages) == 0 && !constants.IsProgrammaticOutputFormat(outputFormat) {
+		if len(pools) == 1 {
+			_, err = fmt.Fprintf(opts.Out, "Worker pool '%s' is not used by any steps.\n", pools[0].GetName())
+		} else {
+			_, err = fmt.Fprintln(opts.Out, "No worker pools are used by any steps.")
+		}
+		return err
+	}
+
+	return output.PrintArray(usages, opts.Command, output.Mappers[*shared.Usage]{
+		Json: func(u *shared.Usage) any {
+			return u
+		},
+		Table: output.TableDefinition[*shared.Usage]{
+			Header: []string{"WORKER POOL", "KIND", "PROJECT", "RUNBOOK", "STEP", "VIA"},
+			Row: func(u *shared.Usage) []string {
+				return []string{u.WorkerPool, u.Kind, output.Bold(u.Project), u.Runbook, u.Step, output.Dim(u.Via)}
+			},
+		},
+		Basic: func(u *shared.Usage) string {
+			return fmt.Sprintf("%s: %s", u.WorkerPool, shared.DescribeUsage(u))
+		},
+	})
+}
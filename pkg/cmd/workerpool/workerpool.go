@@ -6,7 +6,10 @@ import (
 	dynamicCmd "github.com/OctopusDeploy/cli/pkg/cmd/workerpool/dynamic"
 	listCmd "github.com/OctopusDeploy/cli/pkg/cmd/workerpool/list"
 	staticCmd "github.com/OctopusDeploy/cli/pkg/cmd/workerpool/static"
+	updateCmd "github.com/OctopusDeploy/cli/pkg/cmd/workerpool/update"
+	usagesCmd "github.com/OctopusDeploy/cli/pkg/cmd/workerpool/usages"
 	viewCmd "github.com/OctopusDeploy/cli/pkg/cmd/workerpool/view"
+	workersCmd "github.com/OctopusDeploy/cli/pkg/cmd/workerpool/workers"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
@@ -32,6 +35,9 @@ func NewCmdWorkerPool(f factory.Factory) *cobra.Command {
 	cmd.AddCommand(viewCmd.NewCmdView(f))
 	cmd.AddCommand(staticCmd.NewCmdStatic(f))
 	cmd.AddCommand(dynamicCmd.NewCmdSsh(f))
+	cmd.AddCommand(updateCmd.NewCmdUpdate(f))
+	cmd.AddCommand(workersCmd.NewCmdWorkers(f))
+	cmd.AddCommand(usagesCmd.NewCmdUsages(f))
 
 	return cmd
 }
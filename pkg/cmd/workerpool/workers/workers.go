@@ -0,0 +1,147 @@
+package workers
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/workerpool/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"github.com/spf13/cobra"
+)
+
+const FlagWorkerPool = "worker-pool"
+
+type WorkersFlags struct {
+	WorkerPool     *flag.Flag[string]
+	HealthStatuses *flag.Flag[[]string]
+}
+
+func NewWorkersFlags() *WorkersFlags {
+	return &WorkersFlags{
+		WorkerPool:     flag.New[string](FlagWorkerPool, false),
+		HealthStatuses: flag.New[[]string](machinescommon.FlagHealthStatus, false),
+	}
+}
+
+type WorkersOptions struct {
+	*WorkersFlags
+	*cmd.Dependencies
+	*shared.GetWorkerPoolsOptions
+	Command            *cobra.Command
+	GetWorkersCallback func(pool workerpools.IWorkerPool) ([]*machines.Worker, error)
+}
+
+func NewWorkersOptions(flags *WorkersFlags, dependencies *cmd.Dependencies, command *cobra.Command) *WorkersOptions {
+	return &WorkersOptions{
+		WorkersFlags:          flags,
+		Dependencies:          dependencies,
+		GetWorkerPoolsOptions: shared.NewGetWorkerPoolsOptions(dependencies),
+		Command:               command,
+		GetWorkersCallback: func(pool workerpools.IWorkerPool) ([]*machines.Worker, error) {
+			return dependencies.Client.WorkerPools.GetWorkers(pool)
+		},
+	}
+}
+
+func NewCmdWorkers(f factory.Factory) *cobra.Command {
+	workersFlags := NewWorkersFlags()
+
+	cmd := &cobra.Command{
+		Use:   "workers [<name> | <id>]",
+		Short: "List the workers in a worker pool",
+		Long:  "List the workers in a worker pool in Octopus Deploy, with their health",
+		Example: heredoc.Docf(`
+			%[1]s worker-pool workers "Windows workers"
+			%[1]s worker-pool workers --worker-pool WorkerPools-2 --health-status Unhealthy --health-status Unavailable
+		`, constants.ExecutableName),
+		Args: usage.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if workersFlags.WorkerPool.Value == "" && len(args) > 0 {
+				workersFlags.WorkerPool.Value = args[0]
+			}
+
+			return WorkersRun(NewWorkersOptions(workersFlags, cmd.NewDependencies(f, c), c))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&workersFlags.WorkerPool.Value, workersFlags.WorkerPool.Name, "", "Name or ID of the worker pool")
+	flags.StringArrayVar(&workersFlags.HealthStatuses.Value, workersFlags.HealthStatuses.Name, nil, fmt.Sprintf("Only list workers with this health status: %s", output.FormatAsList(machinescommon.HealthStatuses)))
+
+	return cmd
+}
+
+type WorkerAsJson struct {
+	Id                 string `json:"Id"`
+	Name               string `json:"Name"`
+	CommunicationStyle string `json:"CommunicationStyle"`
+	HealthStatus       string `json:"HealthStatus"`
+	StatusSummary      string `json:"StatusSummary"`
+	IsDisabled         bool   `json:"IsDisabled"`
+}
+
+func WorkersRun(opts *WorkersOptions) error {
+	healthStatuses, err := machinescommon.ParseHealthStatuses(opts.HealthStatuses.Value)
+	if err != nil {
+		return err
+	}
+
+	pool, err := shared.ResolveWorkerPool(opts.Ask, opts.NoPrompt, opts.WorkerPool.Value, "Select the worker pool to list the workers of", opts.GetWorkerPoolsOptions)
+	if err != nil {
+		return err
+	}
+	if pool.GetWorkerPoolType() == workerpools.WorkerPoolTypeDynamic {
+		return fmt.Errorf("worker pool '%s' is a dynamic worker pool, whose workers are provided by Octopus on demand, so it has no workers to list", pool.GetName())
+	}
+
+	workers, err := opts.GetWorkersCallback(pool)
+	if err != nil {
+		return err
+	}
+	if len(healthStatuses) > 0 {
+		workers = util.SliceFilter(workers, func(w *machines.Worker) bool { return util.SliceContains(healthStatuses, w.HealthStatus) })
+	}
+
+	outputFormat, _ := opts.Command.Flags().GetString(constants.FlagOutputFormat)
+	if len(workers) == 0 && !constants.IsProgrammaticOutputFormat(outputFormat) {
+		_, err = fmt.Fprintf(opts.Out, "Worker pool '%s' has no matching workers.\n", pool.GetName())
+		return err
+	}
+
+	return output.PrintArray(workers, opts.Command, output.Mappers[*machines.Worker]{
+		Json: func(w *machines.Worker) any {
+			return WorkerAsJson{
+				Id:                 w.GetID(),
+				Name:               w.Name,
+				CommunicationStyle: machinescommon.GetCommunicationStyle(w.Endpoint),
+				HealthStatus:       w.HealthStatus,
+				StatusSummary:      w.StatusSummary,
+				IsDisabled:         w.IsDisabled,
+			}
+		},
+		Table: output.TableDefinition[*machines.Worker]{
+			Header: []string{"NAME", "TYPE", "HEALTH", "DISABLED", "SUMMARY"},
+			Row: func(w *machines.Worker) []string {
+				return []string{
+					output.Bold(w.Name),
+					machinescommon.DescribeCommunicationStyle(w.Endpoint, machinescommon.CommunicationStyleToDescriptionMap),
+					machinescommon.DescribeHealthStatus(w.HealthStatus),
+					fmt.Sprintf("%t", w.IsDisabled),
+					output.Dim(w.StatusSummary),
+				}
+			},
+		},
+		Basic: func(w *machines.Worker) string {
+			return fmt.Sprintf("%s: %s", w.Name, w.HealthStatus)
+		},
+	})
+}
@@ -77,3 +77,16 @@ func TestDescribeCommunicationStyle(t *testing.T) {
 	assert.Equal(t, "KubernetesTentacle", machinescommon.DescribeCommunicationStyle(agent, machinescommon.CommunicationStyleToDescriptionMap))
 	assert.Equal(t, "Unknown", machinescommon.DescribeCommunicationStyle(nil, machinescommon.CommunicationStyleToDescriptionMap))
 }
+
+func TestPollingSubscriptionUri(t *testing.T) {
+	uri, err := machinescommon.PollingSubscriptionUri(" abcdefghij1234567890 ")
+	assert.NoError(t, err)
+	assert.Equal(t, "poll://abcdefghij1234567890/", uri.String())
+
+	uri, err = machinescommon.PollingSubscriptionUri("poll://abcdefghij1234567890/")
+	assert.NoError(t, err)
+	assert.Equal(t, "poll://abcdefghij1234567890/", uri.String())
+
+	_, err = machinescommon.PollingSubscriptionUri("https://tentacle:10933")
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+package machinescommon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagHealthStatus       = "health-status"
+	FlagCommunicationStyle = "communication-style"
+	FlagDisabled           = "disabled"
+	FlagPartialName        = "name"
+)
+
+// HealthStatuses are the health statuses a deployment target or worker can have.
+var HealthStatuses = []string{"Healthy", "HasWarnings", "Unhealthy", "Unavailable", "Unknown"}
+
+// MachineFilterFlags are the flags for filtering deployment targets and workers which the server
+// can filter on for both.
+type MachineFilterFlags struct {
+	HealthStatuses      *flag.Flag[[]string]
+	CommunicationStyles *flag.Flag[[]string]
+	Disabled            *flag.Flag[bool]
+	PartialName         *flag.Flag[string]
+	// DisabledSet is whether --disabled was given, as only then does it filter anything.
+	DisabledSet bool
+}
+
+func NewMachineFilterFlags() *MachineFilterFlags {
+	return &MachineFilterFlags{
+		HealthStatuses:      flag.New[[]string](FlagHealthStatus, false),
+		CommunicationStyles: flag.New[[]string](FlagCommunicationStyle, false),
+		Disabled:            flag.New[bool](FlagDisabled, false),
+		PartialName:         flag.New[string](FlagPartialName, false),
+	}
+}
+
+func RegisterMachineFilterFlags(cmd *cobra.Command, flags *MachineFilterFlags, description string) {
+	cmd.Flags().StringArrayVar(&flags.HealthStatuses.Value, flags.HealthStatuses.Name, nil, fmt.Sprintf("Only list %ss with this health status: %s", description, strings.Join(HealthStatuses, ", ")))
+	cmd.Flags().StringArrayVar(&flags.CommunicationStyles.Value, flags.CommunicationStyles.Name, nil, fmt.Sprintf("Only list %ss with this communication style, such as TentaclePassive, TentacleActive or Ssh", description))
+	cmd.Flags().BoolVar(&flags.Disabled.Value, flags.Disabled.Name, false, fmt.Sprintf("Only list disabled %[1]ss, or only enabled %[1]ss with --%[2]s=false", description, FlagDisabled))
+	cmd.Flags().StringVarP(&flags.PartialName.Value, flags.PartialName.Name, "n", "", fmt.Sprintf("Only list %ss whose name contains this text", description))
+}
+
+// ParseHealthStatuses checks the health statuses given, and returns them as the server expects them.
+func ParseHealthStatuses(values []string) ([]string, error) {
+	var statuses []string
+	for _, value := range values {
+		status, ok := FindIgnoringCase(HealthStatuses, value)
+		if !ok {
+			return nil, fmt.Errorf("unknown health status '%s', must be one of %s", value, strings.Join(HealthStatuses, ", "))
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// ParseCommunicationStyles checks the communication styles given, which may be given by the name
+// the server uses or by their description, and returns them as the server expects them.
+func ParseCommunicationStyles(values []string) ([]string, error) {
+	var styles []string
+	for _, value := range values {
+		style, ok := findCommunicationStyle(value)
+		if !ok {
+			names := make([]string, 0, len(CommunicationStyleToDescriptionMap))
+			for name := range CommunicationStyleToDescriptionMap {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown communication style '%s', must be one of %s", value, strings.Join(names, ", "))
+		}
+		styles = append(styles, style)
+	}
+	return styles, nil
+}
+
+func findCommunicationStyle(value string) (string, bool) {
+	for style, description := range CommunicationStyleToDescriptionMap {
+		if strings.EqualFold(style, value) || strings.EqualFold(description, value) {
+			return style, true
+		}
+	}
+	return "", false
+}
+
+// IncludeDisabledState reports whether a machine which is or is not disabled is wanted. The server
+// can only filter for disabled machines, so enabled ones are filtered out afterwards.
+func (f *MachineFilterFlags) IncludeDisabledState(isDisabled bool) bool {
+	return !f.DisabledSet || f.Disabled.Value == isDisabled
+}
+
+// ResolveIDs turns names or IDs into IDs, using a map of IDs to names.
+func ResolveIDs(identifiers []string, lookup map[string]string, description string) ([]string, error) {
+	var ids []string
+loop:
+	for _, identifier := range identifiers {
+		for id, name := range lookup {
+			if strings.EqualFold(id, identifier) || strings.EqualFold(name, identifier) {
+				ids = append(ids, id)
+				continue loop
+			}
+		}
+		return nil, fmt.Errorf("cannot find %s '%s'", description, identifier)
+	}
+	return ids, nil
+}
+
+// FindIgnoringCase finds a value, ignoring case, and returns it as it appears in values.
+func FindIgnoringCase(values []string, value string) (string, bool) {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return v, true
+		}
+	}
+	return "", false
+}
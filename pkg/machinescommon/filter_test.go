@@ -0,0 +1,49 @@
+package machinescommon_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHealthStatuses_IgnoresCase(t *testing.T) {
+	statuses, err := machinescommon.ParseHealthStatuses([]string{"unhealthy", "HASWARNINGS"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Unhealthy", "HasWarnings"}, statuses)
+}
+
+func TestParseHealthStatuses_Unknown(t *testing.T) {
+	_, err := machinescommon.ParseHealthStatuses([]string{"Sick"})
+
+	assert.EqualError(t, err, "unknown health status 'Sick', must be one of Healthy, HasWarnings, Unhealthy, Unavailable, Unknown")
+}
+
+func TestParseCommunicationStyles_ByNameOrDescription(t *testing.T) {
+	styles, err := machinescommon.ParseCommunicationStyles([]string{"ssh", "Listening Tentacle", "cloud region"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Ssh", "TentaclePassive", "None"}, styles)
+}
+
+func TestResolveIDs(t *testing.T) {
+	lookup := map[string]string{"Environments-1": "Production", "Environments-2": "Staging"}
+
+	ids, err := machinescommon.ResolveIDs([]string{"staging", "Environments-1"}, lookup, "environment")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Environments-2", "Environments-1"}, ids)
+
+	_, err = machinescommon.ResolveIDs([]string{"Test"}, lookup, "environment")
+	assert.EqualError(t, err, "cannot find environment 'Test'")
+}
+
+func TestIncludeDisabledState(t *testing.T) {
+	flags := machinescommon.NewMachineFilterFlags()
+	assert.True(t, flags.IncludeDisabledState(true))
+	assert.True(t, flags.IncludeDisabledState(false))
+
+	flags.DisabledSet = true
+	assert.False(t, flags.IncludeDisabledState(true))
+	assert.True(t, flags.IncludeDisabledState(false))
+}
@@ -0,0 +1,153 @@
+package machinescommon
+
+import (
+	"fmt"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagWait    = "wait"
+	FlagTimeout = "timeout"
+
+	// DefaultHealthCheckTimeout and DefaultHealthCheckMachineTimeout are the timeouts the web portal
+	// gives a health check, as a .NET TimeSpan.
+	DefaultHealthCheckTimeout        = "00:05:00"
+	DefaultHealthCheckMachineTimeout = "00:01:00"
+)
+
+// MaintenanceTask is a server task which acts on deployment targets or workers.
+type MaintenanceTask struct {
+	// Name is the name of the server task.
+	Name string
+	// Title describes the task in messages, such as "health check".
+	Title string
+	// Arguments are the arguments of the task besides the machines it acts on.
+	Arguments map[string]any
+}
+
+var (
+	HealthCheckTask = &MaintenanceTask{
+		Name:  "Health",
+		Title: "health check",
+		Arguments: map[string]any{
+			"Timeout":        DefaultHealthCheckTimeout,
+			"MachineTimeout": DefaultHealthCheckMachineTimeout,
+		},
+	}
+	UpgradeTentacleTask = &MaintenanceTask{Name: "Upgrade", Title: "Tentacle upgrade"}
+	UpdateCalamariTask  = &MaintenanceTask{Name: "UpdateCalamari", Title: "Calamari update"}
+)
+
+// MachineHealth is the health of a deployment target or worker once a task has finished.
+type MachineHealth struct {
+	Id            string `json:"Id"`
+	Name          string `json:"Name"`
+	HealthStatus  string `json:"HealthStatus"`
+	StatusSummary string `json:"StatusSummary"`
+}
+
+type MaintenanceFlags struct {
+	Wait    *flag.Flag[bool]
+	Timeout *flag.Flag[int]
+}
+
+func NewMaintenanceFlags() *MaintenanceFlags {
+	return &MaintenanceFlags{
+		Wait:    flag.New[bool](FlagWait, false),
+		Timeout: flag.New[int](FlagTimeout, false),
+	}
+}
+
+func RegisterMaintenanceFlags(cmd *cobra.Command, flags *MaintenanceFlags, defaultTimeout int) {
+	cmd.Flags().BoolVar(&flags.Wait.Value, flags.Wait.Name, false, "Wait for the task to finish, and show the health of each machine")
+	cmd.Flags().IntVar(&flags.Timeout.Value, flags.Timeout.Name, defaultTimeout, "Time in seconds to wait for the task to finish")
+}
+
+type MaintenanceOptions struct {
+	*MaintenanceFlags
+	*cmd.Dependencies
+	Command *cobra.Command
+	Task    *MaintenanceTask
+	// Description is the kind of machine the task acts on, such as "deployment target".
+	Description       string
+	StartTaskCallback func(task *tasks.Task) (*tasks.Task, error)
+	WaitCallback      func(taskID string) error
+	GetHealthCallback func(machineIDs []string) ([]*MachineHealth, error)
+}
+
+// NewMaintenanceServerTask is the server task which runs a maintenance task on some machines.
+func NewMaintenanceServerTask(task *MaintenanceTask, spaceID string, machineIDs []string, description string) *tasks.Task {
+	serverTask := tasks.NewTask()
+	serverTask.Name = task.Name
+	serverTask.SpaceID = spaceID
+	serverTask.Description = description
+	for k, v := range task.Arguments {
+		serverTask.Arguments[k] = v
+	}
+	serverTask.Arguments["MachineIds"] = machineIDs
+	return serverTask
+}
+
+// RunMaintenance queues a maintenance task for some machines, and when asked to, waits for it and
+// shows the health of each machine afterwards.
+func RunMaintenance(opts *MaintenanceOptions, machineIDs []string) error {
+	if len(machineIDs) == 0 {
+		return fmt.Errorf("no %ss were selected", opts.Description)
+	}
+
+	description := fmt.Sprintf("Run %s on %d %s(s)", opts.Task.Title, len(machineIDs), opts.Description)
+	started, err := opts.StartTaskCallback(NewMaintenanceServerTask(opts.Task, opts.Space.GetID(), machineIDs, description))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(opts.Out, "Queued %s of %d %s(s) %s.\n", opts.Task.Title, len(machineIDs), opts.Description, output.Dimf("(%s)", started.GetID()))
+
+	if !opts.Wait.Value {
+		fmt.Fprintf(opts.Out, "Use '%s task wait %s' to wait for it to finish.\n", constants.ExecutableName, started.GetID())
+		return nil
+	}
+
+	waitErr := opts.WaitCallback(started.GetID())
+	health, err := opts.GetHealthCallback(machineIDs)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(opts.Out)
+	err = output.PrintArray(health, opts.Command, output.Mappers[*MachineHealth]{
+		Json: func(h *MachineHealth) any {
+			return h
+		},
+		Table: output.TableDefinition[*MachineHealth]{
+			Header: []string{"NAME", "HEALTH STATUS", "STATUS SUMMARY"},
+			Row: func(h *MachineHealth) []string {
+				return []string{output.Bold(h.Name), DescribeHealthStatus(h.HealthStatus), h.StatusSummary}
+			},
+		},
+		Basic: func(h *MachineHealth) string {
+			return fmt.Sprintf("%s: %s", h.Name, h.HealthStatus)
+		},
+	})
+	if waitErr != nil {
+		return waitErr
+	}
+	return err
+}
+
+// DescribeHealthStatus colours a health status by how healthy it is.
+func DescribeHealthStatus(status string) string {
+	switch status {
+	case "Healthy":
+		return output.Green(status)
+	case "HasWarnings":
+		return output.Yellow(status)
+	case "Unhealthy", "Unavailable":
+		return output.Red(status)
+	}
+	return status
+}
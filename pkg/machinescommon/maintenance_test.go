@@ -0,0 +1,55 @@
+package machinescommon_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMaintenanceOptions(noPrompt bool) *machinescommon.MaintenanceOptions {
+	return &machinescommon.MaintenanceOptions{
+		MaintenanceFlags: machinescommon.NewMaintenanceFlags(),
+		Dependencies:     &cmd.Dependencies{NoPrompt: noPrompt, Out: &bytes.Buffer{}, Space: spaces.NewSpace("Default")},
+		Task:             machinescommon.HealthCheckTask,
+		Description:      "deployment target",
+	}
+}
+
+func TestNewMaintenanceServerTask(t *testing.T) {
+	task := machinescommon.NewMaintenanceServerTask(machinescommon.HealthCheckTask, "Spaces-1", []string{"Machines-1"}, "Run health check")
+
+	assert.Equal(t, "Health", task.Name)
+	assert.Equal(t, "Spaces-1", task.SpaceID)
+	assert.Equal(t, "Run health check", task.Description)
+	assert.Equal(t, map[string]any{
+		"Timeout":        machinescommon.DefaultHealthCheckTimeout,
+		"MachineTimeout": machinescommon.DefaultHealthCheckMachineTimeout,
+		"MachineIds":     []string{"Machines-1"},
+	}, task.Arguments)
+	assert.NotContains(t, machinescommon.HealthCheckTask.Arguments, "MachineIds")
+}
+
+func TestRunMaintenance_WithoutWait(t *testing.T) {
+	opts := newTestMaintenanceOptions(true)
+	var started *tasks.Task
+	opts.StartTaskCallback = func(task *tasks.Task) (*tasks.Task, error) {
+		started = task
+		task.ID = "ServerTasks-1"
+		return task, nil
+	}
+	opts.WaitCallback = func(taskID string) error {
+		assert.Fail(t, "should not wait without --wait")
+		return nil
+	}
+
+	err := machinescommon.RunMaintenance(opts, []string{"Machines-1", "Machines-2"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Run health check on 2 deployment target(s)", started.Description)
+	assert.Contains(t, opts.Out.(*bytes.Buffer).String(), "task wait ServerTasks-1")
+}
@@ -0,0 +1,35 @@
+package machinescommon
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	FlagSubscriptionId = "subscription-id"
+
+	pollingScheme = "poll"
+)
+
+var subscriptionIdPattern = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+// PollingSubscriptionUri turns the subscription ID a Polling Tentacle was registered with into the
+// URI the server expects, such as poll://abcdefghijklmnopqrst/. The URI itself is also accepted.
+func PollingSubscriptionUri(subscriptionId string) (*url.URL, error) {
+	id := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(subscriptionId), pollingScheme+"://"), "/")
+	if !subscriptionIdPattern.MatchString(id) {
+		return nil, fmt.Errorf("'%s' is not a valid subscription ID, it should be letters and numbers, such as the ID shown by 'Tentacle show-configuration'", subscriptionId)
+	}
+	return &url.URL{Scheme: pollingScheme, Host: id, Path: "/"}, nil
+}
+
+// ValidateSubscriptionId is a survey validator for subscription IDs.
+func ValidateSubscriptionId(val interface{}) error {
+	if str, ok := val.(string); ok {
+		_, err := PollingSubscriptionUri(str)
+		return err
+	}
+	return fmt.Errorf("cannot validate a subscription ID of type %T", val)
+}
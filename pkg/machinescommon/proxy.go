@@ -63,21 +63,22 @@ func RegisterCreateTargetProxyFlags(cmd *cobra.Command, proxyFlags *CreateTarget
 }
 
 func FindProxy(opts *CreateTargetProxyOptions, flags *CreateTargetProxyFlags) (*proxies.Proxy, error) {
-	allProxy, err := opts.Client.Proxies.GetAll()
+	return FindProxyByNameOrID(func() ([]*proxies.Proxy, error) {
+		return opts.Client.Proxies.GetAll()
+	}, flags.Proxy.Value)
+}
+
+func FindProxyByNameOrID(getAllProxiesCallback GetAllProxiesCallback, nameOrId string) (*proxies.Proxy, error) {
+	allProxy, err := getAllProxiesCallback()
 	if err != nil {
 		return nil, err
 	}
-	var proxy *proxies.Proxy
 	for _, p := range allProxy {
-		if strings.EqualFold(p.GetID(), flags.Proxy.Value) || strings.EqualFold(p.GetName(), flags.Proxy.Value) {
-			proxy = p
-			break
+		if strings.EqualFold(p.GetID(), nameOrId) || strings.EqualFold(p.GetName(), nameOrId) {
+			return p, nil
 		}
 	}
-	if proxy == nil {
-		return nil, fmt.Errorf("cannot find proxy '%s'", flags.Proxy.Value)
-	}
-	return proxy, nil
+	return nil, fmt.Errorf("cannot find proxy '%s'", nameOrId)
 }
 
 func getAllProxies(client client.Client) ([]*proxies.Proxy, error) {
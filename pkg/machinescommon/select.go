@@ -0,0 +1,55 @@
+package machinescommon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/question"
+)
+
+// MachineSelector picks deployment targets or workers to act on in bulk.
+type MachineSelector[T any] struct {
+	// Description is the kind of machine being selected, such as "deployment target".
+	Description string
+	// GetMachinesCallback fetches the machines matching the filter flags given, or all machines
+	// when there are none.
+	GetMachinesCallback func() ([]T, error)
+	GetID               func(T) string
+	GetName             func(T) string
+}
+
+// Select picks the machines named, or else all those matching the filter flags given, or else
+// those chosen when prompting.
+func (s *MachineSelector[T]) Select(ask question.Asker, noPrompt bool, message string, identifiers []string, filtered bool) ([]T, error) {
+	if len(identifiers) == 0 && !filtered && noPrompt {
+		return nil, fmt.Errorf("the %ss must be given by name or ID, or selected with the filter flags", s.Description)
+	}
+
+	machines, err := s.GetMachinesCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(identifiers) > 0 {
+		var selected []T
+	loop:
+		for _, identifier := range identifiers {
+			for _, m := range machines {
+				if strings.EqualFold(s.GetID(m), identifier) || strings.EqualFold(s.GetName(m), identifier) {
+					selected = append(selected, m)
+					continue loop
+				}
+			}
+			return nil, fmt.Errorf("cannot find a %s with name or ID of '%s'", s.Description, identifier)
+		}
+		return selected, nil
+	}
+
+	if filtered {
+		if len(machines) == 0 {
+			return nil, fmt.Errorf("no %ss match the filters given", s.Description)
+		}
+		return machines, nil
+	}
+	return question.MultiSelectMap(ask, message, machines, s.GetName, true)
+}
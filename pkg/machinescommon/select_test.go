@@ -0,0 +1,54 @@
+package machinescommon_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/stretchr/testify/assert"
+)
+
+type testMachine struct {
+	id   string
+	name string
+}
+
+func newTestSelector(machines ...*testMachine) *machinescommon.MachineSelector[*testMachine] {
+	return &machinescommon.MachineSelector[*testMachine]{
+		Description:         "deployment target",
+		GetMachinesCallback: func() ([]*testMachine, error) { return machines, nil },
+		GetID:               func(m *testMachine) string { return m.id },
+		GetName:             func(m *testMachine) string { return m.name },
+	}
+}
+
+func TestMachineSelector_ByNameOrID(t *testing.T) {
+	web1, web2 := &testMachine{"Machines-1", "web-01"}, &testMachine{"Machines-2", "web-02"}
+
+	selected, err := newTestSelector(web1, web2).Select(nil, true, "", []string{"WEB-02", "Machines-1"}, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*testMachine{web2, web1}, selected)
+}
+
+func TestMachineSelector_Unknown(t *testing.T) {
+	_, err := newTestSelector(&testMachine{"Machines-1", "web-01"}).Select(nil, true, "", []string{"db-01"}, false)
+
+	assert.EqualError(t, err, "cannot find a deployment target with name or ID of 'db-01'")
+}
+
+func TestMachineSelector_AllFiltered(t *testing.T) {
+	web1, web2 := &testMachine{"Machines-1", "web-01"}, &testMachine{"Machines-2", "web-02"}
+
+	selected, err := newTestSelector(web1, web2).Select(nil, true, "", nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []*testMachine{web1, web2}, selected)
+
+	_, err = newTestSelector().Select(nil, true, "", nil, true)
+	assert.EqualError(t, err, "no deployment targets match the filters given")
+}
+
+func TestMachineSelector_NoPromptRequiresSelection(t *testing.T) {
+	_, err := newTestSelector(&testMachine{"Machines-1", "web-01"}).Select(nil, true, "", nil, false)
+
+	assert.EqualError(t, err, "the deployment targets must be given by name or ID, or selected with the filter flags")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/spf13/cobra"
+	"os"
 	"strconv"
 	"strings"
 )
@@ -41,11 +42,19 @@ type SshCommonFlags struct {
 	Account     *flag.Flag[string]
 	Runtime     *flag.Flag[string]
 	Platform    *flag.Flag[string]
+
+	DiscoverFingerprint        *flag.Flag[bool]
+	TrustDiscoveredFingerprint *flag.Flag[bool]
+	HostsFile                  *flag.Flag[string]
 }
 
 type SshCommonOptions struct {
 	*cmd.Dependencies
 	GetAllAccountsForSshMachine
+	DiscoverHostKeyCallback
+	ReadFileCallback func(name string) ([]byte, error)
+	// FingerprintDiscovered is whether the fingerprint of the host was discovered and trusted.
+	FingerprintDiscovered bool
 }
 
 func NewSshCommonFlags() *SshCommonFlags {
@@ -56,6 +65,10 @@ func NewSshCommonFlags() *SshCommonFlags {
 		Port:        flag.New[int](FlagPort, false),
 		Runtime:     flag.New[string](FlagRuntime, false),
 		Platform:    flag.New[string](FlagPlatform, false),
+
+		DiscoverFingerprint:        flag.New[bool](FlagDiscoverFingerprint, false),
+		TrustDiscoveredFingerprint: flag.New[bool](FlagTrustDiscoveredFingerprint, false),
+		HostsFile:                  flag.New[string](FlagHostsFile, false),
 	}
 }
 
@@ -65,6 +78,10 @@ func NewSshCommonOpts(dependencies *cmd.Dependencies) *SshCommonOptions {
 		GetAllAccountsForSshMachine: func() ([]accounts.IAccount, error) {
 			return getAllAccountsForSshMachine(dependencies.Client)
 		},
+		DiscoverHostKeyCallback: func(host string, port int) (*HostKey, error) {
+			return DiscoverHostKey(host, port, DefaultSshDialTimeout)
+		},
+		ReadFileCallback: os.ReadFile,
 	}
 }
 
@@ -75,6 +92,9 @@ func RegisterSshCommonFlags(cmd *cobra.Command, flags *SshCommonFlags, entityTyp
 	cmd.Flags().IntVar(&flags.Port.Value, flags.Port.Name, 0, fmt.Sprintf("The port to connect to the %s on.", entityType))
 	cmd.Flags().StringVar(&flags.Runtime.Value, flags.Runtime.Name, "", fmt.Sprintf("The runtime to use to run Calamari on the %s. Options are '%s' or '%s'", entityType, SelfContainedCalamari, MonoCalamari))
 	cmd.Flags().StringVar(&flags.Platform.Value, flags.Platform.Name, "", fmt.Sprintf("The platform to use for the %s Calamari. Options are '%s', '%s', '%s' or '%s'", SelfContainedCalamari, LinuxX64, LinuxArm64, LinuxArm, OsxX64))
+	cmd.Flags().BoolVar(&flags.DiscoverFingerprint.Value, flags.DiscoverFingerprint.Name, false, fmt.Sprintf("Connect to the %s to read its host fingerprint, which must be confirmed when prompting", entityType))
+	cmd.Flags().BoolVar(&flags.TrustDiscoveredFingerprint.Value, flags.TrustDiscoveredFingerprint.Name, false, fmt.Sprintf("Trust the host fingerprint discovered without confirming it, when not prompting and no fingerprint is given for the %s", entityType))
+	cmd.Flags().StringVar(&flags.HostsFile.Value, flags.HostsFile.Name, "", fmt.Sprintf("Create a %s for each line of this file, of the form host[:port] [name] [fingerprint]", entityType))
 
 }
 
@@ -109,6 +129,10 @@ func PromptForSshEndpoint(opts *SshCommonOptions, flags *SshCommonFlags, entityT
 		}
 	}
 
+	if err := ResolveSshFingerprint(opts, flags); err != nil {
+		return err
+	}
+
 	if flags.Fingerprint.Value == "" {
 		if err := opts.Ask(&survey.Input{
 			Message: "Host fingerprint",
@@ -0,0 +1,259 @@
+package machinescommon
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	FlagDiscoverFingerprint        = "discover-fingerprint"
+	FlagTrustDiscoveredFingerprint = "trust-discovered-fingerprint"
+	FlagHostsFile                  = "hosts-file"
+
+	DefaultSshDialTimeout = 10 * time.Second
+)
+
+// HostKeyAlgorithms are the host key algorithms in the order Octopus prefers them, so that the
+// fingerprint discovered is that of the key Octopus is given when it connects.
+var HostKeyAlgorithms = []string{
+	ssh.KeyAlgoED25519,
+	ssh.KeyAlgoECDSA256,
+	ssh.KeyAlgoECDSA384,
+	ssh.KeyAlgoECDSA521,
+	ssh.KeyAlgoRSASHA512,
+	ssh.KeyAlgoRSASHA256,
+	ssh.KeyAlgoRSA,
+}
+
+var errHostKeyCaptured = errors.New("host key captured")
+
+// HostKey is the host key an SSH server presented, with its fingerprint as Octopus expects it.
+type HostKey struct {
+	Address     string
+	KeyType     string
+	Fingerprint string
+}
+
+type DiscoverHostKeyCallback func(host string, port int) (*HostKey, error)
+
+// DiscoverHostKey connects to an SSH server and reads its host key. The connection is closed
+// once the key has been exchanged, so no credentials are needed.
+func DiscoverHostKey(host string, port int, timeout time.Duration) (*HostKey, error) {
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to %s: %w", address, err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	var hostKey *HostKey
+	config := &ssh.ClientConfig{
+		HostKeyAlgorithms: HostKeyAlgorithms,
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			hostKey = &HostKey{Address: address, KeyType: key.Type(), Fingerprint: FormatFingerprint(key)}
+			return errHostKeyCaptured
+		},
+		Timeout: timeout,
+	}
+	_, _, _, err = ssh.NewClientConn(conn, address, config)
+	if hostKey != nil {
+		return hostKey, nil
+	}
+	return nil, fmt.Errorf("the SSH handshake with %s failed: %w", address, err)
+}
+
+// FormatFingerprint is the SHA256 fingerprint of a host key, as Octopus shows it.
+func FormatFingerprint(key ssh.PublicKey) string {
+	return strings.TrimPrefix(ssh.FingerprintSHA256(key), "SHA256:")
+}
+
+// DiscoverFingerprint reads the fingerprint of an SSH host. When a fingerprint was already given
+// the host must present a key with that fingerprint. Otherwise the host key is trusted on first
+// use, which must be confirmed when prompting, or opted into with trustDiscovered when not.
+func DiscoverFingerprint(opts *SshCommonOptions, host string, port int, expected string, trustDiscovered bool) (string, error) {
+	hostKey, err := opts.DiscoverHostKeyCallback(host, port)
+	if err != nil {
+		return "", err
+	}
+	if expected != "" {
+		if strings.TrimPrefix(expected, "SHA256:") != hostKey.Fingerprint {
+			return "", fmt.Errorf("the %s host key of %s has the fingerprint %s, not %s", hostKey.KeyType, hostKey.Address, hostKey.Fingerprint, expected)
+		}
+		return expected, nil
+	}
+
+	fmt.Fprintf(opts.Out, "The %s host key of %s has the fingerprint %s\n", hostKey.KeyType, hostKey.Address, output.Bold(hostKey.Fingerprint))
+	if !opts.NoPrompt {
+		trusted := false
+		if err := opts.Ask(&survey.Confirm{
+			Message: fmt.Sprintf("Do you trust the host key of %s?", hostKey.Address),
+			Default: false,
+		}, &trusted); err != nil {
+			return "", err
+		}
+		if !trusted {
+			return "", fmt.Errorf("the host key of %s was not trusted", hostKey.Address)
+		}
+	} else if !trustDiscovered {
+		return "", fmt.Errorf("the host key of %s cannot be confirmed without prompting, so give its fingerprint, or use --%s to trust it anyway", hostKey.Address, FlagTrustDiscoveredFingerprint)
+	}
+	return hostKey.Fingerprint, nil
+}
+
+// ResolveSshFingerprint discovers the fingerprint of the host given by the flags, when asked to.
+func ResolveSshFingerprint(opts *SshCommonOptions, flags *SshCommonFlags) error {
+	if !flags.DiscoverFingerprint.Value || opts.FingerprintDiscovered {
+		return nil
+	}
+	if flags.HostName.Value == "" {
+		return fmt.Errorf("must supply --%s to discover its fingerprint", FlagHost)
+	}
+	port := flags.Port.Value
+	if port == 0 {
+		port = DefaultPort
+	}
+	fingerprint, err := DiscoverFingerprint(opts, flags.HostName.Value, port, flags.Fingerprint.Value, flags.TrustDiscoveredFingerprint.Value)
+	if err != nil {
+		return err
+	}
+	flags.Fingerprint.Value = fingerprint
+	opts.FingerprintDiscovered = true
+	return nil
+}
+
+// SshHost is a host to register from a hosts file.
+type SshHost struct {
+	Name        string
+	Host        string
+	Port        int
+	Fingerprint string
+}
+
+// ReadSshHosts reads a hosts file, which has a line of the form "host[:port] [name] [fingerprint]"
+// for each host. The name defaults to the host, and blank lines and lines starting with # are
+// ignored.
+func ReadSshHosts(content []byte) ([]*SshHost, error) {
+	var hosts []*SshHost
+	names := map[string]int{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) > 3 {
+			return nil, fmt.Errorf("line %d: expected host[:port] [name] [fingerprint], but found %d fields", lineNumber, len(fields))
+		}
+
+		host, port, err := splitHostPort(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		sshHost := &SshHost{Name: host, Host: host, Port: port}
+		if len(fields) > 1 {
+			sshHost.Name = fields[1]
+		}
+		if len(fields) > 2 {
+			sshHost.Fingerprint = fields[2]
+		}
+
+		key := strings.ToLower(sshHost.Name)
+		if previous, ok := names[key]; ok {
+			return nil, fmt.Errorf("line %d: the name '%s' is already used on line %d", lineNumber, sshHost.Name, previous)
+		}
+		names[key] = lineNumber
+		hosts = append(hosts, sshHost)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("the hosts file does not list any hosts")
+	}
+	return hosts, nil
+}
+
+// splitHostPort splits a host from its port, which is 0 when it is not given. IPv6 addresses
+// must be in brackets to be given a port.
+func splitHostPort(value string) (string, int, error) {
+	if !strings.HasPrefix(value, "[") && strings.Count(value, ":") != 1 {
+		return value, 0, nil
+	}
+	host, portText, err := net.SplitHostPort(value)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, fmt.Errorf("'%s' is not a valid port", portText)
+	}
+	return host, port, nil
+}
+
+// RegisterSshHosts registers each host in a hosts file with the callback given, discovering the
+// fingerprints of the hosts when asked to. A host which cannot be registered does not stop the
+// rest, and the hosts which failed are reported at the end.
+func RegisterSshHosts(opts *SshCommonOptions, flags *SshCommonFlags, hosts []*SshHost, description string, register func(host *SshHost) error) error {
+	var failed []string
+	for _, host := range hosts {
+		if host.Port == 0 {
+			host.Port = flags.Port.Value
+		}
+		if host.Port == 0 {
+			host.Port = DefaultPort
+		}
+
+		err := registerSshHost(opts, flags, host, register)
+		if err != nil {
+			fmt.Fprintf(opts.Out, "%s %s\n", output.Red(fmt.Sprintf("Failed to create %s '%s':", description, host.Name)), err)
+			failed = append(failed, host.Name)
+			continue
+		}
+		fmt.Fprintf(opts.Out, "Successfully created %s '%s'.\n", description, host.Name)
+	}
+
+	fmt.Fprintf(opts.Out, "\nCreated %d of %d %ss.\n", len(hosts)-len(failed), len(hosts), description)
+	if len(failed) > 0 {
+		return fmt.Errorf("cannot create %d %s(s): %s", len(failed), description, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func registerSshHost(opts *SshCommonOptions, flags *SshCommonFlags, host *SshHost, register func(host *SshHost) error) error {
+	if flags.DiscoverFingerprint.Value {
+		fingerprint, err := DiscoverFingerprint(opts, host.Host, host.Port, host.Fingerprint, flags.TrustDiscoveredFingerprint.Value)
+		if err != nil {
+			return err
+		}
+		host.Fingerprint = fingerprint
+	}
+	if host.Fingerprint == "" {
+		return fmt.Errorf("no fingerprint was given for %s, so give one in the hosts file or use --%s", host.Host, FlagDiscoverFingerprint)
+	}
+	return register(host)
+}
+
+// CheckHostsFileFlags checks that the flags for a single host are not used with a hosts file.
+func CheckHostsFileFlags(flags *SshCommonFlags, name string) error {
+	if flags.HostsFile.Value == "" {
+		return nil
+	}
+	if name != "" || flags.HostName.Value != "" || flags.Fingerprint.Value != "" {
+		return fmt.Errorf("the name, host and fingerprint come from --%s, so cannot be given as flags too", FlagHostsFile)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package machinescommon_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// startSshServer accepts SSH connections until the test ends, presenting a new ed25519 host key.
+func startSshServer(t *testing.T) (int, ssh.PublicKey) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	require.NoError(t, err)
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _, _, _ = ssh.NewServerConn(conn, config)
+			}()
+		}
+	}()
+	return listener.Addr().(*net.TCPAddr).Port, signer.PublicKey()
+}
+
+func TestDiscoverHostKey(t *testing.T) {
+	port, hostKey := startSshServer(t)
+
+	discovered, err := machinescommon.DiscoverHostKey("127.0.0.1", port, 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, ssh.KeyAlgoED25519, discovered.KeyType)
+	assert.Equal(t, machinescommon.FormatFingerprint(hostKey), discovered.Fingerprint)
+	assert.NotContains(t, discovered.Fingerprint, "SHA256:")
+}
+
+func TestDiscoverHostKey_NotListening(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	_, err = machinescommon.DiscoverHostKey("127.0.0.1", port, time.Second)
+	assert.ErrorContains(t, err, "cannot connect to 127.0.0.1")
+}
+
+func newFingerprintOptions(t *testing.T, pa []*testutil.PA, noPrompt bool) (*machinescommon.SshCommonOptions, *bytes.Buffer, func()) {
+	asker, checkRemainingPrompts := testutil.NewMockAsker(t, pa)
+	out := &bytes.Buffer{}
+	opts := machinescommon.NewSshCommonOpts(&cmd.Dependencies{Ask: asker, Out: out, NoPrompt: noPrompt})
+	opts.DiscoverHostKeyCallback = func(host string, port int) (*machinescommon.HostKey, error) {
+		if host == "unreachable" {
+			return nil, errors.New("cannot connect to unreachable:22")
+		}
+		return &machinescommon.HostKey{Address: net.JoinHostPort(host, "22"), KeyType: ssh.KeyAlgoED25519, Fingerprint: "abc123"}, nil
+	}
+	return opts, out, checkRemainingPrompts
+}
+
+func TestResolveSshFingerprint_TrustedOnFirstUse(t *testing.T) {
+	pa := []*testutil.PA{
+		testutil.NewConfirmPromptWithDefault("Do you trust the host key of web-01:22?", "", true, false),
+	}
+	opts, out, checkRemainingPrompts := newFingerprintOptions(t, pa, false)
+	flags := machinescommon.NewSshCommonFlags()
+	flags.DiscoverFingerprint.Value = true
+	flags.HostName.Value = "web-01"
+
+	err := machinescommon.ResolveSshFingerprint(opts, flags)
+	checkRemainingPrompts()
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", flags.Fingerprint.Value)
+	assert.Contains(t, out.String(), "The ssh-ed25519 host key of web-01:22 has the fingerprint")
+
+	// once trusted, the host is not asked about again
+	require.NoError(t, machinescommon.ResolveSshFingerprint(opts, flags))
+}
+
+func TestResolveSshFingerprint_NotTrusted(t *testing.T) {
+	pa := []*testutil.PA{
+		testutil.NewConfirmPromptWithDefault("Do you trust the host key of web-01:22?", "", false, false),
+	}
+	opts, _, checkRemainingPrompts := newFingerprintOptions(t, pa, false)
+	flags := machinescommon.NewSshCommonFlags()
+	flags.DiscoverFingerprint.Value = true
+	flags.HostName.Value = "web-01"
+
+	err := machinescommon.ResolveSshFingerprint(opts, flags)
+	checkRemainingPrompts()
+	assert.EqualError(t, err, "the host key of web-01:22 was not trusted")
+	assert.Equal(t, "", flags.Fingerprint.Value)
+}
+
+func TestResolveSshFingerprint_GivenFingerprintMustMatch(t *testing.T) {
+	opts, _, _ := newFingerprintOptions(t, nil, true)
+	flags := machinescommon.NewSshCommonFlags()
+	flags.DiscoverFingerprint.Value = true
+	flags.HostName.Value = "web-01"
+	flags.Fingerprint.Value = "SHA256:abc123"
+	require.NoError(t, machinescommon.ResolveSshFingerprint(opts, flags))
+
+	opts, _, _ = newFingerprintOptions(t, nil, true)
+	flags.Fingerprint.Value = "xyz789"
+	err := machinescommon.ResolveSshFingerprint(opts, flags)
+	assert.EqualError(t, err, "the ssh-ed25519 host key of web-01:22 has the fingerprint abc123, not xyz789")
+}
+
+func TestResolveSshFingerprint_NotTrustedWithoutPrompting(t *testing.T) {
+	opts, out, _ := newFingerprintOptions(t, nil, true)
+	flags := machinescommon.NewSshCommonFlags()
+	flags.DiscoverFingerprint.Value = true
+	flags.HostName.Value = "web-01"
+
+	err := machinescommon.ResolveSshFingerprint(opts, flags)
+	assert.EqualError(t, err, "the host key of web-01:22 cannot be confirmed without prompting, so give its fingerprint, or use --trust-discovered-fingerprint to trust it anyway")
+	assert.Equal(t, "", flags.Fingerprint.Value)
+	assert.Contains(t, out.String(), "The ssh-ed25519 host key of web-01:22 has the fingerprint")
+
+	flags.TrustDiscoveredFingerprint.Value = true
+	require.NoError(t, machinescommon.ResolveSshFingerprint(opts, flags))
+	assert.Equal(t, "abc123", flags.Fingerprint.Value)
+}
+
+func TestReadSshHosts(t *testing.T) {
+	hosts, err := machinescommon.ReadSshHosts([]byte(`
+# web servers
+web-01.example.com
+web-02.example.com:2222 web-02
+[2001:db8::1]:22 db-01 abc123
+2001:db8::2
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []*machinescommon.SshHost{
+		{Name: "web-01.example.com", Host: "web-01.example.com"},
+		{Name: "web-02", Host: "web-02.example.com", Port: 2222},
+		{Name: "db-01", Host: "2001:db8::1", Port: 22, Fingerprint: "abc123"},
+		{Name: "2001:db8::2", Host: "2001:db8::2"},
+	}, hosts)
+}
+
+func TestReadSshHosts_Problems(t *testing.T) {
+	_, err := machinescommon.ReadSshHosts([]byte("web-01 web\nweb-02 WEB\n"))
+	assert.EqualError(t, err, "line 2: the name 'WEB' is already used on line 1")
+
+	_, err = machinescommon.ReadSshHosts([]byte("web-01:ssh\n"))
+	assert.EqualError(t, err, "line 1: 'ssh' is not a valid port")
+
+	_, err = machinescommon.ReadSshHosts([]byte("web-01 web abc123 extra\n"))
+	assert.EqualError(t, err, "line 1: expected host[:port] [name] [fingerprint], but found 4 fields")
+
+	_, err = machinescommon.ReadSshHosts([]byte("# nothing here\n"))
+	assert.EqualError(t, err, "the hosts file does not list any hosts")
+}
+
+func TestRegisterSshHosts_ContinuesPastFailures(t *testing.T) {
+	opts, out, _ := newFingerprintOptions(t, nil, true)
+	flags := machinescommon.NewSshCommonFlags()
+	flags.DiscoverFingerprint.Value = true
+	flags.TrustDiscoveredFingerprint.Value = true
+	hosts := []*machinescommon.SshHost{
+		{Name: "web-01", Host: "web-01"},
+		{Name: "web-02", Host: "unreachable"},
+		{Name: "web-03", Host: "web-03", Port: 2222},
+	}
+
+	var registered []*machinescommon.SshHost
+	err := machinescommon.RegisterSshHosts(opts, flags, hosts, "SSH worker", func(host *machinescommon.SshHost) error {
+		registered = append(registered, host)
+		return nil
+	})
+	assert.EqualError(t, err, "cannot create 1 SSH worker(s): web-02")
+	require.Len(t, registered, 2)
+	assert.Equal(t, 22, registered[0].Port)
+	assert.Equal(t, "abc123", registered[0].Fingerprint)
+	assert.Equal(t, 2222, registered[1].Port)
+	assert.Contains(t, out.String(), "Created 2 of 3 SSH workers.")
+}
+
+func TestRegisterSshHosts_NeedsAFingerprint(t *testing.T) {
+	opts, _, _ := newFingerprintOptions(t, nil, true)
+	flags := machinescommon.NewSshCommonFlags()
+
+	err := machinescommon.RegisterSshHosts(opts, flags, []*machinescommon.SshHost{{Name: "web-01", Host: "web-01"}}, "SSH worker", func(host *machinescommon.SshHost) error {
+		return nil
+	})
+	assert.EqualError(t, err, "cannot create 1 SSH worker(s): web-01")
+}
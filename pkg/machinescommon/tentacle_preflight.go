@@ -0,0 +1,210 @@
+package machinescommon
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagDiscoverThumbprint        = "discover-thumbprint"
+	FlagTrustDiscoveredThumbprint = "trust-discovered-thumbprint"
+	FlagPreflight                 = "preflight"
+
+	DefaultTentaclePort        = "10933"
+	DefaultTentacleDialTimeout = 10 * time.Second
+
+	PreflightDNS = "DNS"
+	PreflightTCP = "TCP"
+	PreflightTLS = "TLS"
+)
+
+// PreflightError is a check of a Listening Tentacle which failed, with what to do about it.
+type PreflightError struct {
+	Check  string
+	Err    error
+	Advice string
+}
+
+func (e *PreflightError) Error() string {
+	return fmt.Sprintf("%s check failed: %s. %s", e.Check, e.Err, e.Advice)
+}
+
+func (e *PreflightError) Unwrap() error {
+	return e.Err
+}
+
+// TentacleProbe is what was found by connecting to a Listening Tentacle.
+type TentacleProbe struct {
+	Address    string
+	IPs        []string
+	Subject    string
+	Thumbprint string
+}
+
+type ProbeTentacleCallback func(tentacleUrl string) (*TentacleProbe, error)
+
+// ProbeTentacle resolves the host of a Listening Tentacle, connects to it and reads its
+// certificate, stopping at the first of these which fails. Tentacle only trusts the Octopus
+// Server, so the handshake is expected to be refused once the certificate has been read.
+func ProbeTentacle(tentacleUrl string, timeout time.Duration) (*TentacleProbe, error) {
+	uri, err := url.Parse(tentacleUrl)
+	if err != nil {
+		return nil, err
+	}
+	if uri.Scheme != "https" || uri.Hostname() == "" {
+		return nil, fmt.Errorf("'%s' is not the URL of a Listening Tentacle, which looks like https://<host>:%s/", tentacleUrl, DefaultTentaclePort)
+	}
+	host, port := uri.Hostname(), uri.Port()
+	if port == "" {
+		port = DefaultTentaclePort
+	}
+	probe := &TentacleProbe{Address: net.JoinHostPort(host, port)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if ip := net.ParseIP(host); ip != nil {
+		probe.IPs = []string{ip.String()}
+	} else if probe.IPs, err = net.DefaultResolver.LookupHost(ctx, host); err != nil {
+		return nil, &PreflightError{Check: PreflightDNS, Err: err,
+			Advice: fmt.Sprintf("Check that '%s' is spelled correctly and has a DNS record this machine can resolve", host)}
+	}
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", probe.Address)
+	if err != nil {
+		return nil, &PreflightError{Check: PreflightTCP, Err: err,
+			Advice: fmt.Sprintf("Check that Tentacle is running and listening on port %s, and that firewalls allow connections to it", port)}
+	}
+	defer conn.Close()
+
+	var certificate *x509.Certificate
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName: host,
+		// Tentacle has a self-signed certificate, which is trusted by its thumbprint instead
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) > 0 {
+				certificate, _ = x509.ParseCertificate(rawCerts[0])
+			}
+			return nil
+		},
+	})
+	err = tlsConn.HandshakeContext(ctx)
+	if certificate == nil {
+		if err == nil {
+			err = fmt.Errorf("no certificate was presented")
+		}
+		return nil, &PreflightError{Check: PreflightTLS, Err: err,
+			Advice: fmt.Sprintf("Check that %s is a Listening Tentacle and not another service, and that nothing between here and it intercepts TLS", probe.Address)}
+	}
+	probe.Subject = certificate.Subject.String()
+	probe.Thumbprint = FormatThumbprint(certificate)
+	return probe, nil
+}
+
+// FormatThumbprint is the SHA-1 thumbprint of a certificate, as Octopus shows it.
+func FormatThumbprint(certificate *x509.Certificate) string {
+	sum := sha1.Sum(certificate.Raw)
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+type TentacleCheckFlags struct {
+	DiscoverThumbprint        *flag.Flag[bool]
+	TrustDiscoveredThumbprint *flag.Flag[bool]
+	Preflight                 *flag.Flag[bool]
+}
+
+func NewTentacleCheckFlags() *TentacleCheckFlags {
+	return &TentacleCheckFlags{
+		DiscoverThumbprint:        flag.New[bool](FlagDiscoverThumbprint, false),
+		TrustDiscoveredThumbprint: flag.New[bool](FlagTrustDiscoveredThumbprint, false),
+		Preflight:                 flag.New[bool](FlagPreflight, false),
+	}
+}
+
+func RegisterTentacleCheckFlags(cmd *cobra.Command, flags *TentacleCheckFlags, entityType string) {
+	cmd.Flags().BoolVar(&flags.DiscoverThumbprint.Value, flags.DiscoverThumbprint.Name, false, fmt.Sprintf("Connect to the %s to read its certificate thumbprint, which must be confirmed when prompting", entityType))
+	cmd.Flags().BoolVar(&flags.TrustDiscoveredThumbprint.Value, flags.TrustDiscoveredThumbprint.Name, false, fmt.Sprintf("Trust the certificate thumbprint discovered without confirming it, when not prompting and no thumbprint is given for the %s", entityType))
+	cmd.Flags().BoolVar(&flags.Preflight.Value, flags.Preflight.Name, false, fmt.Sprintf("Check the %s can be resolved, connected to and presents a certificate before it is saved", entityType))
+}
+
+type TentacleCheckOptions struct {
+	*cmd.Dependencies
+	ProbeTentacleCallback
+}
+
+func NewTentacleCheckOptions(dependencies *cmd.Dependencies) *TentacleCheckOptions {
+	return &TentacleCheckOptions{
+		Dependencies: dependencies,
+		ProbeTentacleCallback: func(tentacleUrl string) (*TentacleProbe, error) {
+			return ProbeTentacle(tentacleUrl, DefaultTentacleDialTimeout)
+		},
+	}
+}
+
+// CheckTentacle runs the preflight check of a Listening Tentacle and discovers its thumbprint,
+// when asked to. A thumbprint which was given must match the certificate of the Tentacle, and one
+// which was discovered must be confirmed when prompting, or opted into when not. The checks are made from this machine,
+// so they cannot be made for a Tentacle which the Octopus Server reaches through a proxy.
+func CheckTentacle(opts *TentacleCheckOptions, flags *TentacleCheckFlags, tentacleUrl string, thumbprint *flag.Flag[string], proxy string) error {
+	if !flags.DiscoverThumbprint.Value && !flags.Preflight.Value {
+		return nil
+	}
+	if proxy != "" {
+		return fmt.Errorf("the Tentacle is reached through a proxy, so it cannot be checked from this machine")
+	}
+	if tentacleUrl == "" {
+		return fmt.Errorf("must supply the URL of the Tentacle to check it")
+	}
+
+	probe, err := opts.ProbeTentacleCallback(tentacleUrl)
+	if err != nil {
+		return err
+	}
+	if flags.Preflight.Value {
+		fmt.Fprintf(opts.Out, "%s %s resolves to %s, is listening and presents a certificate\n", output.Green("Preflight check passed:"), probe.Address, strings.Join(probe.IPs, ", "))
+	}
+
+	if thumbprint.Value != "" {
+		if !strings.EqualFold(thumbprint.Value, probe.Thumbprint) {
+			return fmt.Errorf("the Tentacle at %s has the certificate thumbprint %s, not %s. Run 'Tentacle show-thumbprint' on it to check which is right", probe.Address, probe.Thumbprint, thumbprint.Value)
+		}
+		return nil
+	}
+	if !flags.DiscoverThumbprint.Value {
+		return nil
+	}
+
+	fmt.Fprintf(opts.Out, "The Tentacle at %s has the certificate thumbprint %s %s\n", probe.Address, output.Bold(probe.Thumbprint), output.Dimf("(%s)", probe.Subject))
+	if !opts.NoPrompt {
+		trusted := false
+		if err := opts.Ask(&survey.Confirm{
+			Message: fmt.Sprintf("Do you trust the certificate of %s?", probe.Address),
+			Default: false,
+		}, &trusted); err != nil {
+			return err
+		}
+		if !trusted {
+			return fmt.Errorf("the certificate of %s was not trusted", probe.Address)
+		}
+	} else if !flags.TrustDiscoveredThumbprint.Value {
+		return fmt.Errorf("the certificate of %s cannot be confirmed without prompting, so give its thumbprint, or use --%s to trust it anyway", probe.Address, FlagTrustDiscoveredThumbprint)
+	}
+	thumbprint.Value = probe.Thumbprint
+	return nil
+}
@@ -0,0 +1,146 @@
+package machinescommon_test
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeTentacle(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	probe, err := machinescommon.ProbeTentacle(server.URL, 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, server.Listener.Addr().String(), probe.Address)
+	assert.Equal(t, []string{"127.0.0.1"}, probe.IPs)
+	assert.Equal(t, machinescommon.FormatThumbprint(server.Certificate()), probe.Thumbprint)
+	assert.Len(t, probe.Thumbprint, 40)
+}
+
+func TestProbeTentacle_NotListening(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	_, err = machinescommon.ProbeTentacle("https://"+address+"/", time.Second)
+	var preflightErr *machinescommon.PreflightError
+	require.ErrorAs(t, err, &preflightErr)
+	assert.Equal(t, machinescommon.PreflightTCP, preflightErr.Check)
+}
+
+func TestProbeTentacle_NotTls(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := machinescommon.ProbeTentacle("https://"+server.Listener.Addr().String()+"/", 5*time.Second)
+	var preflightErr *machinescommon.PreflightError
+	require.ErrorAs(t, err, &preflightErr)
+	assert.Equal(t, machinescommon.PreflightTLS, preflightErr.Check)
+}
+
+func TestProbeTentacle_NotHttps(t *testing.T) {
+	_, err := machinescommon.ProbeTentacle("http://web-01:10933/", time.Second)
+	assert.EqualError(t, err, "'http://web-01:10933/' is not the URL of a Listening Tentacle, which looks like https://<host>:10933/")
+}
+
+func newTentacleCheckOptions(t *testing.T, pa []*testutil.PA, noPrompt bool) (*machinescommon.TentacleCheckOptions, *bytes.Buffer, func()) {
+	asker, checkRemainingPrompts := testutil.NewMockAsker(t, pa)
+	out := &bytes.Buffer{}
+	opts := machinescommon.NewTentacleCheckOptions(&cmd.Dependencies{Ask: asker, Out: out, NoPrompt: noPrompt})
+	opts.ProbeTentacleCallback = func(tentacleUrl string) (*machinescommon.TentacleProbe, error) {
+		if tentacleUrl == "https://unknown:10933/" {
+			return nil, &machinescommon.PreflightError{Check: machinescommon.PreflightDNS, Err: errors.New("no such host"), Advice: "Check the host"}
+		}
+		return &machinescommon.TentacleProbe{Address: "web-01:10933", IPs: []string{"10.0.0.1"}, Subject: "CN=Octopus Tentacle", Thumbprint: "ABC123"}, nil
+	}
+	return opts, out, checkRemainingPrompts
+}
+
+func TestCheckTentacle_DiscoversThumbprint(t *testing.T) {
+	pa := []*testutil.PA{
+		testutil.NewConfirmPromptWithDefault("Do you trust the certificate of web-01:10933?", "", true, false),
+	}
+	opts, out, checkRemainingPrompts := newTentacleCheckOptions(t, pa, false)
+	flags := machinescommon.NewTentacleCheckFlags()
+	flags.DiscoverThumbprint.Value = true
+	thumbprint := flag.New[string]("thumbprint", true)
+
+	err := machinescommon.CheckTentacle(opts, flags, "https://web-01:10933/", thumbprint, "")
+	checkRemainingPrompts()
+	require.NoError(t, err)
+	assert.Equal(t, "ABC123", thumbprint.Value)
+	assert.Contains(t, out.String(), "The Tentacle at web-01:10933 has the certificate thumbprint")
+}
+
+func TestCheckTentacle_NotTrusted(t *testing.T) {
+	pa := []*testutil.PA{
+		testutil.NewConfirmPromptWithDefault("Do you trust the certificate of web-01:10933?", "", false, false),
+	}
+	opts, _, checkRemainingPrompts := newTentacleCheckOptions(t, pa, false)
+	flags := machinescommon.NewTentacleCheckFlags()
+	flags.DiscoverThumbprint.Value = true
+	thumbprint := flag.New[string]("thumbprint", true)
+
+	err := machinescommon.CheckTentacle(opts, flags, "https://web-01:10933/", thumbprint, "")
+	checkRemainingPrompts()
+	assert.EqualError(t, err, "the certificate of web-01:10933 was not trusted")
+	assert.Equal(t, "", thumbprint.Value)
+}
+
+func TestCheckTentacle_NotTrustedWithoutPrompting(t *testing.T) {
+	opts, out, _ := newTentacleCheckOptions(t, nil, true)
+	flags := machinescommon.NewTentacleCheckFlags()
+	flags.DiscoverThumbprint.Value = true
+	thumbprint := flag.New[string]("thumbprint", true)
+
+	err := machinescommon.CheckTentacle(opts, flags, "https://web-01:10933/", thumbprint, "")
+	assert.EqualError(t, err, "the certificate of web-01:10933 cannot be confirmed without prompting, so give its thumbprint, or use --trust-discovered-thumbprint to trust it anyway")
+	assert.Equal(t, "", thumbprint.Value)
+	assert.Contains(t, out.String(), "The Tentacle at web-01:10933 has the certificate thumbprint")
+
+	flags.TrustDiscoveredThumbprint.Value = true
+	require.NoError(t, machinescommon.CheckTentacle(opts, flags, "https://web-01:10933/", thumbprint, ""))
+	assert.Equal(t, "ABC123", thumbprint.Value)
+}
+
+func TestCheckTentacle_PreflightChecksGivenThumbprint(t *testing.T) {
+	opts, out, _ := newTentacleCheckOptions(t, nil, true)
+	flags := machinescommon.NewTentacleCheckFlags()
+	flags.Preflight.Value = true
+	thumbprint := flag.New[string]("thumbprint", true)
+	thumbprint.Value = "abc123"
+
+	require.NoError(t, machinescommon.CheckTentacle(opts, flags, "https://web-01:10933/", thumbprint, ""))
+	assert.Contains(t, out.String(), "web-01:10933 resolves to 10.0.0.1, is listening and presents a certificate")
+
+	thumbprint.Value = "DEF456"
+	err := machinescommon.CheckTentacle(opts, flags, "https://web-01:10933/", thumbprint, "")
+	assert.EqualError(t, err, "the Tentacle at web-01:10933 has the certificate thumbprint ABC123, not DEF456. Run 'Tentacle show-thumbprint' on it to check which is right")
+
+	err = machinescommon.CheckTentacle(opts, flags, "https://unknown:10933/", thumbprint, "")
+	assert.EqualError(t, err, "DNS check failed: no such host. Check the host")
+}
+
+func TestCheckTentacle_Skipped(t *testing.T) {
+	opts, _, _ := newTentacleCheckOptions(t, nil, true)
+	flags := machinescommon.NewTentacleCheckFlags()
+	thumbprint := flag.New[string]("thumbprint", true)
+	require.NoError(t, machinescommon.CheckTentacle(opts, flags, "https://unknown:10933/", thumbprint, ""))
+
+	flags.Preflight.Value = true
+	err := machinescommon.CheckTentacle(opts, flags, "https://web-01:10933/", thumbprint, "Proxy-1")
+	assert.EqualError(t, err, "the Tentacle is reached through a proxy, so it cannot be checked from this machine")
+}
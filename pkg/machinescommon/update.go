@@ -0,0 +1,132 @@
+package machinescommon
+
+import (
+	"fmt"
+
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagEnable  = "enable"
+	FlagDisable = "disable"
+)
+
+// UpdateMachineFlags are the flags for changing the settings deployment targets and workers share.
+type UpdateMachineFlags struct {
+	MachinePolicy *flag.Flag[string]
+	Proxy         *flag.Flag[string]
+	Enable        *flag.Flag[bool]
+	Disable       *flag.Flag[bool]
+}
+
+func NewUpdateMachineFlags() *UpdateMachineFlags {
+	return &UpdateMachineFlags{
+		MachinePolicy: flag.New[string](FlagMachinePolicy, false),
+		Proxy:         flag.New[string](FlagProxy, false),
+		Enable:        flag.New[bool](FlagEnable, false),
+		Disable:       flag.New[bool](FlagDisable, false),
+	}
+}
+
+func RegisterUpdateMachineFlags(cmd *cobra.Command, flags *UpdateMachineFlags, description string) {
+	cmd.Flags().StringVar(&flags.MachinePolicy.Value, flags.MachinePolicy.Name, "", fmt.Sprintf("Change the machine policy of the %ss", description))
+	cmd.Flags().StringVar(&flags.Proxy.Value, flags.Proxy.Name, "", fmt.Sprintf("Change the proxy used to connect to the %[1]ss, or connect directly with --%[2]s ''", description, FlagProxy))
+	cmd.Flags().BoolVar(&flags.Enable.Value, flags.Enable.Name, false, fmt.Sprintf("Enable the %ss", description))
+	cmd.Flags().BoolVar(&flags.Disable.Value, flags.Disable.Name, false, fmt.Sprintf("Disable the %ss", description))
+	cmd.MarkFlagsMutuallyExclusive(flags.Enable.Name, flags.Disable.Name)
+}
+
+// MachineUpdate is a change to the settings deployment targets and workers share, with names
+// resolved to IDs. Settings which are not changed are nil.
+type MachineUpdate struct {
+	MachinePolicyID *string
+	// ProxyID is empty to connect directly.
+	ProxyID    *string
+	IsDisabled *bool
+}
+
+// ResolveMachineUpdate turns the flags given into a MachineUpdate. Only flags which were set are
+// included, so that an empty --proxy can remove a proxy.
+func ResolveMachineUpdate(flags *UpdateMachineFlags, isFlagSet func(name string) bool, getAllMachinePolicies GetAllMachinePoliciesCallback, getAllProxies GetAllProxiesCallback) (*MachineUpdate, error) {
+	update := &MachineUpdate{}
+	if isFlagSet(flags.MachinePolicy.Name) {
+		policy, err := FindMachinePolicy(getAllMachinePolicies, flags.MachinePolicy.Value)
+		if err != nil {
+			return nil, err
+		}
+		policyID := policy.GetID()
+		update.MachinePolicyID = &policyID
+	}
+	if isFlagSet(flags.Proxy.Name) {
+		proxyID := ""
+		if flags.Proxy.Value != "" {
+			proxy, err := FindProxyByNameOrID(getAllProxies, flags.Proxy.Value)
+			if err != nil {
+				return nil, err
+			}
+			proxyID = proxy.GetID()
+		}
+		update.ProxyID = &proxyID
+	}
+	if flags.Enable.Value || flags.Disable.Value {
+		isDisabled := flags.Disable.Value
+		update.IsDisabled = &isDisabled
+	}
+	return update, nil
+}
+
+// IsEmpty reports whether the update changes nothing.
+func (u *MachineUpdate) IsEmpty() bool {
+	return u.MachinePolicyID == nil && u.ProxyID == nil && u.IsDisabled == nil
+}
+
+// ApplyMachineUpdate changes the settings of a deployment target or worker, given its machine
+// policy, whether it is disabled and its endpoint.
+func ApplyMachineUpdate(update *MachineUpdate, machinePolicyID *string, isDisabled *bool, endpoint machines.IEndpoint) error {
+	if update.ProxyID != nil {
+		switch e := endpoint.(type) {
+		case *machines.ListeningTentacleEndpoint:
+			e.ProxyID = *update.ProxyID
+		case machines.IEndpointWithProxy:
+			e.SetProxyID(*update.ProxyID)
+		default:
+			return fmt.Errorf("a %s cannot connect through a proxy", CommunicationStyleToDescriptionMap[endpoint.GetCommunicationStyle()])
+		}
+	}
+	if update.MachinePolicyID != nil {
+		*machinePolicyID = *update.MachinePolicyID
+	}
+	if update.IsDisabled != nil {
+		*isDisabled = *update.IsDisabled
+	}
+	return nil
+}
+
+// AddValues adds the values which are not already present, ignoring case.
+func AddValues(existing []string, values []string) []string {
+	result := append([]string{}, existing...)
+	for _, value := range values {
+		if !containsIgnoringCase(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// RemoveValues removes the values given, ignoring case.
+func RemoveValues(existing []string, values []string) []string {
+	result := []string{}
+	for _, value := range existing {
+		if !containsIgnoringCase(values, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func containsIgnoringCase(values []string, value string) bool {
+	_, ok := FindIgnoringCase(values, value)
+	return ok
+}
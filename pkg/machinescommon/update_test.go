@@ -0,0 +1,42 @@
+package machinescommon_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/proxies"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveMachineUpdate_OnlyGivenFlags(t *testing.T) {
+	flags := machinescommon.NewUpdateMachineFlags()
+	flags.Proxy.Value = "office proxy"
+	getAllProxies := func() ([]*proxies.Proxy, error) {
+		proxy := proxies.NewProxy("Office proxy", "proxy.local", "user", nil)
+		proxy.ID = "Proxies-1"
+		return []*proxies.Proxy{proxy}, nil
+	}
+
+	update, err := machinescommon.ResolveMachineUpdate(flags, func(name string) bool { return name == machinescommon.FlagProxy }, nil, getAllProxies)
+
+	assert.NoError(t, err)
+	assert.Nil(t, update.MachinePolicyID)
+	assert.Nil(t, update.IsDisabled)
+	assert.Equal(t, "Proxies-1", *update.ProxyID)
+}
+
+func TestApplyMachineUpdate_ProxyOnlyForEndpointsWithProxy(t *testing.T) {
+	proxyID := "Proxies-1"
+	update := &machinescommon.MachineUpdate{ProxyID: &proxyID}
+	target := machines.NewDeploymentTarget("Region", machines.NewCloudRegionEndpoint(), nil, nil)
+
+	err := machinescommon.ApplyMachineUpdate(update, &target.MachinePolicyID, &target.IsDisabled, target.Endpoint)
+
+	assert.EqualError(t, err, "a Cloud Region cannot connect through a proxy")
+}
+
+func TestAddAndRemoveValues(t *testing.T) {
+	assert.Equal(t, []string{"web", "api"}, machinescommon.AddValues([]string{"web"}, []string{"WEB", "api"}))
+	assert.Equal(t, []string{"api"}, machinescommon.RemoveValues([]string{"web", "api"}, []string{"Web"}))
+}
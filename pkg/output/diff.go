@@ -0,0 +1,96 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiffLines returns a line-by-line diff of before and after, with removed lines
+// prefixed "-" in red, added lines prefixed "+" in green and unchanged lines
+// indented to line up with them. It is intended for previewing changes to
+// documents a person can read, such as exported JSON or YAML, not for patching.
+func DiffLines(before string, after string) string {
+	var result strings.Builder
+	for _, line := range diffLines(splitLines(before), splitLines(after)) {
+		switch line.kind {
+		case diffRemoved:
+			result.WriteString(Redf("- %s", line.text))
+		case diffAdded:
+			result.WriteString(Greenf("+ %s", line.text))
+		default:
+			result.WriteString(fmt.Sprintf("  %s", line.text))
+		}
+		result.WriteString("\n")
+	}
+	return result.String()
+}
+
+// HasDiff reports whether before and after differ in any line.
+func HasDiff(before string, after string) bool {
+	return strings.Join(splitLines(before), "\n") != strings.Join(splitLines(after), "\n")
+}
+
+type diffKind int
+
+const (
+	diffUnchanged diffKind = iota
+	diffRemoved
+	diffAdded
+)
+
+type diffLine struct {
+	kind diffKind
+	text string
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, "\n")
+}
+
+// diffLines walks the longest common subsequence of a and b, which is plenty fast
+// for documents of the size the CLI deals with.
+func diffLines(a []string, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var result []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, diffLine{diffUnchanged, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, diffLine{diffRemoved, a[i]})
+			i++
+		default:
+			result = append(result, diffLine{diffAdded, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		result = append(result, diffLine{diffRemoved, a[i]})
+	}
+	for ; j < len(b); j++ {
+		result = append(result, diffLine{diffAdded, b[j]})
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	previous := IsColorEnabled
+	IsColorEnabled = false
+	defer func() { IsColorEnabled = previous }()
+
+	tests := []struct {
+		name     string
+		before   string
+		after    string
+		expected string
+	}{
+		{"identical", "a\nb\n", "a\nb", "  a\n  b\n"},
+		{"added line", "a\nc", "a\nb\nc", "  a\n+ b\n  c\n"},
+		{"removed line", "a\nb\nc", "a\nc", "  a\n- b\n  c\n"},
+		{"changed line", "a\nb\nc", "a\nx\nc", "  a\n- b\n+ x\n  c\n"},
+		{"from empty", "", "a", "+ a\n"},
+		{"to empty", "a", "", "- a\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, DiffLines(test.before, test.after))
+		})
+	}
+}
+
+func TestHasDiff(t *testing.T) {
+	assert.False(t, HasDiff("a\nb\n", "a\nb"))
+	assert.True(t, HasDiff("a\nb", "a\nc"))
+}
@@ -5,3 +5,24 @@ import "strings"
 func FormatAsList(items []string) string {
 	return strings.Join(items, ", ")
 }
+
+// FormatTaskState colours the state of a server task: red when it failed, green when
+// it succeeded, and yellow when it is still in progress, was cancelled or succeeded with warnings.
+func FormatTaskState(state string) string {
+	return FormatByTaskState(state, state)
+}
+
+// FormatByTaskState colours text the way FormatTaskState colours state, for showing
+// something other than the state itself, such as the release version a task deployed.
+func FormatByTaskState(state string, text string) string {
+	switch state {
+	case "Failed", "TimedOut":
+		return Red(text)
+	case "Success":
+		return Green(text)
+	case "SuccessWithWarning", "Queued", "Executing", "Cancelling", "Canceled":
+		return Yellow(text)
+	default:
+		return text
+	}
+}
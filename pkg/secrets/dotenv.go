@@ -0,0 +1,30 @@
+package secrets
+
+import (
+	"fmt"
+
+	"github.com/joho/godotenv"
+)
+
+const SchemeDotenv = "dotenv"
+
+// DotenvSource reads KEY=value lines from a file, in the format used by .env files.
+type DotenvSource struct {
+	Path string
+}
+
+func NewDotenvSource(path string) *DotenvSource {
+	return &DotenvSource{Path: path}
+}
+
+func (s *DotenvSource) Description() string {
+	return fmt.Sprintf("%s:%s", SchemeDotenv, s.Path)
+}
+
+func (s *DotenvSource) Load() (map[string]string, error) {
+	values, err := godotenv.Read(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read '%s': %w", s.Path, err)
+	}
+	return values, nil
+}
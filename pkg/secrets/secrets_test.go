@@ -0,0 +1,93 @@
+package secrets_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSource(t *testing.T) {
+	source, err := secrets.NewSource("config/.env")
+	require.Nil(t, err)
+	assert.Equal(t, "dotenv:config/.env", source.Description())
+
+	source, err = secrets.NewSource("secrets.enc.yaml")
+	require.Nil(t, err)
+	assert.Equal(t, "sops:secrets.enc.yaml", source.Description())
+
+	source, err = secrets.NewSource(`dotenv:C:\config\production`)
+	require.Nil(t, err)
+	assert.Equal(t, `dotenv:C:\config\production`, source.Description())
+
+	_, err = secrets.NewSource("keychain:web-app")
+	assert.EqualError(t, err, "cannot tell what kind of source 'keychain:web-app' is. Use <kind>:<location>, where kind is one of dotenv, sops, vault")
+}
+
+func TestDotenvSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	require.Nil(t, os.WriteFile(path, []byte("# comment\nDB_PASSWORD=hunter2\nAPI_KEY=\"abc 123\"\n"), 0600))
+
+	values, err := secrets.NewDotenvSource(path).Load()
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{"DB_PASSWORD": "hunter2", "API_KEY": "abc 123"}, values)
+}
+
+func TestSopsSource_FlattensNestedKeys(t *testing.T) {
+	source := secrets.NewSopsSource("secrets.enc.yaml")
+	source.Decrypt = func(path string) ([]byte, error) {
+		assert.Equal(t, "secrets.enc.yaml", path)
+		return []byte("Database:\n  Password: hunter2\n  Port: 5432\nApiKey: abc\n"), nil
+	}
+
+	values, err := source.Load()
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{"Database.Password": "hunter2", "Database.Port": "5432", "ApiKey": "abc"}, values)
+}
+
+func TestVaultSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/secret/data/web-app", r.URL.Path)
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"DbPassword":"hunter2","Smtp":{"Password":"letmein"}},"metadata":{"version":3}}}`))
+	}))
+	defer server.Close()
+
+	source, err := secrets.NewVaultSource(server.URL, "s.token", "", "secret/web-app")
+	require.Nil(t, err)
+	values, err := source.Load()
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{"DbPassword": "hunter2", "Smtp.Password": "letmein"}, values)
+
+	source, err = secrets.NewVaultSource(server.URL, "wrong", "", "secret/web-app")
+	require.Nil(t, err)
+	_, err = source.Load()
+	assert.EqualError(t, err, "cannot read vault:secret/web-app from Vault: permission denied")
+}
+
+func TestVaultSource_TimesOut(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	source, err := secrets.NewVaultSource(server.URL, "s.token", "", "secret/web-app")
+	require.Nil(t, err)
+	assert.Equal(t, secrets.DefaultVaultTimeout, source.Client.Timeout)
+
+	source.Client.Timeout = 50 * time.Millisecond
+	_, err = source.Load()
+	assert.ErrorContains(t, err, "cannot reach Vault")
+}
@@ -0,0 +1,61 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const SchemeSops = "sops"
+
+// SopsSource reads a YAML file encrypted with SOPS. Decrypting needs the keys the file was
+// encrypted with, so it is left to the sops executable, which must be on the path.
+type SopsSource struct {
+	Path string
+	// Decrypt returns the decrypted content of the file at path
+	Decrypt func(path string) ([]byte, error)
+}
+
+func NewSopsSource(path string) *SopsSource {
+	return &SopsSource{Path: path, Decrypt: decryptWithSops}
+}
+
+func (s *SopsSource) Description() string {
+	return fmt.Sprintf("%s:%s", SchemeSops, s.Path)
+}
+
+func (s *SopsSource) Load() (map[string]string, error) {
+	decrypted, err := s.Decrypt(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document map[string]any
+	if err := yaml.Unmarshal(decrypted, &document); err != nil {
+		return nil, fmt.Errorf("cannot read the decrypted content of '%s': %w", s.Path, err)
+	}
+	// left behind when a file is read without being decrypted
+	delete(document, "sops")
+
+	values := map[string]string{}
+	flatten("", document, values)
+	return values, nil
+}
+
+func decryptWithSops(path string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	command := exec.Command("sops", "--decrypt", "--output-type", "yaml", path)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	if err := command.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, errors.New("sops must be installed and on the path to read SOPS encrypted files")
+		}
+		return nil, fmt.Errorf("cannot decrypt '%s' with sops: %s", path, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,91 @@
+package secrets
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Source is somewhere secrets are kept outside Octopus, such as a file or a secret store.
+type Source interface {
+	// Description names the source in the audit log, without giving away any secret.
+	Description() string
+	// Load returns the secrets in the source by name.
+	Load() (map[string]string, error)
+}
+
+// SourceFactory creates a source from the part of a --from value after its scheme.
+type SourceFactory func(location string) (Source, error)
+
+var factories = map[string]SourceFactory{}
+
+// Register makes a kind of source available to --from as <scheme>:<location>.
+func Register(scheme string, factory SourceFactory) {
+	factories[strings.ToLower(scheme)] = factory
+}
+
+func init() {
+	Register(SchemeDotenv, func(location string) (Source, error) { return NewDotenvSource(location), nil })
+	Register(SchemeSops, func(location string) (Source, error) { return NewSopsSource(location), nil })
+	Register(SchemeVault, NewVaultSourceFromEnvironment)
+}
+
+// Schemes lists the kinds of source that can be used.
+func Schemes() []string {
+	schemes := make([]string, 0, len(factories))
+	for scheme := range factories {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// NewSource creates the source a --from value names, such as dotenv:.env or
+// vault:secret/my-app. Files can be given without a scheme when their extension
+// shows what they are.
+func NewSource(from string) (Source, error) {
+	scheme, location, found := strings.Cut(from, ":")
+	// a single letter is a Windows drive rather than a scheme
+	if !found || len(scheme) == 1 {
+		scheme, location = inferScheme(from), from
+	}
+	factory, ok := factories[strings.ToLower(scheme)]
+	if !ok {
+		return nil, fmt.Errorf("cannot tell what kind of source '%s' is. Use <kind>:<location>, where kind is one of %s", from, strings.Join(Schemes(), ", "))
+	}
+	if location == "" {
+		return nil, fmt.Errorf("a location is required after '%s:'", scheme)
+	}
+	return factory(location)
+}
+
+func inferScheme(path string) string {
+	name := strings.ToLower(filepath.Base(path))
+	switch {
+	case name == ".env" || strings.HasSuffix(name, ".env"):
+		return SchemeDotenv
+	case strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml"):
+		return SchemeSops
+	}
+	return ""
+}
+
+// flatten turns nested maps into names joined with dots, so that
+// {"Database": {"Password": "x"}} becomes Database.Password.
+func flatten(prefix string, value any, result map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			name := key
+			if prefix != "" {
+				name = prefix + "." + key
+			}
+			flatten(name, child, result)
+		}
+	case nil:
+		result[prefix] = ""
+	default:
+		result[prefix] = fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,112 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	SchemeVault = "vault"
+
+	EnvVaultAddress   = "VAULT_ADDR"
+	EnvVaultToken     = "VAULT_TOKEN"
+	EnvVaultNamespace = "VAULT_NAMESPACE"
+
+	// DefaultVaultTimeout is how long to wait for Vault, so that one which cannot be reached
+	// does not stop the command forever.
+	DefaultVaultTimeout = 30 * time.Second
+)
+
+// VaultSource reads a secret from the version 2 key/value secrets engine of HashiCorp Vault,
+// through its HTTP API. The location is the mount followed by the path of the secret, such
+// as secret/my-app.
+type VaultSource struct {
+	Address   string
+	Token     string
+	Namespace string
+	Mount     string
+	Path      string
+	Client    *http.Client
+}
+
+// NewVaultSourceFromEnvironment connects to Vault with the same environment variables as
+// the vault command line.
+func NewVaultSourceFromEnvironment(location string) (Source, error) {
+	address := os.Getenv(EnvVaultAddress)
+	if address == "" {
+		return nil, fmt.Errorf("%s must be set to read secrets from Vault", EnvVaultAddress)
+	}
+	token := os.Getenv(EnvVaultToken)
+	if token == "" {
+		return nil, fmt.Errorf("%s must be set to read secrets from Vault", EnvVaultToken)
+	}
+	return NewVaultSource(address, token, os.Getenv(EnvVaultNamespace), location)
+}
+
+func NewVaultSource(address string, token string, namespace string, location string) (*VaultSource, error) {
+	mount, path, found := strings.Cut(strings.Trim(location, "/"), "/")
+	if !found || path == "" {
+		return nil, fmt.Errorf("'%s' is not a Vault secret. Use <mount>/<path>, such as secret/my-app", location)
+	}
+	return &VaultSource{
+		Address:   strings.TrimRight(address, "/"),
+		Token:     token,
+		Namespace: namespace,
+		Mount:     mount,
+		Path:      path,
+		Client:    &http.Client{Timeout: DefaultVaultTimeout},
+	}, nil
+}
+
+func (s *VaultSource) Description() string {
+	return fmt.Sprintf("%s:%s/%s", SchemeVault, s.Mount, s.Path)
+}
+
+type vaultKvResponse struct {
+	Data struct {
+		Data map[string]any `json:"data"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func (s *VaultSource) Load() (map[string]string, error) {
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/%s/data/%s", s.Address, s.Mount, s.Path), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("X-Vault-Token", s.Token)
+	if s.Namespace != "" {
+		request.Header.Set("X-Vault-Namespace", s.Namespace)
+	}
+
+	response, err := s.Client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("cannot reach Vault: %w", err)
+	}
+	defer response.Body.Close()
+
+	var body vaultKvResponse
+	decodeErr := json.NewDecoder(response.Body).Decode(&body)
+	if response.StatusCode != http.StatusOK {
+		message := response.Status
+		if decodeErr == nil && len(body.Errors) > 0 {
+			message = strings.Join(body.Errors, "; ")
+		}
+		return nil, fmt.Errorf("cannot read %s from Vault: %s", s.Description(), message)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("cannot read the response from Vault: %w", decodeErr)
+	}
+	if body.Data.Data == nil {
+		return nil, errors.New("the Vault secret has no data; only the version 2 key/value secrets engine is supported")
+	}
+
+	values := map[string]string{}
+	flatten("", body.Data.Data, values)
+	return values, nil
+}
@@ -0,0 +1,34 @@
+package flag
+
+// IsSetFunc reports whether a flag was given on the command line. Update commands change
+// only the fields whose flags are set, so that everything else keeps its current value.
+type IsSetFunc func(name string) bool
+
+// Prompted tracks the flags that were asked for interactively, so that an answer which
+// changes the current value is applied and shown in the automation command as if the
+// flag had been given.
+type Prompted struct {
+	isSet   IsSetFunc
+	changed map[string]bool
+}
+
+func NewPrompted(isSet IsSetFunc) *Prompted {
+	return &Prompted{
+		isSet:   isSet,
+		changed: map[string]bool{},
+	}
+}
+
+// Record notes the answer given for a flag. An answer that keeps the current value is
+// cleared, as there is nothing to change.
+func (p *Prompted) Record(flag *Flag[string], current string) {
+	p.changed[flag.Name] = flag.Value != current
+	if !p.changed[flag.Name] {
+		flag.Value = ""
+	}
+}
+
+// IsSet reports whether a flag was given on the command line or changed by an answer.
+func (p *Prompted) IsSet(name string) bool {
+	return p.isSet(name) || p.changed[name]
+}
@@ -228,6 +228,7 @@ func NewRootResource() *octopusApiClient.RootResource {
 	root.Links[constants.LinkPackages] = "/api/Spaces-1/packages{/id}{?nuGetPackageId,filter,latest,skip,take,includeNotes}"
 	root.Links[constants.LinkLifecycles] = "/api/Spaces-1/lifecycles{/id}{?skip,take,ids,partialName}"
 	root.Links[constants.LinkProjectGroups] = "/api/Spaces-1/projectgroups{/id}{?skip,take,ids,partialName}"
+	root.Links[constants.LinkTasks] = "/api/Spaces-1/tasks{/id}{?skip,active,environment,tenant,runbook,project,name,node,running,states,hasPendingInterruptions,hasWarningsOrErrors,take,ids,partialName,spaces,includeSystem}"
 	root.Links[constants.LinkUsers] = "/api/users"
 	root.Links[constants.LinkCurrentUser] = "/api/users/me"
 	return root